@@ -1,9 +1,33 @@
 package main
 
 import (
+	"context"
+	"core/internal/application/audit"
+	"core/internal/application/invoice"
+	"core/internal/application/marketplacesync"
+	"core/internal/application/outbox"
+	"core/internal/application/product"
+	"core/internal/application/productfeed"
+	"core/internal/application/productimport"
+	"core/internal/application/shipment"
+	"core/internal/application/stockalert"
+	"core/internal/application/webhook"
 	"core/internal/config"
+	"core/internal/domain/entity"
 	"core/internal/domain/service"
+	"core/internal/domain/tax"
+	"core/internal/infrastructure/carrier"
+	"core/internal/infrastructure/eventsink"
+	"core/internal/infrastructure/feed"
+	"core/internal/infrastructure/label"
+	"core/internal/infrastructure/marketplace"
+	"core/internal/infrastructure/notify"
+	"core/internal/infrastructure/payment"
+	"core/internal/infrastructure/pdf"
 	"core/internal/infrastructure/persistence/mysql"
+	"core/internal/infrastructure/spreadsheet"
+	"core/internal/infrastructure/taxauthority"
+	"core/internal/presentation/dto"
 	"core/internal/presentation/http/handler"
 	"core/internal/presentation/http/router"
 	"database/sql"
@@ -42,20 +66,175 @@ func main() {
 	}
 
 	// Repositorios
-	productRepo := mysql.NewProductRepository(db)
-	productImageRepo := mysql.NewProductImageRepository(db)
-	userRepo := mysql.NewUserRepository(db)
+	auditRepo := mysql.NewAuditRepository(db)
+	auditRecorder := audit.NewRecorder(auditRepo)
+
+	// Los eventos del outbox se encolan para los webhooks registrados
+	webhookRepo := audit.NewWebhookRepository(mysql.NewWebhookRepository(db), auditRecorder)
+	webhookService := service.NewWebhookService(webhookRepo, notify.NewWebhookSender(), cfg.Webhook.MaxAttempts)
+
+	// Las mutaciones de catálogo y usuarios quedan registradas en audit_log
+	productRepo := audit.NewProductRepository(mysql.NewProductRepository(db), auditRecorder)
+	productImageRepo := audit.NewProductImageRepository(mysql.NewProductImageRepository(db), auditRecorder)
+	userRepo := audit.NewUserRepository(mysql.NewUserRepository(db), auditRecorder)
+	priceChangeRepo := audit.NewPriceChangeRepository(mysql.NewPriceChangeRepository(db), auditRecorder)
+	exchangeRateRepo := audit.NewExchangeRateRepository(mysql.NewExchangeRateRepository(db), auditRecorder)
+	productPriceRepo := audit.NewProductPriceRepository(mysql.NewProductPriceRepository(db), auditRecorder)
+	promotionRepo := audit.NewPromotionRepository(mysql.NewPromotionRepository(db), auditRecorder)
+	taxRateRepo := audit.NewTaxRateRepository(mysql.NewTaxRateRepository(db), auditRecorder)
+	shippingRepo := audit.NewShippingRepository(mysql.NewShippingRepository(db), auditRecorder)
+	inventoryRepo := audit.NewInventoryRepository(mysql.NewInventoryRepository(db), auditRecorder)
+	addressRepo := mysql.NewAddressRepository(db)
+	orderRepo := mysql.NewOrderRepository(db)
+	invoiceRepo := mysql.NewInvoiceRepository(db)
+	shipmentRepo := mysql.NewShipmentRepository(db)
+	returnRepo := mysql.NewReturnRepository(db)
+	stockCountRepo := mysql.NewStockCountRepository(db)
+	stockAlertRepo := audit.NewStockAlertRepository(mysql.NewStockAlertRepository(db), auditRecorder)
+	supplierRepo := audit.NewSupplierRepository(mysql.NewSupplierRepository(db), auditRecorder)
+	purchaseOrderRepo := audit.NewPurchaseOrderRepository(mysql.NewPurchaseOrderRepository(db), auditRecorder)
+	posRepo := audit.NewPosRepository(mysql.NewPosRepository(db), auditRecorder)
+	productImportRepo := mysql.NewProductImportRepository(db)
+	feedRepo := audit.NewFeedRepository(mysql.NewFeedRepository(db), auditRecorder)
+	marketplaceRepo := audit.NewMarketplaceRepository(mysql.NewMarketplaceRepository(db), auditRecorder)
 
 	// Servicios
 	productService := service.NewProductService(productRepo)
+	pricingService := service.NewPricingService(productRepo, priceChangeRepo)
+	currencyService := service.NewCurrencyService(productRepo, exchangeRateRepo, productPriceRepo)
+	promotionService := service.NewPromotionService(promotionRepo)
+	taxService := service.NewTaxService(taxRateRepo, tax.Mode(cfg.TaxPriceMode))
+	shippingService := service.NewShippingService(shippingRepo, currencyService)
+	addressService := service.NewAddressService(addressRepo)
+	inventoryService := service.NewInventoryService(inventoryRepo, productRepo)
+	stockCountService := service.NewStockCountService(stockCountRepo, productRepo)
+	purchaseService := service.NewPurchaseService(supplierRepo, purchaseOrderRepo, productRepo)
+	labelService := service.NewLabelService(productRepo, label.NewRenderer())
+	productImportService := service.NewProductImportService(productImportRepo, productRepo, productService, spreadsheet.NewReader(), dto.ProductValidator{})
+	productExportService := service.NewProductExportService(productRepo, spreadsheet.NewWriter(), cfg.AppBaseURL)
+	feedService := service.NewFeedService(feedRepo, productRepo, feed.NewRenderer(cfg.Feed.Brand, cfg.FrontendURL), cfg.FrontendURL, cfg.AppBaseURL, cfg.Feed.Brand)
+	orderService := service.NewOrderService(orderRepo, productRepo, inventoryRepo, addressRepo, currencyService, promotionService, taxService, shippingService)
+	posService := service.NewPosService(posRepo, productRepo, inventoryRepo, orderService, pdf.NewReceiptRenderer())
+
+	// Las facturas se autorizan con el stub local hasta integrar el web service del organismo
+	invoiceService := service.NewInvoiceService(invoiceRepo, orderRepo, taxauthority.NewStub(), pdf.NewInvoiceRenderer(),
+		cfg.Invoice.PointOfSale, entity.InvoiceIssuer{
+			Name:    cfg.Invoice.IssuerName,
+			TaxID:   cfg.Invoice.IssuerTaxID,
+			Address: cfg.Invoice.IssuerAddress,
+		})
+
+	// Las etiquetas y el seguimiento usan el carrier local hasta integrar uno real
+	fakeCarrier := carrier.NewFake(cfg.Invoice.IssuerName, cfg.Shipment.CarrierWebhookSecret, cfg.Shipment.FakeCarrierStep)
+	shipmentService := service.NewShipmentService(shipmentRepo, orderRepo, cfg.Shipment.TrackingInterval, fakeCarrier)
+
+	// Los reembolsos se acreditan con el stub local hasta integrar la pasarela de pagos
+	returnService := service.NewReturnService(returnRepo, orderRepo, productRepo, shipmentRepo, payment.NewStub())
+
+	// Las alertas de stock bajo salen por los canales configurados
+	var stockNotifiers []service.StockAlertNotifier
+	if cfg.SMTP.Enabled && len(cfg.StockAlert.Emails) > 0 {
+		stockNotifiers = append(stockNotifiers, notify.NewEmail(cfg.SMTP, cfg.StockAlert.Emails))
+	}
+	if cfg.StockAlert.WebhookURL != "" {
+		stockNotifiers = append(stockNotifiers, notify.NewWebhook(cfg.StockAlert.WebhookURL, cfg.StockAlert.WebhookSecret))
+	}
+	stockAlertService := service.NewStockAlertService(stockAlertRepo, productRepo, stockNotifiers...)
+
+	// Sin marketplace configurado se pueden consultar las ventas importadas pero no sincronizar
+	var marketplaceClients []service.MarketplaceClient
+	if cfg.Marketplace.Enabled {
+		marketplaceClients = append(marketplaceClients, marketplace.NewClient(cfg.Marketplace.Name, cfg.Marketplace.BaseURL, cfg.Marketplace.AccessToken))
+	}
+	marketplaceService := service.NewMarketplaceService(marketplaceRepo, productRepo, productImageRepo, cfg.AppBaseURL, marketplaceClients...)
+
+	// Los eventos de dominio se guardan en el outbox junto con cada cambio y el relay los
+	// publica en los webhooks y en los destinos opcionales
+	outboxSinks := []service.EventSink{outbox.NewWebhookSink(webhookService)}
+	if cfg.Outbox.LogEvents {
+		outboxSinks = append(outboxSinks, eventsink.NewLog())
+	}
+	if cfg.Outbox.NATSURL != "" {
+		natsSink, err := eventsink.NewNATS(cfg.Outbox.NATSURL, cfg.Outbox.NATSSubjectPrefix)
+		if err != nil {
+			log.Fatalf("failed to configure outbox: %v", err)
+		}
+		defer natsSink.Close()
+		outboxSinks = append(outboxSinks, natsSink)
+	}
+	outboxService := service.NewOutboxService(mysql.NewOutboxRepository(db), outboxSinks, cfg.Outbox.Retention)
+
+	// Tareas en segundo plano
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	publishScheduler := product.NewPublishScheduler(productRepo, cfg.PublishSchedulerInterval)
+	go publishScheduler.Run(ctx)
+
+	priceScheduler := product.NewPriceScheduler(priceChangeRepo, cfg.PriceSchedulerInterval)
+	go priceScheduler.Run(ctx)
+
+	importScheduler := productimport.NewScheduler(productImportService, cfg.ImportSchedulerInterval)
+	go importScheduler.Run(ctx)
+
+	feedScheduler := productfeed.NewScheduler(feedService, cfg.Feed.Interval)
+	go feedScheduler.Run(ctx)
+
+	invoiceScheduler := invoice.NewScheduler(invoiceService, cfg.Invoice.SchedulerInterval)
+	go invoiceScheduler.Run(ctx)
+
+	shipmentScheduler := shipment.NewScheduler(shipmentService, cfg.Shipment.TrackingInterval)
+	go shipmentScheduler.Run(ctx)
+
+	if len(stockNotifiers) > 0 {
+		stockAlertScheduler := stockalert.NewScheduler(stockAlertService, cfg.StockAlert.Interval)
+		go stockAlertScheduler.Run(ctx)
+	}
+
+	outboxScheduler := outbox.NewScheduler(outboxService, cfg.Outbox.RelayInterval)
+	go outboxScheduler.Run(ctx)
+
+	webhookScheduler := webhook.NewScheduler(webhookService, cfg.Webhook.Interval)
+	go webhookScheduler.Run(ctx)
+
+	if len(marketplaceClients) > 0 {
+		marketplaceSyncScheduler := marketplacesync.NewSyncScheduler(marketplaceService, cfg.Marketplace.SyncInterval)
+		go marketplaceSyncScheduler.Run(ctx)
+
+		marketplaceReconcileScheduler := marketplacesync.NewReconcileScheduler(marketplaceService, cfg.Marketplace.ReconcileInterval)
+		go marketplaceReconcileScheduler.Run(ctx)
+	}
 
 	// Handlers
-	productHandler := handler.NewProductHandler(productService)
+	productHandler := handler.NewProductHandler(productService, currencyService)
 	productImageHandler := handler.NewProductImageHandler(productRepo, productImageRepo)
 	authHandler := handler.NewAuthHandler(userRepo, cfg)
+	pricingHandler := handler.NewPricingHandler(pricingService)
+	auditHandler := handler.NewAuditHandler(auditRepo)
+	currencyHandler := handler.NewCurrencyHandler(currencyService)
+	orderHandler := handler.NewOrderHandler(orderService)
+	promotionHandler := handler.NewPromotionHandler(promotionService)
+	taxHandler := handler.NewTaxHandler(taxService)
+	invoiceHandler := handler.NewInvoiceHandler(invoiceService)
+	addressHandler := handler.NewAddressHandler(addressService)
+	shippingHandler := handler.NewShippingHandler(shippingService)
+	shipmentHandler := handler.NewShipmentHandler(shipmentService)
+	returnHandler := handler.NewReturnHandler(returnService)
+	inventoryHandler := handler.NewInventoryHandler(inventoryService)
+	stockCountHandler := handler.NewStockCountHandler(stockCountService)
+	stockAlertHandler := handler.NewStockAlertHandler(stockAlertService)
+	purchaseHandler := handler.NewPurchaseHandler(purchaseService)
+	labelHandler := handler.NewLabelHandler(labelService)
+	posHandler := handler.NewPosHandler(posService)
+	productImportHandler := handler.NewProductImportHandler(productImportService)
+	productExportHandler := handler.NewProductExportHandler(productExportService)
+	feedHandler := handler.NewFeedHandler(feedService, cfg.AppBaseURL)
+	marketplaceHandler := handler.NewMarketplaceHandler(marketplaceService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	outboxHandler := handler.NewOutboxHandler(outboxService)
 
 	// Router
-	e := router.Router(productHandler, productImageHandler, authHandler, cfg)
+	e := router.Router(productHandler, productImageHandler, authHandler, pricingHandler, auditHandler, currencyHandler, orderHandler, promotionHandler, taxHandler, invoiceHandler, addressHandler, shippingHandler, shipmentHandler, returnHandler, inventoryHandler, stockCountHandler, stockAlertHandler, purchaseHandler, labelHandler, posHandler, productImportHandler, productExportHandler, feedHandler, marketplaceHandler, webhookHandler, outboxHandler, cfg)
 
 	// Start server
 	log.Printf("Server starting on %s", cfg.ServerAddress)
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"core/internal/infrastructure/marketplace"
+)
+
+// fake-marketplace levanta un marketplace en memoria para probar la sincronización sin
+// una cuenta real. La API pide el mismo token que MARKETPLACE_ACCESS_TOKEN; las compras se
+// simulan con POST /items/{id}/purchase {"quantity": 1, "buyer": "..."}.
+func main() {
+	addr := flag.String("addr", ":9091", "dirección donde escucha")
+	token := flag.String("token", "", "token de acceso exigido; vacío acepta cualquiera")
+	flag.Parse()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           marketplace.NewFakeServer(*token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("Fake marketplace listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != nil {
+		log.Fatalf("Fake marketplace stopped: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"core/internal/application/audit"
+	"core/internal/config"
+	"core/internal/domain/service"
+	"core/internal/infrastructure/persistence/mysql"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// reconcile-stock compara el stock guardado con el libro de movimientos. Sin -fix solo
+// informa y termina con código 1 si hay diferencias; con -fix el libro manda y se
+// reescribe el stock de los productos afectados.
+func main() {
+	fix := flag.Bool("fix", false, "reescribir el stock con el saldo del libro")
+	flag.Parse()
+
+	// Cargar configuración desde .env
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	db, err := sql.Open("mysql", cfg.DSN)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		log.Fatalf("Failed to ping database: %v", err)
+	}
+
+	log.Printf("Connected to database: %s@%s:%s/%s", cfg.DBUser, cfg.DBHost, cfg.DBPort, cfg.DBName)
+
+	auditRecorder := audit.NewRecorder(mysql.NewAuditRepository(db))
+	inventoryRepo := audit.NewInventoryRepository(mysql.NewInventoryRepository(db), auditRecorder)
+	inventoryService := service.NewInventoryService(inventoryRepo, mysql.NewProductRepository(db))
+
+	found, err := inventoryService.Reconcile(context.Background(), *fix)
+	if err != nil {
+		log.Fatalf("Failed to reconcile stock: %v", err)
+	}
+	if len(found) == 0 {
+		log.Println("✓ Stock matches the ledger")
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("Stock Discrepancies")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("%-12s %-12s %-16s %-16s\n", "PRODUCT", "LOCATION", "EXPECTED", "RECORDED")
+	fmt.Println(strings.Repeat("-", 60))
+	for _, d := range found {
+		location := "available"
+		if d.LocationID > 0 {
+			location = fmt.Sprint(d.LocationID)
+		}
+		fmt.Printf("%-12d %-12s %-16d %-16d\n", d.ProductID, location, d.Expected, d.Recorded)
+	}
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Println()
+
+	if !*fix {
+		fmt.Println("💡 Run with -fix to rewrite the stock from the ledger")
+		fmt.Println()
+		os.Exit(1)
+	}
+	log.Printf("✓ Rebuilt stock of the affected products from the ledger (%d discrepancies)", len(found))
+}
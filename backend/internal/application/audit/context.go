@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type actorKey struct{}
+
+// WithActor agrega al contexto el actor que origina las mutaciones
+func WithActor(ctx context.Context, actor entity.Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext retorna el actor del contexto; sin actor se asume el sistema
+func ActorFromContext(ctx context.Context) entity.Actor {
+	if actor, ok := ctx.Value(actorKey{}).(entity.Actor); ok {
+		return actor
+	}
+	return entity.Actor{Role: "system"}
+}
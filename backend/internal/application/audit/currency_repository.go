@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+)
+
+// ExchangeRateRepository decora un repository.ExchangeRateRepository registrando cada tabla cargada.
+type ExchangeRateRepository struct {
+	repository.ExchangeRateRepository
+	rec *Recorder
+}
+
+func NewExchangeRateRepository(inner repository.ExchangeRateRepository, rec *Recorder) *ExchangeRateRepository {
+	return &ExchangeRateRepository{ExchangeRateRepository: inner, rec: rec}
+}
+
+var _ repository.ExchangeRateRepository = (*ExchangeRateRepository)(nil)
+
+func (r *ExchangeRateRepository) Create(ctx context.Context, t *entity.ExchangeRateTable) error {
+	if err := r.ExchangeRateRepository.Create(ctx, t); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityExchangeRate, t.ID, entity.AuditActionCreate, Diff(nil, t))
+	return nil
+}
+
+// ProductPriceRepository decora un repository.ProductPriceRepository registrando cada mutación.
+// El id de la entidad auditada es el del producto.
+type ProductPriceRepository struct {
+	repository.ProductPriceRepository
+	rec *Recorder
+}
+
+func NewProductPriceRepository(inner repository.ProductPriceRepository, rec *Recorder) *ProductPriceRepository {
+	return &ProductPriceRepository{ProductPriceRepository: inner, rec: rec}
+}
+
+var _ repository.ProductPriceRepository = (*ProductPriceRepository)(nil)
+
+func (r *ProductPriceRepository) Upsert(ctx context.Context, p *entity.ProductPrice) error {
+	before, err := r.find(ctx, p.ProductID, p.UnitPrice.Currency())
+	if err != nil {
+		return err
+	}
+	if err := r.ProductPriceRepository.Upsert(ctx, p); err != nil {
+		return err
+	}
+	action := entity.AuditActionUpdate
+	if before == nil {
+		action = entity.AuditActionCreate
+	}
+	r.rec.Record(ctx, entity.AuditEntityProductPrice, p.ProductID, action, Diff(before, p))
+	return nil
+}
+
+func (r *ProductPriceRepository) Delete(ctx context.Context, productID int64, currency money.Currency) error {
+	before, err := r.find(ctx, productID, currency)
+	if err != nil {
+		return err
+	}
+	if err := r.ProductPriceRepository.Delete(ctx, productID, currency); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityProductPrice, productID, entity.AuditActionDelete, Diff(before, nil))
+	return nil
+}
+
+func (r *ProductPriceRepository) find(ctx context.Context, productID int64, currency money.Currency) (*entity.ProductPrice, error) {
+	prices, err := r.ProductPriceRepository.FindByProducts(ctx, []int64{productID}, currency)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := prices[productID]; ok {
+		return &p, nil
+	}
+	return nil, nil
+}
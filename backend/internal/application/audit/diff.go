@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"core/internal/domain/entity"
+	"reflect"
+	"strings"
+)
+
+// Campos que cambian en cada escritura y no aportan al historial
+var ignoredFields = map[string]bool{
+	"updated_at": true,
+	"created_at": true,
+}
+
+// Diff compara dos structs del mismo tipo campo a campo, usando el tag json como nombre.
+// Acepta punteros; un nil se compara contra el valor cero del tipo.
+func Diff(before, after any) []entity.FieldChange {
+	bv, av := structValue(before), structValue(after)
+	if !bv.IsValid() && !av.IsValid() {
+		return nil
+	}
+	if !bv.IsValid() {
+		bv = reflect.New(av.Type()).Elem()
+	}
+	if !av.IsValid() {
+		av = reflect.New(bv.Type()).Elem()
+	}
+	if bv.Type() != av.Type() {
+		return nil
+	}
+
+	var changes []entity.FieldChange
+	t := bv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" || ignoredFields[name] {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		oldVal, newVal := plain(bv.Field(i)), plain(av.Field(i))
+		if !reflect.DeepEqual(oldVal, newVal) {
+			changes = append(changes, entity.FieldChange{Field: name, Old: oldVal, New: newVal})
+		}
+	}
+	return changes
+}
+
+func structValue(v any) reflect.Value {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || rv.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return rv
+}
+
+// plain desreferencia punteros para que el JSON guarde el valor y no la dirección
+func plain(v reflect.Value) any {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface()
+}
@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+// FeedRepository decora un repository.FeedRepository registrando los cambios de mapeo de
+// categorías. Los productos regenerados del feed no se auditan.
+type FeedRepository struct {
+	repository.FeedRepository
+	rec *Recorder
+}
+
+func NewFeedRepository(inner repository.FeedRepository, rec *Recorder) *FeedRepository {
+	return &FeedRepository{FeedRepository: inner, rec: rec}
+}
+
+var _ repository.FeedRepository = (*FeedRepository)(nil)
+
+func (r *FeedRepository) SaveCategoryMapping(ctx context.Context, m *entity.FeedCategoryMapping) error {
+	var before any
+	prev, err := r.FeedRepository.GetCategoryMapping(ctx, m.Category)
+	switch err {
+	case nil:
+		before = prev
+	case errors.ErrNotFound:
+	default:
+		return err
+	}
+	if err := r.FeedRepository.SaveCategoryMapping(ctx, m); err != nil {
+		return err
+	}
+	action := entity.AuditActionUpdate
+	if before == nil {
+		action = entity.AuditActionCreate
+	}
+	r.rec.Record(ctx, entity.AuditEntityFeedCategory, m.ID, action, Diff(before, m))
+	return nil
+}
+
+func (r *FeedRepository) DeleteCategoryMapping(ctx context.Context, category string) error {
+	prev, err := r.FeedRepository.GetCategoryMapping(ctx, category)
+	if err != nil {
+		return err
+	}
+	if err := r.FeedRepository.DeleteCategoryMapping(ctx, category); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityFeedCategory, prev.ID, entity.AuditActionDelete, Diff(prev, nil))
+	return nil
+}
@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+// InventoryRepository decora un repository.InventoryRepository registrando las ubicaciones,
+// los ajustes, las transferencias y las asignaciones de stock a órdenes
+type InventoryRepository struct {
+	repository.InventoryRepository
+	rec *Recorder
+}
+
+func NewInventoryRepository(inner repository.InventoryRepository, rec *Recorder) *InventoryRepository {
+	return &InventoryRepository{InventoryRepository: inner, rec: rec}
+}
+
+var _ repository.InventoryRepository = (*InventoryRepository)(nil)
+
+func (r *InventoryRepository) CreateLocation(ctx context.Context, l *entity.Location) error {
+	if err := r.InventoryRepository.CreateLocation(ctx, l); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityLocation, l.ID, entity.AuditActionCreate, Diff(nil, l))
+	return nil
+}
+
+func (r *InventoryRepository) UpdateLocation(ctx context.Context, l *entity.Location) error {
+	before, err := r.InventoryRepository.GetLocation(ctx, l.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.InventoryRepository.UpdateLocation(ctx, l); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityLocation, l.ID, entity.AuditActionUpdate, Diff(before, l))
+	return nil
+}
+
+func (r *InventoryRepository) Adjust(ctx context.Context, productID, locationID, delta int64, ref entity.StockMovementRef) error {
+	if err := r.InventoryRepository.Adjust(ctx, productID, locationID, delta, ref); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityProduct, productID, entity.AuditActionStock, []entity.FieldChange{
+		{Field: "location_id", Old: nil, New: locationID},
+		{Field: "stock_delta", Old: nil, New: delta},
+	})
+	return nil
+}
+
+func (r *InventoryRepository) Transfer(ctx context.Context, t *entity.StockTransfer) error {
+	if err := r.InventoryRepository.Transfer(ctx, t); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityStockTransfer, t.ID, entity.AuditActionCreate, Diff(nil, t))
+	return nil
+}
+
+func (r *InventoryRepository) Allocate(ctx context.Context, productID, qty int64, ref entity.StockMovementRef) ([]entity.StockAllocation, error) {
+	allocations, err := r.InventoryRepository.Allocate(ctx, productID, qty, ref)
+	if err != nil {
+		return nil, err
+	}
+	r.rec.Record(ctx, entity.AuditEntityProduct, productID, entity.AuditActionStock, []entity.FieldChange{
+		{Field: "stock_delta", Old: nil, New: -qty},
+		{Field: "allocations", Old: nil, New: allocations},
+	})
+	return allocations, nil
+}
+
+func (r *InventoryRepository) Release(ctx context.Context, productID int64, allocations []entity.StockAllocation, ref entity.StockMovementRef) error {
+	if err := r.InventoryRepository.Release(ctx, productID, allocations, ref); err != nil {
+		return err
+	}
+	var qty int64
+	for _, a := range allocations {
+		qty += a.Quantity
+	}
+	r.rec.Record(ctx, entity.AuditEntityProduct, productID, entity.AuditActionStock, []entity.FieldChange{
+		{Field: "stock_delta", Old: nil, New: qty},
+		{Field: "allocations", Old: nil, New: allocations},
+	})
+	return nil
+}
+
+// RebuildFromLedger registra el stock por ubicación de cada producto antes y después
+func (r *InventoryRepository) RebuildFromLedger(ctx context.Context, productIDs []int64) error {
+	before := make(map[int64][]entity.InventoryLevel, len(productIDs))
+	for _, id := range productIDs {
+		levels, err := r.InventoryRepository.Levels(ctx, id)
+		if err != nil {
+			return err
+		}
+		before[id] = levels
+	}
+	if err := r.InventoryRepository.RebuildFromLedger(ctx, productIDs); err != nil {
+		return err
+	}
+	for _, id := range productIDs {
+		after, err := r.InventoryRepository.Levels(ctx, id)
+		if err != nil {
+			continue
+		}
+		r.rec.Record(ctx, entity.AuditEntityProduct, id, entity.AuditActionStock,
+			[]entity.FieldChange{{Field: "levels", Old: before[id], New: after}})
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+// MarketplaceRepository decora un repository.MarketplaceRepository registrando los
+// vínculos de publicaciones. La sincronización y las ventas importadas no se auditan;
+// el stock que descuentan queda en el libro de stock.
+type MarketplaceRepository struct {
+	repository.MarketplaceRepository
+	rec *Recorder
+}
+
+func NewMarketplaceRepository(inner repository.MarketplaceRepository, rec *Recorder) *MarketplaceRepository {
+	return &MarketplaceRepository{MarketplaceRepository: inner, rec: rec}
+}
+
+var _ repository.MarketplaceRepository = (*MarketplaceRepository)(nil)
+
+func (r *MarketplaceRepository) CreateListing(ctx context.Context, l *entity.MarketplaceListing) error {
+	if err := r.MarketplaceRepository.CreateListing(ctx, l); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityMarketplaceListing, l.ID, entity.AuditActionCreate, Diff(nil, l))
+	return nil
+}
+
+func (r *MarketplaceRepository) UpdateListingStatus(ctx context.Context, id int64, status entity.MarketplaceListingStatus) error {
+	before, err := r.MarketplaceRepository.GetListing(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.MarketplaceRepository.UpdateListingStatus(ctx, id, status); err != nil {
+		return err
+	}
+	after := before
+	after.Status = status
+	r.rec.Record(ctx, entity.AuditEntityMarketplaceListing, id, entity.AuditActionStatus, Diff(before, after))
+	return nil
+}
+
+func (r *MarketplaceRepository) DeleteListing(ctx context.Context, id int64) error {
+	before, err := r.MarketplaceRepository.GetListing(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.MarketplaceRepository.DeleteListing(ctx, id); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityMarketplaceListing, id, entity.AuditActionDelete, Diff(before, nil))
+	return nil
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+// PosRepository decora un repository.PosRepository registrando la apertura y el cierre
+// de los turnos de caja. Las ventas quedan en sus órdenes y el stock en el libro.
+type PosRepository struct {
+	repository.PosRepository
+	rec *Recorder
+}
+
+func NewPosRepository(inner repository.PosRepository, rec *Recorder) *PosRepository {
+	return &PosRepository{PosRepository: inner, rec: rec}
+}
+
+var _ repository.PosRepository = (*PosRepository)(nil)
+
+func (r *PosRepository) OpenShift(ctx context.Context, sh *entity.RegisterShift) error {
+	if err := r.PosRepository.OpenShift(ctx, sh); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityRegisterShift, sh.ID, entity.AuditActionCreate, Diff(nil, sh))
+	return nil
+}
+
+func (r *PosRepository) CloseShift(ctx context.Context, sh *entity.RegisterShift) error {
+	if err := r.PosRepository.CloseShift(ctx, sh); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityRegisterShift, sh.ID, entity.AuditActionStatus, []entity.FieldChange{
+		{Field: "status", Old: entity.RegisterShiftStatusOpen, New: entity.RegisterShiftStatusClosed},
+		{Field: "expected_cash", Old: nil, New: sh.ExpectedCash},
+		{Field: "counted_cash", Old: nil, New: sh.CountedCash},
+	})
+	return nil
+}
@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+	"time"
+)
+
+// PriceChangeRepository decora un repository.PriceChangeRepository registrando cada mutación.
+type PriceChangeRepository struct {
+	repository.PriceChangeRepository
+	rec *Recorder
+}
+
+func NewPriceChangeRepository(inner repository.PriceChangeRepository, rec *Recorder) *PriceChangeRepository {
+	return &PriceChangeRepository{PriceChangeRepository: inner, rec: rec}
+}
+
+var _ repository.PriceChangeRepository = (*PriceChangeRepository)(nil)
+
+func (r *PriceChangeRepository) Create(ctx context.Context, c *entity.PriceChange) error {
+	if err := r.PriceChangeRepository.Create(ctx, c); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityPriceChange, c.ID, entity.AuditActionCreate, Diff(nil, c))
+	return nil
+}
+
+func (r *PriceChangeRepository) Delete(ctx context.Context, id int64) error {
+	before, err := r.PriceChangeRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.PriceChangeRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityPriceChange, id, entity.AuditActionDelete, Diff(before, nil))
+	return nil
+}
+
+// ApplyDue registra el precio anterior y el nuevo en el historial de cada producto, y el
+// motivo de los cambios que no se pudieron aplicar
+func (r *PriceChangeRepository) ApplyDue(ctx context.Context, now time.Time) ([]entity.AppliedPriceChange, []entity.PriceChange, error) {
+	applied, failed, err := r.PriceChangeRepository.ApplyDue(ctx, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, a := range applied {
+		r.rec.Record(ctx, entity.AuditEntityProduct, a.Change.ProductID, entity.AuditActionPricing, Diff(a.Before, a.After))
+	}
+	for _, c := range failed {
+		r.rec.Record(ctx, entity.AuditEntityPriceChange, c.ID, entity.AuditActionUpdate,
+			[]entity.FieldChange{{Field: "failure", Old: "", New: c.Failure}})
+	}
+	return applied, failed, nil
+}
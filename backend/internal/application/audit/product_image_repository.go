@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+// ProductImageRepository decora un repository.ProductImageRepository registrando cada mutación.
+type ProductImageRepository struct {
+	repository.ProductImageRepository
+	rec *Recorder
+}
+
+func NewProductImageRepository(inner repository.ProductImageRepository, rec *Recorder) *ProductImageRepository {
+	return &ProductImageRepository{ProductImageRepository: inner, rec: rec}
+}
+
+var _ repository.ProductImageRepository = (*ProductImageRepository)(nil)
+
+func (r *ProductImageRepository) Create(ctx context.Context, image *entity.ProductImage) error {
+	if err := r.ProductImageRepository.Create(ctx, image); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityProductImage, image.ID, entity.AuditActionCreate, Diff(nil, image))
+	return nil
+}
+
+func (r *ProductImageRepository) Delete(ctx context.Context, id int64) error {
+	if err := r.ProductImageRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityProductImage, id, entity.AuditActionDelete, nil)
+	return nil
+}
@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+	"time"
+)
+
+// ProductRepository decora un repository.ProductRepository registrando cada mutación.
+// Las lecturas se delegan sin cambios.
+type ProductRepository struct {
+	repository.ProductRepository
+	rec *Recorder
+}
+
+func NewProductRepository(inner repository.ProductRepository, rec *Recorder) *ProductRepository {
+	return &ProductRepository{ProductRepository: inner, rec: rec}
+}
+
+var _ repository.ProductRepository = (*ProductRepository)(nil)
+
+func (r *ProductRepository) Create(ctx context.Context, p *entity.Product) error {
+	if err := r.ProductRepository.Create(ctx, p); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityProduct, p.ID, entity.AuditActionCreate, Diff(nil, p))
+	return nil
+}
+
+func (r *ProductRepository) Update(ctx context.Context, p *entity.Product) error {
+	before, err := r.ProductRepository.GetByID(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.ProductRepository.Update(ctx, p); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityProduct, p.ID, entity.AuditActionUpdate, Diff(before, p))
+	return nil
+}
+
+func (r *ProductRepository) Delete(ctx context.Context, id int64) error {
+	before, err := r.ProductRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.ProductRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityProduct, id, entity.AuditActionDelete, Diff(before, nil))
+	return nil
+}
+
+func (r *ProductRepository) UpdateStock(ctx context.Context, id int64, delta int64, ref entity.StockMovementRef) (entity.StockUpdate, error) {
+	u, err := r.ProductRepository.UpdateStock(ctx, id, delta, ref)
+	if err != nil || delta == 0 {
+		return u, err
+	}
+	r.rec.Record(ctx, entity.AuditEntityProduct, id, entity.AuditActionStock,
+		[]entity.FieldChange{{Field: "stock", Old: u.Before, New: u.After}})
+	return u, nil
+}
+
+func (r *ProductRepository) UpdateStatus(ctx context.Context, p *entity.Product) error {
+	before, err := r.ProductRepository.GetByID(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.ProductRepository.UpdateStatus(ctx, p); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityProduct, p.ID, entity.AuditActionStatus, Diff(before, p))
+	return nil
+}
+
+func (r *ProductRepository) UpdatePricing(ctx context.Context, p *entity.Product) error {
+	before, err := r.ProductRepository.GetByID(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.ProductRepository.UpdatePricing(ctx, p); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityProduct, p.ID, entity.AuditActionPricing, Diff(before, p))
+	return nil
+}
+
+func (r *ProductRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	n, err := r.ProductRepository.PublishDue(ctx, now)
+	if err == nil && n > 0 {
+		r.rec.Record(ctx, entity.AuditEntityProduct, 0, entity.AuditActionBulk,
+			[]entity.FieldChange{{Field: "status", Old: entity.ProductStatusScheduled, New: entity.ProductStatusPublished}, {Field: "affected", New: n}})
+	}
+	return n, err
+}
+
+func (r *ProductRepository) ArchiveExpired(ctx context.Context, now time.Time) (int64, error) {
+	n, err := r.ProductRepository.ArchiveExpired(ctx, now)
+	if err == nil && n > 0 {
+		r.rec.Record(ctx, entity.AuditEntityProduct, 0, entity.AuditActionBulk,
+			[]entity.FieldChange{{Field: "status", Old: entity.ProductStatusPublished, New: entity.ProductStatusArchived}, {Field: "affected", New: n}})
+	}
+	return n, err
+}
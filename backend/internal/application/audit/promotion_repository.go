@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+// PromotionRepository decora un repository.PromotionRepository registrando cada mutación.
+type PromotionRepository struct {
+	repository.PromotionRepository
+	rec *Recorder
+}
+
+func NewPromotionRepository(inner repository.PromotionRepository, rec *Recorder) *PromotionRepository {
+	return &PromotionRepository{PromotionRepository: inner, rec: rec}
+}
+
+var _ repository.PromotionRepository = (*PromotionRepository)(nil)
+
+func (r *PromotionRepository) Create(ctx context.Context, p *entity.Promotion) error {
+	if err := r.PromotionRepository.Create(ctx, p); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityPromotion, p.ID, entity.AuditActionCreate, Diff(nil, p))
+	return nil
+}
+
+func (r *PromotionRepository) Update(ctx context.Context, p *entity.Promotion) error {
+	before, err := r.PromotionRepository.GetByID(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.PromotionRepository.Update(ctx, p); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityPromotion, p.ID, entity.AuditActionUpdate, Diff(before, p))
+	return nil
+}
+
+func (r *PromotionRepository) Delete(ctx context.Context, id int64) error {
+	before, err := r.PromotionRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.PromotionRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityPromotion, id, entity.AuditActionDelete, Diff(before, nil))
+	return nil
+}
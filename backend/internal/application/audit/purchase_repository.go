@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+// SupplierRepository decora un repository.SupplierRepository registrando altas y ediciones
+type SupplierRepository struct {
+	repository.SupplierRepository
+	rec *Recorder
+}
+
+func NewSupplierRepository(inner repository.SupplierRepository, rec *Recorder) *SupplierRepository {
+	return &SupplierRepository{SupplierRepository: inner, rec: rec}
+}
+
+var _ repository.SupplierRepository = (*SupplierRepository)(nil)
+
+func (r *SupplierRepository) Create(ctx context.Context, s *entity.Supplier) error {
+	if err := r.SupplierRepository.Create(ctx, s); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntitySupplier, s.ID, entity.AuditActionCreate, Diff(nil, s))
+	return nil
+}
+
+func (r *SupplierRepository) Update(ctx context.Context, s *entity.Supplier) error {
+	before, err := r.SupplierRepository.GetByID(ctx, s.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.SupplierRepository.Update(ctx, s); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntitySupplier, s.ID, entity.AuditActionUpdate, Diff(before, s))
+	return nil
+}
+
+// PurchaseOrderRepository decora un repository.PurchaseOrderRepository registrando las
+// órdenes, sus cambios de estado y las recepciones. El stock recibido queda en el libro.
+type PurchaseOrderRepository struct {
+	repository.PurchaseOrderRepository
+	rec *Recorder
+}
+
+func NewPurchaseOrderRepository(inner repository.PurchaseOrderRepository, rec *Recorder) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{PurchaseOrderRepository: inner, rec: rec}
+}
+
+var _ repository.PurchaseOrderRepository = (*PurchaseOrderRepository)(nil)
+
+func (r *PurchaseOrderRepository) Create(ctx context.Context, po *entity.PurchaseOrder) error {
+	if err := r.PurchaseOrderRepository.Create(ctx, po); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityPurchaseOrder, po.ID, entity.AuditActionCreate, Diff(nil, po))
+	return nil
+}
+
+func (r *PurchaseOrderRepository) UpdateStatus(ctx context.Context, id int64, from, to entity.PurchaseOrderStatus) error {
+	if err := r.PurchaseOrderRepository.UpdateStatus(ctx, id, from, to); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityPurchaseOrder, id, entity.AuditActionStatus, []entity.FieldChange{
+		{Field: "status", Old: from, New: to},
+	})
+	return nil
+}
+
+func (r *PurchaseOrderRepository) Receive(ctx context.Context, rc *entity.PurchaseReceipt) error {
+	if err := r.PurchaseOrderRepository.Receive(ctx, rc); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityPurchaseOrder, rc.PurchaseOrderID, entity.AuditActionStock, []entity.FieldChange{
+		{Field: "receipt", Old: nil, New: rc},
+	})
+	return nil
+}
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+	"log"
+)
+
+// Recorder escribe entradas de auditoría con el actor tomado del contexto
+type Recorder struct {
+	repo repository.AuditRepository
+}
+
+func NewRecorder(repo repository.AuditRepository) *Recorder {
+	return &Recorder{repo: repo}
+}
+
+// Record registra una mutación. Las actualizaciones sin cambios se omiten.
+// Un error al auditar se loguea pero no revierte la mutación ya aplicada.
+func (r *Recorder) Record(ctx context.Context, entityType string, entityID int64, action entity.AuditAction, changes []entity.FieldChange) {
+	if action == entity.AuditActionUpdate && len(changes) == 0 {
+		return
+	}
+	entry := &entity.AuditEntry{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Changes:    changes,
+		Actor:      ActorFromContext(ctx),
+	}
+	if err := r.repo.Create(context.WithoutCancel(ctx), entry); err != nil {
+		log.Printf("[AUDIT] Error recording %s %s #%d: %v", action, entityType, entityID, err)
+	}
+}
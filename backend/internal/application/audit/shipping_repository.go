@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+// ShippingRepository decora un repository.ShippingRepository registrando cada mutación
+// de zonas y métodos de envío.
+type ShippingRepository struct {
+	repository.ShippingRepository
+	rec *Recorder
+}
+
+func NewShippingRepository(inner repository.ShippingRepository, rec *Recorder) *ShippingRepository {
+	return &ShippingRepository{ShippingRepository: inner, rec: rec}
+}
+
+var _ repository.ShippingRepository = (*ShippingRepository)(nil)
+
+func (r *ShippingRepository) CreateZone(ctx context.Context, z *entity.ShippingZone) error {
+	if err := r.ShippingRepository.CreateZone(ctx, z); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityShippingZone, z.ID, entity.AuditActionCreate, Diff(nil, z))
+	return nil
+}
+
+func (r *ShippingRepository) UpdateZone(ctx context.Context, z *entity.ShippingZone) error {
+	before, err := r.ShippingRepository.GetZone(ctx, z.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.ShippingRepository.UpdateZone(ctx, z); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityShippingZone, z.ID, entity.AuditActionUpdate, Diff(before, z))
+	return nil
+}
+
+func (r *ShippingRepository) DeleteZone(ctx context.Context, id int64) error {
+	before, err := r.ShippingRepository.GetZone(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.ShippingRepository.DeleteZone(ctx, id); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityShippingZone, id, entity.AuditActionDelete, Diff(before, nil))
+	return nil
+}
+
+func (r *ShippingRepository) CreateMethod(ctx context.Context, m *entity.ShippingMethod) error {
+	if err := r.ShippingRepository.CreateMethod(ctx, m); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityShippingMethod, m.ID, entity.AuditActionCreate, Diff(nil, m))
+	return nil
+}
+
+func (r *ShippingRepository) UpdateMethod(ctx context.Context, m *entity.ShippingMethod) error {
+	before, err := r.ShippingRepository.GetMethod(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.ShippingRepository.UpdateMethod(ctx, m); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityShippingMethod, m.ID, entity.AuditActionUpdate, Diff(before, m))
+	return nil
+}
+
+func (r *ShippingRepository) DeleteMethod(ctx context.Context, id int64) error {
+	before, err := r.ShippingRepository.GetMethod(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.ShippingRepository.DeleteMethod(ctx, id); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityShippingMethod, id, entity.AuditActionDelete, Diff(before, nil))
+	return nil
+}
@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+// StockAlertRepository decora un repository.StockAlertRepository registrando los cambios
+// de umbrales de reposición. Las alertas enviadas no se auditan.
+type StockAlertRepository struct {
+	repository.StockAlertRepository
+	rec *Recorder
+}
+
+func NewStockAlertRepository(inner repository.StockAlertRepository, rec *Recorder) *StockAlertRepository {
+	return &StockAlertRepository{StockAlertRepository: inner, rec: rec}
+}
+
+var _ repository.StockAlertRepository = (*StockAlertRepository)(nil)
+
+func (r *StockAlertRepository) SetReorderPoint(ctx context.Context, rp *entity.ReorderPoint) error {
+	var before any
+	prev, err := r.StockAlertRepository.GetReorderPoint(ctx, rp.ProductID)
+	switch err {
+	case nil:
+		before = prev
+	case errors.ErrNotFound:
+	default:
+		return err
+	}
+	if err := r.StockAlertRepository.SetReorderPoint(ctx, rp); err != nil {
+		return err
+	}
+	action := entity.AuditActionUpdate
+	var after any = rp
+	switch {
+	case before == nil && rp.Threshold == 0:
+		return nil
+	case before == nil:
+		action = entity.AuditActionCreate
+	case rp.Threshold == 0:
+		action, after = entity.AuditActionDelete, nil
+	}
+	r.rec.Record(ctx, entity.AuditEntityReorderPoint, rp.ProductID, action, Diff(before, after))
+	return nil
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+// TaxRateRepository decora un repository.TaxRateRepository registrando cada mutación.
+type TaxRateRepository struct {
+	repository.TaxRateRepository
+	rec *Recorder
+}
+
+func NewTaxRateRepository(inner repository.TaxRateRepository, rec *Recorder) *TaxRateRepository {
+	return &TaxRateRepository{TaxRateRepository: inner, rec: rec}
+}
+
+var _ repository.TaxRateRepository = (*TaxRateRepository)(nil)
+
+func (r *TaxRateRepository) Upsert(ctx context.Context, t *entity.TaxRate) error {
+	var before *entity.TaxRate
+	rates, err := r.TaxRateRepository.List(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range rates {
+		if rates[i].Category == t.Category {
+			before = &rates[i]
+		}
+	}
+	if err := r.TaxRateRepository.Upsert(ctx, t); err != nil {
+		return err
+	}
+	action := entity.AuditActionUpdate
+	if before == nil {
+		action = entity.AuditActionCreate
+	}
+	r.rec.Record(ctx, entity.AuditEntityTaxRate, t.ID, action, Diff(before, t))
+	return nil
+}
+
+func (r *TaxRateRepository) Delete(ctx context.Context, id int64) error {
+	before, err := r.TaxRateRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.TaxRateRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityTaxRate, id, entity.AuditActionDelete, Diff(before, nil))
+	return nil
+}
@@ -0,0 +1,49 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+// UserRepository decora un repository.UserRepository registrando cada mutación.
+// Las contraseñas nunca se guardan en el historial.
+type UserRepository struct {
+	repository.UserRepository
+	rec *Recorder
+}
+
+func NewUserRepository(inner repository.UserRepository, rec *Recorder) *UserRepository {
+	return &UserRepository{UserRepository: inner, rec: rec}
+}
+
+var _ repository.UserRepository = (*UserRepository)(nil)
+
+func (r *UserRepository) Store(ctx context.Context, user *entity.User) error {
+	if err := r.UserRepository.Store(ctx, user); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityUser, user.ID, entity.AuditActionCreate, Diff(nil, user))
+	return nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
+	before, err := r.UserRepository.GetByID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityUser, user.ID, entity.AuditActionUpdate, Diff(before, user))
+	return nil
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id int64, hashedPassword string) error {
+	if err := r.UserRepository.UpdatePassword(ctx, id, hashedPassword); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityUser, id, entity.AuditActionUpdate,
+		[]entity.FieldChange{{Field: "password", Old: "***", New: "***"}})
+	return nil
+}
@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+// WebhookRepository decora un repository.WebhookRepository registrando los cambios de
+// destinos. El secreto no se guarda en el historial; los envíos tienen su propio registro.
+type WebhookRepository struct {
+	repository.WebhookRepository
+	rec *Recorder
+}
+
+func NewWebhookRepository(inner repository.WebhookRepository, rec *Recorder) *WebhookRepository {
+	return &WebhookRepository{WebhookRepository: inner, rec: rec}
+}
+
+var _ repository.WebhookRepository = (*WebhookRepository)(nil)
+
+func (r *WebhookRepository) CreateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	if err := r.WebhookRepository.CreateEndpoint(ctx, e); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityWebhookEndpoint, e.ID, entity.AuditActionCreate, Diff(nil, e))
+	return nil
+}
+
+func (r *WebhookRepository) UpdateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	before, err := r.WebhookRepository.GetEndpoint(ctx, e.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.WebhookRepository.UpdateEndpoint(ctx, e); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityWebhookEndpoint, e.ID, entity.AuditActionUpdate, Diff(before, e))
+	return nil
+}
+
+func (r *WebhookRepository) DeleteEndpoint(ctx context.Context, id int64) error {
+	before, err := r.WebhookRepository.GetEndpoint(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.WebhookRepository.DeleteEndpoint(ctx, id); err != nil {
+		return err
+	}
+	r.rec.Record(ctx, entity.AuditEntityWebhookEndpoint, id, entity.AuditActionDelete, Diff(before, nil))
+	return nil
+}
@@ -0,0 +1,49 @@
+// Package invoice emite y autoriza las facturas de las órdenes pagadas en segundo plano
+package invoice
+
+import (
+	"context"
+	"core/internal/domain/service"
+	"log"
+	"time"
+)
+
+// Scheduler factura las órdenes pagadas y reintenta las autorizaciones pendientes
+type Scheduler struct {
+	svc      service.InvoiceService
+	interval time.Duration
+}
+
+func NewScheduler(svc service.InvoiceService, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{svc: svc, interval: interval}
+}
+
+// Run ejecuta el scheduler hasta que se cancele el contexto
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(ctx)
+		}
+	}
+}
+
+// Tick aplica una pasada del scheduler
+func (s *Scheduler) Tick(ctx context.Context) {
+	issued, authorized, err := s.svc.ProcessPending(ctx)
+	if err != nil {
+		log.Printf("[SCHEDULER] Error processing invoices: %v", err)
+	}
+	if issued > 0 || authorized > 0 {
+		log.Printf("[SCHEDULER] Issued %d invoice(s), %d authorized", issued, authorized)
+	}
+}
@@ -0,0 +1,53 @@
+package marketplacesync
+
+import (
+	"context"
+	"core/internal/domain/service"
+	"log"
+	"time"
+)
+
+// ReconcileScheduler compara periódicamente las publicaciones con los productos y
+// corrige las diferencias de stock y precio que no detectó la sincronización
+type ReconcileScheduler struct {
+	svc      service.MarketplaceService
+	interval time.Duration
+}
+
+func NewReconcileScheduler(svc service.MarketplaceService, interval time.Duration) *ReconcileScheduler {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &ReconcileScheduler{svc: svc, interval: interval}
+}
+
+// Run ejecuta el scheduler hasta que se cancele el contexto. La primera pasada espera
+// un intervalo para no competir con la sincronización del arranque.
+func (s *ReconcileScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(ctx)
+		}
+	}
+}
+
+// Tick aplica una pasada del scheduler
+func (s *ReconcileScheduler) Tick(ctx context.Context) {
+	found, err := s.svc.Reconcile(ctx, true)
+	if err != nil {
+		log.Printf("[SCHEDULER] Error reconciling marketplace listings: %v", err)
+		return
+	}
+	for _, d := range found {
+		if !d.Fixed {
+			log.Printf("[SCHEDULER] Marketplace listing %s (%s) of product %d needs attention: %s is %q, expected %q",
+				d.ExternalID, d.Marketplace, d.ProductID, d.Field, d.Remote, d.Local)
+		}
+	}
+}
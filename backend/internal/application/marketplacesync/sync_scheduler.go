@@ -0,0 +1,46 @@
+// Package marketplacesync sincroniza en segundo plano las publicaciones en marketplaces
+package marketplacesync
+
+import (
+	"context"
+	"core/internal/domain/service"
+	"log"
+	"time"
+)
+
+// SyncScheduler importa las ventas de los marketplaces y envía los cambios de stock y
+// precio de los productos publicados
+type SyncScheduler struct {
+	svc      service.MarketplaceService
+	interval time.Duration
+}
+
+func NewSyncScheduler(svc service.MarketplaceService, interval time.Duration) *SyncScheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &SyncScheduler{svc: svc, interval: interval}
+}
+
+// Run ejecuta el scheduler hasta que se cancele el contexto
+func (s *SyncScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(ctx)
+		}
+	}
+}
+
+// Tick aplica una pasada del scheduler
+func (s *SyncScheduler) Tick(ctx context.Context) {
+	if _, err := s.svc.Sync(ctx); err != nil {
+		log.Printf("[SCHEDULER] Error syncing marketplaces: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"core/internal/domain/service"
+	"log"
+	"time"
+)
+
+// Scheduler publica los eventos pendientes del outbox y borra los ya publicados que
+// superaron la retención
+type Scheduler struct {
+	svc      service.OutboxService
+	interval time.Duration
+}
+
+func NewScheduler(svc service.OutboxService, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{svc: svc, interval: interval}
+}
+
+// Run ejecuta el scheduler hasta que se cancele el contexto
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(ctx)
+		}
+	}
+}
+
+// Tick aplica una pasada del scheduler
+func (s *Scheduler) Tick(ctx context.Context) {
+	published, err := s.svc.Relay(ctx)
+	if err != nil {
+		log.Printf("[SCHEDULER] Error relaying outbox events: %v", err)
+	}
+	if published > 0 {
+		log.Printf("[SCHEDULER] Published %d outbox event(s)", published)
+	}
+	purged, err := s.svc.Purge(ctx)
+	if err != nil {
+		log.Printf("[SCHEDULER] Error purging outbox events: %v", err)
+	}
+	if purged > 0 {
+		log.Printf("[SCHEDULER] Purged %d published outbox event(s)", purged)
+	}
+}
@@ -0,0 +1,27 @@
+// Package outbox conecta el relay del outbox con los destinos de la aplicación y lo
+// ejecuta en segundo plano
+package outbox
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/service"
+)
+
+// WebhookSink encola los eventos del outbox para los webhooks registrados. El envío
+// HTTP lo hace después el scheduler de webhooks, con sus propios reintentos.
+type WebhookSink struct {
+	svc service.WebhookService
+}
+
+func NewWebhookSink(svc service.WebhookService) *WebhookSink {
+	return &WebhookSink{svc: svc}
+}
+
+var _ service.EventSink = (*WebhookSink)(nil)
+
+func (s *WebhookSink) Name() string { return "webhooks" }
+
+func (s *WebhookSink) Publish(ctx context.Context, e entity.OutboxEvent) error {
+	return s.svc.PublishEvent(ctx, e)
+}
@@ -0,0 +1,52 @@
+package product
+
+import (
+	"context"
+	"core/internal/domain/repository"
+	"log"
+	"time"
+)
+
+// PriceScheduler aplica los cambios de precio programados cuando llega su fecha
+type PriceScheduler struct {
+	repo     repository.PriceChangeRepository
+	interval time.Duration
+}
+
+func NewPriceScheduler(repo repository.PriceChangeRepository, interval time.Duration) *PriceScheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &PriceScheduler{repo: repo, interval: interval}
+}
+
+// Run ejecuta el scheduler hasta que se cancele el contexto
+func (s *PriceScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Tick(ctx, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.Tick(ctx, now)
+		}
+	}
+}
+
+// Tick aplica una pasada del scheduler con la hora indicada
+func (s *PriceScheduler) Tick(ctx context.Context, now time.Time) {
+	applied, failed, err := s.repo.ApplyDue(ctx, now)
+	if err != nil {
+		log.Printf("[SCHEDULER] Error applying scheduled price changes: %v", err)
+		return
+	}
+	if len(applied) > 0 {
+		log.Printf("[SCHEDULER] Applied %d scheduled price change(s)", len(applied))
+	}
+	for _, c := range failed {
+		log.Printf("[SCHEDULER] Scheduled price change %d of product %d not applied: %s", c.ID, c.ProductID, c.Failure)
+	}
+}
@@ -0,0 +1,54 @@
+package product
+
+import (
+	"context"
+	"core/internal/domain/repository"
+	"log"
+	"time"
+)
+
+// PublishScheduler cambia el estado de los productos según publish_at y unpublish_at
+type PublishScheduler struct {
+	repo     repository.ProductRepository
+	interval time.Duration
+}
+
+func NewPublishScheduler(repo repository.ProductRepository, interval time.Duration) *PublishScheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &PublishScheduler{repo: repo, interval: interval}
+}
+
+// Run ejecuta el scheduler hasta que se cancele el contexto
+func (s *PublishScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Tick(ctx, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.Tick(ctx, now)
+		}
+	}
+}
+
+// Tick aplica una pasada del scheduler con la hora indicada
+func (s *PublishScheduler) Tick(ctx context.Context, now time.Time) {
+	published, err := s.repo.PublishDue(ctx, now)
+	if err != nil {
+		log.Printf("[SCHEDULER] Error publishing scheduled products: %v", err)
+	} else if published > 0 {
+		log.Printf("[SCHEDULER] Published %d scheduled product(s)", published)
+	}
+
+	archived, err := s.repo.ArchiveExpired(ctx, now)
+	if err != nil {
+		log.Printf("[SCHEDULER] Error archiving expired products: %v", err)
+	} else if archived > 0 {
+		log.Printf("[SCHEDULER] Archived %d expired product(s)", archived)
+	}
+}
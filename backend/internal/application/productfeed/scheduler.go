@@ -0,0 +1,45 @@
+// Package productfeed regenera en segundo plano los feeds de productos
+package productfeed
+
+import (
+	"context"
+	"core/internal/domain/service"
+	"log"
+	"time"
+)
+
+// Scheduler regenera los feeds de Google y Meta con los cambios del catálogo
+type Scheduler struct {
+	svc      service.FeedService
+	interval time.Duration
+}
+
+func NewScheduler(svc service.FeedService, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{svc: svc, interval: interval}
+}
+
+// Run ejecuta el scheduler hasta que se cancele el contexto
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(ctx)
+		}
+	}
+}
+
+// Tick aplica una pasada del scheduler
+func (s *Scheduler) Tick(ctx context.Context) {
+	if _, err := s.svc.Refresh(ctx); err != nil {
+		log.Printf("[SCHEDULER] Error refreshing product feeds: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+// Package productimport procesa en segundo plano las importaciones de productos
+package productimport
+
+import (
+	"context"
+	"core/internal/domain/service"
+	"log"
+	"time"
+)
+
+// Scheduler procesa las importaciones en cola y retoma las que quedaron cortadas
+type Scheduler struct {
+	svc      service.ProductImportService
+	interval time.Duration
+}
+
+func NewScheduler(svc service.ProductImportService, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{svc: svc, interval: interval}
+}
+
+// Run ejecuta el scheduler hasta que se cancele el contexto
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(ctx)
+		}
+	}
+}
+
+// Tick aplica una pasada del scheduler
+func (s *Scheduler) Tick(ctx context.Context) {
+	done, err := s.svc.ProcessPending(ctx)
+	if err != nil {
+		log.Printf("[SCHEDULER] Error processing product imports: %v", err)
+	}
+	if done > 0 {
+		log.Printf("[SCHEDULER] Finished %d product import(s)", done)
+	}
+}
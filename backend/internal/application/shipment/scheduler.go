@@ -0,0 +1,49 @@
+// Package shipment actualiza el seguimiento de los envíos en segundo plano
+package shipment
+
+import (
+	"context"
+	"core/internal/domain/service"
+	"log"
+	"time"
+)
+
+// Scheduler consulta a los carriers el seguimiento de los envíos abiertos
+type Scheduler struct {
+	svc      service.ShipmentService
+	interval time.Duration
+}
+
+func NewScheduler(svc service.ShipmentService, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{svc: svc, interval: interval}
+}
+
+// Run ejecuta el scheduler hasta que se cancele el contexto
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(ctx)
+		}
+	}
+}
+
+// Tick aplica una pasada del scheduler
+func (s *Scheduler) Tick(ctx context.Context) {
+	updated, err := s.svc.RefreshOpen(ctx)
+	if err != nil {
+		log.Printf("[SCHEDULER] Error refreshing shipments: %v", err)
+	}
+	if updated > 0 {
+		log.Printf("[SCHEDULER] Updated tracking of %d shipment(s)", updated)
+	}
+}
@@ -0,0 +1,49 @@
+// Package stockalert avisa en segundo plano los productos con stock bajo
+package stockalert
+
+import (
+	"context"
+	"core/internal/domain/service"
+	"log"
+	"time"
+)
+
+// Scheduler busca productos que bajaron de su umbral después de ventas y ajustes y los avisa
+type Scheduler struct {
+	svc      service.StockAlertService
+	interval time.Duration
+}
+
+func NewScheduler(svc service.StockAlertService, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{svc: svc, interval: interval}
+}
+
+// Run ejecuta el scheduler hasta que se cancele el contexto
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(ctx)
+		}
+	}
+}
+
+// Tick aplica una pasada del scheduler
+func (s *Scheduler) Tick(ctx context.Context) {
+	sent, err := s.svc.Notify(ctx)
+	if err != nil {
+		log.Printf("[SCHEDULER] Error sending low stock alerts: %v", err)
+	}
+	if sent > 0 {
+		log.Printf("[SCHEDULER] Sent %d low stock alert(s)", sent)
+	}
+}
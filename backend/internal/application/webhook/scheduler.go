@@ -0,0 +1,50 @@
+// Package webhook hace en segundo plano los envíos de los webhooks. Los eventos llegan
+// desde el outbox.
+package webhook
+
+import (
+	"context"
+	"core/internal/domain/service"
+	"log"
+	"time"
+)
+
+// Scheduler hace los envíos pendientes
+type Scheduler struct {
+	svc      service.WebhookService
+	interval time.Duration
+}
+
+func NewScheduler(svc service.WebhookService, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{svc: svc, interval: interval}
+}
+
+// Run ejecuta el scheduler hasta que se cancele el contexto
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(ctx)
+		}
+	}
+}
+
+// Tick aplica una pasada del scheduler
+func (s *Scheduler) Tick(ctx context.Context) {
+	delivered, err := s.svc.DeliverDue(ctx)
+	if err != nil {
+		log.Printf("[SCHEDULER] Error delivering webhooks: %v", err)
+	}
+	if delivered > 0 {
+		log.Printf("[SCHEDULER] Delivered %d webhook(s)", delivered)
+	}
+}
@@ -27,6 +27,63 @@ type GoogleOAuthConfig struct {
 	Enabled      bool
 }
 
+// InvoiceConfig son los datos del emisor y la numeración de las facturas
+type InvoiceConfig struct {
+	PointOfSale       int
+	IssuerName        string
+	IssuerTaxID       string // CUIT sin guiones
+	IssuerAddress     string
+	SchedulerInterval time.Duration
+}
+
+// ShipmentConfig configura el seguimiento de envíos y el carrier local
+type ShipmentConfig struct {
+	CarrierWebhookSecret string
+	TrackingInterval     time.Duration
+	FakeCarrierStep      time.Duration // cada cuánto avanza un envío del carrier local
+}
+
+// StockAlertConfig configura los avisos de stock bajo; sin destinatarios ni URL no se avisa
+type StockAlertConfig struct {
+	Interval      time.Duration
+	Emails        []string // requiere SMTP habilitado
+	WebhookURL    string
+	WebhookSecret string
+}
+
+// FeedConfig configura los feeds de productos para Google Merchant Center y Meta
+type FeedConfig struct {
+	Interval time.Duration
+	Brand    string // marca de todos los productos; por defecto el nombre del emisor
+}
+
+// MarketplaceConfig configura la sincronización con el marketplace. BaseURL apunta por
+// defecto al marketplace local (make fake-marketplace).
+type MarketplaceConfig struct {
+	Enabled           bool
+	Name              string // identifica al marketplace en las publicaciones y ventas
+	BaseURL           string
+	AccessToken       string
+	SyncInterval      time.Duration // ventas nuevas y envío de stock y precio
+	ReconcileInterval time.Duration
+}
+
+// WebhookConfig configura los envíos de eventos a los destinos registrados
+type WebhookConfig struct {
+	Interval    time.Duration // envíos pendientes
+	MaxAttempts int           // intentos por envío antes de darlo por fallido
+}
+
+// OutboxConfig configura el relay de los eventos de dominio. Los webhooks reciben
+// siempre los eventos; el log y NATS son destinos opcionales.
+type OutboxConfig struct {
+	RelayInterval     time.Duration
+	Retention         time.Duration // cuánto se conservan los eventos ya publicados
+	LogEvents         bool
+	NATSURL           string // nats://[usuario:contraseña@]host:puerto; vacío desactiva NATS
+	NATSSubjectPrefix string
+}
+
 type Config struct {
 	Debug          bool
 	ServerAddress  string
@@ -49,6 +106,22 @@ type Config struct {
 
 	SMTP   SMTPConfig
 	Google GoogleOAuthConfig
+
+	// Intervalos de los schedulers de productos
+	PublishSchedulerInterval time.Duration
+	PriceSchedulerInterval   time.Duration
+	ImportSchedulerInterval  time.Duration
+
+	// Indica si los precios del catálogo incluyen IVA ("inclusive") o no ("exclusive")
+	TaxPriceMode string
+
+	Invoice     InvoiceConfig
+	Shipment    ShipmentConfig
+	StockAlert  StockAlertConfig
+	Feed        FeedConfig
+	Marketplace MarketplaceConfig
+	Webhook     WebhookConfig
+	Outbox      OutboxConfig
 }
 
 func Load() (Config, error) {
@@ -73,6 +146,56 @@ func Load() (Config, error) {
 
 		AppBaseURL:  getString("APP_BASE_URL", "http://localhost:8080"),
 		FrontendURL: getString("FRONTEND_URL", "http://localhost:3000"),
+
+		PublishSchedulerInterval: getDurationSeconds("PUBLISH_SCHEDULER_INTERVAL", 60) * time.Second,
+		PriceSchedulerInterval:   getDurationSeconds("PRICE_SCHEDULER_INTERVAL", 60) * time.Second,
+		ImportSchedulerInterval:  getDurationSeconds("IMPORT_SCHEDULER_INTERVAL", 5) * time.Second,
+
+		TaxPriceMode: strings.ToLower(getString("TAX_PRICE_MODE", "inclusive")),
+
+		Invoice: InvoiceConfig{
+			PointOfSale:       getInt("INVOICE_POINT_OF_SALE", 1),
+			IssuerName:        getString("INVOICE_ISSUER_NAME", "Core"),
+			IssuerTaxID:       strings.ReplaceAll(getString("INVOICE_ISSUER_TAX_ID", "20123456786"), "-", ""),
+			IssuerAddress:     getString("INVOICE_ISSUER_ADDRESS", ""),
+			SchedulerInterval: getDurationSeconds("INVOICE_SCHEDULER_INTERVAL", 30) * time.Second,
+		},
+
+		Shipment: ShipmentConfig{
+			CarrierWebhookSecret: getString("CARRIER_WEBHOOK_SECRET", "dev-carrier-secret"),
+			TrackingInterval:     getDurationSeconds("SHIPMENT_TRACKING_INTERVAL", 300) * time.Second,
+			FakeCarrierStep:      getDurationSeconds("FAKE_CARRIER_STEP", 3600) * time.Second,
+		},
+
+		StockAlert: StockAlertConfig{
+			Interval:      getDurationSeconds("LOW_STOCK_ALERT_INTERVAL", 60) * time.Second,
+			Emails:        getList("LOW_STOCK_ALERT_EMAILS"),
+			WebhookURL:    getString("LOW_STOCK_WEBHOOK_URL", ""),
+			WebhookSecret: getString("LOW_STOCK_WEBHOOK_SECRET", ""),
+		},
+	}
+	cfg.Feed = FeedConfig{
+		Interval: getDurationSeconds("FEED_REFRESH_INTERVAL", 900) * time.Second,
+		Brand:    getString("FEED_BRAND", cfg.Invoice.IssuerName),
+	}
+	cfg.Marketplace = MarketplaceConfig{
+		Enabled:           getBool("MARKETPLACE_ENABLED", false),
+		Name:              getString("MARKETPLACE_NAME", "mercadolibre"),
+		BaseURL:           getString("MARKETPLACE_URL", "http://localhost:9091"),
+		AccessToken:       getString("MARKETPLACE_ACCESS_TOKEN", ""),
+		SyncInterval:      getDurationSeconds("MARKETPLACE_SYNC_INTERVAL", 30) * time.Second,
+		ReconcileInterval: getDurationSeconds("MARKETPLACE_RECONCILE_INTERVAL", 3600) * time.Second,
+	}
+	cfg.Webhook = WebhookConfig{
+		Interval:    getDurationSeconds("WEBHOOK_DELIVERY_INTERVAL", 10) * time.Second,
+		MaxAttempts: getInt("WEBHOOK_MAX_ATTEMPTS", 8),
+	}
+	cfg.Outbox = OutboxConfig{
+		RelayInterval:     getDurationSeconds("OUTBOX_RELAY_INTERVAL", 2) * time.Second,
+		Retention:         time.Duration(getInt("OUTBOX_RETENTION_DAYS", 7)) * 24 * time.Hour,
+		LogEvents:         getBool("OUTBOX_LOG_EVENTS", false),
+		NATSURL:           getString("OUTBOX_NATS_URL", ""),
+		NATSSubjectPrefix: getString("OUTBOX_NATS_SUBJECT_PREFIX", "core"),
 	}
 
 	cfg.DSN = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&collation=utf8mb4_unicode_ci",
@@ -103,6 +226,12 @@ func Load() (Config, error) {
 	if cfg.JWTSecret == "" {
 		return cfg, fmt.Errorf("JWT_SECRET es requerido")
 	}
+	if cfg.TaxPriceMode != "inclusive" && cfg.TaxPriceMode != "exclusive" {
+		return cfg, fmt.Errorf("TAX_PRICE_MODE debe ser inclusive o exclusive")
+	}
+	if cfg.Invoice.PointOfSale < 1 || cfg.Invoice.PointOfSale > 99999 {
+		return cfg, fmt.Errorf("INVOICE_POINT_OF_SALE debe estar entre 1 y 99999")
+	}
 	return cfg, nil
 }
 
@@ -113,6 +242,17 @@ func getString(key, def string) string {
 	return def
 }
 
+// getList separa por comas y descarta los valores vacíos
+func getList(key string) []string {
+	var out []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 func getInt(key string, def int) int {
 	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
 		if n, err := strconv.Atoi(v); err == nil {
@@ -0,0 +1,100 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidBarCode = errors.New("invalid bar code")
+
+// InternalPrefix es el prefijo GS1 de circulación restringida que usan los códigos
+// internos: no choca con los códigos asignados a fabricantes
+const InternalPrefix = "20"
+
+// Length es el largo de un EAN-13, la forma en que se guardan los códigos
+const Length = 13
+
+// Normalize deja solo los dígitos del código (acepta espacios y guiones) y completa con
+// ceros a la izquierda hasta 13, así un UPC-A de 12 dígitos queda como su EAN-13 y se
+// recuperan los ceros que pierde un lector o una planilla. No verifica el dígito.
+func Normalize(s string) (string, error) {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '-' || r == ' ':
+		default:
+			return "", ErrInvalidBarCode
+		}
+	}
+	code := b.String()
+	if code == "" || len(code) > 14 {
+		return "", ErrInvalidBarCode
+	}
+	if len(code) < Length {
+		code = strings.Repeat("0", Length-len(code)) + code
+	}
+	return code, nil
+}
+
+// Parse normaliza un EAN-13 o UPC-A y verifica su dígito verificador
+func Parse(s string) (string, error) {
+	code, err := Normalize(s)
+	if err != nil {
+		return "", err
+	}
+	if !Valid(code) {
+		return "", ErrInvalidBarCode
+	}
+	return code, nil
+}
+
+// Valid indica si code es un EAN-13 con dígito verificador correcto
+func Valid(code string) bool {
+	if len(code) != Length {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return CheckDigit(code[:Length-1]) == code[Length-1]
+}
+
+// CheckDigit calcula el dígito verificador GS1 (módulo 10) de los 12 dígitos del cuerpo
+func CheckDigit(body string) byte {
+	sum := 0
+	for i := len(body) - 1; i >= 0; i-- {
+		d := int(body[i] - '0')
+		// Desde la derecha los pesos alternan 3 y 1
+		if (len(body)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// Internal arma el código interno número seq: InternalPrefix, seq en 10 dígitos y el
+// dígito verificador
+func Internal(seq int64) (string, error) {
+	width := Length - 1 - len(InternalPrefix)
+	body := fmt.Sprintf("%s%0*d", InternalPrefix, width, seq)
+	if seq <= 0 || len(body) != Length-1 {
+		return "", ErrInvalidBarCode
+	}
+	return body + string(CheckDigit(body)), nil
+}
+
+// InternalSeq retorna el número de un código interno; ok es false si code no lo es
+func InternalSeq(code string) (seq int64, ok bool) {
+	if len(code) != Length || !strings.HasPrefix(code, InternalPrefix) {
+		return 0, false
+	}
+	seq, err := strconv.ParseInt(code[len(InternalPrefix):Length-1], 10, 64)
+	return seq, err == nil
+}
@@ -0,0 +1,85 @@
+package barcode
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		body string
+		want byte
+	}{
+		{"400638133393", '1'},
+		{"590123412345", '7'},
+		{"779123456789", '8'},
+		{"003600029145", '2'}, // UPC-A 036000291452
+		{"000000000000", '0'},
+		{"200000000001", '5'},
+	}
+	for _, tt := range tests {
+		if got := CheckDigit(tt.body); got != tt.want {
+			t.Errorf("CheckDigit(%q) = %c, want %c", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		err  bool
+	}{
+		{"4006381333931", "4006381333931", false},
+		{"400-638 133393-1", "4006381333931", false},
+		{"036000291452", "0036000291452", false},
+		{"4006381333932", "", true},
+		{"40063813339a1", "", true},
+		{"", "", true},
+		{"123456789012345", "", true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if tt.err {
+			if !errors.Is(err, ErrInvalidBarCode) {
+				t.Errorf("Parse(%q) error = %v, want %v", tt.in, err, ErrInvalidBarCode)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestInternal(t *testing.T) {
+	tests := []struct {
+		seq  int64
+		want string
+		err  bool
+	}{
+		{1, "2000000000015", false},
+		{1234567890, "2012345678903", false},
+		{0, "", true},
+		{10000000000, "", true},
+	}
+	for _, tt := range tests {
+		got, err := Internal(tt.seq)
+		if tt.err {
+			if err == nil {
+				t.Errorf("Internal(%d) = %q, want error", tt.seq, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Internal(%d) = %q, %v; want %q", tt.seq, got, err, tt.want)
+			continue
+		}
+		if !Valid(got) {
+			t.Errorf("Internal(%d) = %q is not a valid EAN-13", tt.seq, got)
+		}
+		if seq, ok := InternalSeq(got); !ok || seq != tt.seq {
+			t.Errorf("InternalSeq(%q) = %d, %v; want %d", got, seq, ok, tt.seq)
+		}
+	}
+}
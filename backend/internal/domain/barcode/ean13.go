@@ -0,0 +1,71 @@
+package barcode
+
+// Modules es la cantidad de módulos (barras y espacios de ancho unitario) de un EAN-13,
+// sin contar las zonas de silencio
+const Modules = 95
+
+// Zonas de silencio mínimas a cada lado, en módulos
+const (
+	QuietLeft  = 11
+	QuietRight = 7
+)
+
+// Codificación de cada dígito en 7 módulos: L y G para la mitad izquierda, R para la derecha
+var (
+	codesL = [10]string{"0001101", "0011001", "0010011", "0111101", "0100011", "0110001", "0101111", "0111011", "0110111", "0001011"}
+	codesG = [10]string{"0100111", "0110011", "0011011", "0100001", "0011101", "0111001", "0000101", "0010001", "0001001", "0010111"}
+	codesR = [10]string{"1110010", "1100110", "1101100", "1000010", "1011100", "1001110", "1010000", "1000100", "1001000", "1110100"}
+)
+
+// parity indica, según el primer dígito, qué dígitos de la mitad izquierda van con G
+var parity = [10]string{"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG", "LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL"}
+
+// Encode retorna los 95 módulos del EAN-13; true es barra. El primer dígito no se dibuja,
+// queda implícito en la paridad de la mitad izquierda.
+func Encode(code string) ([]bool, error) {
+	if !Valid(code) {
+		return nil, ErrInvalidBarCode
+	}
+	pattern := "101"
+	first := code[0] - '0'
+	for i := 1; i <= 6; i++ {
+		d := code[i] - '0'
+		if parity[first][i-1] == 'G' {
+			pattern += codesG[d]
+		} else {
+			pattern += codesL[d]
+		}
+	}
+	pattern += "01010"
+	for i := 7; i <= 12; i++ {
+		pattern += codesR[code[i]-'0']
+	}
+	pattern += "101"
+
+	modules := make([]bool, len(pattern))
+	for i := range pattern {
+		modules[i] = pattern[i] == '1'
+	}
+	return modules, nil
+}
+
+// Bar es una barra: empieza en el módulo Start y ocupa Width módulos
+type Bar struct {
+	Start, Width int
+}
+
+// Bars agrupa los módulos consecutivos en barras, que es lo que se dibuja
+func Bars(modules []bool) []Bar {
+	var bars []Bar
+	for i := 0; i < len(modules); i++ {
+		if !modules[i] {
+			continue
+		}
+		start := i
+		for i+1 < len(modules) && modules[i+1] {
+			i++
+		}
+		bars = append(bars, Bar{Start: start, Width: i - start + 1})
+	}
+	return bars
+}
@@ -0,0 +1,79 @@
+package barcode
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{
+			"0000000000000",
+			"101" +
+				"0001101" + "0001101" + "0001101" + "0001101" + "0001101" + "0001101" +
+				"01010" +
+				"1110010" + "1110010" + "1110010" + "1110010" + "1110010" + "1110010" +
+				"101",
+		},
+		{
+			// El 7 inicial fija la paridad LGLGLG de la mitad izquierda
+			"7501234567893",
+			"101" +
+				"0110001" + "0100111" + "0011001" + "0011011" + "0111101" + "0011101" +
+				"01010" +
+				"1001110" + "1010000" + "1000100" + "1001000" + "1110100" + "1000010" +
+				"101",
+		},
+		{
+			// El 5 inicial fija la paridad LGGLLG de la mitad izquierda
+			"5901234123457",
+			"101" +
+				"0001011" + "0100111" + "0110011" + "0010011" + "0111101" + "0011101" +
+				"01010" +
+				"1100110" + "1101100" + "1000010" + "1011100" + "1001110" + "1000100" +
+				"101",
+		},
+	}
+	for _, tt := range tests {
+		modules, err := Encode(tt.code)
+		if err != nil {
+			t.Fatalf("Encode(%q) unexpected error: %v", tt.code, err)
+		}
+		if len(modules) != Modules {
+			t.Fatalf("Encode(%q) returned %d modules, want %d", tt.code, len(modules), Modules)
+		}
+		got := make([]byte, len(modules))
+		for i, m := range modules {
+			got[i] = '0'
+			if m {
+				got[i] = '1'
+			}
+		}
+		if string(got) != tt.want {
+			t.Errorf("Encode(%q) =\n%s\nwant\n%s", tt.code, got, tt.want)
+		}
+	}
+
+	for _, code := range []string{"7501234567890", "5901234123458", "590123412345", "59012341234a7"} {
+		if _, err := Encode(code); !errors.Is(err, ErrInvalidBarCode) {
+			t.Errorf("Encode(%q) error = %v, want %v", code, err, ErrInvalidBarCode)
+		}
+	}
+}
+
+func TestBars(t *testing.T) {
+	modules := []bool{true, false, true, true, false, false, true, true, true}
+	want := []Bar{{0, 1}, {2, 2}, {6, 3}}
+	got := Bars(modules)
+	if len(got) != len(want) {
+		t.Fatalf("Bars() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Bars()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Provinces son las jurisdicciones de Argentina por su código ISO 3166-2 (sin el prefijo AR-)
+var Provinces = map[string]string{
+	"C": "Ciudad Autónoma de Buenos Aires",
+	"B": "Buenos Aires",
+	"K": "Catamarca",
+	"H": "Chaco",
+	"U": "Chubut",
+	"X": "Córdoba",
+	"W": "Corrientes",
+	"E": "Entre Ríos",
+	"P": "Formosa",
+	"Y": "Jujuy",
+	"L": "La Pampa",
+	"F": "La Rioja",
+	"M": "Mendoza",
+	"N": "Misiones",
+	"Q": "Neuquén",
+	"R": "Río Negro",
+	"A": "Salta",
+	"J": "San Juan",
+	"D": "San Luis",
+	"Z": "Santa Cruz",
+	"S": "Santa Fe",
+	"G": "Santiago del Estero",
+	"V": "Tierra del Fuego",
+	"T": "Tucumán",
+}
+
+// NormalizeProvince acepta el código con o sin prefijo "AR-"; retorna "" si no es válido
+func NormalizeProvince(code string) string {
+	code = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(code)), "AR-")
+	if _, ok := Provinces[code]; !ok {
+		return ""
+	}
+	return code
+}
+
+// Address es una dirección de la libreta del usuario
+type Address struct {
+	ID                int64     `json:"id"`
+	UserID            int64     `json:"user_id"`
+	Label             string    `json:"label"` // ej. "Casa", "Oficina"
+	RecipientName     string    `json:"recipient_name"`
+	Phone             string    `json:"phone"`
+	Street            string    `json:"street"`
+	Number            string    `json:"number"`
+	Apartment         string    `json:"apartment"`
+	City              string    `json:"city"`
+	Province          string    `json:"province"` // código ISO, ver Provinces
+	PostalCode        string    `json:"postal_code"`
+	IsDefaultShipping bool      `json:"is_default_shipping"`
+	IsDefaultBilling  bool      `json:"is_default_billing"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// Line retorna la dirección en una línea, como se imprime en facturas y etiquetas
+func (a *Address) Line() string {
+	street := strings.TrimSpace(a.Street + " " + a.Number)
+	if a.Apartment != "" {
+		street += " " + a.Apartment
+	}
+	parts := []string{street}
+	if a.City != "" {
+		parts = append(parts, a.City)
+	}
+	if name := Provinces[a.Province]; name != "" && a.Province != "C" {
+		parts = append(parts, name)
+	}
+	if a.PostalCode != "" {
+		parts = append(parts, "CP "+a.PostalCode)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ShippingAddress es la dirección de entrega congelada en la orden
+type ShippingAddress struct {
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone"`
+	Street        string `json:"street"`
+	Number        string `json:"number"`
+	Apartment     string `json:"apartment,omitempty"`
+	City          string `json:"city"`
+	Province      string `json:"province"`
+	PostalCode    string `json:"postal_code"`
+}
+
+// Snapshot copia los datos de entrega de la dirección
+func (a *Address) Snapshot() ShippingAddress {
+	return ShippingAddress{
+		RecipientName: a.RecipientName,
+		Phone:         a.Phone,
+		Street:        a.Street,
+		Number:        a.Number,
+		Apartment:     a.Apartment,
+		City:          a.City,
+		Province:      a.Province,
+		PostalCode:    a.PostalCode,
+	}
+}
@@ -0,0 +1,76 @@
+package entity
+
+import "time"
+
+// AuditAction identifica el tipo de mutación registrada
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionStock   AuditAction = "stock"
+	AuditActionStatus  AuditAction = "status"
+	AuditActionPricing AuditAction = "pricing"
+	AuditActionBulk    AuditAction = "bulk" // cambios masivos del scheduler
+)
+
+// Tipos de entidad auditados
+const (
+	AuditEntityProduct            = "product"
+	AuditEntityProductImage       = "product_image"
+	AuditEntityPriceChange        = "price_change"
+	AuditEntityProductPrice       = "product_price"
+	AuditEntityExchangeRate       = "exchange_rate_table"
+	AuditEntityPromotion          = "promotion"
+	AuditEntityTaxRate            = "tax_rate"
+	AuditEntityUser               = "user"
+	AuditEntityShippingZone       = "shipping_zone"
+	AuditEntityShippingMethod     = "shipping_method"
+	AuditEntityLocation           = "location"
+	AuditEntityStockTransfer      = "stock_transfer"
+	AuditEntityReorderPoint       = "reorder_point"
+	AuditEntitySupplier           = "supplier"
+	AuditEntityPurchaseOrder      = "purchase_order"
+	AuditEntityRegisterShift      = "register_shift"
+	AuditEntityFeedCategory       = "feed_category"
+	AuditEntityMarketplaceListing = "marketplace_listing"
+	AuditEntityWebhookEndpoint    = "webhook_endpoint"
+)
+
+// Actor es quien origina una mutación. UserID nil significa el propio sistema.
+type Actor struct {
+	UserID *int64 `json:"user_id,omitempty"`
+	Email  string `json:"email,omitempty"`
+	Role   string `json:"role,omitempty"`
+	IP     string `json:"ip,omitempty"`
+}
+
+// FieldChange es la diferencia de un campo entre el estado anterior y el nuevo
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// AuditEntry es un registro inmutable de una mutación
+type AuditEntry struct {
+	ID         int64         `json:"id"`
+	EntityType string        `json:"entity_type"`
+	EntityID   int64         `json:"entity_id"`
+	Action     AuditAction   `json:"action"`
+	Changes    []FieldChange `json:"changes"`
+	Actor      Actor         `json:"actor"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type AuditFilter struct {
+	EntityType string
+	EntityID   int64
+	ActorID    int64
+	Action     AuditAction
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
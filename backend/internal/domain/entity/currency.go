@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"core/internal/domain/money"
+	"time"
+)
+
+// ExchangeRateTable es una tabla de tasas cargada por un admin. Es inmutable:
+// para actualizar tasas se carga una tabla nueva y rige la más reciente vigente.
+type ExchangeRateTable struct {
+	ID            int64                         `json:"id"`
+	BaseCurrency  money.Currency                `json:"base_currency"`
+	Rates         map[money.Currency]money.Rate `json:"rates"` // unidades de la moneda por 1 unidad de la base
+	EffectiveFrom time.Time                     `json:"effective_from"`
+	CreatedBy     *int64                        `json:"created_by,omitempty"`
+	CreatedAt     time.Time                     `json:"created_at"`
+}
+
+// RateTo retorna la tasa de la base a la moneda indicada
+func (t *ExchangeRateTable) RateTo(c money.Currency) (money.Rate, bool) {
+	if c == t.BaseCurrency {
+		return money.IdentityRate(), true
+	}
+	r, ok := t.Rates[c]
+	return r, ok
+}
+
+// ProductPrice es el precio fijo de un producto en una moneda distinta a la suya.
+// Tiene prioridad sobre la conversión por tabla de tasas.
+type ProductPrice struct {
+	ProductID int64       `json:"product_id"`
+	UnitPrice money.Money `json:"unit_price"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Origen del precio en una moneda de visualización
+const (
+	PriceSourceBase      = "base"       // misma moneda del producto
+	PriceSourcePriceList = "price_list" // precio fijo cargado por un admin
+	PriceSourceRateTable = "rate_table" // convertido con la tabla de tasas vigente
+)
+
+// RateSnapshot congela la tasa usada en una conversión para poder reproducirla
+type RateSnapshot struct {
+	RateTableID  *int64         `json:"rate_table_id,omitempty"`
+	BaseCurrency money.Currency `json:"base_currency"`
+	Currency     money.Currency `json:"currency"`
+	Rate         money.Rate     `json:"rate"`
+	CapturedAt   time.Time      `json:"captured_at"`
+}
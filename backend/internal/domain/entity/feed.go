@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"core/internal/domain/money"
+	"time"
+)
+
+// FeedChannel es el destino de un feed de productos para anuncios de compras
+type FeedChannel string
+
+const (
+	FeedChannelGoogle FeedChannel = "google" // Google Merchant Center, XML RSS 2.0
+	FeedChannelMeta   FeedChannel = "meta"   // catálogo de Meta, CSV
+)
+
+// FeedChannels lista los canales que se regeneran
+var FeedChannels = []FeedChannel{FeedChannelGoogle, FeedChannelMeta}
+
+func (c FeedChannel) IsValid() bool {
+	return c == FeedChannelGoogle || c == FeedChannelMeta
+}
+
+// FeedCategoryMapping asocia una categoría del catálogo con las taxonomías de cada canal.
+// Las categorías sin mapeo salen solo con product_type.
+type FeedCategoryMapping struct {
+	ID       int64  `json:"id"`
+	Category string `json:"category"`
+	// GoogleCategory es el ID o la ruta de la taxonomía de Google, que Meta también acepta
+	GoogleCategory string    `json:"google_category"`
+	MetaCategory   string    `json:"meta_category,omitempty"` // fb_product_category
+	UpdatedAt      time.Time `json:"updated_at"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// FeedProduct es un producto publicado con los valores que piden los canales ya resueltos
+type FeedProduct struct {
+	ID                   int64
+	Title                string
+	Description          string
+	Link                 string
+	ImageLink            string // la imagen principal
+	AdditionalImageLinks []string
+	InStock              bool
+	Price                money.Money
+	// SalePrice va con su vigencia solo si la oferta está activa o tiene fechas
+	SalePrice      *money.Money
+	SaleStartsAt   *time.Time
+	SaleEndsAt     *time.Time
+	Brand          string
+	GTIN           string // vacío para los códigos internos, que no son GTIN
+	Size           string
+	ProductType    string // categoría del catálogo
+	GoogleCategory string
+	MetaCategory   string
+}
+
+// FeedItem es un producto ya escrito en el formato de un canal. Fingerprint es el hash
+// del contenido y permite regenerar solo los que cambian.
+type FeedItem struct {
+	Channel     FeedChannel
+	ProductID   int64
+	Fingerprint string
+	Content     string
+}
+
+// FeedState es el estado de la última regeneración de un feed. GeneratedAt es la última
+// vez que cambió su contenido.
+type FeedState struct {
+	Channel     FeedChannel `json:"channel"`
+	Items       int         `json:"items"`
+	GeneratedAt *time.Time  `json:"generated_at,omitempty"`
+	CheckedAt   *time.Time  `json:"checked_at,omitempty"`
+}
+
+// FeedRefresh resume los cambios de una regeneración en un canal
+type FeedRefresh struct {
+	Channel FeedChannel `json:"channel"`
+	Changed int         `json:"changed"` // productos nuevos o con cambios
+	Removed int         `json:"removed"`
+	Items   int         `json:"items"`
+}
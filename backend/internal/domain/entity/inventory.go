@@ -0,0 +1,173 @@
+package entity
+
+import "time"
+
+// LocationKind distingue los locales de venta de los depósitos
+type LocationKind string
+
+const (
+	LocationKindStore     LocationKind = "store"     // local a la calle
+	LocationKindWarehouse LocationKind = "warehouse" // depósito
+)
+
+func (k LocationKind) IsValid() bool {
+	return k == LocationKindStore || k == LocationKindWarehouse
+}
+
+// Location es un lugar donde se guarda stock. Las órdenes se preparan desde las
+// ubicaciones activas de menor prioridad; la ubicación por defecto recibe el stock
+// que no indica ubicación (alta de productos, devoluciones).
+type Location struct {
+	ID        int64        `json:"id"`
+	Code      string       `json:"code"` // ej. DEP, LOCAL
+	Name      string       `json:"name"`
+	Kind      LocationKind `json:"kind"`
+	Priority  int          `json:"priority"` // menor = se usa primero al preparar órdenes
+	Active    bool         `json:"active"`   // solo las activas suman al disponible para la venta
+	IsDefault bool         `json:"is_default"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// InventoryLevel es el stock de un producto en una ubicación
+type InventoryLevel struct {
+	ProductID      int64     `json:"product_id"`
+	LocationID     int64     `json:"location_id"`
+	LocationCode   string    `json:"location_code"`
+	LocationActive bool      `json:"location_active"`
+	Priority       int       `json:"-"`
+	Quantity       int64     `json:"quantity"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// StockAllocation son las unidades de una línea que salen de una ubicación
+type StockAllocation struct {
+	LocationID   int64  `json:"location_id"`
+	LocationCode string `json:"location_code"`
+	Quantity     int64  `json:"quantity"`
+}
+
+// PickLocations elige de dónde sacar qty unidades. levels viene ordenado por prioridad
+// y solo con ubicaciones activas. Se prefiere la primera ubicación que tenga todo para
+// no partir el paquete; si ninguna alcanza se completa en orden de prioridad.
+// Retorna false si entre todas no alcanza.
+func PickLocations(levels []InventoryLevel, qty int64) ([]StockAllocation, bool) {
+	if qty <= 0 {
+		return nil, false
+	}
+	for _, l := range levels {
+		if l.Quantity >= qty {
+			return []StockAllocation{{LocationID: l.LocationID, LocationCode: l.LocationCode, Quantity: qty}}, true
+		}
+	}
+	var out []StockAllocation
+	remaining := qty
+	for _, l := range levels {
+		if l.Quantity <= 0 {
+			continue
+		}
+		take := min(l.Quantity, remaining)
+		out = append(out, StockAllocation{LocationID: l.LocationID, LocationCode: l.LocationCode, Quantity: take})
+		remaining -= take
+		if remaining == 0 {
+			return out, true
+		}
+	}
+	return nil, false
+}
+
+// StockTransfer es un movimiento de unidades entre dos ubicaciones
+type StockTransfer struct {
+	ID             int64     `json:"id"`
+	ProductID      int64     `json:"product_id"`
+	FromLocationID int64     `json:"from_location_id"`
+	ToLocationID   int64     `json:"to_location_id"`
+	Quantity       int64     `json:"quantity"`
+	Note           string    `json:"note,omitempty"`
+	ActorID        int64     `json:"actor_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type StockTransferFilter struct {
+	ProductID  int64
+	LocationID int64 // origen o destino
+	Limit      int
+	Offset     int
+}
+
+// ProductInventory es el stock de un producto desglosado por ubicación
+type ProductInventory struct {
+	ProductID int64            `json:"product_id"`
+	Available int64            `json:"available"` // disponible para la venta: suma de las ubicaciones activas
+	OnHand    int64            `json:"on_hand"`   // total físico, incluidas las ubicaciones inactivas
+	Levels    []InventoryLevel `json:"levels"`
+}
+
+// StockMovementKind es el origen de un movimiento del libro de stock
+type StockMovementKind string
+
+const (
+	StockMovementSale       StockMovementKind = "sale"       // orden confirmada o cancelada
+	StockMovementReturn     StockMovementKind = "return"     // devolución recibida o talle reservado para un cambio
+	StockMovementAdjustment StockMovementKind = "adjustment" // corrección manual, alta o edición del producto
+	StockMovementTransfer   StockMovementKind = "transfer"   // salida y entrada entre ubicaciones
+	StockMovementCount      StockMovementKind = "count"      // diferencia encontrada en un recuento físico
+	StockMovementPurchase   StockMovementKind = "purchase"   // mercadería recibida de un proveedor
+)
+
+func (k StockMovementKind) IsValid() bool {
+	switch k {
+	case StockMovementSale, StockMovementReturn, StockMovementAdjustment, StockMovementTransfer, StockMovementCount,
+		StockMovementPurchase:
+		return true
+	}
+	return false
+}
+
+// StockMovementRef describe por qué cambia el stock; se copia a cada movimiento que genera.
+// Reference identifica el documento de origen (ej. "order:12"); ActorID 0 es el sistema.
+type StockMovementRef struct {
+	Kind      StockMovementKind
+	Reason    string
+	Reference string
+	ActorID   int64
+}
+
+// StockUpdate es el disponible del producto antes y después de un ajuste, leído en la
+// misma transacción que lo aplica
+type StockUpdate struct {
+	Before int64
+	After  int64
+}
+
+// StockMovement es una entrada del libro de stock. El libro solo crece: el stock de un
+// producto en una ubicación es la suma de sus movimientos.
+type StockMovement struct {
+	ID           int64             `json:"id"`
+	ProductID    int64             `json:"product_id"`
+	LocationID   int64             `json:"location_id"`
+	LocationCode string            `json:"location_code"`
+	Delta        int64             `json:"delta"`
+	Kind         StockMovementKind `json:"kind"`
+	Reason       string            `json:"reason,omitempty"`
+	Reference    string            `json:"reference,omitempty"`
+	ActorID      int64             `json:"actor_id,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+type StockMovementFilter struct {
+	ProductID  int64
+	LocationID int64
+	Kind       StockMovementKind
+	Limit      int
+	Offset     int
+}
+
+// StockDiscrepancy es una diferencia entre el stock guardado y el que surge del libro.
+// LocationID 0 compara products.stock con la suma de las ubicaciones activas.
+type StockDiscrepancy struct {
+	ProductID  int64 `json:"product_id"`
+	LocationID int64 `json:"location_id,omitempty"`
+	Expected   int64 `json:"expected"` // según el libro (o las ubicaciones, si LocationID es 0)
+	Recorded   int64 `json:"recorded"`
+}
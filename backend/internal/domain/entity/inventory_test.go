@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPickLocations(t *testing.T) {
+	levels := func(qty ...int64) []InventoryLevel {
+		out := make([]InventoryLevel, len(qty))
+		for i, q := range qty {
+			out[i] = InventoryLevel{LocationID: int64(i + 1), LocationCode: string(rune('A' + i)), Priority: i, Quantity: q}
+		}
+		return out
+	}
+	alloc := func(id, qty int64) StockAllocation {
+		return StockAllocation{LocationID: id, LocationCode: string(rune('A' + id - 1)), Quantity: qty}
+	}
+
+	tests := []struct {
+		name   string
+		levels []InventoryLevel
+		qty    int64
+		want   []StockAllocation
+		ok     bool
+	}{
+		{"first location has everything", levels(10, 10), 5, []StockAllocation{alloc(1, 5)}, true},
+		{"exact quantity in first location", levels(5, 10), 5, []StockAllocation{alloc(1, 5)}, true},
+		{"single location preferred over splitting", levels(3, 8, 2), 5, []StockAllocation{alloc(2, 5)}, true},
+		{"split in priority order", levels(3, 2, 4), 7, []StockAllocation{alloc(1, 3), alloc(2, 2), alloc(3, 2)}, true},
+		{"skips empty and negative locations", levels(0, -2, 3, 4), 6, []StockAllocation{alloc(3, 3), alloc(4, 3)}, true},
+		{"uses all stock", levels(2, 3), 5, []StockAllocation{alloc(1, 2), alloc(2, 3)}, true},
+		{"not enough stock", levels(2, 3), 6, nil, false},
+		{"no locations", nil, 1, nil, false},
+		{"zero quantity", levels(5), 0, nil, false},
+		{"negative quantity", levels(5), -1, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := PickLocations(tt.levels, tt.qty)
+			if ok != tt.ok || !slices.Equal(got, tt.want) {
+				t.Errorf("PickLocations(%d) = %v, %v; want %v, %v", tt.qty, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"core/internal/domain/money"
+	"core/internal/domain/tax"
+	"fmt"
+	"time"
+)
+
+// InvoiceType es la letra del comprobante
+type InvoiceType string
+
+const (
+	InvoiceTypeA InvoiceType = "A" // a clientes con CUIT, IVA discriminado
+	InvoiceTypeB InvoiceType = "B" // a consumidores finales, IVA incluido
+)
+
+// InvoiceTypeFor elige la letra según el documento del cliente
+func InvoiceTypeFor(idType tax.IDType) InvoiceType {
+	if idType == tax.IDTypeCUIT {
+		return InvoiceTypeA
+	}
+	return InvoiceTypeB
+}
+
+// InvoiceStatus es el estado de la factura ante el organismo fiscal
+type InvoiceStatus string
+
+const (
+	InvoiceStatusPending    InvoiceStatus = "pending"    // numerada, esperando autorización
+	InvoiceStatusAuthorized InvoiceStatus = "authorized" // con CAE
+	InvoiceStatusRejected   InvoiceStatus = "rejected"   // rechazada por el organismo
+)
+
+// InvoiceIssuer son los datos del emisor que se imprimen en la factura
+type InvoiceIssuer struct {
+	Name    string `json:"name"`
+	TaxID   string `json:"tax_id"`
+	Address string `json:"address"`
+}
+
+// InvoiceLine es una línea de la factura, copiada del ítem de la orden
+type InvoiceLine struct {
+	ID          int64       `json:"id"`
+	InvoiceID   int64       `json:"invoice_id"`
+	Description string      `json:"description"`
+	Quantity    int64       `json:"quantity"`
+	UnitPrice   money.Money `json:"unit_price"` // precio cobrado, según el modo de IVA de la orden
+	Discount    money.Money `json:"discount"`
+	TaxRate     tax.Rate    `json:"tax_rate"`
+	Net         money.Money `json:"net"`
+	Tax         money.Money `json:"tax"`
+	Total       money.Money `json:"total"` // Net + Tax
+}
+
+// Invoice es el comprobante de una orden pagada. El número es correlativo por punto de
+// venta y letra; los datos del emisor y del cliente quedan congelados al emitirla.
+type Invoice struct {
+	ID          int64          `json:"id"`
+	OrderID     int64          `json:"order_id"`
+	PointOfSale int            `json:"point_of_sale"`
+	Type        InvoiceType    `json:"type"`
+	Number      int64          `json:"number"`
+	Issuer      InvoiceIssuer  `json:"issuer"`
+	Customer    BillingInfo    `json:"customer"`
+	Currency    money.Currency `json:"currency"`
+	// Pesos por unidad de la moneda de la factura; nil si está en la moneda base
+	ExchangeRate *money.Rate   `json:"exchange_rate,omitempty"`
+	Lines        []InvoiceLine `json:"lines"`
+	Net          money.Money   `json:"net"`
+	Tax          money.Money   `json:"tax"`
+	Total        money.Money   `json:"total"`
+
+	Status                 InvoiceStatus `json:"status"`
+	AuthorizationCode      string        `json:"authorization_code,omitempty"` // CAE
+	AuthorizationExpiresAt *time.Time    `json:"authorization_expires_at,omitempty"`
+	RejectionReason        string        `json:"rejection_reason,omitempty"`
+	SubmitAttempts         int           `json:"submit_attempts"`
+	IssuedAt               time.Time     `json:"issued_at"`
+	UpdatedAt              time.Time     `json:"updated_at"`
+}
+
+// FullNumber retorna el número con el formato impreso, ej. "0001-00000042"
+func (i *Invoice) FullNumber() string {
+	return fmt.Sprintf("%04d-%08d", i.PointOfSale, i.Number)
+}
+
+// TaxBreakdown agrupa el IVA de las líneas por alícuota, de mayor a menor
+func (i *Invoice) TaxBreakdown() []tax.Group {
+	lines := make([]tax.LineTax, len(i.Lines))
+	for k, l := range i.Lines {
+		lines[k] = tax.LineTax{Rate: l.TaxRate, Net: l.Net, Tax: l.Tax}
+	}
+	return tax.Summarize(lines, i.Currency)
+}
+
+// InvoiceAuthorization es la respuesta del organismo fiscal a una factura
+type InvoiceAuthorization struct {
+	Approved  bool
+	Code      string // CAE
+	ExpiresAt time.Time
+	Reason    string // motivo del rechazo
+}
+
+type InvoiceFilter struct {
+	OrderID int64
+	Status  InvoiceStatus
+	Limit   int
+	Offset  int
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "core/internal/domain/money"
+
+// BarCodeFormat es el formato de imagen de un código de barras
+type BarCodeFormat string
+
+const (
+	BarCodeFormatPNG BarCodeFormat = "png"
+	BarCodeFormatSVG BarCodeFormat = "svg"
+)
+
+func (f BarCodeFormat) IsValid() bool {
+	return f == BarCodeFormatPNG || f == BarCodeFormatSVG
+}
+
+// ProductLabel es una etiqueta de góndola o colgante; Copies es cuántas se imprimen
+type ProductLabel struct {
+	ProductID int64       `json:"product_id"`
+	BarCode   string      `json:"bar_code"`
+	Title     string      `json:"title"`
+	Size      string      `json:"size"`
+	Price     money.Money `json:"price"`
+	Copies    int         `json:"copies"`
+}
@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"core/internal/domain/money"
+	"time"
+)
+
+// MarketplaceListingStatus indica si una publicación se sincroniza
+type MarketplaceListingStatus string
+
+const (
+	MarketplaceListingActive MarketplaceListingStatus = "active" // stock y precio se envían al marketplace
+	MarketplaceListingPaused MarketplaceListingStatus = "paused" // no se envía nada; las ventas se siguen importando
+)
+
+func (s MarketplaceListingStatus) IsValid() bool {
+	return s == MarketplaceListingActive || s == MarketplaceListingPaused
+}
+
+// MarketplaceListing vincula un producto con su publicación en un marketplace. Synced*
+// son los últimos valores que aceptó el marketplace; cuando difieren de los del producto
+// la publicación se vuelve a enviar.
+type MarketplaceListing struct {
+	ID          int64                    `json:"id"`
+	Marketplace string                   `json:"marketplace"`
+	ProductID   int64                    `json:"product_id"`
+	ExternalID  string                   `json:"external_id"`
+	Status      MarketplaceListingStatus `json:"status"`
+	SyncedStock int64                    `json:"synced_stock"`
+	SyncedPrice *money.Money             `json:"synced_price,omitempty"`
+	SyncedAt    *time.Time               `json:"synced_at,omitempty"`
+	SyncError   string                   `json:"sync_error,omitempty"` // último envío fallido
+	UpdatedAt   time.Time                `json:"updated_at"`
+	CreatedAt   time.Time                `json:"created_at"`
+}
+
+// NeedsPush indica si la publicación no tiene el stock y el precio indicados o si el
+// último envío falló
+func (l *MarketplaceListing) NeedsPush(stock int64, price money.Money) bool {
+	if l.SyncedAt == nil || l.SyncError != "" || l.SyncedPrice == nil || l.SyncedStock != stock {
+		return true
+	}
+	return *l.SyncedPrice != price
+}
+
+type MarketplaceListingFilter struct {
+	Marketplace string
+	ProductID   int64
+	Status      MarketplaceListingStatus
+	Limit       int
+	Offset      int
+}
+
+// MarketplaceSyncItem es una publicación activa con su producto
+type MarketplaceSyncItem struct {
+	Listing MarketplaceListing
+	Product Product
+}
+
+// MarketplaceOffer es lo que se publica del producto en el instante dado: su stock
+// disponible y su precio efectivo. Un producto que no se ve en el catálogo se publica
+// sin stock para que no se venda.
+func MarketplaceOffer(p *Product, now time.Time) (stock int64, price money.Money) {
+	stock = p.Stock
+	if !p.IsVisible(now) || stock < 0 {
+		stock = 0
+	}
+	return stock, p.EffectivePrice(now)
+}
+
+// MarketplaceItem es una publicación tal como la informa el marketplace
+type MarketplaceItem struct {
+	ExternalID  string      `json:"external_id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	Price       money.Money `json:"price"`
+	Stock       int64       `json:"stock"`
+	Status      string      `json:"status"` // active, paused o closed, según el marketplace
+	Pictures    []string    `json:"pictures,omitempty"`
+}
+
+// MarketplaceOrderStatus indica si una venta del marketplace se importó completa
+type MarketplaceOrderStatus string
+
+const (
+	MarketplaceOrderImported MarketplaceOrderStatus = "imported"
+	// Hay artículos sin publicación vinculada o sin stock suficiente para descontar
+	MarketplaceOrderReview MarketplaceOrderStatus = "review"
+)
+
+func (s MarketplaceOrderStatus) IsValid() bool {
+	return s == MarketplaceOrderImported || s == MarketplaceOrderReview
+}
+
+// MarketplaceOrder es una venta pagada en el marketplace. Al importarla se descuenta el
+// stock de los productos vinculados con un movimiento de venta.
+type MarketplaceOrder struct {
+	ID          int64                  `json:"id"`
+	Marketplace string                 `json:"marketplace"`
+	ExternalID  string                 `json:"external_id"`
+	Status      MarketplaceOrderStatus `json:"status"`
+	Buyer       string                 `json:"buyer,omitempty"`
+	Total       money.Money            `json:"total"`
+	Lines       []MarketplaceOrderLine `json:"lines"`
+	OrderedAt   time.Time              `json:"ordered_at"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// MarketplaceOrderLine es un artículo vendido. ProductID es nil si la publicación no
+// está vinculada; Shortage son las unidades vendidas que no había en stock.
+type MarketplaceOrderLine struct {
+	ID             int64       `json:"id"`
+	ExternalItemID string      `json:"external_item_id"`
+	Title          string      `json:"title"`
+	ProductID      *int64      `json:"product_id,omitempty"`
+	Quantity       int64       `json:"quantity"`
+	UnitPrice      money.Money `json:"unit_price"`
+	Shortage       int64       `json:"shortage,omitempty"`
+}
+
+type MarketplaceOrderFilter struct {
+	Marketplace string
+	Status      MarketplaceOrderStatus
+	Limit       int
+	Offset      int
+}
+
+// MarketplaceSync resume una pasada de sincronización con un marketplace
+type MarketplaceSync struct {
+	Marketplace    string `json:"marketplace"`
+	OrdersImported int    `json:"orders_imported"`
+	Pushed         int    `json:"pushed"` // publicaciones actualizadas
+	Failed         int    `json:"failed"` // envíos rechazados, se reintentan en la próxima pasada
+	// Error es el motivo por el que no se pudieron traer las ventas; sin ellas no se envía stock
+	Error string `json:"error,omitempty"`
+}
+
+// Campos que compara la conciliación
+const (
+	MarketplaceFieldStock  = "stock"
+	MarketplaceFieldPrice  = "price"
+	MarketplaceFieldStatus = "status"
+)
+
+// MarketplaceDiscrepancy es una diferencia entre una publicación y su producto. Fixed
+// indica que se corrigió enviando los valores locales.
+type MarketplaceDiscrepancy struct {
+	ListingID   int64  `json:"listing_id"`
+	Marketplace string `json:"marketplace"`
+	ProductID   int64  `json:"product_id"`
+	ExternalID  string `json:"external_id"`
+	Field       string `json:"field"`
+	Local       string `json:"local"`
+	Remote      string `json:"remote"`
+	Fixed       bool   `json:"fixed"`
+}
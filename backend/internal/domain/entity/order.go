@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"core/internal/domain/money"
+	"core/internal/domain/tax"
+	"time"
+)
+
+// OrderStatus representa el estado de una orden
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "pending"   // creada, esperando pago
+	OrderStatusPaid      OrderStatus = "paid"      // pago acreditado
+	OrderStatusCancelled OrderStatus = "cancelled" // cancelada, stock devuelto
+)
+
+// CanTransitionTo indica si la orden puede pasar al estado indicado.
+// Solo una orden pendiente se paga o se cancela.
+func (s OrderStatus) CanTransitionTo(to OrderStatus) bool {
+	return s == OrderStatusPending && (to == OrderStatusPaid || to == OrderStatusCancelled)
+}
+
+// OrderLine es un pedido de unidades de un producto al hacer checkout
+type OrderLine struct {
+	ProductID int64 `json:"product_id"`
+	Quantity  int64 `json:"quantity"`
+}
+
+// OrderItem es una línea de la orden con el precio congelado al momento de la compra
+type OrderItem struct {
+	ID            int64       `json:"id"`
+	OrderID       int64       `json:"order_id"`
+	ProductID     int64       `json:"product_id"`
+	BarCode       string      `json:"bar_code"`
+	Title         string      `json:"title"`
+	Size          string      `json:"size"`
+	Category      string      `json:"category"`
+	WeightGrams   int64       `json:"weight_grams"` // peso unitario
+	Quantity      int64       `json:"quantity"`
+	BaseUnitPrice money.Money `json:"base_unit_price"` // precio efectivo en la moneda del producto
+	UnitPrice     money.Money `json:"unit_price"`      // precio en la moneda de la orden
+	PriceSource   string      `json:"price_source"`
+	LineTotal     money.Money `json:"line_total"` // UnitPrice * Quantity, antes de descuentos
+	Discount      money.Money `json:"discount"`   // parte de los descuentos de la orden asignada a la línea
+	TaxRate       tax.Rate    `json:"tax_rate"`
+	NetAmount     money.Money `json:"net_amount"` // LineTotal - Discount sin IVA
+	TaxAmount     money.Money `json:"tax_amount"`
+	// Ubicaciones de las que se prepara la línea; vacío en órdenes anteriores al stock por ubicación
+	Allocations []StockAllocation `json:"allocations,omitempty"`
+}
+
+// OrderDiscount es una promoción aplicada a la orden, congelada al momento de la compra
+type OrderDiscount struct {
+	ID          int64       `json:"id"`
+	OrderID     int64       `json:"order_id"`
+	PromotionID int64       `json:"promotion_id"`
+	Code        string      `json:"code,omitempty"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Amount      money.Money `json:"amount"`
+}
+
+type Order struct {
+	ID           int64           `json:"id"`
+	UserID       int64           `json:"user_id"`
+	Status       OrderStatus     `json:"status"`
+	Currency     money.Currency  `json:"currency"`
+	Subtotal     money.Money     `json:"subtotal"`
+	Discount     money.Money     `json:"discount"`
+	TaxMode      tax.Mode        `json:"tax_mode"` // si los precios de la orden incluían IVA
+	Tax          money.Money     `json:"tax"`
+	Total        money.Money     `json:"total"`
+	ExchangeRate *RateSnapshot   `json:"exchange_rate,omitempty"` // nil si la orden está en la moneda base
+	Billing      BillingInfo     `json:"billing"`
+	Shipping     *OrderShipping  `json:"shipping,omitempty"` // nil = retiro en local
+	Items        []OrderItem     `json:"items"`
+	Discounts    []OrderDiscount `json:"discounts"`
+	// Ubicación de la que se descuenta primero el stock (ventas de mostrador); 0 = por
+	// prioridad. No se persiste: lo asignado queda en los ítems.
+	LocationID int64      `json:"-"`
+	PaidAt     *time.Time `json:"paid_at,omitempty"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// BillingInfo son los datos del cliente para la factura; vacíos = consumidor final
+type BillingInfo struct {
+	TaxID     string     `json:"tax_id,omitempty"` // solo dígitos
+	TaxIDType tax.IDType `json:"tax_id_type,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	Address   string     `json:"address,omitempty"`
+}
+
+// Checkout es lo que el cliente envía para valuar o confirmar el carrito
+type Checkout struct {
+	UserID   int64
+	Lines    []OrderLine
+	Currency money.Currency
+	Codes    []string // cupones ingresados, ya normalizados
+	Billing  BillingInfo
+	// Direcciones de la libreta del usuario; 0 = la marcada por defecto
+	ShippingAddressID int64
+	BillingAddressID  int64
+	ShippingMethodID  int64 // 0 = sin envío (retiro en local)
+	// Venta de mostrador: el stock sale primero de esta ubicación y no se usa la libreta de direcciones
+	LocationID int64
+}
+
+// CheckoutQuote es la valuación de un carrito: la orden sin confirmar, los cupones
+// y promociones que no aplicaron y los envíos disponibles para la dirección elegida
+type CheckoutQuote struct {
+	Order           *Order
+	Rejected        []RejectedPromotion
+	ShippingOptions []ShippingQuote
+}
+
+// WeightGrams retorna el peso total de los ítems de la orden
+func (o *Order) WeightGrams() int64 {
+	var total int64
+	for _, it := range o.Items {
+		total += it.WeightGrams * it.Quantity
+	}
+	return total
+}
+
+// Net retorna el total de la orden sin impuestos
+func (o *Order) Net() money.Money {
+	net, err := o.Total.Sub(o.Tax)
+	if err != nil {
+		return money.Zero(o.Currency)
+	}
+	return net
+}
+
+// TaxBreakdown agrupa el IVA de las líneas y el envío por alícuota, de mayor a menor.
+// Como el impuesto se reparte sin perder centavos, los grupos suman exactamente el total.
+func (o *Order) TaxBreakdown() []tax.Group {
+	lines := make([]tax.LineTax, len(o.Items))
+	for i, it := range o.Items {
+		lines[i] = tax.LineTax{Rate: it.TaxRate, Net: it.NetAmount, Tax: it.TaxAmount}
+	}
+	if o.Shipping != nil {
+		lines = append(lines, tax.LineTax{Rate: o.Shipping.TaxRate, Net: o.Shipping.Net, Tax: o.Shipping.Tax})
+	}
+	return tax.Summarize(lines, o.Currency)
+}
+
+type OrderFilter struct {
+	UserID int64
+	Status OrderStatus
+	Limit  int
+	Offset int
+}
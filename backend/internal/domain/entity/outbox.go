@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DomainEvent es el nombre de un evento que se publica desde el outbox
+type DomainEvent string
+
+const (
+	EventProductCreated DomainEvent = "product.created"
+	EventProductUpdated DomainEvent = "product.updated" // datos, estado o precios
+	EventProductDeleted DomainEvent = "product.deleted"
+	EventStockChanged   DomainEvent = "stock.changed"
+	EventOrderPaid      DomainEvent = "order.paid"
+)
+
+// Agregados de los eventos. El relay publica en orden los eventos de un mismo agregado.
+const (
+	OutboxAggregateProduct = "product"
+	OutboxAggregateOrder   = "order"
+)
+
+// OutboxEvent es un evento guardado en la misma transacción que la mutación que lo
+// origina. ID sirve a los consumidores para descartar repetidos: el relay garantiza al
+// menos una entrega, no una sola.
+type OutboxEvent struct {
+	ID            int64           `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   int64           `json:"aggregate_id"`
+	Event         DomainEvent     `json:"event"`
+	Data          json.RawMessage `json:"data"`
+	Attempts      int             `json:"-"`
+	LastError     string          `json:"-"`
+	NextAttemptAt *time.Time      `json:"-"`
+	PublishedAt   *time.Time      `json:"-"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"
+	OutboxPublished OutboxStatus = "published"
+)
+
+func (s OutboxStatus) IsValid() bool {
+	return s == OutboxPending || s == OutboxPublished
+}
+
+type OutboxFilter struct {
+	Status        OutboxStatus
+	AggregateType string
+	AggregateID   int64
+	Limit         int
+	Offset        int
+}
+
+// OutboxRetryDelay es la espera antes de reintentar un evento tras attempts fallos: un
+// segundo que se duplica en cada fallo, hasta 5 minutos. El relay no descarta eventos.
+func OutboxRetryDelay(attempts int) time.Duration {
+	const base, max = time.Second, 5 * time.Minute
+	if attempts < 1 {
+		return base
+	}
+	if attempts > 9 {
+		return max
+	}
+	return min(base<<(attempts-1), max)
+}
+
+// StockChange es el dato de stock.changed: un movimiento del libro de stock y el stock
+// disponible del producto después de aplicarlo. Una transferencia entre ubicaciones
+// genera un cambio por ubicación. Sin LocationID, es el disponible que se recalculó al
+// activar o desactivar una ubicación o al reconstruir el stock desde el libro.
+type StockChange struct {
+	ProductID  int64  `json:"product_id"`
+	BarCode    string `json:"bar_code"`
+	LocationID int64  `json:"location_id,omitempty"`
+	Delta      int64  `json:"delta"`
+	Stock      int64  `json:"stock"`
+}
+
+// ProductDeleted es el dato de product.deleted
+type ProductDeleted struct {
+	ID      int64  `json:"id"`
+	BarCode string `json:"bar_code"`
+}
@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"core/internal/domain/money"
+	"time"
+)
+
+type RegisterShiftStatus string
+
+const (
+	RegisterShiftStatusOpen   RegisterShiftStatus = "open"
+	RegisterShiftStatusClosed RegisterShiftStatus = "closed"
+)
+
+// RegisterShift es el turno de un cajero en una caja, desde que abre con el fondo
+// inicial hasta que cierra contando el efectivo. Los montos están en la moneda base.
+type RegisterShift struct {
+	ID           int64               `json:"id"`
+	Register     string              `json:"register"` // identificador de la caja dentro de la ubicación
+	LocationID   int64               `json:"location_id"`
+	LocationCode string              `json:"location_code"`
+	CashierID    int64               `json:"cashier_id"`
+	Status       RegisterShiftStatus `json:"status"`
+	OpeningFloat money.Money         `json:"opening_float"`
+	// Se congelan al cerrar
+	ExpectedCash *money.Money `json:"expected_cash,omitempty"`
+	CountedCash  *money.Money `json:"counted_cash,omitempty"`
+	Note         string       `json:"note,omitempty"`
+	OpenedAt     time.Time    `json:"opened_at"`
+	ClosedAt     *time.Time   `json:"closed_at,omitempty"`
+}
+
+type RegisterShiftFilter struct {
+	CashierID  int64
+	LocationID int64
+	Status     RegisterShiftStatus
+	Limit      int
+	Offset     int
+}
+
+// ShiftTotals resume lo cobrado en las ventas completadas de un turno. Cash ya tiene
+// descontado el vuelto entregado.
+type ShiftTotals struct {
+	Sales int64       `json:"sales"`
+	Total money.Money `json:"total"`
+	Cash  money.Money `json:"cash"`
+	Card  money.Money `json:"card"`
+}
+
+// ShiftReport es el arqueo de un turno: el efectivo que debería haber en la caja contra
+// el contado. Difference negativo es faltante.
+type ShiftReport struct {
+	Shift        RegisterShift `json:"shift"`
+	Totals       ShiftTotals   `json:"totals"`
+	ExpectedCash money.Money   `json:"expected_cash"`
+	CountedCash  *money.Money  `json:"counted_cash,omitempty"`
+	Difference   *money.Money  `json:"difference,omitempty"`
+}
+
+// Reconcile arma el arqueo del turno con sus totales. Sin cierre no hay contado ni diferencia.
+func (s RegisterShift) Reconcile(t ShiftTotals) (ShiftReport, error) {
+	expected, err := s.OpeningFloat.Add(t.Cash)
+	if err != nil {
+		return ShiftReport{}, err
+	}
+	report := ShiftReport{Shift: s, Totals: t, ExpectedCash: expected}
+	if s.CountedCash != nil {
+		diff, err := s.CountedCash.Sub(expected)
+		if err != nil {
+			return ShiftReport{}, err
+		}
+		report.CountedCash, report.Difference = s.CountedCash, &diff
+	}
+	return report, nil
+}
+
+type PosSaleStatus string
+
+const (
+	PosSaleStatusOpen      PosSaleStatus = "open"      // escaneando productos
+	PosSaleStatusCompleted PosSaleStatus = "completed" // cobrada, con su orden
+	PosSaleStatusVoided    PosSaleStatus = "voided"    // anulada antes de cobrar
+)
+
+type PaymentMethod string
+
+const (
+	PaymentMethodCash PaymentMethod = "cash"
+	PaymentMethodCard PaymentMethod = "card"
+)
+
+func (m PaymentMethod) IsValid() bool {
+	return m == PaymentMethodCash || m == PaymentMethodCard
+}
+
+// PosPayment es un cobro de la venta. En efectivo Amount es lo que entregó el cliente.
+type PosPayment struct {
+	ID        int64         `json:"id"`
+	Method    PaymentMethod `json:"method"`
+	Amount    money.Money   `json:"amount"`
+	Reference string        `json:"reference,omitempty"` // cupón o código de autorización de la tarjeta
+}
+
+// PosSaleLine son las unidades escaneadas de un producto
+type PosSaleLine struct {
+	ProductID int64  `json:"product_id"`
+	BarCode   string `json:"bar_code"`
+	Title     string `json:"title"`
+	Size      string `json:"size"`
+	Quantity  int64  `json:"quantity"`
+}
+
+// PosSale es una venta de mostrador. Al cobrarse se confirma como una orden pagada, que
+// descuenta el stock y se factura igual que las ventas online.
+type PosSale struct {
+	ID          int64         `json:"id"`
+	ShiftID     int64         `json:"shift_id"`
+	CashierID   int64         `json:"cashier_id"`
+	Status      PosSaleStatus `json:"status"`
+	OrderID     *int64        `json:"order_id,omitempty"`
+	Lines       []PosSaleLine `json:"lines"`
+	Payments    []PosPayment  `json:"payments"`
+	Total       *money.Money  `json:"total,omitempty"`  // el de la orden, al cobrar
+	Change      *money.Money  `json:"change,omitempty"` // vuelto entregado en efectivo
+	CompletedAt *time.Time    `json:"completed_at,omitempty"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+	// Order es la valuación vigente de una venta abierta o la orden de una cobrada; no se persiste
+	Order *Order `json:"order,omitempty"`
+}
+
+// OrderLines retorna lo escaneado como líneas de checkout
+func (s *PosSale) OrderLines() []OrderLine {
+	lines := make([]OrderLine, 0, len(s.Lines))
+	for _, l := range s.Lines {
+		lines = append(lines, OrderLine{ProductID: l.ProductID, Quantity: l.Quantity})
+	}
+	return lines
+}
+
+// PosReceipt es lo que se imprime en el ticket de una venta cobrada
+type PosReceipt struct {
+	Sale  PosSale
+	Order Order
+	Shift RegisterShift
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"core/internal/domain/money"
+	"time"
+)
+
+// PriceChange es un cambio de precio programado que se aplica automáticamente en EffectiveAt
+type PriceChange struct {
+	ID             int64        `json:"id"`
+	ProductID      int64        `json:"product_id"`
+	UnitPrice      money.Money  `json:"unit_price"`
+	CompareAtPrice *money.Money `json:"compare_at_price,omitempty"`
+	EffectiveAt    time.Time    `json:"effective_at"`
+	AppliedAt      *time.Time   `json:"applied_at,omitempty"`
+	FailedAt       *time.Time   `json:"failed_at,omitempty"` // no se pudo aplicar; Failure dice por qué
+	Failure        string       `json:"failure,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+// AppliedPriceChange es un cambio programado ya aplicado, con el producto antes y después
+// de aplicarlo
+type AppliedPriceChange struct {
+	Change PriceChange
+	Before Product
+	After  Product
+}
+
+// IsApplied indica si el cambio ya fue aplicado al producto
+func (c *PriceChange) IsApplied() bool {
+	return c.AppliedAt != nil
+}
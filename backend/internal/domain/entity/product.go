@@ -1,24 +1,133 @@
 package entity
 
-import "time"
+import (
+	"core/internal/domain/money"
+	"time"
+)
+
+// ProductStatus representa el estado de publicación de un producto
+type ProductStatus string
+
+const (
+	ProductStatusDraft     ProductStatus = "draft"     // En edición, solo visible para admins
+	ProductStatusScheduled ProductStatus = "scheduled" // Se publica automáticamente en PublishAt
+	ProductStatusPublished ProductStatus = "published" // Visible en el catálogo público
+	ProductStatusArchived  ProductStatus = "archived"  // Retirado del catálogo
+)
+
+// IsValid verifica si el estado es uno de los conocidos
+func (s ProductStatus) IsValid() bool {
+	switch s {
+	case ProductStatusDraft, ProductStatusScheduled, ProductStatusPublished, ProductStatusArchived:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo indica si se permite pasar del estado actual al indicado
+func (s ProductStatus) CanTransitionTo(next ProductStatus) bool {
+	switch s {
+	case ProductStatusDraft:
+		return next == ProductStatusScheduled || next == ProductStatusPublished || next == ProductStatusArchived
+	case ProductStatusScheduled:
+		return next == ProductStatusDraft || next == ProductStatusPublished || next == ProductStatusArchived
+	case ProductStatusPublished:
+		return next == ProductStatusArchived
+	case ProductStatusArchived:
+		return next == ProductStatusDraft
+	}
+	return false
+}
 
 type Product struct {
-	ID          int64     `json:"id"`
-	BarCode     int64     `json:"bar_code"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Stock       int64     `json:"stock"`
-	Size        string    `json:"size"` // S,M,L,XL,XXL
-	Category    string    `json:"category"`
-	UnitPrice   float64   `json:"unit_price"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int64         `json:"id"`
+	BarCode     string        `json:"bar_code"` // EAN-13
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Stock       int64         `json:"stock"`
+	Size        string        `json:"size"` // S,M,L,XL,XXL
+	Category    string        `json:"category"`
+	WeightGrams int64         `json:"weight_grams"` // peso con empaque, para cotizar envíos
+	UnitPrice   money.Money   `json:"unit_price"`
+	Status      ProductStatus `json:"status"`
+	PublishAt   *time.Time    `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time    `json:"unpublish_at,omitempty"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+
+	// Precios de referencia y ofertas
+	CompareAtPrice *money.Money `json:"compare_at_price,omitempty"` // precio "antes" tachado
+	SalePrice      *money.Money `json:"sale_price,omitempty"`
+	SaleStartsAt   *time.Time   `json:"sale_starts_at,omitempty"`
+	SaleEndsAt     *time.Time   `json:"sale_ends_at,omitempty"`
+}
+
+// IsOnSale indica si la oferta está vigente en el instante dado
+func (p *Product) IsOnSale(now time.Time) bool {
+	if p.SalePrice == nil || !p.SalePrice.LessThan(p.UnitPrice) {
+		return false
+	}
+	if p.SaleStartsAt != nil && now.Before(*p.SaleStartsAt) {
+		return false
+	}
+	if p.SaleEndsAt != nil && !now.Before(*p.SaleEndsAt) {
+		return false
+	}
+	return true
+}
+
+// EffectivePrice retorna el precio a cobrar en el instante dado
+func (p *Product) EffectivePrice(now time.Time) money.Money {
+	if p.IsOnSale(now) {
+		return *p.SalePrice
+	}
+	return p.UnitPrice
+}
+
+// OriginalPrice retorna el precio de referencia contra el que se muestra el descuento
+func (p *Product) OriginalPrice() money.Money {
+	if p.CompareAtPrice != nil && p.CompareAtPrice.GreaterThan(p.UnitPrice) {
+		return *p.CompareAtPrice
+	}
+	return p.UnitPrice
+}
+
+// DiscountPercentage retorna el descuento del precio efectivo sobre el original, con dos decimales
+func (p *Product) DiscountPercentage(now time.Time) float64 {
+	original := p.OriginalPrice()
+	if !original.IsPositive() {
+		return 0
+	}
+	diff, err := original.Sub(p.EffectivePrice(now))
+	if err != nil {
+		return 0
+	}
+	// Se calcula en centésimos de punto porcentual para no arrastrar errores de float
+	bps, err := diff.MulFrac(10000, original.Minor(), money.RoundHalfUp)
+	if err != nil {
+		return 0
+	}
+	return float64(bps.Minor()) / 100
+}
+
+// IsVisible indica si el producto debe mostrarse en el catálogo público en el instante
+// dado. ProductFilter.VisibleAt aplica la misma regla en la consulta.
+func (p *Product) IsVisible(now time.Time) bool {
+	if p.Status != ProductStatusPublished {
+		return false
+	}
+	if p.PublishAt != nil && p.PublishAt.After(now) {
+		return false
+	}
+	return p.UnpublishAt == nil || now.Before(*p.UnpublishAt)
 }
 
 type ProductFilter struct {
-	Category string
-	Size     string
-	Query    string
-	Limit    int
-	Offset   int
+	Category  string
+	Size      string
+	Query     string
+	Status    ProductStatus // vacío = todos los estados
+	VisibleAt *time.Time    // solo los visibles en el catálogo público en ese instante
+	Limit     int
+	Offset    int
 }
@@ -0,0 +1,30 @@
+package entity
+
+// CatalogEntry es un producto con sus imágenes, la principal primero
+type CatalogEntry struct {
+	Product
+	Images []ProductImage
+}
+
+// Campos de la exportación del catálogo. Los que comparten nombre con la importación
+// se pueden volver a importar sin mapeo.
+const (
+	ExportFieldID             = "id"
+	ExportFieldCurrency       = "currency"
+	ExportFieldStatus         = "status"
+	ExportFieldCompareAtPrice = "compare_at_price"
+	ExportFieldSalePrice      = "sale_price"
+	ExportFieldSaleStartsAt   = "sale_starts_at"
+	ExportFieldSaleEndsAt     = "sale_ends_at"
+	ExportFieldImageURLs      = "image_urls"
+	ExportFieldUpdatedAt      = "updated_at"
+	ExportFieldCreatedAt      = "created_at"
+)
+
+// ExportFields lista los campos exportables en el orden por defecto
+var ExportFields = []string{
+	ExportFieldID, ImportFieldBarCode, ImportFieldTitle, ImportFieldDescription, ImportFieldStock, ImportFieldSize,
+	ImportFieldCategory, ImportFieldWeightGrams, ImportFieldUnitPrice, ExportFieldCurrency, ExportFieldStatus,
+	ImportFieldPublishAt, ImportFieldUnpublishAt, ExportFieldCompareAtPrice, ExportFieldSalePrice,
+	ExportFieldSaleStartsAt, ExportFieldSaleEndsAt, ExportFieldImageURLs, ExportFieldUpdatedAt, ExportFieldCreatedAt,
+}
@@ -0,0 +1,107 @@
+package entity
+
+import "time"
+
+// FileFormat es el formato de un archivo de catálogo
+type FileFormat string
+
+const (
+	FileFormatCSV   FileFormat = "csv"
+	FileFormatXLSX  FileFormat = "xlsx"
+	FileFormatJSONL FileFormat = "jsonl" // un objeto JSON por línea
+)
+
+func (f FileFormat) IsValid() bool {
+	return f == FileFormatCSV || f == FileFormatXLSX || f == FileFormatJSONL
+}
+
+// IsSpreadsheet indica si el formato es una planilla, los únicos que se importan
+func (f FileFormat) IsSpreadsheet() bool {
+	return f == FileFormatCSV || f == FileFormatXLSX
+}
+
+type ProductImportStatus string
+
+const (
+	ProductImportStatusQueued    ProductImportStatus = "queued"
+	ProductImportStatusRunning   ProductImportStatus = "running"
+	ProductImportStatusCompleted ProductImportStatus = "completed"
+	ProductImportStatusFailed    ProductImportStatus = "failed"
+)
+
+func (s ProductImportStatus) IsValid() bool {
+	switch s {
+	case ProductImportStatusQueued, ProductImportStatusRunning, ProductImportStatusCompleted, ProductImportStatusFailed:
+		return true
+	}
+	return false
+}
+
+// Campos del producto que se pueden importar; son los de CreateProductRequest
+const (
+	ImportFieldBarCode     = "bar_code"
+	ImportFieldTitle       = "title"
+	ImportFieldDescription = "description"
+	ImportFieldStock       = "stock"
+	ImportFieldSize        = "size"
+	ImportFieldCategory    = "category"
+	ImportFieldWeightGrams = "weight_grams"
+	ImportFieldUnitPrice   = "unit_price"
+	ImportFieldPublishAt   = "publish_at"
+	ImportFieldUnpublishAt = "unpublish_at"
+)
+
+// ImportFields lista los campos importables en el orden de la planilla modelo
+var ImportFields = []string{
+	ImportFieldBarCode, ImportFieldTitle, ImportFieldDescription, ImportFieldStock, ImportFieldSize,
+	ImportFieldCategory, ImportFieldWeightGrams, ImportFieldUnitPrice, ImportFieldPublishAt, ImportFieldUnpublishAt,
+}
+
+// ProductImport es una importación de productos desde una planilla. Cada fila se da de
+// alta o actualiza el producto con el mismo código de barras; en modo prueba solo se
+// valida y se informa qué pasaría.
+type ProductImport struct {
+	ID       int64      `json:"id"`
+	Filename string     `json:"filename"`
+	Format   FileFormat `json:"format"`
+	DryRun   bool       `json:"dry_run"`
+	// Mapping asocia encabezados de la planilla con campos del producto. Los encabezados
+	// sin mapear se comparan con el nombre del campo.
+	Mapping       map[string]string   `json:"mapping,omitempty"`
+	Status        ProductImportStatus `json:"status"`
+	TotalRows     int                 `json:"total_rows"`
+	ProcessedRows int                 `json:"processed_rows"`
+	Created       int                 `json:"created"`
+	Updated       int                 `json:"updated"`
+	Failed        int                 `json:"failed"`
+	Error         string              `json:"error,omitempty"` // motivo si la importación entera falló
+	CreatedBy     int64               `json:"created_by"`
+	Errors        []ImportRowError    `json:"errors,omitempty"`
+	StartedAt     *time.Time          `json:"started_at,omitempty"`
+	FinishedAt    *time.Time          `json:"finished_at,omitempty"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
+
+// ImportRowError es el motivo por el que se rechazó una fila. Row es el número de fila
+// en la planilla, contando el encabezado como la fila 1.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	BarCode string `json:"bar_code,omitempty"`
+	Message string `json:"message"`
+}
+
+// ProductImportProgress es el avance parcial que se guarda mientras corre la importación
+type ProductImportProgress struct {
+	ProcessedRows int
+	Created       int
+	Updated       int
+	Failed        int
+}
+
+type ProductImportFilter struct {
+	Status ProductImportStatus
+	Limit  int
+	Offset int
+}
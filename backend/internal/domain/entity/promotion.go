@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"core/internal/domain/money"
+	"strings"
+	"time"
+)
+
+// PromotionType define cómo se calcula el descuento de una promoción
+type PromotionType string
+
+const (
+	PromotionTypePercentage PromotionType = "percentage"  // PercentOff % sobre los ítems alcanzados
+	PromotionTypeFixed      PromotionType = "fixed"       // AmountOff repartido entre los ítems alcanzados
+	PromotionTypeBuyXGetY   PromotionType = "buy_x_get_y" // cada BuyQuantity+GetQuantity unidades, las GetQuantity más baratas gratis
+)
+
+// IsValid verifica si el tipo es uno de los conocidos
+func (t PromotionType) IsValid() bool {
+	switch t {
+	case PromotionTypePercentage, PromotionTypeFixed, PromotionTypeBuyXGetY:
+		return true
+	}
+	return false
+}
+
+// Promotion es un cupón (con Code) o una promoción automática (sin Code).
+// Los montos están en Currency; si la promoción no tiene montos aplica en cualquier moneda.
+type Promotion struct {
+	ID          int64          `json:"id"`
+	Name        string         `json:"name"`
+	Code        *string        `json:"code,omitempty"` // nil = se aplica automáticamente
+	Type        PromotionType  `json:"type"`
+	PercentOff  int64          `json:"percent_off,omitempty"` // 1 a 100
+	AmountOff   *money.Money   `json:"amount_off,omitempty"`
+	BuyQuantity int64          `json:"buy_quantity,omitempty"`
+	GetQuantity int64          `json:"get_quantity,omitempty"`
+	Category    string         `json:"category,omitempty"` // vacío = todo el catálogo
+	MinSubtotal *money.Money   `json:"min_subtotal,omitempty"`
+	Currency    money.Currency `json:"currency"`
+
+	UsageLimit       *int64     `json:"usage_limit,omitempty"`        // usos totales
+	PerCustomerLimit *int64     `json:"per_customer_limit,omitempty"` // usos por cliente
+	StartsAt         *time.Time `json:"starts_at,omitempty"`
+	EndsAt           *time.Time `json:"ends_at,omitempty"`
+
+	// Se evalúan por prioridad descendente y luego por id; una exclusiva no se combina
+	Priority  int       `json:"priority"`
+	Exclusive bool      `json:"exclusive"`
+	Active    bool      `json:"active"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsCoupon indica si la promoción requiere ingresar un código
+func (p *Promotion) IsCoupon() bool {
+	return p.Code != nil
+}
+
+// HasAmounts indica si la promoción tiene montos y por lo tanto una moneda propia
+func (p *Promotion) HasAmounts() bool {
+	return p.AmountOff != nil || p.MinSubtotal != nil
+}
+
+// IsActiveAt indica si la promoción está habilitada y dentro de su ventana de vigencia
+func (p *Promotion) IsActiveAt(now time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return false
+	}
+	return p.EndsAt == nil || now.Before(*p.EndsAt)
+}
+
+// AppliesToCategory indica si la promoción alcanza a los productos de la categoría
+func (p *Promotion) AppliesToCategory(category string) bool {
+	return p.Category == "" || strings.EqualFold(p.Category, category)
+}
+
+// NormalizeCouponCode normaliza un código para compararlo sin importar mayúsculas
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// PromotionUsage son los usos de una promoción en órdenes no canceladas
+type PromotionUsage struct {
+	Total    int64
+	Customer int64
+}
+
+type PromotionFilter struct {
+	Active *bool
+	Code   string
+	Limit  int
+	Offset int
+}
+
+// CartItem es una línea del carrito ya valuada en la moneda del carrito
+type CartItem struct {
+	ProductID int64
+	Category  string
+	Quantity  int64
+	UnitPrice money.Money
+}
+
+// Cart es la entrada del motor de promociones
+type Cart struct {
+	UserID   int64
+	Currency money.Currency
+	Items    []CartItem
+	Codes    []string // cupones ingresados, ya normalizados
+}
+
+// AppliedPromotion explica una promoción aplicada y cuánto descontó en cada línea
+type AppliedPromotion struct {
+	PromotionID int64         `json:"promotion_id"`
+	Code        string        `json:"code,omitempty"`
+	Name        string        `json:"name"`
+	Type        PromotionType `json:"type"`
+	Description string        `json:"description"`
+	Amount      money.Money   `json:"amount"`
+	// Descuento por línea del carrito, en el mismo orden que Cart.Items
+	LineAmounts []money.Money `json:"line_amounts"`
+}
+
+// RejectedPromotion explica por qué un cupón o promoción no se aplicó
+type RejectedPromotion struct {
+	PromotionID int64  `json:"promotion_id,omitempty"`
+	Code        string `json:"code,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Reason      string `json:"reason"`
+}
+
+// PromotionResult es el resultado de evaluar un carrito
+type PromotionResult struct {
+	Subtotal money.Money         `json:"subtotal"`
+	Discount money.Money         `json:"discount"`
+	Total    money.Money         `json:"total"`
+	Applied  []AppliedPromotion  `json:"applied"`
+	Rejected []RejectedPromotion `json:"rejected"`
+	// Descuento total por línea, en el mismo orden que Cart.Items
+	LineDiscounts []money.Money `json:"line_discounts"`
+}
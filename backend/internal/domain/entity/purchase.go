@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"core/internal/domain/money"
+	"time"
+)
+
+// Supplier es un proveedor al que se le compra mercadería
+type Supplier struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	TaxID     string    `json:"tax_id,omitempty"` // CUIT, solo dígitos
+	Email     string    `json:"email,omitempty"`
+	Phone     string    `json:"phone,omitempty"`
+	Address   string    `json:"address,omitempty"`
+	Notes     string    `json:"notes,omitempty"`
+	Active    bool      `json:"active"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderStatusOrdered           PurchaseOrderStatus = "ordered" // enviada al proveedor
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderStatusCancelled         PurchaseOrderStatus = "cancelled" // lo pendiente no se recibe
+)
+
+func (s PurchaseOrderStatus) IsValid() bool {
+	switch s {
+	case PurchaseOrderStatusDraft, PurchaseOrderStatusOrdered, PurchaseOrderStatusPartiallyReceived,
+		PurchaseOrderStatusReceived, PurchaseOrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo valida los cambios de estado manuales. La recepción pasa la orden a
+// recibida o parcialmente recibida por su cuenta.
+func (s PurchaseOrderStatus) CanTransitionTo(to PurchaseOrderStatus) bool {
+	switch s {
+	case PurchaseOrderStatusDraft:
+		return to == PurchaseOrderStatusOrdered || to == PurchaseOrderStatusCancelled
+	case PurchaseOrderStatusOrdered, PurchaseOrderStatusPartiallyReceived:
+		return to == PurchaseOrderStatusCancelled
+	}
+	return false
+}
+
+// CanReceive indica si la orden admite recepciones
+func (s PurchaseOrderStatus) CanReceive() bool {
+	return s == PurchaseOrderStatusOrdered || s == PurchaseOrderStatusPartiallyReceived
+}
+
+// PurchaseOrderLine es un producto pedido al proveedor con su costo unitario pactado
+type PurchaseOrderLine struct {
+	ID        int64       `json:"id"`
+	ProductID int64       `json:"product_id"`
+	Title     string      `json:"title"`
+	Size      string      `json:"size"`
+	Quantity  int64       `json:"quantity"`
+	Received  int64       `json:"received"`
+	UnitCost  money.Money `json:"unit_cost"`
+}
+
+func (l PurchaseOrderLine) Remaining() int64 { return l.Quantity - l.Received }
+
+// PurchaseOrder es un pedido a un proveedor. Los costos están en la moneda base y la
+// mercadería ingresa a LocationID.
+type PurchaseOrder struct {
+	ID           int64               `json:"id"`
+	SupplierID   int64               `json:"supplier_id"`
+	SupplierName string              `json:"supplier_name"`
+	LocationID   int64               `json:"location_id"`
+	LocationCode string              `json:"location_code"`
+	Status       PurchaseOrderStatus `json:"status"`
+	Currency     money.Currency      `json:"currency"`
+	Total        money.Money         `json:"total"`
+	Note         string              `json:"note,omitempty"`
+	ExpectedAt   *time.Time          `json:"expected_at,omitempty"`
+	OrderedAt    *time.Time          `json:"ordered_at,omitempty"`
+	CreatedBy    int64               `json:"created_by"`
+	Lines        []PurchaseOrderLine `json:"lines"`
+	Receipts     []PurchaseReceipt   `json:"receipts"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+	CreatedAt    time.Time           `json:"created_at"`
+}
+
+// PurchaseReceipt es una entrega del proveedor, total o parcial
+type PurchaseReceipt struct {
+	ID              int64                 `json:"id"`
+	PurchaseOrderID int64                 `json:"purchase_order_id"`
+	ActorID         int64                 `json:"actor_id"`
+	Note            string                `json:"note,omitempty"`
+	Lines           []PurchaseReceiptLine `json:"lines"`
+	CreatedAt       time.Time             `json:"created_at"`
+}
+
+// PurchaseReceiptLine son unidades recibidas de una línea. UnitCost nil = el costo del pedido.
+type PurchaseReceiptLine struct {
+	LineID    int64        `json:"line_id"`
+	ProductID int64        `json:"product_id"`
+	Quantity  int64        `json:"quantity"`
+	UnitCost  *money.Money `json:"unit_cost,omitempty"`
+}
+
+type PurchaseOrderFilter struct {
+	SupplierID int64
+	Status     PurchaseOrderStatus
+	Limit      int
+	Offset     int
+}
+
+// ProductCost es el costo promedio ponderado de un producto en la moneda base
+type ProductCost struct {
+	ProductID   int64       `json:"product_id"`
+	AverageCost money.Money `json:"average_cost"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// WeightedAverageCost incorpora qty unidades a costo cost a un stock de onHand unidades
+// valuadas a avg. Sin stock previo el promedio es el costo de la compra.
+func WeightedAverageCost(avg money.Money, onHand int64, cost money.Money, qty int64) (money.Money, error) {
+	if onHand <= 0 || avg.Currency() == "" {
+		return cost, nil
+	}
+	total, err := avg.Mul(onHand).Add(cost.Mul(qty))
+	if err != nil {
+		return money.Money{}, err
+	}
+	return total.MulFrac(1, onHand+qty, money.RoundHalfEven)
+}
+
+// ProductMargin compara el precio de lista con el costo promedio
+type ProductMargin struct {
+	ProductID   int64        `json:"product_id"`
+	BarCode     string       `json:"bar_code"`
+	Title       string       `json:"title"`
+	Size        string       `json:"size"`
+	Category    string       `json:"category"`
+	UnitPrice   money.Money  `json:"unit_price"`
+	AverageCost *money.Money `json:"average_cost,omitempty"` // nil = sin compras registradas
+}
+
+// Margin retorna la ganancia por unidad y su porcentaje sobre el precio en puntos básicos
+func (m ProductMargin) Margin() (money.Money, int64, bool) {
+	if m.AverageCost == nil || m.AverageCost.Currency() != m.UnitPrice.Currency() {
+		return money.Money{}, 0, false
+	}
+	margin, err := m.UnitPrice.Sub(*m.AverageCost)
+	if err != nil {
+		return money.Money{}, 0, false
+	}
+	if m.UnitPrice.IsZero() {
+		return margin, 0, true
+	}
+	return margin, margin.Minor() * 10000 / m.UnitPrice.Minor(), true
+}
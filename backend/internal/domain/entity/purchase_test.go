@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"core/internal/domain/money"
+)
+
+func TestWeightedAverageCost(t *testing.T) {
+	ars := func(minor int64) money.Money { return money.New(minor, money.ARS) }
+
+	tests := []struct {
+		name   string
+		avg    money.Money
+		onHand int64
+		cost   money.Money
+		qty    int64
+		want   money.Money
+	}{
+		{"no previous stock", ars(10000), 0, ars(13000), 5, ars(13000)},
+		{"negative stock", ars(10000), -3, ars(13000), 5, ars(13000)},
+		{"no previous average", money.Money{}, 10, ars(13000), 5, ars(13000)},
+		{"weighted", ars(10000), 10, ars(13000), 5, ars(11000)},
+		{"same cost", ars(2550), 4, ars(2550), 6, ars(2550)},
+		{"half rounds to even down", ars(100), 1, ars(101), 1, ars(100)},
+		{"half rounds to even up", ars(101), 1, ars(102), 1, ars(102)},
+		{"rounds to nearest", ars(1000), 2, ars(1001), 1, ars(1000)},
+		{"other currency without previous stock", money.New(500, money.USD), 0, ars(13000), 1, ars(13000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WeightedAverageCost(tt.avg, tt.onHand, tt.cost, tt.qty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("WeightedAverageCost(%s, %d, %s, %d) = %s, want %s", tt.avg, tt.onHand, tt.cost, tt.qty, got, tt.want)
+			}
+		})
+	}
+
+	_, err := WeightedAverageCost(money.New(500, money.USD), 2, ars(13000), 1)
+	if !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("WeightedAverageCost USD avg, ARS cost error = %v, want %v", err, money.ErrCurrencyMismatch)
+	}
+}
@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"core/internal/domain/money"
+	"time"
+)
+
+// ReturnStatus representa el estado de una solicitud de devolución o cambio
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested" // pedida por el cliente, esperando revisión
+	ReturnStatusApproved  ReturnStatus = "approved"  // aprobada, talles de cambio reservados
+	ReturnStatusRejected  ReturnStatus = "rejected"  // rechazada por el staff
+	ReturnStatusReceived  ReturnStatus = "received"  // mercadería recibida y reingresada al stock
+	ReturnStatusCompleted ReturnStatus = "completed" // reembolso hecho y cambios despachados
+	ReturnStatusCancelled ReturnStatus = "cancelled" // cancelada antes de recibir la mercadería
+)
+
+// IsValid verifica si el estado es uno de los conocidos
+func (s ReturnStatus) IsValid() bool {
+	switch s {
+	case ReturnStatusRequested, ReturnStatusApproved, ReturnStatusRejected,
+		ReturnStatusReceived, ReturnStatusCompleted, ReturnStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo indica si la solicitud puede pasar al estado indicado.
+// Una vez recibida la mercadería ya no se puede cancelar, solo completar.
+func (s ReturnStatus) CanTransitionTo(to ReturnStatus) bool {
+	switch s {
+	case ReturnStatusRequested:
+		return to == ReturnStatusApproved || to == ReturnStatusRejected || to == ReturnStatusCancelled
+	case ReturnStatusApproved:
+		return to == ReturnStatusReceived || to == ReturnStatusCancelled
+	case ReturnStatusReceived:
+		return to == ReturnStatusCompleted
+	}
+	return false
+}
+
+// IsOpen indica si la solicitud todavía compromete unidades de la orden
+func (s ReturnStatus) IsOpen() bool {
+	return s != ReturnStatusRejected && s != ReturnStatusCancelled
+}
+
+// ReturnReason es el motivo de la devolución de una línea
+type ReturnReason string
+
+const (
+	ReturnReasonWrongSize      ReturnReason = "wrong_size"
+	ReturnReasonDefective      ReturnReason = "defective"
+	ReturnReasonNotAsDescribed ReturnReason = "not_as_described"
+	ReturnReasonChangedMind    ReturnReason = "changed_mind"
+	ReturnReasonOther          ReturnReason = "other"
+)
+
+func (r ReturnReason) IsValid() bool {
+	switch r {
+	case ReturnReasonWrongSize, ReturnReasonDefective, ReturnReasonNotAsDescribed, ReturnReasonChangedMind, ReturnReasonOther:
+		return true
+	}
+	return false
+}
+
+// ReturnResolution es lo que recibe el cliente a cambio de las unidades devueltas
+type ReturnResolution string
+
+const (
+	ReturnResolutionRefund   ReturnResolution = "refund"   // se reintegra lo pagado
+	ReturnResolutionExchange ReturnResolution = "exchange" // se entrega otro talle del mismo artículo
+)
+
+func (r ReturnResolution) IsValid() bool {
+	return r == ReturnResolutionRefund || r == ReturnResolutionExchange
+}
+
+// ReturnLine es lo que pide el cliente para una línea de la orden
+type ReturnLine struct {
+	OrderItemID       int64
+	Quantity          int64
+	Reason            ReturnReason
+	Resolution        ReturnResolution
+	ExchangeProductID int64 // solo para cambios: el otro talle
+}
+
+// ReturnItem es una línea de la solicitud con los datos de la orden congelados
+type ReturnItem struct {
+	ID                int64            `json:"id"`
+	ReturnID          int64            `json:"return_id"`
+	OrderItemID       int64            `json:"order_item_id"`
+	ProductID         int64            `json:"product_id"`
+	Title             string           `json:"title"`
+	Size              string           `json:"size"`
+	Quantity          int64            `json:"quantity"`
+	Reason            ReturnReason     `json:"reason"`
+	Resolution        ReturnResolution `json:"resolution"`
+	ExchangeProductID int64            `json:"exchange_product_id,omitempty"`
+	ExchangeSize      string           `json:"exchange_size,omitempty"`
+	RefundAmount      money.Money      `json:"refund_amount"` // lo pagado por las unidades; cero en los cambios
+}
+
+// Restocks indica si las unidades vuelven a la venta al recibirlas. Las fallas no.
+func (it ReturnItem) Restocks() bool {
+	return it.Reason != ReturnReasonDefective
+}
+
+// ReturnStatusChange es una entrada del historial de la solicitud.
+// From vacío es el alta; ActorID 0 es el propio sistema.
+type ReturnStatusChange struct {
+	ID        int64        `json:"id"`
+	ReturnID  int64        `json:"return_id"`
+	From      ReturnStatus `json:"from,omitempty"`
+	To        ReturnStatus `json:"to"`
+	ActorID   int64        `json:"actor_id,omitempty"`
+	Note      string       `json:"note,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// ReturnRequest es una solicitud de devolución o cambio (RMA) sobre una orden pagada
+type ReturnRequest struct {
+	ID              int64                `json:"id"`
+	OrderID         int64                `json:"order_id"`
+	UserID          int64                `json:"user_id"`
+	Status          ReturnStatus         `json:"status"`
+	Comment         string               `json:"comment,omitempty"`
+	Currency        money.Currency       `json:"currency"`
+	RefundTotal     money.Money          `json:"refund_total"`
+	RefundReference string               `json:"refund_reference,omitempty"` // id del reembolso en la pasarela
+	RefundedAt      *time.Time           `json:"refunded_at,omitempty"`
+	Items           []ReturnItem         `json:"items"`
+	History         []ReturnStatusChange `json:"history"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+	CreatedAt       time.Time            `json:"created_at"`
+}
+
+// Refund es un reembolso acreditado por la pasarela de pagos
+type Refund struct {
+	Reference string      `json:"reference"`
+	Amount    money.Money `json:"amount"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type ReturnFilter struct {
+	UserID  int64
+	OrderID int64
+	Status  ReturnStatus
+	Limit   int
+	Offset  int
+}
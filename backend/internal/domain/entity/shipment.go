@@ -0,0 +1,70 @@
+package entity
+
+import "time"
+
+// ShipmentStatus es el estado de seguimiento informado por el carrier
+type ShipmentStatus string
+
+const (
+	ShipmentStatusLabelCreated   ShipmentStatus = "label_created"    // etiqueta generada, sin despachar
+	ShipmentStatusInTransit      ShipmentStatus = "in_transit"       // en viaje
+	ShipmentStatusOutForDelivery ShipmentStatus = "out_for_delivery" // en reparto
+	ShipmentStatusDelivered      ShipmentStatus = "delivered"        // entregado
+	ShipmentStatusException      ShipmentStatus = "exception"        // demora o incidencia, sigue abierto
+	ShipmentStatusReturned       ShipmentStatus = "returned"         // devuelto al remitente
+)
+
+func (s ShipmentStatus) IsValid() bool {
+	switch s {
+	case ShipmentStatusLabelCreated, ShipmentStatusInTransit, ShipmentStatusOutForDelivery,
+		ShipmentStatusDelivered, ShipmentStatusException, ShipmentStatusReturned:
+		return true
+	}
+	return false
+}
+
+// IsFinal indica si el envío terminó y ya no hace falta seguirlo
+func (s ShipmentStatus) IsFinal() bool {
+	return s == ShipmentStatusDelivered || s == ShipmentStatusReturned
+}
+
+// TrackingEvent es una novedad de seguimiento. El carrier puede informarla más de una vez
+// (por webhook y por consulta); se identifica por estado y momento.
+type TrackingEvent struct {
+	ID          int64          `json:"id"`
+	ShipmentID  int64          `json:"shipment_id"`
+	Status      ShipmentStatus `json:"status"`
+	Description string         `json:"description"`
+	Location    string         `json:"location"`
+	OccurredAt  time.Time      `json:"occurred_at"`
+}
+
+// Shipment es el despacho de una orden con envío. El estado es el del evento más reciente.
+type Shipment struct {
+	ID             int64           `json:"id"`
+	OrderID        int64           `json:"order_id"`
+	Carrier        string          `json:"carrier"`
+	TrackingNumber string          `json:"tracking_number"`
+	Status         ShipmentStatus  `json:"status"`
+	Address        ShippingAddress `json:"address"`
+	WeightGrams    int64           `json:"weight_grams"`
+	Label          []byte          `json:"-"` // PDF de la etiqueta generada por el carrier
+	Events         []TrackingEvent `json:"events"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	LastCheckedAt  *time.Time      `json:"last_checked_at,omitempty"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// CarrierLabel es la respuesta del carrier al dar de alta un envío
+type CarrierLabel struct {
+	TrackingNumber string
+	Document       []byte // PDF para imprimir y pegar en el paquete
+}
+
+type ShipmentFilter struct {
+	Status  ShipmentStatus
+	Carrier string
+	Limit   int
+	Offset  int
+}
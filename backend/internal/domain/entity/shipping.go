@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"core/internal/domain/money"
+	"core/internal/domain/tax"
+	"sort"
+	"time"
+)
+
+// ShippingZone agrupa provincias que comparten tarifas. Cada provincia pertenece a una sola zona.
+type ShippingZone struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Provinces []string  `json:"provinces"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ShippingRate es el precio de envío a una zona para paquetes de hasta MaxWeightGrams
+type ShippingRate struct {
+	ID             int64       `json:"id"`
+	MethodID       int64       `json:"method_id"`
+	ZoneID         int64       `json:"zone_id"`
+	MaxWeightGrams int64       `json:"max_weight_grams"`
+	Price          money.Money `json:"price"`
+}
+
+// ShippingMethod es una forma de envío con su tabla de tarifas. Los precios y el umbral
+// de envío gratis están en la moneda base y se convierten a la moneda de la orden.
+type ShippingMethod struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MinDays     int    `json:"min_days"`
+	MaxDays     int    `json:"max_days"`
+	// Si el subtotal con descuentos alcanza el umbral, el envío es gratis
+	FreeShippingThreshold *money.Money   `json:"free_shipping_threshold,omitempty"`
+	Active                bool           `json:"active"`
+	Rates                 []ShippingRate `json:"rates"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+	CreatedAt             time.Time      `json:"created_at"`
+}
+
+// RateFor busca la tarifa de menor peso que cubra el paquete; false si no hay
+func (m *ShippingMethod) RateFor(zoneID, weightGrams int64) (ShippingRate, bool) {
+	var candidates []ShippingRate
+	for _, r := range m.Rates {
+		if r.ZoneID == zoneID && r.MaxWeightGrams >= weightGrams {
+			candidates = append(candidates, r)
+		}
+	}
+	if len(candidates) == 0 {
+		return ShippingRate{}, false
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].MaxWeightGrams < candidates[j].MaxWeightGrams })
+	return candidates[0], true
+}
+
+// ShippingQuote es el costo de un método de envío para un carrito y destino
+type ShippingQuote struct {
+	MethodID      int64       `json:"method_id"`
+	Name          string      `json:"name"`
+	Description   string      `json:"description"`
+	MinDays       int         `json:"min_days"`
+	MaxDays       int         `json:"max_days"`
+	Price         money.Money `json:"price"`          // a cobrar, en la moneda de la orden
+	OriginalPrice money.Money `json:"original_price"` // antes del envío gratis
+	Free          bool        `json:"free"`
+}
+
+// OrderShipping es el envío elegido, congelado en la orden
+type OrderShipping struct {
+	MethodID    int64           `json:"method_id"`
+	MethodName  string          `json:"method_name"`
+	Address     ShippingAddress `json:"address"`
+	WeightGrams int64           `json:"weight_grams"`
+	Amount      money.Money     `json:"amount"` // precio cobrado, según el modo de IVA de la orden
+	TaxRate     tax.Rate        `json:"tax_rate"`
+	Net         money.Money     `json:"net"`
+	Tax         money.Money     `json:"tax"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// ReorderPoint es el umbral de stock de un producto: con menos unidades disponibles se
+// alerta. Threshold 0 = sin alerta.
+type ReorderPoint struct {
+	ProductID       int64     `json:"product_id"`
+	Threshold       int64     `json:"threshold"`
+	ReorderQuantity int64     `json:"reorder_quantity"` // cantidad sugerida para reponer
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// LowStockItem es un producto con el disponible por debajo de su umbral
+type LowStockItem struct {
+	ProductID       int64      `json:"product_id"`
+	BarCode         string     `json:"bar_code"`
+	Title           string     `json:"title"`
+	Size            string     `json:"size"`
+	Category        string     `json:"category"`
+	Stock           int64      `json:"stock"`
+	Threshold       int64      `json:"threshold"`
+	ReorderQuantity int64      `json:"reorder_quantity"`
+	AlertedAt       *time.Time `json:"alerted_at,omitempty"` // nil = todavía no se avisó
+}
+
+// Canales de entrega de las alertas de stock bajo
+const (
+	StockAlertChannelEmail   = "email"
+	StockAlertChannelWebhook = "webhook"
+)
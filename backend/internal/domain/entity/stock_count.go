@@ -0,0 +1,99 @@
+package entity
+
+import "time"
+
+type StockCountStatus string
+
+const (
+	StockCountStatusOpen      StockCountStatus = "open"
+	StockCountStatusApproved  StockCountStatus = "approved"
+	StockCountStatusCancelled StockCountStatus = "cancelled"
+)
+
+func (s StockCountStatus) IsValid() bool {
+	switch s {
+	case StockCountStatusOpen, StockCountStatusApproved, StockCountStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// StockCount es un recuento físico de una ubicación. Al abrirlo se toma el stock de
+// cada producto del alcance como esperado, y al escanear un producto por primera vez su
+// esperado pasa a ser el stock de ese momento. Al aprobarlo se ajusta lo contado menos
+// el esperado, así lo que se vendió después de escanear no se pierde.
+type StockCount struct {
+	ID           int64            `json:"id"`
+	LocationID   int64            `json:"location_id"`
+	LocationCode string           `json:"location_code"`
+	Category     string           `json:"category,omitempty"` // vacío = todos los productos
+	Status       StockCountStatus `json:"status"`
+	Note         string           `json:"note,omitempty"`
+	OpenedBy     int64            `json:"opened_by"`
+	ApprovedBy   *int64           `json:"approved_by,omitempty"`
+	ApprovedAt   *time.Time       `json:"approved_at,omitempty"`
+	Lines        []StockCountLine `json:"lines,omitempty"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	CreatedAt    time.Time        `json:"created_at"`
+}
+
+// StockCountLine es un producto del alcance del recuento. Un producto sin escanear
+// cuenta como cero al aprobar.
+type StockCountLine struct {
+	ProductID int64      `json:"product_id"`
+	BarCode   string     `json:"bar_code"`
+	Title     string     `json:"title"`
+	Size      string     `json:"size"`
+	Expected  int64      `json:"expected"`
+	Counted   int64      `json:"counted"`
+	ScannedAt *time.Time `json:"scanned_at,omitempty"`
+}
+
+func (l StockCountLine) Variance() int64 { return l.Counted - l.Expected }
+
+// StockCountScan son unidades escaneadas de un código; una cantidad negativa corrige
+// un escaneo de más
+type StockCountScan struct {
+	BarCode  string `json:"bar_code"`
+	Quantity int64  `json:"quantity"`
+}
+
+// StockCountScanResult informa cuántos escaneos se sumaron y qué códigos no pertenecen al recuento
+type StockCountScanResult struct {
+	Accepted int      `json:"accepted"`
+	Unknown  []string `json:"unknown"`
+}
+
+// StockCountSummary resume las diferencias del recuento
+type StockCountSummary struct {
+	Lines        int   `json:"lines"`
+	Scanned      int   `json:"scanned"`
+	WithVariance int   `json:"with_variance"`
+	UnitsOver    int64 `json:"units_over"`  // sobrantes
+	UnitsShort   int64 `json:"units_short"` // faltantes
+}
+
+func (c StockCount) Summary() StockCountSummary {
+	s := StockCountSummary{Lines: len(c.Lines)}
+	for _, l := range c.Lines {
+		if l.ScannedAt != nil {
+			s.Scanned++
+		}
+		switch v := l.Variance(); {
+		case v > 0:
+			s.WithVariance++
+			s.UnitsOver += v
+		case v < 0:
+			s.WithVariance++
+			s.UnitsShort -= v
+		}
+	}
+	return s
+}
+
+type StockCountFilter struct {
+	Status     StockCountStatus
+	LocationID int64
+	Limit      int
+	Offset     int
+}
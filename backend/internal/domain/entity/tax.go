@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"core/internal/domain/tax"
+	"time"
+)
+
+// TaxRate es la alícuota de IVA de una categoría. La categoría vacía es la alícuota
+// por defecto para las categorías sin una propia.
+type TaxRate struct {
+	ID        int64     `json:"id"`
+	Category  string    `json:"category"`
+	Rate      tax.Rate  `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -6,8 +6,9 @@ import "time"
 type UserRole string
 
 const (
-	RoleUser  UserRole = "user"  // Usuario normal
-	RoleAdmin UserRole = "admin" // Administrador con permisos especiales
+	RoleUser    UserRole = "user"    // Usuario normal
+	RoleAdmin   UserRole = "admin"   // Administrador con permisos especiales
+	RoleCashier UserRole = "cashier" // Cajero del punto de venta
 )
 
 // User representa un usuario del sistema
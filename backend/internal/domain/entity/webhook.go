@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookEvent es un tipo de evento al que se puede suscribir un destino
+type WebhookEvent string
+
+const (
+	WebhookEventProductCreated = WebhookEvent(EventProductCreated)
+	WebhookEventProductUpdated = WebhookEvent(EventProductUpdated)
+	WebhookEventProductDeleted = WebhookEvent(EventProductDeleted)
+	WebhookEventStockChanged   = WebhookEvent(EventStockChanged)
+	WebhookEventOrderPaid      = WebhookEvent(EventOrderPaid)
+)
+
+// WebhookEvents lista los eventos que se publican
+var WebhookEvents = []WebhookEvent{
+	WebhookEventProductCreated,
+	WebhookEventProductUpdated,
+	WebhookEventProductDeleted,
+	WebhookEventStockChanged,
+	WebhookEventOrderPaid,
+}
+
+func (e WebhookEvent) IsValid() bool {
+	for _, known := range WebhookEvents {
+		if e == known {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEndpoint es una URL que recibe los eventos suscriptos. Secret firma cada envío
+// y no se expone después del alta.
+type WebhookEndpoint struct {
+	ID          int64          `json:"id"`
+	URL         string         `json:"url"`
+	Description string         `json:"description,omitempty"`
+	Secret      string         `json:"-"`
+	Events      []WebhookEvent `json:"events"`
+	Active      bool           `json:"active"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// Subscribes indica si el destino recibe el evento
+func (e *WebhookEndpoint) Subscribes(event WebhookEvent) bool {
+	for _, ev := range e.Events {
+		if ev == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookMessage es un evento publicado, tal como se envía a cada destino. SourceID es
+// el evento del outbox que lo originó y evita encolarlo dos veces; AggregateType y
+// AggregateID son su agregado, para entregar en orden los eventos de cada uno.
+type WebhookMessage struct {
+	ID            int64           `json:"id"`
+	SourceID      *int64          `json:"-"`
+	AggregateType string          `json:"-"`
+	AggregateID   int64           `json:"-"`
+	Event         WebhookEvent    `json:"event"`
+	Data          json.RawMessage `json:"data"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed" // se agotaron los intentos
+)
+
+func (s WebhookDeliveryStatus) IsValid() bool {
+	return s == WebhookDeliveryPending || s == WebhookDeliveryDelivered || s == WebhookDeliveryFailed
+}
+
+// WebhookDelivery es el envío de un evento a un destino. ResponseStatus y LastError
+// son los del último intento; RedeliveryOf apunta al envío que se reenvió a mano.
+type WebhookDelivery struct {
+	ID             int64                 `json:"id"`
+	EventID        int64                 `json:"event_id"`
+	Event          WebhookEvent          `json:"event"`
+	EndpointID     int64                 `json:"endpoint_id"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	ResponseStatus int                   `json:"response_status,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	RedeliveryOf   *int64                `json:"redelivery_of,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+type WebhookDeliveryFilter struct {
+	EndpointID int64
+	EventID    int64
+	Status     WebhookDeliveryStatus
+	Limit      int
+	Offset     int
+}
+
+// WebhookJob es un envío pendiente con lo necesario para hacerlo
+type WebhookJob struct {
+	Delivery WebhookDelivery
+	Endpoint WebhookEndpoint
+	Message  WebhookMessage
+}
+
+// WebhookRetryDelay es la espera antes del intento siguiente a attempts fallidos: 30
+// segundos que se duplican en cada fallo, hasta 6 horas
+func WebhookRetryDelay(attempts int) time.Duration {
+	const base, max = 30 * time.Second, 6 * time.Hour
+	if attempts < 1 {
+		return base
+	}
+	if attempts > 10 {
+		return max
+	}
+	return min(base<<(attempts-1), max)
+}
@@ -1,14 +1,46 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
-	ErrNotFound      = errors.New("not found")
-	ErrConflict      = errors.New("conflict")
-	ErrUnauthorized  = errors.New("unauthorized")
-	ErrInvalidInput  = errors.New("invalid input")
-	ErrEmailTaken    = errors.New("email already in use")
-	ErrWrongPassword = errors.New("wrong password")
-	ErrInvalidToken  = errors.New("invalid token")
-	ErrBadParamInput = errors.New("params invalid")
+	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrInvalidInput        = errors.New("invalid input")
+	ErrEmailTaken          = errors.New("email already in use")
+	ErrWrongPassword       = errors.New("wrong password")
+	ErrInvalidToken        = errors.New("invalid token")
+	ErrBadParamInput       = errors.New("params invalid")
+	ErrInvalidTransition   = errors.New("invalid status transition")
+	ErrInsufficientStock   = errors.New("insufficient stock")
+	ErrCurrencyNotPriced   = errors.New("no price or exchange rate for currency")
+	ErrPromotionLimit      = errors.New("promotion usage limit reached")
+	ErrCouponRejected      = errors.New("coupon not applicable")
+	ErrInvalidTaxID        = errors.New("invalid customer tax id")
+	ErrShippingUnavailable = errors.New("shipping method not available for destination")
+	ErrPaymentShort        = errors.New("payments do not cover the total")
+	ErrInvalidFile         = errors.New("unreadable or unsupported file")
 )
+
+// FieldError es un campo rechazado al validar una petición
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError reúne los campos inválidos de una petición. Equivale a ErrInvalidInput
+// para errors.Is.
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, len(e))
+	for i, f := range e {
+		msgs[i] = f.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e ValidationError) Unwrap() error { return ErrInvalidInput }
@@ -0,0 +1,55 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Currency es un código ISO 4217
+type Currency string
+
+const (
+	ARS Currency = "ARS" // Peso argentino
+	UYU Currency = "UYU" // Peso uruguayo
+	CLP Currency = "CLP" // Peso chileno
+	USD Currency = "USD" // Dólar estadounidense
+)
+
+// Base es la moneda en la que se cargan los precios del catálogo
+const Base = ARS
+
+// Cantidad de decimales de cada moneda soportada
+var minorUnits = map[Currency]int{
+	ARS: 2,
+	UYU: 2,
+	CLP: 0,
+	USD: 2,
+}
+
+// IsValid indica si la moneda está soportada
+func (c Currency) IsValid() bool {
+	_, ok := minorUnits[c]
+	return ok
+}
+
+// MinorUnits retorna la cantidad de decimales de la moneda
+func (c Currency) MinorUnits() int {
+	return minorUnits[c]
+}
+
+// ParseCurrency normaliza y valida un código de moneda
+func ParseCurrency(s string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(s)))
+	if !c.IsValid() {
+		return "", fmt.Errorf("%w: %q", ErrUnknownCurrency, s)
+	}
+	return c, nil
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
@@ -0,0 +1,74 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// MarshalJSON serializa el monto como número decimal exacto (ej. 2500.00).
+// La moneda se expone en un campo aparte de cada respuesta.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON acepta un número o un string decimal. Si el receptor no tiene moneda
+// se asume Base. Rechaza montos con más decimales que los de la moneda.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidAmount, data)
+		}
+		data = []byte(s)
+	}
+
+	c := m.currency
+	if c == "" {
+		c = Base
+	}
+	parsed, err := Parse(string(data), c, RoundUnnecessary)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value guarda el monto como decimal para columnas DECIMAL
+func (m Money) Value() (driver.Value, error) {
+	return m.Decimal(), nil
+}
+
+// Scan lee una columna DECIMAL. Como la columna no trae la moneda se usa la del
+// receptor o Base; los repositorios con columna de moneda deben usar Parse.
+func (m *Money) Scan(src any) error {
+	c := m.currency
+	if c == "" {
+		c = Base
+	}
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("%w: cannot scan %T into Money", ErrInvalidAmount, src)
+	}
+	parsed, err := Parse(s, c, RoundHalfEven)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
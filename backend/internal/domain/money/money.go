@@ -0,0 +1,263 @@
+// Package money implementa montos monetarios exactos en unidades menores (centavos).
+// Nunca se usan float64 para calcular: los redondeos son explícitos.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrPrecision        = errors.New("amount has more decimals than the currency allows")
+)
+
+// Money es un monto en unidades menores de una moneda
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// New crea un monto a partir de unidades menores (ej. centavos)
+func New(minor int64, c Currency) Money {
+	return Money{amount: minor, currency: c}
+}
+
+// Zero retorna un monto cero en la moneda indicada
+func Zero(c Currency) Money {
+	return Money{currency: c}
+}
+
+// Parse interpreta un decimal como "2500.50" o "-10" en la moneda indicada.
+// Si tiene más decimales que la moneda se aplica el modo de redondeo.
+func Parse(s string, c Currency, mode RoundingMode) (Money, error) {
+	if !c.IsValid() {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, c)
+	}
+	s = strings.TrimSpace(s)
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	// Se trabaja con todos los decimales y luego se reduce a la escala de la moneda
+	digits := intPart + fracPart
+	if len(strings.TrimLeft(digits, "0")) > 18 {
+		return Money{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+	}
+	raw, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if neg {
+		raw = -raw
+	}
+
+	scale := c.MinorUnits()
+	var minor int64
+	if extra := len(fracPart) - scale; extra > 0 {
+		var ok bool
+		minor, ok = divRound(raw, pow10(extra), mode)
+		if !ok {
+			return Money{}, fmt.Errorf("%w: %q for %s", ErrPrecision, s, c)
+		}
+	} else {
+		minor = raw * pow10(-extra)
+	}
+	return Money{amount: minor, currency: c}, nil
+}
+
+// MustParse es como Parse pero entra en pánico ante un error. Solo para constantes.
+func MustParse(s string, c Currency) Money {
+	m, err := Parse(s, c, RoundUnnecessary)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Minor retorna el monto en unidades menores
+func (m Money) Minor() int64 { return m.amount }
+
+// Currency retorna la moneda del monto
+func (m Money) Currency() Currency { return m.currency }
+
+func (m Money) IsZero() bool     { return m.amount == 0 }
+func (m Money) IsPositive() bool { return m.amount > 0 }
+func (m Money) IsNegative() bool { return m.amount < 0 }
+
+func (m Money) sameCurrency(o Money) error {
+	if m.currency != o.currency {
+		return fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+	return nil
+}
+
+// Add suma dos montos de la misma moneda
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount + o.amount, currency: m.currency}, nil
+}
+
+// Sub resta dos montos de la misma moneda
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount - o.amount, currency: m.currency}, nil
+}
+
+// Mul multiplica por una cantidad entera (ej. unidades de una línea)
+func (m Money) Mul(qty int64) Money {
+	return Money{amount: m.amount * qty, currency: m.currency}
+}
+
+// MulFrac multiplica por num/den redondeando a la unidad menor (ej. IVA 10,5% = 105/1000).
+// RoundUnnecessary no aplica a productos y se trata como RoundHalfEven. El producto
+// intermedio se calcula sin límite; falla si el resultado no entra en un int64.
+func (m Money) MulFrac(num, den int64, mode RoundingMode) (Money, error) {
+	if den == 0 {
+		return Money{}, fmt.Errorf("%w: zero denominator", ErrInvalidAmount)
+	}
+	if mode == RoundUnnecessary {
+		mode = RoundHalfEven
+	}
+	x := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(num)), big.NewInt(den))
+	v, err := roundRat(x, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: v, currency: m.currency}, nil
+}
+
+// Neg retorna el monto con signo invertido
+func (m Money) Neg() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// Cmp compara dos montos: -1 si m < o, 0 si son iguales, 1 si m > o
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < o.amount:
+		return -1, nil
+	case m.amount > o.amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// LessThan indica si m < o. Montos de distinta moneda no son comparables y retornan false.
+func (m Money) LessThan(o Money) bool {
+	c, err := m.Cmp(o)
+	return err == nil && c < 0
+}
+
+// GreaterThan indica si m > o. Montos de distinta moneda no son comparables y retornan false.
+func (m Money) GreaterThan(o Money) bool {
+	c, err := m.Cmp(o)
+	return err == nil && c > 0
+}
+
+// Allocate reparte el monto según los pesos indicados sin perder centavos:
+// el resto se asigna uno a uno a las primeras partes. Los pesos no pueden ser
+// negativos; los productos intermedios se calculan sin límite.
+func (m Money) Allocate(weights ...int64) ([]Money, error) {
+	total := new(big.Int)
+	for _, w := range weights {
+		if w < 0 {
+			return nil, fmt.Errorf("%w: negative weight", ErrInvalidAmount)
+		}
+		total.Add(total, big.NewInt(w))
+	}
+	parts := make([]Money, len(weights))
+	if total.Sign() == 0 {
+		for i := range parts {
+			parts[i] = Zero(m.currency)
+		}
+		return parts, nil
+	}
+
+	// Cada parte es a lo sumo el monto, así que entra en un int64
+	remainder := m.amount
+	amount, share := big.NewInt(m.amount), new(big.Int)
+	for i, w := range weights {
+		share.Mul(amount, big.NewInt(w))
+		share.Quo(share, total)
+		parts[i] = Money{amount: share.Int64(), currency: m.currency}
+		remainder -= share.Int64()
+	}
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if weights[i] == 0 {
+			continue
+		}
+		parts[i].amount += step
+		remainder -= step
+	}
+	return parts, nil
+}
+
+// Sum suma montos en la moneda indicada
+func Sum(c Currency, ms ...Money) (Money, error) {
+	total := Zero(c)
+	for _, m := range ms {
+		var err error
+		if total, err = total.Add(m); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Decimal retorna el monto como decimal sin moneda, ej. "2500.00"
+func (m Money) Decimal() string {
+	scale := m.currency.MinorUnits()
+	sign := ""
+	a := m.amount
+	if a < 0 {
+		sign, a = "-", -a
+	}
+	if scale == 0 {
+		return sign + strconv.FormatInt(a, 10)
+	}
+	p := pow10(scale)
+	return fmt.Sprintf("%s%d.%0*d", sign, a/p, scale, a%p)
+}
+
+// String retorna el monto con su moneda, ej. "ARS 2500.00"
+func (m Money) String() string {
+	return string(m.currency) + " " + m.Decimal()
+}
@@ -0,0 +1,167 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		c       Currency
+		mode    RoundingMode
+		want    int64
+		wantErr error
+	}{
+		{"2500.50", ARS, RoundUnnecessary, 250050, nil},
+		{"-10", ARS, RoundUnnecessary, -1000, nil},
+		{"+3.1", ARS, RoundUnnecessary, 310, nil},
+		{".5", ARS, RoundUnnecessary, 50, nil},
+		{" 7 ", CLP, RoundUnnecessary, 7, nil},
+		{"10.005", ARS, RoundHalfEven, 1000, nil},
+		{"10.015", ARS, RoundHalfEven, 1002, nil},
+		{"10.005", ARS, RoundHalfUp, 1001, nil},
+		{"-0.015", ARS, RoundHalfEven, -2, nil},
+		{"-0.015", ARS, RoundDown, -1, nil},
+		{"1.5", CLP, RoundHalfEven, 2, nil},
+		{"1.5", CLP, RoundUnnecessary, 0, ErrPrecision},
+		{"", ARS, RoundHalfEven, 0, ErrInvalidAmount},
+		{".", ARS, RoundHalfEven, 0, ErrInvalidAmount},
+		{"1,5", ARS, RoundHalfEven, 0, ErrInvalidAmount},
+		{"1e3", ARS, RoundHalfEven, 0, ErrInvalidAmount},
+		{"1234567890123456789", ARS, RoundHalfEven, 0, ErrInvalidAmount},
+		{"1", "XXX", RoundHalfEven, 0, ErrUnknownCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in, tt.c, tt.mode)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse(%q, %s) error = %v, want %v", tt.in, tt.c, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q, %s) unexpected error: %v", tt.in, tt.c, err)
+			}
+			if got.Minor() != tt.want || got.Currency() != tt.c {
+				t.Errorf("Parse(%q, %s) = %s, want %d minor", tt.in, tt.c, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{New(250050, ARS), "2500.50"},
+		{New(5, ARS), "0.05"},
+		{New(-105, USD), "-1.05"},
+		{New(0, ARS), "0.00"},
+		{New(1500, CLP), "1500"},
+		{New(-7, CLP), "-7"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Decimal(); got != tt.want {
+			t.Errorf("Decimal() of %d %s = %q, want %q", tt.m.Minor(), tt.m.Currency(), got, tt.want)
+		}
+	}
+}
+
+func TestMulFrac(t *testing.T) {
+	tests := []struct {
+		minor    int64
+		num, den int64
+		mode     RoundingMode
+		want     int64
+	}{
+		{1000, 21, 100, RoundHalfEven, 210},
+		{1234, 105, 1000, RoundHalfEven, 130},
+		{125, 1, 10, RoundHalfEven, 12},
+		{135, 1, 10, RoundHalfEven, 14},
+		{125, 1, 10, RoundHalfUp, 13},
+		{125, 1, 10, RoundDown, 12},
+		{121, 1, 10, RoundUp, 13},
+		{125, 1, 10, RoundUnnecessary, 12},
+		{-125, 1, 10, RoundHalfUp, -13},
+		{125, -1, -10, RoundHalfEven, 12},
+		// El producto intermedio excede int64 pero el resultado no
+		{math.MaxInt64, 3, 4, RoundHalfEven, 6917529027641081855},
+		{math.MaxInt64 - 1, 1000, 1000, RoundHalfEven, math.MaxInt64 - 1},
+	}
+	for _, tt := range tests {
+		got, err := New(tt.minor, ARS).MulFrac(tt.num, tt.den, tt.mode)
+		if err != nil {
+			t.Errorf("%d.MulFrac(%d, %d, %d) error = %v", tt.minor, tt.num, tt.den, tt.mode, err)
+			continue
+		}
+		if got.Minor() != tt.want {
+			t.Errorf("%d.MulFrac(%d, %d, %d) = %d, want %d", tt.minor, tt.num, tt.den, tt.mode, got.Minor(), tt.want)
+		}
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		minor   int64
+		weights []int64
+		want    []int64
+	}{
+		{"even", 300, []int64{1, 1, 1}, []int64{100, 100, 100}},
+		{"remainder to first parts", 100, []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{"negative remainder", -100, []int64{1, 1, 1}, []int64{-34, -33, -33}},
+		{"proportional", 1000, []int64{3, 1}, []int64{750, 250}},
+		{"proportional with remainder", 1001, []int64{2, 1}, []int64{668, 333}},
+		{"zero weight gets nothing", 5, []int64{0, 1, 1}, []int64{0, 3, 2}},
+		{"all zero weights", 100, []int64{0, 0}, []int64{0, 0}},
+		{"single part", 999, []int64{7}, []int64{999}},
+		{"large amount and weights", math.MaxInt64, []int64{math.MaxInt64, math.MaxInt64}, []int64{math.MaxInt64/2 + 1, math.MaxInt64 / 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := New(tt.minor, ARS).Allocate(tt.weights...)
+			if err != nil {
+				t.Fatalf("Allocate(%v) of %d error = %v", tt.weights, tt.minor, err)
+			}
+			got := make([]int64, len(parts))
+			for i, p := range parts {
+				if p.Currency() != ARS {
+					t.Fatalf("part %d has currency %q", i, p.Currency())
+				}
+				got[i] = p.Minor()
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Allocate(%v) of %d = %v, want %v", tt.weights, tt.minor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddCurrencyMismatch(t *testing.T) {
+	if _, err := New(100, ARS).Add(New(100, USD)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add ARS + USD error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+	if _, err := Sum(ARS, New(1, ARS), New(1, UYU)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Sum ARS, UYU error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	if _, err := New(math.MaxInt64, ARS).MulFrac(3, 2, RoundHalfEven); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("MulFrac past int64 error = %v, want %v", err, ErrInvalidAmount)
+	}
+	if _, err := New(math.MinInt64, ARS).MulFrac(-1, 1, RoundHalfEven); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("MulFrac of MinInt64 by -1 error = %v, want %v", err, ErrInvalidAmount)
+	}
+	if _, err := New(100, ARS).MulFrac(1, 0, RoundHalfEven); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("MulFrac by x/0 error = %v, want %v", err, ErrInvalidAmount)
+	}
+	if _, err := New(100, ARS).Allocate(2, -1); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("Allocate with negative weight error = %v, want %v", err, ErrInvalidAmount)
+	}
+}
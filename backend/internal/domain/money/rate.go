@@ -0,0 +1,185 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidRate = errors.New("invalid exchange rate")
+
+// rateDecimals es la precisión con la que se guardan y muestran las tasas
+const rateDecimals = 10
+
+// Rate es una tasa de cambio exacta: unidades de la moneda destino por unidad de la moneda origen
+type Rate struct {
+	r *big.Rat
+}
+
+// ParseRate interpreta una tasa decimal positiva como "0.0425"
+func ParseRate(s string) (Rate, error) {
+	s = strings.TrimSpace(s)
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if s == "" || !isDigits(intPart) || !isDigits(fracPart) || len(fracPart) > rateDecimals {
+		return Rate{}, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() <= 0 {
+		return Rate{}, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+	return Rate{r: r}, nil
+}
+
+// IdentityRate es la tasa 1:1 usada cuando origen y destino coinciden
+func IdentityRate() Rate {
+	return Rate{r: big.NewRat(1, 1)}
+}
+
+// RateOf retorna la tasa implícita entre dos montos (to / from, en unidades mayores)
+func RateOf(from, to Money) (Rate, error) {
+	if from.amount <= 0 || to.amount <= 0 {
+		return Rate{}, ErrInvalidRate
+	}
+	r := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(to.amount), big.NewInt(pow10(from.currency.MinorUnits()))),
+		new(big.Int).Mul(big.NewInt(from.amount), big.NewInt(pow10(to.currency.MinorUnits()))),
+	)
+	return Rate{r: r}, nil
+}
+
+// IsZero indica si la tasa no fue inicializada
+func (r Rate) IsZero() bool {
+	return r.r == nil
+}
+
+// Inverse retorna la tasa en sentido contrario
+func (r Rate) Inverse() Rate {
+	if r.r == nil {
+		return r
+	}
+	return Rate{r: new(big.Rat).Inv(r.r)}
+}
+
+// Mul compone dos tasas: de A a B por de B a C da de A a C
+func (r Rate) Mul(o Rate) Rate {
+	if r.r == nil || o.r == nil {
+		return Rate{}
+	}
+	return Rate{r: new(big.Rat).Mul(r.r, o.r)}
+}
+
+// String retorna la tasa con hasta 10 decimales, sin ceros finales
+func (r Rate) String() string {
+	if r.r == nil {
+		return "0"
+	}
+	s := r.r.FloatString(rateDecimals)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// Convert expresa el monto en otra moneda aplicando la tasa y el modo de redondeo
+func Convert(m Money, to Currency, rate Rate, mode RoundingMode) (Money, error) {
+	if !to.IsValid() {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, to)
+	}
+	if rate.r == nil {
+		return Money{}, ErrInvalidRate
+	}
+	// minor_destino = minor_origen * tasa * 10^escala_destino / 10^escala_origen
+	x := new(big.Rat).SetInt64(m.amount)
+	x.Mul(x, rate.r)
+	x.Mul(x, new(big.Rat).SetFrac(big.NewInt(pow10(to.MinorUnits())), big.NewInt(pow10(m.currency.MinorUnits()))))
+
+	minor, err := roundRat(x, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minor, currency: to}, nil
+}
+
+func roundRat(x *big.Rat, mode RoundingMode) (int64, error) {
+	num, den := x.Num(), x.Denom()
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() != 0 {
+		// Se compara el doble del resto contra el denominador
+		twice := new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2))
+		cmp := twice.Cmp(den)
+		up := false
+		switch mode {
+		case RoundDown:
+		case RoundUp:
+			up = true
+		case RoundHalfUp:
+			up = cmp >= 0
+		case RoundHalfEven:
+			up = cmp > 0 || cmp == 0 && q.Bit(0) == 1
+		default:
+			return 0, ErrPrecision
+		}
+		if up {
+			q.Add(q, big.NewInt(int64(r.Sign())))
+		}
+	}
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("%w: result out of range", ErrInvalidAmount)
+	}
+	return q.Int64(), nil
+}
+
+// MarshalJSON serializa la tasa como string para no perder precisión
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(r.String())), nil
+}
+
+// UnmarshalJSON acepta la tasa como string o número
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidRate, data)
+		}
+		data = []byte(s)
+	}
+	parsed, err := ParseRate(string(data))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Value guarda la tasa como decimal para columnas DECIMAL(20,10)
+func (r Rate) Value() (driver.Value, error) {
+	if r.r == nil {
+		return nil, nil
+	}
+	return r.r.FloatString(rateDecimals), nil
+}
+
+// Scan lee una columna DECIMAL con la tasa
+func (r *Rate) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("%w: cannot scan %T into Rate", ErrInvalidRate, src)
+	}
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
@@ -0,0 +1,98 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"0.0425", "0.0425", true},
+		{"1000.50", "1000.5", true},
+		{"1", "1", true},
+		{"0.0000000001", "0.0000000001", true},
+		{"0.00000000001", "", false},
+		{"0", "", false},
+		{"-1", "", false},
+		{"", "", false},
+		{"1/3", "", false},
+	}
+	for _, tt := range tests {
+		r, err := ParseRate(tt.in)
+		if !tt.ok {
+			if !errors.Is(err, ErrInvalidRate) {
+				t.Errorf("ParseRate(%q) error = %v, want %v", tt.in, err, ErrInvalidRate)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseRate(%q) unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got := r.String(); got != tt.want {
+			t.Errorf("ParseRate(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name string
+		from Money
+		to   Currency
+		rate string
+		mode RoundingMode
+		want int64
+	}{
+		{"USD to ARS", New(100, USD), ARS, "1000.5", RoundHalfEven, 100050},
+		{"ARS to USD", New(100000, ARS), USD, "0.001", RoundHalfEven, 100},
+		{"ARS to CLP half even", New(10000, ARS), CLP, "1.005", RoundHalfEven, 100},
+		{"ARS to CLP half up", New(10000, ARS), CLP, "1.005", RoundHalfUp, 101},
+		{"CLP to ARS", New(1500, CLP), ARS, "0.95", RoundHalfEven, 142500},
+		{"round down", New(999, ARS), USD, "0.001", RoundDown, 0},
+		{"round up", New(999, ARS), USD, "0.001", RoundUp, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := ParseRate(tt.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Convert(tt.from, tt.to, rate, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Minor() != tt.want || got.Currency() != tt.to {
+				t.Errorf("Convert(%s, %s, %s) = %s, want %d minor", tt.from, tt.to, tt.rate, got, tt.want)
+			}
+		})
+	}
+
+	rate, _ := ParseRate("1.005")
+	if _, err := Convert(New(10000, ARS), CLP, rate, RoundUnnecessary); !errors.Is(err, ErrPrecision) {
+		t.Errorf("Convert with RoundUnnecessary error = %v, want %v", err, ErrPrecision)
+	}
+	if _, err := Convert(New(100, ARS), USD, Rate{}, RoundHalfEven); !errors.Is(err, ErrInvalidRate) {
+		t.Errorf("Convert with zero rate error = %v, want %v", err, ErrInvalidRate)
+	}
+}
+
+func TestRateOf(t *testing.T) {
+	r, err := RateOf(New(100, USD), New(100050, ARS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.String(); got != "1000.5" {
+		t.Errorf("RateOf(USD 1.00, ARS 1000.50) = %s, want 1000.5", got)
+	}
+	if got := r.Inverse().Mul(r).String(); got != "1" {
+		t.Errorf("rate * inverse = %s, want 1", got)
+	}
+	if _, err := RateOf(Zero(USD), New(1, ARS)); !errors.Is(err, ErrInvalidRate) {
+		t.Errorf("RateOf from zero error = %v, want %v", err, ErrInvalidRate)
+	}
+}
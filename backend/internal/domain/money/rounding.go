@@ -0,0 +1,50 @@
+package money
+
+// RoundingMode define cómo se redondea un resultado que no cae en una unidad menor exacta
+type RoundingMode int
+
+const (
+	RoundHalfUp      RoundingMode = iota // 0.5 se aleja de cero (el redondeo "comercial")
+	RoundHalfEven                        // 0.5 va al par más cercano (redondeo bancario)
+	RoundDown                            // trunca hacia cero
+	RoundUp                              // se aleja de cero si hay resto
+	RoundUnnecessary                     // falla si el resultado no es exacto
+)
+
+// divRound divide num/den aplicando el modo de redondeo. den debe ser positivo.
+// Retorna ok=false solo con RoundUnnecessary y resto distinto de cero.
+func divRound(num, den int64, mode RoundingMode) (int64, bool) {
+	q, r := num/den, num%den
+	if r == 0 {
+		return q, true
+	}
+
+	sign := int64(1)
+	if num < 0 {
+		sign = -1
+		r = -r
+	}
+
+	switch mode {
+	case RoundDown:
+		return q, true
+	case RoundUp:
+		return q + sign, true
+	case RoundHalfUp:
+		if 2*r >= den {
+			return q + sign, true
+		}
+		return q, true
+	case RoundHalfEven:
+		switch {
+		case 2*r > den:
+			return q + sign, true
+		case 2*r < den:
+			return q, true
+		case q%2 != 0:
+			return q + sign, true
+		}
+		return q, true
+	}
+	return q, false
+}
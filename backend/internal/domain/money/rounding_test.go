@@ -0,0 +1,33 @@
+package money
+
+import "testing"
+
+func TestDivRound(t *testing.T) {
+	tests := []struct {
+		num, den int64
+		mode     RoundingMode
+		want     int64
+		ok       bool
+	}{
+		{20, 10, RoundUnnecessary, 2, true},
+		{25, 10, RoundHalfUp, 3, true},
+		{25, 10, RoundHalfEven, 2, true},
+		{35, 10, RoundHalfEven, 4, true},
+		{26, 10, RoundHalfEven, 3, true},
+		{-25, 10, RoundHalfUp, -3, true},
+		{-25, 10, RoundHalfEven, -2, true},
+		{-35, 10, RoundHalfEven, -4, true},
+		{24, 10, RoundHalfUp, 2, true},
+		{21, 10, RoundUp, 3, true},
+		{-21, 10, RoundUp, -3, true},
+		{29, 10, RoundDown, 2, true},
+		{-29, 10, RoundDown, -2, true},
+		{21, 10, RoundUnnecessary, 2, false},
+	}
+	for _, tt := range tests {
+		got, ok := divRound(tt.num, tt.den, tt.mode)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("divRound(%d, %d, %d) = %d, %v; want %d, %v", tt.num, tt.den, tt.mode, got, ok, tt.want, tt.ok)
+		}
+	}
+}
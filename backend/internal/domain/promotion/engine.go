@@ -0,0 +1,261 @@
+// Package promotion evalúa cupones y promociones automáticas sobre un carrito.
+// La evaluación es pura y determinística: el mismo carrito, promociones, usos e
+// instante producen siempre el mismo resultado.
+package promotion
+
+import (
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Evaluate aplica las promociones al carrito. Las promociones se recorren por prioridad
+// descendente y luego por id; cada una descuenta sobre lo que dejaron las anteriores.
+func Evaluate(cart entity.Cart, promos []entity.Promotion, usage map[int64]entity.PromotionUsage, now time.Time) (entity.PromotionResult, error) {
+	cur := cart.Currency
+	lineTotals := make([]int64, len(cart.Items))
+	var subtotal int64
+	for i, it := range cart.Items {
+		if it.UnitPrice.Currency() != cur {
+			return entity.PromotionResult{}, money.ErrCurrencyMismatch
+		}
+		lineTotals[i] = it.UnitPrice.Mul(it.Quantity).Minor()
+		subtotal += lineTotals[i]
+	}
+	remaining := append([]int64(nil), lineTotals...)
+
+	result := entity.PromotionResult{
+		Applied:  []entity.AppliedPromotion{},
+		Rejected: []entity.RejectedPromotion{},
+	}
+
+	// Solo participan las automáticas y los cupones ingresados
+	codes := map[string]bool{}
+	for _, c := range cart.Codes {
+		codes[c] = false
+	}
+	var candidates []entity.Promotion
+	for _, p := range promos {
+		if !p.IsCoupon() {
+			candidates = append(candidates, p)
+			continue
+		}
+		if _, ok := codes[*p.Code]; ok {
+			codes[*p.Code] = true
+			candidates = append(candidates, p)
+		}
+	}
+	for _, c := range sortedKeys(codes) {
+		if !codes[c] {
+			result.Rejected = append(result.Rejected, entity.RejectedPromotion{Code: c, Reason: "unknown coupon code"})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Priority != candidates[j].Priority {
+			return candidates[i].Priority > candidates[j].Priority
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	var exclusive *entity.AppliedPromotion
+	for _, p := range candidates {
+		reason := rejectReason(&p, cart, subtotal, usage[p.ID], now)
+		if reason == "" && exclusive != nil {
+			reason = fmt.Sprintf("not combinable with exclusive promotion %q", exclusive.Name)
+		}
+		if reason == "" && p.Exclusive && len(result.Applied) > 0 {
+			reason = fmt.Sprintf("exclusive promotion, not combinable with %q", result.Applied[0].Name)
+		}
+
+		var amounts []int64
+		if reason == "" {
+			var err error
+			if amounts, err = lineDiscounts(&p, cart, remaining); err != nil {
+				return entity.PromotionResult{}, err
+			}
+			if sum(amounts) == 0 {
+				reason = "no eligible items in cart"
+				if p.Category != "" {
+					reason = fmt.Sprintf("no eligible items in category %q", p.Category)
+				}
+			}
+		}
+		if reason != "" {
+			result.Rejected = append(result.Rejected, entity.RejectedPromotion{
+				PromotionID: p.ID, Code: codeOf(&p), Name: p.Name, Reason: reason,
+			})
+			continue
+		}
+
+		applied := entity.AppliedPromotion{
+			PromotionID: p.ID,
+			Code:        codeOf(&p),
+			Name:        p.Name,
+			Type:        p.Type,
+			Description: describe(&p),
+			Amount:      money.New(sum(amounts), cur),
+			LineAmounts: make([]money.Money, len(amounts)),
+		}
+		for i, a := range amounts {
+			remaining[i] -= a
+			applied.LineAmounts[i] = money.New(a, cur)
+		}
+		result.Applied = append(result.Applied, applied)
+		if p.Exclusive {
+			exclusive = &result.Applied[len(result.Applied)-1]
+		}
+	}
+
+	result.LineDiscounts = make([]money.Money, len(lineTotals))
+	var discount int64
+	for i := range lineTotals {
+		d := lineTotals[i] - remaining[i]
+		result.LineDiscounts[i] = money.New(d, cur)
+		discount += d
+	}
+	result.Subtotal = money.New(subtotal, cur)
+	result.Discount = money.New(discount, cur)
+	result.Total = money.New(subtotal-discount, cur)
+	return result, nil
+}
+
+// rejectReason retorna por qué la promoción no aplica al carrito, o "" si aplica
+func rejectReason(p *entity.Promotion, cart entity.Cart, subtotal int64, usage entity.PromotionUsage, now time.Time) string {
+	switch {
+	case !p.Active:
+		return "promotion is not active"
+	case p.StartsAt != nil && now.Before(*p.StartsAt):
+		return fmt.Sprintf("promotion starts at %s", p.StartsAt.UTC().Format(time.RFC3339))
+	case p.EndsAt != nil && !now.Before(*p.EndsAt):
+		return "promotion has expired"
+	case p.HasAmounts() && p.Currency != cart.Currency:
+		return fmt.Sprintf("promotion is only valid for %s carts", p.Currency)
+	case p.UsageLimit != nil && usage.Total >= *p.UsageLimit:
+		return "usage limit reached"
+	case p.PerCustomerLimit != nil && cart.UserID > 0 && usage.Customer >= *p.PerCustomerLimit:
+		return "usage limit per customer reached"
+	case p.MinSubtotal != nil && subtotal < p.MinSubtotal.Minor():
+		return fmt.Sprintf("minimum subtotal of %s not reached", p.MinSubtotal)
+	}
+	return ""
+}
+
+// lineDiscounts calcula el descuento de la promoción en cada línea, sin superar lo que queda de cada una
+func lineDiscounts(p *entity.Promotion, cart entity.Cart, remaining []int64) ([]int64, error) {
+	cur := cart.Currency
+	out := make([]int64, len(cart.Items))
+	eligible := func(i int) bool {
+		return remaining[i] > 0 && p.AppliesToCategory(cart.Items[i].Category)
+	}
+
+	switch p.Type {
+	case entity.PromotionTypePercentage:
+		for i := range cart.Items {
+			if !eligible(i) {
+				continue
+			}
+			d, err := money.New(remaining[i], cur).MulFrac(p.PercentOff, 100, money.RoundHalfUp)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = d.Minor()
+		}
+
+	case entity.PromotionTypeFixed:
+		weights := make([]int64, len(cart.Items))
+		var base int64
+		for i := range cart.Items {
+			if eligible(i) {
+				weights[i] = remaining[i]
+				base += remaining[i]
+			}
+		}
+		amount := min(p.AmountOff.Minor(), base)
+		parts, err := money.New(amount, cur).Allocate(weights...)
+		if err != nil {
+			return nil, err
+		}
+		for i, part := range parts {
+			out[i] = part.Minor()
+		}
+
+	case entity.PromotionTypeBuyXGetY:
+		// Se ordenan las unidades de mayor a menor precio; en cada grupo de X+Y
+		// las últimas Y (las más baratas del grupo) son gratis
+		type unit struct {
+			line  int
+			price int64
+		}
+		var units []unit
+		for i, it := range cart.Items {
+			if !eligible(i) {
+				continue
+			}
+			for q := int64(0); q < it.Quantity; q++ {
+				units = append(units, unit{line: i, price: it.UnitPrice.Minor()})
+			}
+		}
+		sort.SliceStable(units, func(a, b int) bool {
+			if units[a].price != units[b].price {
+				return units[a].price > units[b].price
+			}
+			return cart.Items[units[a].line].ProductID < cart.Items[units[b].line].ProductID
+		})
+		group := p.BuyQuantity + p.GetQuantity
+		for n := int64(len(units)) / group * group; n > 0; n -= group {
+			for _, u := range units[n-p.GetQuantity : n] {
+				out[u.line] += u.price
+			}
+		}
+		for i := range out {
+			out[i] = min(out[i], remaining[i])
+		}
+	}
+	return out, nil
+}
+
+// describe arma la explicación legible de la promoción
+func describe(p *entity.Promotion) string {
+	var d string
+	switch p.Type {
+	case entity.PromotionTypePercentage:
+		d = fmt.Sprintf("%d%% off", p.PercentOff)
+	case entity.PromotionTypeFixed:
+		d = fmt.Sprintf("%s off", p.AmountOff)
+	case entity.PromotionTypeBuyXGetY:
+		d = fmt.Sprintf("buy %d get %d free", p.BuyQuantity, p.GetQuantity)
+	}
+	if p.Category != "" {
+		d += " on " + p.Category
+	}
+	if p.MinSubtotal != nil {
+		d += fmt.Sprintf(" with a minimum subtotal of %s", p.MinSubtotal)
+	}
+	return d
+}
+
+func codeOf(p *entity.Promotion) string {
+	if p.Code == nil {
+		return ""
+	}
+	return *p.Code
+}
+
+func sum(xs []int64) int64 {
+	var s int64
+	for _, x := range xs {
+		s += x
+	}
+	return s
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,308 @@
+package promotion
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+)
+
+var now = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func ars(minor int64) *money.Money {
+	m := money.New(minor, money.ARS)
+	return &m
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func item(id int64, category string, qty, price int64) entity.CartItem {
+	return entity.CartItem{ProductID: id, Category: category, Quantity: qty, UnitPrice: money.New(price, money.ARS)}
+}
+
+func percent(id int64, pct int64, priority int) entity.Promotion {
+	return entity.Promotion{ID: id, Name: "pct", Type: entity.PromotionTypePercentage, PercentOff: pct, Priority: priority, Currency: money.ARS, Active: true}
+}
+
+func fixed(id int64, amount int64, priority int) entity.Promotion {
+	return entity.Promotion{ID: id, Name: "fixed", Type: entity.PromotionTypeFixed, AmountOff: ars(amount), Priority: priority, Currency: money.ARS, Active: true}
+}
+
+func buyGet(id int64, buy, get int64) entity.Promotion {
+	return entity.Promotion{ID: id, Name: "bxgy", Type: entity.PromotionTypeBuyXGetY, BuyQuantity: buy, GetQuantity: get, Currency: money.ARS, Active: true}
+}
+
+func with(p entity.Promotion, f func(*entity.Promotion)) entity.Promotion {
+	f(&p)
+	return p
+}
+
+func TestEvaluate(t *testing.T) {
+	// 2 x 1000 + 1 x 500
+	basic := []entity.CartItem{item(1, "Remeras", 2, 1000), item(2, "Buzos", 1, 500)}
+
+	tests := []struct {
+		name         string
+		items        []entity.CartItem
+		userID       int64
+		codes        []string
+		promos       []entity.Promotion
+		usage        map[int64]entity.PromotionUsage
+		wantLines    []int64
+		wantApplied  []int64
+		wantRejected []string
+	}{
+		{
+			name:        "no promotions",
+			items:       basic,
+			wantLines:   []int64{0, 0},
+			wantApplied: []int64{},
+		},
+		{
+			name:        "percentage on every line",
+			items:       basic,
+			promos:      []entity.Promotion{percent(1, 10, 0)},
+			wantLines:   []int64{200, 50},
+			wantApplied: []int64{1},
+		},
+		{
+			name:        "percentage rounds half up per line",
+			items:       []entity.CartItem{item(1, "Remeras", 1, 125), item(2, "Remeras", 1, 135)},
+			promos:      []entity.Promotion{percent(1, 10, 0)},
+			wantLines:   []int64{13, 14},
+			wantApplied: []int64{1},
+		},
+		{
+			name:        "fixed split by line total",
+			items:       basic,
+			promos:      []entity.Promotion{fixed(1, 300, 0)},
+			wantLines:   []int64{240, 60},
+			wantApplied: []int64{1},
+		},
+		{
+			name:        "fixed capped at the cart",
+			items:       basic,
+			promos:      []entity.Promotion{fixed(1, 5000, 0)},
+			wantLines:   []int64{2000, 500},
+			wantApplied: []int64{1},
+		},
+		{
+			name:        "percentage before fixed by priority",
+			items:       basic,
+			promos:      []entity.Promotion{fixed(1, 300, 5), percent(2, 10, 10)},
+			wantLines:   []int64{200 + 240, 50 + 60},
+			wantApplied: []int64{2, 1},
+		},
+		{
+			name:        "fixed before percentage by priority",
+			items:       basic,
+			promos:      []entity.Promotion{fixed(1, 300, 10), percent(2, 10, 5)},
+			wantLines:   []int64{240 + 176, 60 + 44},
+			wantApplied: []int64{1, 2},
+		},
+		{
+			name:        "same priority goes by id",
+			items:       basic,
+			promos:      []entity.Promotion{percent(2, 10, 0), fixed(1, 300, 0)},
+			wantLines:   []int64{240 + 176, 60 + 44},
+			wantApplied: []int64{1, 2},
+		},
+		{
+			name:        "buy 2 get 1 frees the cheapest unit of each group",
+			items:       []entity.CartItem{item(1, "Remeras", 2, 1000), item(2, "Remeras", 2, 500)},
+			promos:      []entity.Promotion{buyGet(1, 2, 1)},
+			wantLines:   []int64{0, 500},
+			wantApplied: []int64{1},
+		},
+		{
+			name:        "buy 2 get 1 with two full groups",
+			items:       []entity.CartItem{item(1, "Remeras", 6, 300)},
+			promos:      []entity.Promotion{buyGet(1, 2, 1)},
+			wantLines:   []int64{600},
+			wantApplied: []int64{1},
+		},
+		{
+			name:         "buy 2 get 1 without a full group",
+			items:        []entity.CartItem{item(1, "Remeras", 2, 300)},
+			promos:       []entity.Promotion{buyGet(1, 2, 1)},
+			wantLines:    []int64{0},
+			wantApplied:  []int64{},
+			wantRejected: []string{"no eligible items in cart"},
+		},
+		{
+			name:        "category limits the lines",
+			items:       basic,
+			promos:      []entity.Promotion{with(percent(1, 10, 0), func(p *entity.Promotion) { p.Category = "buzos" })},
+			wantLines:   []int64{0, 50},
+			wantApplied: []int64{1},
+		},
+		{
+			name:         "category without items",
+			items:        basic,
+			promos:       []entity.Promotion{with(percent(1, 10, 0), func(p *entity.Promotion) { p.Category = "Camperas" })},
+			wantLines:    []int64{0, 0},
+			wantApplied:  []int64{},
+			wantRejected: []string{`no eligible items in category "Camperas"`},
+		},
+		{
+			name:  "exclusive first blocks the rest",
+			items: basic,
+			promos: []entity.Promotion{
+				with(percent(1, 10, 10), func(p *entity.Promotion) { p.Name, p.Exclusive = "VIP", true }),
+				fixed(2, 300, 0),
+			},
+			wantLines:    []int64{200, 50},
+			wantApplied:  []int64{1},
+			wantRejected: []string{`not combinable with exclusive promotion "VIP"`},
+		},
+		{
+			name:  "exclusive after another is rejected",
+			items: basic,
+			promos: []entity.Promotion{
+				with(percent(1, 10, 10), func(p *entity.Promotion) { p.Name = "Otoño" }),
+				with(fixed(2, 300, 0), func(p *entity.Promotion) { p.Exclusive = true }),
+			},
+			wantLines:    []int64{200, 50},
+			wantApplied:  []int64{1},
+			wantRejected: []string{`exclusive promotion, not combinable with "Otoño"`},
+		},
+		{
+			name:         "total usage limit",
+			items:        basic,
+			promos:       []entity.Promotion{with(percent(1, 10, 0), func(p *entity.Promotion) { p.UsageLimit = ptr(int64(5)) })},
+			usage:        map[int64]entity.PromotionUsage{1: {Total: 5}},
+			wantLines:    []int64{0, 0},
+			wantApplied:  []int64{},
+			wantRejected: []string{"usage limit reached"},
+		},
+		{
+			name:        "usage below the limit",
+			items:       basic,
+			promos:      []entity.Promotion{with(percent(1, 10, 0), func(p *entity.Promotion) { p.UsageLimit = ptr(int64(5)) })},
+			usage:       map[int64]entity.PromotionUsage{1: {Total: 4}},
+			wantLines:   []int64{200, 50},
+			wantApplied: []int64{1},
+		},
+		{
+			name:         "per customer limit",
+			items:        basic,
+			userID:       7,
+			promos:       []entity.Promotion{with(percent(1, 10, 0), func(p *entity.Promotion) { p.PerCustomerLimit = ptr(int64(1)) })},
+			usage:        map[int64]entity.PromotionUsage{1: {Total: 3, Customer: 1}},
+			wantLines:    []int64{0, 0},
+			wantApplied:  []int64{},
+			wantRejected: []string{"usage limit per customer reached"},
+		},
+		{
+			name:        "per customer limit does not apply to guests",
+			items:       basic,
+			promos:      []entity.Promotion{with(percent(1, 10, 0), func(p *entity.Promotion) { p.PerCustomerLimit = ptr(int64(1)) })},
+			usage:       map[int64]entity.PromotionUsage{1: {Total: 3, Customer: 1}},
+			wantLines:   []int64{200, 50},
+			wantApplied: []int64{1},
+		},
+		{
+			name:         "minimum subtotal not reached",
+			items:        basic,
+			promos:       []entity.Promotion{with(percent(1, 10, 0), func(p *entity.Promotion) { p.MinSubtotal = ars(3000) })},
+			wantLines:    []int64{0, 0},
+			wantApplied:  []int64{},
+			wantRejected: []string{"minimum subtotal of ARS 30.00 not reached"},
+		},
+		{
+			name:  "inactive, not started and expired",
+			items: basic,
+			promos: []entity.Promotion{
+				with(percent(1, 10, 0), func(p *entity.Promotion) { p.Active = false }),
+				with(percent(2, 10, 0), func(p *entity.Promotion) { p.StartsAt = ptr(now.Add(time.Hour)) }),
+				with(percent(3, 10, 0), func(p *entity.Promotion) { p.EndsAt = ptr(now) }),
+			},
+			wantLines:   []int64{0, 0},
+			wantApplied: []int64{},
+			wantRejected: []string{
+				"promotion is not active",
+				"promotion starts at 2025-03-10T13:00:00Z",
+				"promotion has expired",
+			},
+		},
+		{
+			name:         "amounts in another currency",
+			items:        basic,
+			promos:       []entity.Promotion{with(fixed(1, 300, 0), func(p *entity.Promotion) { p.Currency = money.USD })},
+			wantLines:    []int64{0, 0},
+			wantApplied:  []int64{},
+			wantRejected: []string{"promotion is only valid for USD carts"},
+		},
+		{
+			name:  "coupons apply only when entered",
+			items: basic,
+			codes: []string{"OTOÑO10", "NOEXISTE"},
+			promos: []entity.Promotion{
+				with(percent(1, 10, 0), func(p *entity.Promotion) { p.Code = ptr("OTOÑO10") }),
+				with(fixed(2, 300, 0), func(p *entity.Promotion) { p.Code = ptr("OTRO") }),
+			},
+			wantLines:    []int64{200, 50},
+			wantApplied:  []int64{1},
+			wantRejected: []string{"unknown coupon code"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cart := entity.Cart{UserID: tt.userID, Currency: money.ARS, Items: tt.items, Codes: tt.codes}
+			res, err := Evaluate(cart, tt.promos, tt.usage, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var subtotal, discount int64
+			lines := make([]int64, len(res.LineDiscounts))
+			for i, d := range res.LineDiscounts {
+				lines[i] = d.Minor()
+				discount += d.Minor()
+				subtotal += tt.items[i].UnitPrice.Mul(tt.items[i].Quantity).Minor()
+			}
+			if !slices.Equal(lines, tt.wantLines) {
+				t.Errorf("line discounts = %v, want %v", lines, tt.wantLines)
+			}
+			if res.Subtotal.Minor() != subtotal || res.Discount.Minor() != discount || res.Total.Minor() != subtotal-discount {
+				t.Errorf("subtotal/discount/total = %s/%s/%s, want %d/%d/%d",
+					res.Subtotal, res.Discount, res.Total, subtotal, discount, subtotal-discount)
+			}
+
+			applied := []int64{}
+			for _, a := range res.Applied {
+				applied = append(applied, a.PromotionID)
+				var total int64
+				for _, l := range a.LineAmounts {
+					total += l.Minor()
+				}
+				if total != a.Amount.Minor() {
+					t.Errorf("promotion %d: line amounts add up to %d, amount is %s", a.PromotionID, total, a.Amount)
+				}
+			}
+			if !slices.Equal(applied, tt.wantApplied) {
+				t.Errorf("applied = %v, want %v", applied, tt.wantApplied)
+			}
+
+			var rejected []string
+			for _, r := range res.Rejected {
+				rejected = append(rejected, r.Reason)
+			}
+			if !slices.Equal(rejected, tt.wantRejected) {
+				t.Errorf("rejected = %q, want %q", rejected, tt.wantRejected)
+			}
+		})
+	}
+}
+
+func TestEvaluateCurrencyMismatch(t *testing.T) {
+	cart := entity.Cart{Currency: money.ARS, Items: []entity.CartItem{
+		{ProductID: 1, Quantity: 1, UnitPrice: money.New(100, money.USD)},
+	}}
+	if _, err := Evaluate(cart, nil, nil, now); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("Evaluate with a USD line in an ARS cart error = %v, want %v", err, money.ErrCurrencyMismatch)
+	}
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type AddressRepository interface {
+	// Create guarda la dirección; la primera del usuario queda por defecto para envío y facturación.
+	// Marcarla por defecto desmarca a las demás del usuario.
+	Create(ctx context.Context, address *entity.Address) error
+	Update(ctx context.Context, address *entity.Address) error
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (entity.Address, error)
+	ListByUser(ctx context.Context, userID int64) ([]entity.Address, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *entity.AuditEntry) error
+	List(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error)
+}
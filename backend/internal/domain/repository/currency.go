@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"time"
+)
+
+type ExchangeRateRepository interface {
+	Create(ctx context.Context, table *entity.ExchangeRateTable) error
+	// Current retorna la tabla más reciente de la moneda base vigente en el instante dado
+	Current(ctx context.Context, base money.Currency, at time.Time) (entity.ExchangeRateTable, error)
+	List(ctx context.Context, limit int) ([]entity.ExchangeRateTable, error)
+}
+
+type ProductPriceRepository interface {
+	Upsert(ctx context.Context, price *entity.ProductPrice) error
+	Delete(ctx context.Context, productID int64, currency money.Currency) error
+	FindByProductID(ctx context.Context, productID int64) ([]entity.ProductPrice, error)
+	// FindByProducts retorna los precios en la moneda indicada indexados por producto
+	FindByProducts(ctx context.Context, productIDs []int64, currency money.Currency) (map[int64]entity.ProductPrice, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type FeedRepository interface {
+	ListCategoryMappings(ctx context.Context) ([]entity.FeedCategoryMapping, error)
+	GetCategoryMapping(ctx context.Context, category string) (entity.FeedCategoryMapping, error)
+	// SaveCategoryMapping crea o reemplaza el mapeo de la categoría
+	SaveCategoryMapping(ctx context.Context, m *entity.FeedCategoryMapping) error
+	DeleteCategoryMapping(ctx context.Context, category string) error
+
+	// Fingerprints retorna el hash de cada producto del feed del canal
+	Fingerprints(ctx context.Context, channel entity.FeedChannel) (map[int64]string, error)
+	// SaveItems crea o reemplaza los productos del feed
+	SaveItems(ctx context.Context, items []entity.FeedItem) error
+	DeleteItems(ctx context.Context, channel entity.FeedChannel, productIDs []int64) error
+	// MarkChecked registra la regeneración del feed; con changed también la fecha de
+	// modificación del contenido
+	MarkChecked(ctx context.Context, channel entity.FeedChannel, changed bool) (entity.FeedState, error)
+	GetState(ctx context.Context, channel entity.FeedChannel) (entity.FeedState, error)
+	// StreamItems recorre el contenido de los productos del feed en orden de ID sin
+	// cargarlos juntos en memoria
+	StreamItems(ctx context.Context, channel entity.FeedChannel, fn func(content string) error) error
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+// InventoryRepository guarda el stock por producto y ubicación. Cada cambio agrega un
+// movimiento al libro de stock y products.stock se mantiene en la misma transacción
+// como el total de las ubicaciones activas.
+type InventoryRepository interface {
+	// CreateLocation falla con ErrConflict si el código ya existe. Si es la ubicación
+	// por defecto, deja de serlo la anterior.
+	CreateLocation(ctx context.Context, l *entity.Location) error
+	UpdateLocation(ctx context.Context, l *entity.Location) error
+	GetLocation(ctx context.Context, id int64) (entity.Location, error)
+	ListLocations(ctx context.Context) ([]entity.Location, error)
+
+	// Levels retorna el stock del producto en cada ubicación, por prioridad
+	Levels(ctx context.Context, productID int64) ([]entity.InventoryLevel, error)
+	// Adjust suma delta al stock del producto en la ubicación; un ajuste que deje
+	// el stock negativo falla con ErrInsufficientStock sin modificar nada
+	Adjust(ctx context.Context, productID, locationID, delta int64, ref entity.StockMovementRef) error
+	// Transfer mueve unidades entre ubicaciones en una sola transacción; los dos
+	// movimientos referencian la transferencia
+	Transfer(ctx context.Context, t *entity.StockTransfer) error
+	ListTransfers(ctx context.Context, filter entity.StockTransferFilter) ([]entity.StockTransfer, error)
+
+	// Allocate descuenta qty unidades de las ubicaciones elegidas con entity.PickLocations
+	Allocate(ctx context.Context, productID, qty int64, ref entity.StockMovementRef) ([]entity.StockAllocation, error)
+	// Release devuelve unidades asignadas a sus ubicaciones
+	Release(ctx context.Context, productID int64, allocations []entity.StockAllocation, ref entity.StockMovementRef) error
+
+	// Movements lista el libro de stock, del más reciente al más antiguo
+	Movements(ctx context.Context, filter entity.StockMovementFilter) ([]entity.StockMovement, error)
+	// Discrepancies compara el stock guardado con el libro y products.stock con la
+	// suma de las ubicaciones activas
+	Discrepancies(ctx context.Context) ([]entity.StockDiscrepancy, error)
+	// RebuildFromLedger reescribe el stock de los productos con el saldo del libro
+	RebuildFromLedger(ctx context.Context, productIDs []int64) error
+}
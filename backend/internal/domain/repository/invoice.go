@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type InvoiceRepository interface {
+	// Issue numera la factura con el siguiente número de su punto de venta y letra y la guarda.
+	// Retorna ErrConflict si la orden ya tiene factura.
+	Issue(ctx context.Context, invoice *entity.Invoice) error
+	GetByID(ctx context.Context, id int64) (entity.Invoice, error)
+	GetByOrderID(ctx context.Context, orderID int64) (entity.Invoice, error)
+	List(ctx context.Context, filter entity.InvoiceFilter) ([]entity.Invoice, error)
+	// UpdateSubmission guarda el resultado de enviar la factura al organismo fiscal.
+	// Retorna ErrConflict si otro envío de la misma factura se guardó antes.
+	UpdateSubmission(ctx context.Context, invoice *entity.Invoice) error
+	// PendingOrders retorna las órdenes pagadas que todavía no tienen factura
+	PendingOrders(ctx context.Context, limit int) ([]int64, error)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"time"
+)
+
+// MarketplaceRepository guarda las publicaciones vinculadas y las ventas importadas de
+// los marketplaces
+type MarketplaceRepository interface {
+	// CreateListing falla con ErrConflict si el producto ya tiene publicación en el
+	// marketplace o la publicación ya está vinculada a otro producto
+	CreateListing(ctx context.Context, l *entity.MarketplaceListing) error
+	GetListing(ctx context.Context, id int64) (entity.MarketplaceListing, error)
+	ListListings(ctx context.Context, filter entity.MarketplaceListingFilter) ([]entity.MarketplaceListing, error)
+	UpdateListingStatus(ctx context.Context, id int64, status entity.MarketplaceListingStatus) error
+	DeleteListing(ctx context.Context, id int64) error
+
+	// SyncItems retorna las publicaciones activas del marketplace con sus productos
+	SyncItems(ctx context.Context, marketplace string) ([]entity.MarketplaceSyncItem, error)
+	// MarkSynced guarda los valores que aceptó el marketplace y limpia el error
+	MarkSynced(ctx context.Context, listingID, stock int64, price money.Money) error
+	MarkSyncFailed(ctx context.Context, listingID int64, message string) error
+
+	// OrdersCursor es la fecha desde la que se buscan ventas nuevas: la de la última
+	// venta importada o, si todavía no hubo, la de la primera publicación vinculada.
+	// Es nil si el marketplace no tiene publicaciones.
+	OrdersCursor(ctx context.Context, marketplace string) (*time.Time, error)
+	// ImportOrder guarda la venta, resuelve los productos por la publicación, descuenta
+	// su stock y avanza el cursor en la misma transacción. Falla con ErrConflict si ya
+	// estaba importada.
+	ImportOrder(ctx context.Context, o *entity.MarketplaceOrder) error
+	GetOrder(ctx context.Context, id int64) (entity.MarketplaceOrder, error)
+	ListOrders(ctx context.Context, filter entity.MarketplaceOrderFilter) ([]entity.MarketplaceOrder, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type OrderRepository interface {
+	// Create descuenta el stock de cada línea de las ubicaciones que la preparan en la
+	// misma transacción; sin stock falla con ErrInsufficientStock sin crear nada
+	Create(ctx context.Context, order *entity.Order) error
+	GetByID(ctx context.Context, id int64) (entity.Order, error)
+	List(ctx context.Context, filter entity.OrderFilter) ([]entity.Order, error)
+	// UpdateStatus cambia el estado solo si la orden sigue en from; si no, retorna ErrInvalidTransition
+	UpdateStatus(ctx context.Context, id int64, from, to entity.OrderStatus) error
+}
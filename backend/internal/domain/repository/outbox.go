@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"time"
+)
+
+// OutboxRepository lee y cierra los eventos del outbox. Los eventos se escriben dentro de
+// las transacciones de los repositorios de los agregados, no desde acá.
+type OutboxRepository interface {
+	// Pending retorna hasta limit eventos sin publicar en orden de ID, salvo los de un
+	// agregado cuyo evento pendiente más viejo todavía espera su reintento
+	Pending(ctx context.Context, now time.Time, limit int) ([]entity.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id int64) error
+	// MarkFailed suma el intento y reprograma el evento para next
+	MarkFailed(ctx context.Context, id int64, reason string, next time.Time) error
+	// List lista los eventos, del más reciente al más antiguo
+	List(ctx context.Context, filter entity.OutboxFilter) ([]entity.OutboxEvent, error)
+	// DeletePublishedBefore borra los eventos publicados antes de la fecha y retorna cuántos
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
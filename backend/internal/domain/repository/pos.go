@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type PosRepository interface {
+	// OpenShift abre el turno; si el cajero ya tiene un turno abierto o la caja está en
+	// uso en esa ubicación retorna ErrConflict
+	OpenShift(ctx context.Context, shift *entity.RegisterShift) error
+	GetShift(ctx context.Context, id int64) (entity.RegisterShift, error)
+	// CurrentShift retorna el turno abierto del cajero o ErrNotFound
+	CurrentShift(ctx context.Context, cashierID int64) (entity.RegisterShift, error)
+	ListShifts(ctx context.Context, filter entity.RegisterShiftFilter) ([]entity.RegisterShift, error)
+	ShiftTotals(ctx context.Context, shiftID int64) (entity.ShiftTotals, error)
+	// CloseShift anula las ventas sin cobrar, congela el efectivo esperado y el contado y
+	// cierra el turno. Si ya estaba cerrado retorna ErrInvalidTransition.
+	CloseShift(ctx context.Context, shift *entity.RegisterShift) error
+
+	// CreateSale abre una venta en un turno abierto
+	CreateSale(ctx context.Context, sale *entity.PosSale) error
+	// GetSale retorna la venta con sus líneas y cobros
+	GetSale(ctx context.Context, id int64) (entity.PosSale, error)
+	// AddLine suma line.Quantity (negativo para quitar) a las unidades del producto en una
+	// venta abierta; la línea desaparece al llegar a cero y no puede quedar negativa
+	AddLine(ctx context.Context, saleID int64, line entity.PosSaleLine) error
+	// CompleteSale registra los cobros y la orden de una venta abierta y pasa la orden de
+	// pendiente a pagada en la misma transacción
+	CompleteSale(ctx context.Context, sale *entity.PosSale) error
+	// VoidSale anula una venta abierta; si no lo está retorna ErrInvalidTransition
+	VoidSale(ctx context.Context, id int64) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"time"
+)
+
+type PriceChangeRepository interface {
+	Create(ctx context.Context, change *entity.PriceChange) error
+	GetByID(ctx context.Context, id int64) (entity.PriceChange, error)
+	FindByProductID(ctx context.Context, productID int64) ([]entity.PriceChange, error)
+	Delete(ctx context.Context, id int64) error
+	// ApplyDue aplica al producto los cambios vencidos, los marca como aplicados y los
+	// retorna con el producto antes y después de cada uno. Los que no se pueden aplicar
+	// porque el producto ya no está en la moneda del cambio quedan fallidos y se retornan
+	// aparte.
+	ApplyDue(ctx context.Context, now time.Time) ([]entity.AppliedPriceChange, []entity.PriceChange, error)
+}
@@ -3,15 +3,34 @@ package repository
 import (
 	"context"
 	"core/internal/domain/entity"
+	"time"
 )
 
 type ProductRepository interface {
 	GetByID(ctx context.Context, id int64) (entity.Product, error)
+	// GetByBarCode busca por el código normalizado a EAN-13
+	GetByBarCode(ctx context.Context, code string) (entity.Product, error)
+	// MaxBarCode retorna el mayor código de 13 dígitos con el prefijo, o "" si no hay
+	MaxBarCode(ctx context.Context, prefix string) (string, error)
 	List(ctx context.Context, filter entity.ProductFilter) ([]entity.Product, error)
-	UpdateStock(ctx context.Context, id int64, delta int64) error
+	// Stream recorre en orden de ID todos los productos del filtro, con sus imágenes, sin
+	// cargarlos juntos en memoria. Ignora Limit y Offset; si fn falla se corta y retorna
+	// ese error.
+	Stream(ctx context.Context, filter entity.ProductFilter, fn func(entity.CatalogEntry) error) error
+	// UpdateStock suma delta al stock y lo registra en el libro con ref. Retorna el
+	// disponible antes y después del ajuste; con delta 0 no hace nada y lo retorna vacío.
+	UpdateStock(ctx context.Context, id int64, delta int64, ref entity.StockMovementRef) (entity.StockUpdate, error)
 
 	Create(ctx context.Context, p *entity.Product) error
 	Read(ctx context.Context) ([]entity.Product, error)
 	Update(ctx context.Context, p *entity.Product) error
 	Delete(ctx context.Context, id int64) error
+
+	// Flujo de publicación
+	UpdateStatus(ctx context.Context, p *entity.Product) error
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
+	ArchiveExpired(ctx context.Context, now time.Time) (int64, error)
+
+	// Precios de referencia y ofertas
+	UpdatePricing(ctx context.Context, p *entity.Product) error
 }
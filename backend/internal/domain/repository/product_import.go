@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"time"
+)
+
+type ProductImportRepository interface {
+	// Create encola la importación junto con el archivo subido
+	Create(ctx context.Context, imp *entity.ProductImport, data []byte) error
+	// GetByID retorna la importación con los errores por fila registrados
+	GetByID(ctx context.Context, id int64) (entity.ProductImport, error)
+	List(ctx context.Context, filter entity.ProductImportFilter) ([]entity.ProductImport, error)
+	// Claim toma la importación en cola más antigua, o una que quedó corriendo sin avance
+	// desde antes de staleBefore, y la pasa a running descartando el avance anterior.
+	// Retorna ErrNotFound si no hay ninguna.
+	Claim(ctx context.Context, staleBefore time.Time) (entity.ProductImport, []byte, error)
+	// SaveProgress guarda el avance y agrega los errores de las filas procesadas desde
+	// el último guardado
+	SaveProgress(ctx context.Context, id int64, progress entity.ProductImportProgress, rowErrors []entity.ImportRowError) error
+	// Finish cierra la importación con el estado final y libera el archivo
+	Finish(ctx context.Context, id int64, status entity.ProductImportStatus, reason string) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"time"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, p *entity.Promotion) error
+	GetByID(ctx context.Context, id int64) (entity.Promotion, error)
+	Update(ctx context.Context, p *entity.Promotion) error
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, filter entity.PromotionFilter) ([]entity.Promotion, error)
+	// FindForCart retorna las promociones automáticas vigentes en now y los cupones con los
+	// códigos dados, estos últimos en cualquier estado para poder explicar el rechazo
+	FindForCart(ctx context.Context, codes []string, now time.Time) ([]entity.Promotion, error)
+	// Usage cuenta los usos en órdenes no canceladas, en total y del usuario indicado
+	Usage(ctx context.Context, promotionIDs []int64, userID int64) (map[int64]entity.PromotionUsage, error)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type SupplierRepository interface {
+	Create(ctx context.Context, s *entity.Supplier) error
+	Update(ctx context.Context, s *entity.Supplier) error
+	GetByID(ctx context.Context, id int64) (entity.Supplier, error)
+	List(ctx context.Context) ([]entity.Supplier, error)
+}
+
+type PurchaseOrderRepository interface {
+	// Create guarda la orden con sus líneas; sin LocationID la mercadería va a la
+	// ubicación por defecto
+	Create(ctx context.Context, po *entity.PurchaseOrder) error
+	// GetByID retorna la orden con sus líneas y recepciones
+	GetByID(ctx context.Context, id int64) (entity.PurchaseOrder, error)
+	List(ctx context.Context, filter entity.PurchaseOrderFilter) ([]entity.PurchaseOrder, error)
+	// UpdateStatus cambia el estado solo si la orden sigue en from; si no, retorna ErrInvalidTransition
+	UpdateStatus(ctx context.Context, id int64, from, to entity.PurchaseOrderStatus) error
+	// Receive registra la entrega en una transacción: suma lo recibido a las líneas,
+	// ingresa el stock por el libro, actualiza el costo promedio y el estado de la orden.
+	// Recibir más de lo pendiente falla con ErrConflict.
+	Receive(ctx context.Context, receipt *entity.PurchaseReceipt) error
+
+	// Cost retorna ErrNotFound si el producto nunca se compró
+	Cost(ctx context.Context, productID int64) (entity.ProductCost, error)
+	// Margins lista los productos con su precio y costo promedio
+	Margins(ctx context.Context, filter entity.ProductFilter) ([]entity.ProductMargin, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type ReturnRepository interface {
+	// Create guarda la solicitud con sus líneas y el alta en el historial. Falla con ErrConflict
+	// si alguna línea supera las unidades de la orden que no están en otra solicitud abierta.
+	Create(ctx context.Context, r *entity.ReturnRequest) error
+	GetByID(ctx context.Context, id int64) (entity.ReturnRequest, error)
+	List(ctx context.Context, filter entity.ReturnFilter) ([]entity.ReturnRequest, error)
+	// Transition cambia el estado solo si la solicitud sigue en change.From y agrega la entrada
+	// al historial; si no, retorna ErrInvalidTransition
+	Transition(ctx context.Context, change *entity.ReturnStatusChange) error
+	// SetRefund registra el reembolso acreditado por la pasarela
+	SetRefund(ctx context.Context, id int64, refund entity.Refund) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"time"
+)
+
+type ShipmentRepository interface {
+	// Create guarda el envío con su etiqueta; retorna ErrConflict si la orden ya fue despachada
+	Create(ctx context.Context, shipment *entity.Shipment) error
+	GetByID(ctx context.Context, id int64) (entity.Shipment, error)
+	GetByOrderID(ctx context.Context, orderID int64) (entity.Shipment, error)
+	GetByTrackingNumber(ctx context.Context, carrier, trackingNumber string) (entity.Shipment, error)
+	List(ctx context.Context, filter entity.ShipmentFilter) ([]entity.Shipment, error)
+	Label(ctx context.Context, id int64) ([]byte, error)
+
+	// AddEvents guarda los eventos que no estaban registrados y recalcula el estado del envío
+	// con el más reciente. Retorna cuántos eventos eran nuevos.
+	AddEvents(ctx context.Context, shipmentID int64, events []entity.TrackingEvent) (int, error)
+	// MarkChecked registra la última consulta al carrier
+	MarkChecked(ctx context.Context, shipmentID int64, at time.Time) error
+	// Open retorna los envíos no terminados que no se consultaron desde checkedBefore,
+	// empezando por los consultados hace más tiempo
+	Open(ctx context.Context, checkedBefore time.Time, limit int) ([]entity.Shipment, error)
+}
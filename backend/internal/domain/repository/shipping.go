@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type ShippingRepository interface {
+	// Zonas; una provincia asignada a otra zona retorna ErrConflict
+	CreateZone(ctx context.Context, zone *entity.ShippingZone) error
+	UpdateZone(ctx context.Context, zone *entity.ShippingZone) error
+	DeleteZone(ctx context.Context, id int64) error
+	GetZone(ctx context.Context, id int64) (entity.ShippingZone, error)
+	ListZones(ctx context.Context) ([]entity.ShippingZone, error)
+	ZoneForProvince(ctx context.Context, province string) (entity.ShippingZone, error)
+
+	// Métodos con sus tarifas; Update reemplaza la tabla de tarifas completa
+	CreateMethod(ctx context.Context, method *entity.ShippingMethod) error
+	UpdateMethod(ctx context.Context, method *entity.ShippingMethod) error
+	DeleteMethod(ctx context.Context, id int64) error
+	GetMethod(ctx context.Context, id int64) (entity.ShippingMethod, error)
+	ListMethods(ctx context.Context, activeOnly bool) ([]entity.ShippingMethod, error)
+}
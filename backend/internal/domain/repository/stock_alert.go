@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+// StockAlertRepository guarda los umbrales de reposición y qué alertas ya se enviaron.
+// Un producto se avisa una vez por canal mientras siga por debajo del umbral.
+type StockAlertRepository interface {
+	// GetReorderPoint retorna ErrNotFound si el producto no tiene umbral
+	GetReorderPoint(ctx context.Context, productID int64) (entity.ReorderPoint, error)
+	// SetReorderPoint crea o reemplaza el umbral; con Threshold 0 lo elimina
+	SetReorderPoint(ctx context.Context, rp *entity.ReorderPoint) error
+
+	// BelowThreshold lista los productos con el disponible por debajo del umbral
+	BelowThreshold(ctx context.Context) ([]entity.LowStockItem, error)
+	// Pending lista los productos por debajo del umbral que el canal todavía no avisó
+	Pending(ctx context.Context, channel string) ([]entity.LowStockItem, error)
+	MarkNotified(ctx context.Context, channel string, items []entity.LowStockItem) error
+	// ResolveRecovered olvida las alertas de los productos que volvieron a estar sobre
+	// el umbral, así una nueva baja se vuelve a avisar
+	ResolveRecovered(ctx context.Context) (int64, error)
+}
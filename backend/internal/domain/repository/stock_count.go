@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type StockCountRepository interface {
+	// Create abre el recuento y toma el stock esperado de cada producto del alcance.
+	// Falla con ErrConflict si la ubicación ya tiene un recuento abierto.
+	Create(ctx context.Context, c *entity.StockCount) error
+	// GetByID retorna el recuento con sus líneas
+	GetByID(ctx context.Context, id int64) (entity.StockCount, error)
+	List(ctx context.Context, filter entity.StockCountFilter) ([]entity.StockCount, error)
+	// AddScans suma los escaneos a las líneas del recuento abierto y, al primer escaneo de
+	// una línea, guarda como esperado el nivel de la ubicación. Los códigos que no están
+	// en el alcance se informan sin frenar el resto; un total negativo falla con
+	// ErrInvalidInput sin sumar nada.
+	AddScans(ctx context.Context, id int64, scans []entity.StockCountScan) (entity.StockCountScanResult, error)
+	// Approve ajusta en el libro lo contado menos el nivel de la ubicación al primer
+	// escaneo de cada línea; las líneas sin escanear llevan el nivel a cero. Cierra el
+	// recuento en la misma transacción.
+	Approve(ctx context.Context, id, actorID int64) error
+	// Cancel cierra un recuento abierto sin ajustar stock
+	Cancel(ctx context.Context, id int64) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type TaxRateRepository interface {
+	List(ctx context.Context) ([]entity.TaxRate, error)
+	GetByID(ctx context.Context, id int64) (entity.TaxRate, error)
+	// Upsert crea o reemplaza la alícuota de la categoría
+	Upsert(ctx context.Context, rate *entity.TaxRate) error
+	Delete(ctx context.Context, id int64) error
+}
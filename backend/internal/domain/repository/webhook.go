@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"time"
+)
+
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error
+	GetEndpoint(ctx context.Context, id int64) (entity.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context) ([]entity.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error
+	// DeleteEndpoint borra el destino junto con su registro de envíos
+	DeleteEndpoint(ctx context.Context, id int64) error
+
+	// Enqueue guarda el evento y un envío pendiente para cada destino activo suscripto.
+	// Sin destinos no guarda nada y retorna 0. Si msg.SourceID ya se encoló retorna 0
+	// sin error.
+	Enqueue(ctx context.Context, msg *entity.WebhookMessage) (int, error)
+	// Due retorna hasta limit envíos pendientes cuyo intento ya venció, de destinos
+	// activos, del más viejo al más nuevo. No incluye los que tienen pendiente en el
+	// mismo destino un evento anterior del mismo agregado.
+	Due(ctx context.Context, now time.Time, limit int) ([]entity.WebhookJob, error)
+	// MarkDelivered cierra el envío con la respuesta del destino
+	MarkDelivered(ctx context.Context, id int64, responseStatus int) error
+	// MarkAttemptFailed suma el intento; con next nil el envío queda fallido
+	MarkAttemptFailed(ctx context.Context, id int64, responseStatus int, reason string, next *time.Time) error
+	GetDelivery(ctx context.Context, id int64) (entity.WebhookDelivery, error)
+	// ListDeliveries lista el registro de envíos, del más reciente al más antiguo
+	ListDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error)
+	// Redeliver crea un envío pendiente del mismo evento al mismo destino
+	Redeliver(ctx context.Context, id int64) (entity.WebhookDelivery, error)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+// AddressService administra la libreta de direcciones de cada usuario.
+// Una dirección de otro usuario se trata como inexistente.
+type AddressService interface {
+	Create(ctx context.Context, a *entity.Address) (*entity.Address, error)
+	Update(ctx context.Context, a *entity.Address) (*entity.Address, error)
+	Delete(ctx context.Context, userID, id int64) error
+	GetForUser(ctx context.Context, userID, id int64) (*entity.Address, error)
+	ListForUser(ctx context.Context, userID int64) ([]entity.Address, error)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+	"strings"
+)
+
+type addressServiceImpl struct {
+	repo repository.AddressRepository
+}
+
+func NewAddressService(repo repository.AddressRepository) AddressService {
+	return &addressServiceImpl{repo: repo}
+}
+
+func (s *addressServiceImpl) Create(ctx context.Context, a *entity.Address) (*entity.Address, error) {
+	if err := normalizeAddress(a); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *addressServiceImpl) Update(ctx context.Context, a *entity.Address) (*entity.Address, error) {
+	current, err := s.GetForUser(ctx, a.UserID, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := normalizeAddress(a); err != nil {
+		return nil, err
+	}
+	// Los defaults se cambian marcando otra dirección, no desmarcando la actual
+	a.IsDefaultShipping = a.IsDefaultShipping || current.IsDefaultShipping
+	a.IsDefaultBilling = a.IsDefaultBilling || current.IsDefaultBilling
+	a.CreatedAt = current.CreatedAt
+	if err := s.repo.Update(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *addressServiceImpl) Delete(ctx context.Context, userID, id int64) error {
+	if _, err := s.GetForUser(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *addressServiceImpl) GetForUser(ctx context.Context, userID, id int64) (*entity.Address, error) {
+	a, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.UserID != userID {
+		return nil, errors.ErrNotFound
+	}
+	return &a, nil
+}
+
+func (s *addressServiceImpl) ListForUser(ctx context.Context, userID int64) ([]entity.Address, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// normalizeAddress limpia los campos y valida los obligatorios para poder entregar
+func normalizeAddress(a *entity.Address) error {
+	for _, f := range []*string{&a.Label, &a.RecipientName, &a.Phone, &a.Street, &a.Number, &a.Apartment, &a.City, &a.PostalCode} {
+		*f = strings.TrimSpace(*f)
+	}
+	a.Province = entity.NormalizeProvince(a.Province)
+	if a.RecipientName == "" || a.Street == "" || a.City == "" || a.Province == "" {
+		return errors.ErrInvalidInput
+	}
+	return nil
+}
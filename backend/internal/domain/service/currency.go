@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+)
+
+type CurrencyService interface {
+	// Localize expresa los precios de los productos en la moneda indicada.
+	// Usa el precio fijo de la lista si existe y si no convierte con la tabla vigente.
+	// Retorna la tasa vigente de la tabla para toda moneda distinta de la base, aunque
+	// los precios salgan de la lista; en la base, nil si no hizo falta convertir.
+	Localize(ctx context.Context, products []entity.Product, currency money.Currency) ([]LocalizedProduct, *entity.RateSnapshot, error)
+	// RateFromBase retorna la tasa vigente de la moneda base a la indicada; nil si es la base
+	RateFromBase(ctx context.Context, currency money.Currency) (*entity.RateSnapshot, error)
+
+	CreateRateTable(ctx context.Context, table *entity.ExchangeRateTable) (*entity.ExchangeRateTable, error)
+	CurrentRateTable(ctx context.Context) (*entity.ExchangeRateTable, error)
+	ListRateTables(ctx context.Context, limit int) ([]entity.ExchangeRateTable, error)
+
+	SetProductPrice(ctx context.Context, price *entity.ProductPrice) (*entity.ProductPrice, error)
+	DeleteProductPrice(ctx context.Context, productID int64, currency money.Currency) error
+	ListProductPrices(ctx context.Context, productID int64) ([]entity.ProductPrice, error)
+}
+
+// LocalizedProduct es un producto con sus precios expresados en otra moneda
+type LocalizedProduct struct {
+	Product     entity.Product // precios en la moneda pedida
+	PriceSource string
+}
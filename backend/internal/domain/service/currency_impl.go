@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+	"time"
+)
+
+type currencyServiceImpl struct {
+	productRepo repository.ProductRepository
+	rateRepo    repository.ExchangeRateRepository
+	priceRepo   repository.ProductPriceRepository
+}
+
+func NewCurrencyService(productRepo repository.ProductRepository, rateRepo repository.ExchangeRateRepository, priceRepo repository.ProductPriceRepository) CurrencyService {
+	return &currencyServiceImpl{productRepo: productRepo, rateRepo: rateRepo, priceRepo: priceRepo}
+}
+
+func (s *currencyServiceImpl) Localize(ctx context.Context, products []entity.Product, currency money.Currency) ([]LocalizedProduct, *entity.RateSnapshot, error) {
+	if !currency.IsValid() {
+		return nil, nil, errors.ErrInvalidInput
+	}
+
+	var ids []int64
+	for _, p := range products {
+		if p.UnitPrice.Currency() != currency {
+			ids = append(ids, p.ID)
+		}
+	}
+	prices, err := s.priceRepo.FindByProducts(ctx, ids, currency)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// La tabla se carga solo si algún producto no tiene precio fijo o la moneda no es la base
+	var table *entity.ExchangeRateTable
+	now := time.Now()
+	loadTable := func() error {
+		if table != nil {
+			return nil
+		}
+		current, err := s.rateRepo.Current(ctx, money.Base, now)
+		if err == errors.ErrNotFound {
+			return errors.ErrCurrencyNotPriced
+		}
+		if err != nil {
+			return err
+		}
+		table = &current
+		return nil
+	}
+	out := make([]LocalizedProduct, len(products))
+	for i, p := range products {
+		if p.UnitPrice.Currency() == currency {
+			out[i] = LocalizedProduct{Product: p, PriceSource: entity.PriceSourceBase}
+			continue
+		}
+
+		if listed, ok := prices[p.ID]; ok {
+			// Los precios de referencia y oferta siguen la proporción del precio fijo
+			rate, rateErr := money.RateOf(p.UnitPrice, listed.UnitPrice)
+			localized, err := convertProduct(p, listed.UnitPrice, rate, rateErr == nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			out[i] = LocalizedProduct{Product: localized, PriceSource: entity.PriceSourcePriceList}
+			continue
+		}
+
+		if err := loadTable(); err != nil {
+			return nil, nil, err
+		}
+		rate, ok := crossRate(table, p.UnitPrice.Currency(), currency)
+		if !ok {
+			return nil, nil, errors.ErrCurrencyNotPriced
+		}
+		unitPrice, err := money.Convert(p.UnitPrice, currency, rate, money.RoundHalfUp)
+		if err != nil {
+			return nil, nil, err
+		}
+		localized, err := convertProduct(p, unitPrice, rate, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		out[i] = LocalizedProduct{Product: localized, PriceSource: entity.PriceSourceRateTable}
+	}
+
+	// Un pedido en otra moneda registra siempre la tasa vigente, aunque todos sus
+	// precios salgan de la lista
+	if table == nil && currency == money.Base {
+		return out, nil, nil
+	}
+	if err := loadTable(); err != nil {
+		return nil, nil, err
+	}
+	rate, ok := table.RateTo(currency)
+	if !ok {
+		return nil, nil, errors.ErrCurrencyNotPriced
+	}
+	id := table.ID
+	return out, &entity.RateSnapshot{
+		RateTableID:  &id,
+		BaseCurrency: table.BaseCurrency,
+		Currency:     currency,
+		Rate:         rate,
+		CapturedAt:   now,
+	}, nil
+}
+
+func (s *currencyServiceImpl) RateFromBase(ctx context.Context, currency money.Currency) (*entity.RateSnapshot, error) {
+	if currency == money.Base {
+		return nil, nil
+	}
+	now := time.Now()
+	table, err := s.rateRepo.Current(ctx, money.Base, now)
+	if err == errors.ErrNotFound {
+		return nil, errors.ErrCurrencyNotPriced
+	}
+	if err != nil {
+		return nil, err
+	}
+	rate, ok := table.RateTo(currency)
+	if !ok {
+		return nil, errors.ErrCurrencyNotPriced
+	}
+	id := table.ID
+	return &entity.RateSnapshot{
+		RateTableID:  &id,
+		BaseCurrency: table.BaseCurrency,
+		Currency:     currency,
+		Rate:         rate,
+		CapturedAt:   now,
+	}, nil
+}
+
+// crossRate arma la tasa entre dos monedas pasando por la base de la tabla
+func crossRate(table *entity.ExchangeRateTable, from, to money.Currency) (money.Rate, bool) {
+	fromRate, ok := table.RateTo(from)
+	if !ok {
+		return money.Rate{}, false
+	}
+	toRate, ok := table.RateTo(to)
+	if !ok {
+		return money.Rate{}, false
+	}
+	return fromRate.Inverse().Mul(toRate), true
+}
+
+// convertProduct retorna una copia del producto con sus precios en la moneda de unitPrice.
+// Sin tasa (precio base en cero) se descartan los precios de referencia y oferta.
+func convertProduct(p entity.Product, unitPrice money.Money, rate money.Rate, hasRate bool) (entity.Product, error) {
+	to := unitPrice.Currency()
+	convert := func(m *money.Money) (*money.Money, error) {
+		if m == nil || !hasRate {
+			return nil, nil
+		}
+		c, err := money.Convert(*m, to, rate, money.RoundHalfUp)
+		if err != nil {
+			return nil, err
+		}
+		return &c, nil
+	}
+
+	var err error
+	p.UnitPrice = unitPrice
+	if p.CompareAtPrice, err = convert(p.CompareAtPrice); err != nil {
+		return entity.Product{}, err
+	}
+	if p.SalePrice, err = convert(p.SalePrice); err != nil {
+		return entity.Product{}, err
+	}
+	return p, nil
+}
+
+func (s *currencyServiceImpl) CreateRateTable(ctx context.Context, t *entity.ExchangeRateTable) (*entity.ExchangeRateTable, error) {
+	if t.BaseCurrency == "" {
+		t.BaseCurrency = money.Base
+	}
+	// Las conversiones se hacen siempre a través de la moneda base
+	if t.BaseCurrency != money.Base || len(t.Rates) == 0 {
+		return nil, errors.ErrInvalidInput
+	}
+	for cur, rate := range t.Rates {
+		if !cur.IsValid() || cur == t.BaseCurrency || rate.IsZero() {
+			return nil, errors.ErrInvalidInput
+		}
+	}
+	if t.EffectiveFrom.IsZero() {
+		t.EffectiveFrom = time.Now()
+	}
+	if err := s.rateRepo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (s *currencyServiceImpl) CurrentRateTable(ctx context.Context) (*entity.ExchangeRateTable, error) {
+	table, err := s.rateRepo.Current(ctx, money.Base, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+func (s *currencyServiceImpl) ListRateTables(ctx context.Context, limit int) ([]entity.ExchangeRateTable, error) {
+	return s.rateRepo.List(ctx, limit)
+}
+
+func (s *currencyServiceImpl) SetProductPrice(ctx context.Context, price *entity.ProductPrice) (*entity.ProductPrice, error) {
+	if !price.UnitPrice.Currency().IsValid() || !price.UnitPrice.IsPositive() {
+		return nil, errors.ErrInvalidInput
+	}
+	product, err := s.productRepo.GetByID(ctx, price.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	// En la moneda propia del producto el precio es unit_price
+	if product.UnitPrice.Currency() == price.UnitPrice.Currency() {
+		return nil, errors.ErrInvalidInput
+	}
+	if err := s.priceRepo.Upsert(ctx, price); err != nil {
+		return nil, err
+	}
+	price.UpdatedAt = time.Now()
+	return price, nil
+}
+
+func (s *currencyServiceImpl) DeleteProductPrice(ctx context.Context, productID int64, currency money.Currency) error {
+	return s.priceRepo.Delete(ctx, productID, currency)
+}
+
+func (s *currencyServiceImpl) ListProductPrices(ctx context.Context, productID int64) ([]entity.ProductPrice, error) {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.priceRepo.FindByProductID(ctx, productID)
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"io"
+)
+
+// FeedRenderer escribe los productos en el formato de cada canal
+type FeedRenderer interface {
+	// Item escribe un producto; el resultado no depende del resto del feed
+	Item(channel entity.FeedChannel, p *entity.FeedProduct) (string, error)
+	// Header y Footer encierran los productos del feed
+	Header(channel entity.FeedChannel) string
+	Footer(channel entity.FeedChannel) string
+}
+
+// FeedService genera los feeds de productos para Google Merchant Center y Meta. Cada
+// producto se guarda ya escrito y solo se vuelve a escribir cuando cambia.
+type FeedService interface {
+	// Refresh recorre los productos publicados con imagen, reescribe los que cambiaron
+	// desde la última pasada y quita los que ya no se publican
+	Refresh(ctx context.Context) ([]entity.FeedRefresh, error)
+	// WriteFeed escribe en w el feed completo del canal tal como quedó en la última pasada
+	WriteFeed(ctx context.Context, w io.Writer, channel entity.FeedChannel) error
+	State(ctx context.Context, channel entity.FeedChannel) (*entity.FeedState, error)
+	States(ctx context.Context) ([]entity.FeedState, error)
+
+	ListCategoryMappings(ctx context.Context) ([]entity.FeedCategoryMapping, error)
+	// SaveCategoryMapping crea o reemplaza el mapeo; se aplica en la próxima pasada
+	SaveCategoryMapping(ctx context.Context, m *entity.FeedCategoryMapping) (*entity.FeedCategoryMapping, error)
+	DeleteCategoryMapping(ctx context.Context, category string) error
+}
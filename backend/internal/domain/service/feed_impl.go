@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"core/internal/domain/barcode"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+type feedServiceImpl struct {
+	repo      repository.FeedRepository
+	products  repository.ProductRepository
+	renderer  FeedRenderer
+	storeURL  string
+	assetsURL string
+	brand     string
+
+	// mu evita que el scheduler y una regeneración manual pisen las huellas del otro
+	mu sync.Mutex
+}
+
+// NewFeedService arma los enlaces de los productos sobre storeURL (el frontend) y los de
+// las imágenes sobre assetsURL (la API, que sirve /static)
+func NewFeedService(repo repository.FeedRepository, products repository.ProductRepository, renderer FeedRenderer, storeURL, assetsURL, brand string) FeedService {
+	return &feedServiceImpl{
+		repo:      repo,
+		products:  products,
+		renderer:  renderer,
+		storeURL:  strings.TrimRight(storeURL, "/"),
+		assetsURL: strings.TrimRight(assetsURL, "/"),
+		brand:     brand,
+	}
+}
+
+// Límites de Google Merchant Center, los más estrictos de los dos canales
+const (
+	feedMaxTitle            = 150
+	feedMaxDescription      = 5000
+	feedMaxAdditionalImages = 10
+	// Cada cuántos productos cambiados se guarda, para no juntar el feed entero en memoria
+	feedSaveBatch = 200
+)
+
+func (s *feedServiceImpl) Refresh(ctx context.Context) ([]entity.FeedRefresh, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mappings, err := s.repo.ListCategoryMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byCategory := make(map[string]entity.FeedCategoryMapping, len(mappings))
+	for _, m := range mappings {
+		byCategory[m.Category] = m
+	}
+	prints := make(map[entity.FeedChannel]map[int64]string, len(entity.FeedChannels))
+	for _, ch := range entity.FeedChannels {
+		if prints[ch], err = s.repo.Fingerprints(ctx, ch); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make(map[entity.FeedChannel]*entity.FeedRefresh, len(entity.FeedChannels))
+	for _, ch := range entity.FeedChannels {
+		results[ch] = &entity.FeedRefresh{Channel: ch}
+	}
+	var pending []entity.FeedItem
+	seen := make(map[int64]bool)
+	now := time.Now()
+	err = s.products.Stream(ctx, entity.ProductFilter{Status: entity.ProductStatusPublished}, func(e entity.CatalogEntry) error {
+		// Los dos canales rechazan productos sin imagen
+		if !e.IsVisible(now) || len(e.Images) == 0 {
+			return nil
+		}
+		seen[e.ID] = true
+		fp := s.feedProduct(&e, byCategory[e.Category], now)
+		for _, ch := range entity.FeedChannels {
+			content, err := s.renderer.Item(ch, fp)
+			if err != nil {
+				return err
+			}
+			sum := sha256.Sum256([]byte(content))
+			fingerprint := hex.EncodeToString(sum[:])
+			if prints[ch][e.ID] == fingerprint {
+				continue
+			}
+			results[ch].Changed++
+			pending = append(pending, entity.FeedItem{Channel: ch, ProductID: e.ID, Fingerprint: fingerprint, Content: content})
+		}
+		if len(pending) >= feedSaveBatch {
+			if err := s.repo.SaveItems(ctx, pending); err != nil {
+				return err
+			}
+			pending = pending[:0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveItems(ctx, pending); err != nil {
+		return nil, err
+	}
+
+	out := make([]entity.FeedRefresh, 0, len(entity.FeedChannels))
+	for _, ch := range entity.FeedChannels {
+		res := results[ch]
+		var removed []int64
+		for id := range prints[ch] {
+			if !seen[id] {
+				removed = append(removed, id)
+			}
+		}
+		if err := s.repo.DeleteItems(ctx, ch, removed); err != nil {
+			return nil, err
+		}
+		res.Removed = len(removed)
+		st, err := s.repo.MarkChecked(ctx, ch, res.Changed > 0 || res.Removed > 0)
+		if err != nil {
+			return nil, err
+		}
+		res.Items = st.Items
+		if res.Changed > 0 || res.Removed > 0 {
+			log.Printf("[FEED] %s feed regenerated: %d changed, %d removed, %d items", ch, res.Changed, res.Removed, res.Items)
+		}
+		out = append(out, *res)
+	}
+	return out, nil
+}
+
+// feedProduct resuelve los valores del feed. El resultado depende del instante solo por
+// la oferta: una oferta sin fechas entra y sale del feed cuando empieza y termina.
+func (s *feedServiceImpl) feedProduct(e *entity.CatalogEntry, mapping entity.FeedCategoryMapping, now time.Time) *entity.FeedProduct {
+	fp := &entity.FeedProduct{
+		ID:             e.ID,
+		Title:          truncateRunes(e.Title, feedMaxTitle),
+		Description:    truncateRunes(e.Description, feedMaxDescription),
+		Link:           s.storeURL + "/products/" + strconv.FormatInt(e.ID, 10),
+		ImageLink:      s.assetURL(e.Images[0].URL),
+		InStock:        e.Stock > 0,
+		Price:          e.UnitPrice,
+		Brand:          s.brand,
+		Size:           e.Size,
+		ProductType:    e.Category,
+		GoogleCategory: mapping.GoogleCategory,
+		MetaCategory:   mapping.MetaCategory,
+	}
+	for _, img := range e.Images[1:] {
+		if len(fp.AdditionalImageLinks) == feedMaxAdditionalImages {
+			break
+		}
+		fp.AdditionalImageLinks = append(fp.AdditionalImageLinks, s.assetURL(img.URL))
+	}
+	// Los códigos de circulación restringida no son GTIN y los canales los rechazan
+	if barcode.Valid(e.BarCode) && !strings.HasPrefix(e.BarCode, barcode.InternalPrefix) {
+		fp.GTIN = e.BarCode
+	}
+
+	// Con ambas fechas el canal aplica la oferta por su cuenta; sin ellas solo se informa
+	// mientras está vigente
+	if e.SalePrice != nil && e.SalePrice.LessThan(e.UnitPrice) {
+		scheduled := e.SaleStartsAt != nil && e.SaleEndsAt != nil && now.Before(*e.SaleEndsAt)
+		if scheduled || e.IsOnSale(now) {
+			fp.SalePrice = e.SalePrice
+			if scheduled {
+				fp.SaleStartsAt, fp.SaleEndsAt = e.SaleStartsAt, e.SaleEndsAt
+			}
+		}
+	}
+	return fp
+}
+
+func (s *feedServiceImpl) assetURL(u string) string {
+	if strings.HasPrefix(u, "/") {
+		return s.assetsURL + u
+	}
+	return u
+}
+
+func truncateRunes(s string, n int) string {
+	r := []rune(strings.TrimSpace(s))
+	if len(r) <= n {
+		return string(r)
+	}
+	return strings.TrimSpace(string(r[:n-1])) + "…"
+}
+
+func (s *feedServiceImpl) WriteFeed(ctx context.Context, w io.Writer, channel entity.FeedChannel) error {
+	if !channel.IsValid() {
+		return errors.ErrInvalidInput
+	}
+	if _, err := io.WriteString(w, s.renderer.Header(channel)); err != nil {
+		return err
+	}
+	err := s.repo.StreamItems(ctx, channel, func(content string) error {
+		_, err := io.WriteString(w, content)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, s.renderer.Footer(channel))
+	return err
+}
+
+func (s *feedServiceImpl) State(ctx context.Context, channel entity.FeedChannel) (*entity.FeedState, error) {
+	if !channel.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	st, err := s.repo.GetState(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+func (s *feedServiceImpl) States(ctx context.Context) ([]entity.FeedState, error) {
+	out := make([]entity.FeedState, 0, len(entity.FeedChannels))
+	for _, ch := range entity.FeedChannels {
+		st, err := s.repo.GetState(ctx, ch)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+func (s *feedServiceImpl) ListCategoryMappings(ctx context.Context) ([]entity.FeedCategoryMapping, error) {
+	return s.repo.ListCategoryMappings(ctx)
+}
+
+func (s *feedServiceImpl) SaveCategoryMapping(ctx context.Context, m *entity.FeedCategoryMapping) (*entity.FeedCategoryMapping, error) {
+	m.Category = strings.TrimSpace(m.Category)
+	m.GoogleCategory = strings.TrimSpace(m.GoogleCategory)
+	m.MetaCategory = strings.TrimSpace(m.MetaCategory)
+	if m.Category == "" || m.GoogleCategory == "" {
+		return nil, errors.ErrInvalidInput
+	}
+	if err := s.repo.SaveCategoryMapping(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *feedServiceImpl) DeleteCategoryMapping(ctx context.Context, category string) error {
+	return s.repo.DeleteCategoryMapping(ctx, category)
+}
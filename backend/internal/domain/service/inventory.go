@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type InventoryService interface {
+	CreateLocation(ctx context.Context, l *entity.Location) (*entity.Location, error)
+	// UpdateLocation no permite dejar el sistema sin ubicación por defecto activa
+	UpdateLocation(ctx context.Context, l *entity.Location) (*entity.Location, error)
+	GetLocation(ctx context.Context, id int64) (*entity.Location, error)
+	ListLocations(ctx context.Context) ([]entity.Location, error)
+
+	// ProductInventory retorna el stock del producto por ubicación y el disponible total
+	ProductInventory(ctx context.Context, productID int64) (*entity.ProductInventory, error)
+	// Adjust corrige el stock de un producto en una ubicación (rotura, ingreso); el motivo
+	// es obligatorio y queda en el libro de stock junto con quién lo hizo
+	Adjust(ctx context.Context, productID, locationID, delta, actorID int64, reason string) (*entity.ProductInventory, error)
+	Transfer(ctx context.Context, t *entity.StockTransfer) (*entity.StockTransfer, error)
+	ListTransfers(ctx context.Context, filter entity.StockTransferFilter) ([]entity.StockTransfer, error)
+
+	// Movements retorna el historial de movimientos de stock del producto
+	Movements(ctx context.Context, filter entity.StockMovementFilter) ([]entity.StockMovement, error)
+	// Reconcile busca diferencias entre el stock guardado y el libro; con fix reescribe
+	// el stock de los productos afectados con el saldo del libro
+	Reconcile(ctx context.Context, fix bool) ([]entity.StockDiscrepancy, error)
+}
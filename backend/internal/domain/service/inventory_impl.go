@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+	"strings"
+	"time"
+)
+
+type inventoryServiceImpl struct {
+	inventoryRepo repository.InventoryRepository
+	productRepo   repository.ProductRepository
+}
+
+func NewInventoryService(inventoryRepo repository.InventoryRepository, productRepo repository.ProductRepository) InventoryService {
+	return &inventoryServiceImpl{inventoryRepo: inventoryRepo, productRepo: productRepo}
+}
+
+func normalizeLocation(l *entity.Location) error {
+	l.Code = strings.ToUpper(strings.TrimSpace(l.Code))
+	l.Name = strings.TrimSpace(l.Name)
+	if l.Code == "" || len(l.Code) > 20 || l.Name == "" || !l.Kind.IsValid() || l.Priority < 0 {
+		return errors.ErrInvalidInput
+	}
+	// La ubicación por defecto recibe altas y devoluciones, así que tiene que estar a la venta
+	if l.IsDefault && !l.Active {
+		return errors.ErrInvalidInput
+	}
+	return nil
+}
+
+func (s *inventoryServiceImpl) CreateLocation(ctx context.Context, l *entity.Location) (*entity.Location, error) {
+	if err := normalizeLocation(l); err != nil {
+		return nil, err
+	}
+	if err := s.inventoryRepo.CreateLocation(ctx, l); err != nil {
+		return nil, err
+	}
+	return s.GetLocation(ctx, l.ID)
+}
+
+func (s *inventoryServiceImpl) UpdateLocation(ctx context.Context, l *entity.Location) (*entity.Location, error) {
+	if err := normalizeLocation(l); err != nil {
+		return nil, err
+	}
+	before, err := s.inventoryRepo.GetLocation(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Para cambiar la ubicación por defecto se marca otra, no se desmarca la actual
+	if before.IsDefault && !l.IsDefault {
+		return nil, errors.ErrInvalidInput
+	}
+	if err := s.inventoryRepo.UpdateLocation(ctx, l); err != nil {
+		return nil, err
+	}
+	return s.GetLocation(ctx, l.ID)
+}
+
+func (s *inventoryServiceImpl) GetLocation(ctx context.Context, id int64) (*entity.Location, error) {
+	l, err := s.inventoryRepo.GetLocation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (s *inventoryServiceImpl) ListLocations(ctx context.Context) ([]entity.Location, error) {
+	return s.inventoryRepo.ListLocations(ctx)
+}
+
+func (s *inventoryServiceImpl) ProductInventory(ctx context.Context, productID int64) (*entity.ProductInventory, error) {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+	levels, err := s.inventoryRepo.Levels(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	inv := &entity.ProductInventory{ProductID: productID, Levels: levels}
+	for _, lv := range levels {
+		inv.OnHand += lv.Quantity
+		if lv.LocationActive {
+			inv.Available += lv.Quantity
+		}
+	}
+	return inv, nil
+}
+
+func (s *inventoryServiceImpl) Adjust(ctx context.Context, productID, locationID, delta, actorID int64, reason string) (*entity.ProductInventory, error) {
+	reason = strings.TrimSpace(reason)
+	if delta == 0 || reason == "" {
+		return nil, errors.ErrInvalidInput
+	}
+	ref := entity.StockMovementRef{Kind: entity.StockMovementAdjustment, Reason: reason, ActorID: actorID}
+	if err := s.inventoryRepo.Adjust(ctx, productID, locationID, delta, ref); err != nil {
+		return nil, err
+	}
+	return s.ProductInventory(ctx, productID)
+}
+
+func (s *inventoryServiceImpl) Transfer(ctx context.Context, t *entity.StockTransfer) (*entity.StockTransfer, error) {
+	t.Note = strings.TrimSpace(t.Note)
+	if t.ProductID <= 0 || t.Quantity <= 0 || t.FromLocationID == t.ToLocationID {
+		return nil, errors.ErrInvalidInput
+	}
+	if err := s.inventoryRepo.Transfer(ctx, t); err != nil {
+		return nil, err
+	}
+	t.CreatedAt = time.Now()
+	return t, nil
+}
+
+func (s *inventoryServiceImpl) ListTransfers(ctx context.Context, filter entity.StockTransferFilter) ([]entity.StockTransfer, error) {
+	return s.inventoryRepo.ListTransfers(ctx, filter)
+}
+
+func (s *inventoryServiceImpl) Movements(ctx context.Context, filter entity.StockMovementFilter) ([]entity.StockMovement, error) {
+	if filter.Kind != "" && !filter.Kind.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	if _, err := s.productRepo.GetByID(ctx, filter.ProductID); err != nil {
+		return nil, err
+	}
+	return s.inventoryRepo.Movements(ctx, filter)
+}
+
+func (s *inventoryServiceImpl) Reconcile(ctx context.Context, fix bool) ([]entity.StockDiscrepancy, error) {
+	found, err := s.inventoryRepo.Discrepancies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !fix || len(found) == 0 {
+		return found, nil
+	}
+	seen := make(map[int64]bool, len(found))
+	var productIDs []int64
+	for _, d := range found {
+		if !seen[d.ProductID] {
+			seen[d.ProductID] = true
+			productIDs = append(productIDs, d.ProductID)
+		}
+	}
+	if err := s.inventoryRepo.RebuildFromLedger(ctx, productIDs); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
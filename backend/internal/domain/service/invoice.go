@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+// TaxAuthority es el organismo fiscal que autoriza las facturas electrónicas.
+// Un error indica que no se pudo consultar y el envío se reintenta; un rechazo viene en la respuesta.
+type TaxAuthority interface {
+	Authorize(ctx context.Context, invoice *entity.Invoice) (entity.InvoiceAuthorization, error)
+}
+
+// InvoiceRenderer genera el documento imprimible de una factura
+type InvoiceRenderer interface {
+	Render(invoice *entity.Invoice) ([]byte, error)
+}
+
+type InvoiceService interface {
+	// IssueForOrder numera la factura de una orden pagada y la envía a autorizar.
+	// Si la orden ya estaba facturada retorna la factura existente.
+	IssueForOrder(ctx context.Context, orderID int64) (*entity.Invoice, error)
+	// Submit reintenta la autorización de una factura pendiente o rechazada
+	Submit(ctx context.Context, id int64) (*entity.Invoice, error)
+	// ProcessPending factura las órdenes pagadas sin factura y reintenta las pendientes de autorización
+	ProcessPending(ctx context.Context) (issued, authorized int, err error)
+
+	GetByID(ctx context.Context, id int64) (*entity.Invoice, error)
+	// GetForOrder retorna la factura autorizada de una orden del usuario
+	GetForOrder(ctx context.Context, userID, orderID int64) (*entity.Invoice, error)
+	List(ctx context.Context, filter entity.InvoiceFilter) ([]entity.Invoice, error)
+	RenderPDF(invoice *entity.Invoice) ([]byte, error)
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+	"log"
+)
+
+// pendingBatch es la cantidad de órdenes y facturas que se procesan por pasada
+const pendingBatch = 50
+
+type invoiceServiceImpl struct {
+	invoiceRepo repository.InvoiceRepository
+	orderRepo   repository.OrderRepository
+	authority   TaxAuthority
+	renderer    InvoiceRenderer
+	pointOfSale int
+	issuer      entity.InvoiceIssuer
+}
+
+// NewInvoiceService recibe el punto de venta con el que se numeran las facturas y los datos del emisor
+func NewInvoiceService(invoiceRepo repository.InvoiceRepository, orderRepo repository.OrderRepository, authority TaxAuthority, renderer InvoiceRenderer, pointOfSale int, issuer entity.InvoiceIssuer) InvoiceService {
+	return &invoiceServiceImpl{
+		invoiceRepo: invoiceRepo,
+		orderRepo:   orderRepo,
+		authority:   authority,
+		renderer:    renderer,
+		pointOfSale: pointOfSale,
+		issuer:      issuer,
+	}
+}
+
+func (s *invoiceServiceImpl) IssueForOrder(ctx context.Context, orderID int64) (*entity.Invoice, error) {
+	if existing, err := s.invoiceRepo.GetByOrderID(ctx, orderID); err == nil {
+		return &existing, nil
+	} else if err != errors.ErrNotFound {
+		return nil, err
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != entity.OrderStatusPaid {
+		return nil, errors.ErrInvalidTransition
+	}
+
+	inv := s.buildInvoice(&order)
+	if err := s.invoiceRepo.Issue(ctx, inv); err != nil {
+		if err == errors.ErrConflict {
+			// Otro proceso la emitió en paralelo
+			existing, gerr := s.invoiceRepo.GetByOrderID(ctx, orderID)
+			if gerr != nil {
+				return nil, gerr
+			}
+			return &existing, nil
+		}
+		return nil, err
+	}
+	if err := s.submit(ctx, inv); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// buildInvoice copia los montos ya calculados de la orden; la factura no recalcula nada
+func (s *invoiceServiceImpl) buildInvoice(order *entity.Order) *entity.Invoice {
+	inv := &entity.Invoice{
+		OrderID:     order.ID,
+		PointOfSale: s.pointOfSale,
+		Type:        entity.InvoiceTypeFor(order.Billing.TaxIDType),
+		Issuer:      s.issuer,
+		Customer:    order.Billing,
+		Currency:    order.Currency,
+		Lines:       make([]entity.InvoiceLine, 0, len(order.Items)+1),
+		Net:         order.Net(),
+		Tax:         order.Tax,
+		Total:       order.Total,
+		Status:      entity.InvoiceStatusPending,
+	}
+	if inv.Customer.Name == "" {
+		inv.Customer.Name = "Consumidor Final"
+	}
+	if order.ExchangeRate != nil {
+		rate := order.ExchangeRate.Rate.Inverse()
+		inv.ExchangeRate = &rate
+	}
+	for _, it := range order.Items {
+		description := it.Title
+		if it.Size != "" {
+			description += " - Talle " + it.Size
+		}
+		total, _ := it.NetAmount.Add(it.TaxAmount)
+		inv.Lines = append(inv.Lines, entity.InvoiceLine{
+			Description: description,
+			Quantity:    it.Quantity,
+			UnitPrice:   it.UnitPrice,
+			Discount:    it.Discount,
+			TaxRate:     it.TaxRate,
+			Net:         it.NetAmount,
+			Tax:         it.TaxAmount,
+			Total:       total,
+		})
+	}
+	// El envío se factura como una línea más; el envío gratis no se imprime
+	if sh := order.Shipping; sh != nil && !sh.Amount.IsZero() {
+		total, _ := sh.Net.Add(sh.Tax)
+		inv.Lines = append(inv.Lines, entity.InvoiceLine{
+			Description: "Envío - " + sh.MethodName,
+			Quantity:    1,
+			UnitPrice:   sh.Amount,
+			Discount:    money.Zero(order.Currency),
+			TaxRate:     sh.TaxRate,
+			Net:         sh.Net,
+			Tax:         sh.Tax,
+			Total:       total,
+		})
+	}
+	return inv
+}
+
+func (s *invoiceServiceImpl) Submit(ctx context.Context, id int64) (*entity.Invoice, error) {
+	inv, err := s.invoiceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status == entity.InvoiceStatusAuthorized {
+		return nil, errors.ErrConflict
+	}
+	if err := s.submit(ctx, &inv); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// submit envía la factura al organismo y guarda el resultado. Una falla de comunicación
+// no es un error para el llamador: la factura queda pendiente y se reintenta después.
+func (s *invoiceServiceImpl) submit(ctx context.Context, inv *entity.Invoice) error {
+	inv.SubmitAttempts++
+	auth, err := s.authority.Authorize(ctx, inv)
+	switch {
+	case err != nil:
+		log.Printf("[INVOICE] Authorization of invoice %s %s failed (attempt %d): %v", inv.Type, inv.FullNumber(), inv.SubmitAttempts, err)
+	case auth.Approved:
+		expiresAt := auth.ExpiresAt
+		inv.Status = entity.InvoiceStatusAuthorized
+		inv.AuthorizationCode = auth.Code
+		inv.AuthorizationExpiresAt = &expiresAt
+		inv.RejectionReason = ""
+	default:
+		inv.Status = entity.InvoiceStatusRejected
+		inv.RejectionReason = auth.Reason
+		log.Printf("[INVOICE] Invoice %s %s rejected: %s", inv.Type, inv.FullNumber(), auth.Reason)
+	}
+	return s.invoiceRepo.UpdateSubmission(ctx, inv)
+}
+
+func (s *invoiceServiceImpl) ProcessPending(ctx context.Context) (int, int, error) {
+	var issued, authorized int
+
+	orderIDs, err := s.invoiceRepo.PendingOrders(ctx, pendingBatch)
+	if err != nil {
+		return 0, 0, err
+	}
+	// Las facturas emitidas en esta pasada ya tuvieron su envío
+	submitted := map[int64]bool{}
+	for _, id := range orderIDs {
+		inv, err := s.IssueForOrder(ctx, id)
+		if err != nil {
+			log.Printf("[INVOICE] Failed to invoice order %d: %v", id, err)
+			continue
+		}
+		submitted[inv.ID] = true
+		issued++
+		if inv.Status == entity.InvoiceStatusAuthorized {
+			authorized++
+		}
+	}
+
+	pending, err := s.invoiceRepo.List(ctx, entity.InvoiceFilter{Status: entity.InvoiceStatusPending, Limit: pendingBatch})
+	if err != nil {
+		return issued, authorized, err
+	}
+	for _, listed := range pending {
+		if submitted[listed.ID] {
+			continue
+		}
+		// Se relee antes de enviar: la factura pudo enviarse a mano desde el listado
+		inv, err := s.invoiceRepo.GetByID(ctx, listed.ID)
+		if err != nil {
+			return issued, authorized, err
+		}
+		if inv.Status != entity.InvoiceStatusPending || inv.SubmitAttempts != listed.SubmitAttempts {
+			continue
+		}
+		if err := s.submit(ctx, &inv); err == errors.ErrConflict {
+			log.Printf("[INVOICE] Invoice %s %s was submitted concurrently, skipping", inv.Type, inv.FullNumber())
+			continue
+		} else if err != nil {
+			return issued, authorized, err
+		}
+		if inv.Status == entity.InvoiceStatusAuthorized {
+			authorized++
+		}
+	}
+	return issued, authorized, nil
+}
+
+func (s *invoiceServiceImpl) GetByID(ctx context.Context, id int64) (*entity.Invoice, error) {
+	inv, err := s.invoiceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+func (s *invoiceServiceImpl) GetForOrder(ctx context.Context, userID, orderID int64) (*entity.Invoice, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, errors.ErrNotFound
+	}
+	inv, err := s.invoiceRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	// El cliente solo ve comprobantes válidos
+	if inv.Status != entity.InvoiceStatusAuthorized {
+		return nil, errors.ErrNotFound
+	}
+	return &inv, nil
+}
+
+func (s *invoiceServiceImpl) List(ctx context.Context, filter entity.InvoiceFilter) ([]entity.Invoice, error) {
+	return s.invoiceRepo.List(ctx, filter)
+}
+
+func (s *invoiceServiceImpl) RenderPDF(invoice *entity.Invoice) ([]byte, error) {
+	return s.renderer.Render(invoice)
+}
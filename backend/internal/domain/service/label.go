@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+// LabelRenderer dibuja códigos de barras y hojas de etiquetas imprimibles
+type LabelRenderer interface {
+	BarCode(code string, format entity.BarCodeFormat) ([]byte, error)
+	// Sheet retorna un PDF con Copies etiquetas de cada producto
+	Sheet(labels []entity.ProductLabel) ([]byte, error)
+}
+
+type LabelService interface {
+	// BarCode dibuja el código de barras del producto
+	BarCode(ctx context.Context, productID int64, format entity.BarCodeFormat) ([]byte, error)
+	// Sheet arma la hoja de etiquetas de los productos pedidos, con su precio vigente
+	Sheet(ctx context.Context, items []entity.ProductLabel) ([]byte, error)
+}
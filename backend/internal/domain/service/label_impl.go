@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+	"time"
+)
+
+type labelServiceImpl struct {
+	productRepo repository.ProductRepository
+	renderer    LabelRenderer
+}
+
+func NewLabelService(productRepo repository.ProductRepository, renderer LabelRenderer) LabelService {
+	return &labelServiceImpl{productRepo: productRepo, renderer: renderer}
+}
+
+// maxLabelsPerSheet limita las etiquetas de un pedido (20 hojas de 24)
+const maxLabelsPerSheet = 480
+
+func (s *labelServiceImpl) BarCode(ctx context.Context, productID int64, format entity.BarCodeFormat) ([]byte, error) {
+	if !format.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	p, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return s.renderer.BarCode(p.BarCode, format)
+}
+
+func (s *labelServiceImpl) Sheet(ctx context.Context, items []entity.ProductLabel) ([]byte, error) {
+	if len(items) == 0 {
+		return nil, errors.ErrInvalidInput
+	}
+	total := 0
+	now := time.Now()
+	labels := make([]entity.ProductLabel, 0, len(items))
+	for _, it := range items {
+		if it.Copies == 0 {
+			it.Copies = 1
+		}
+		if it.ProductID <= 0 || it.Copies < 0 {
+			return nil, errors.ErrInvalidInput
+		}
+		total += it.Copies
+		if total > maxLabelsPerSheet {
+			return nil, errors.ErrInvalidInput
+		}
+		p, err := s.productRepo.GetByID(ctx, it.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, entity.ProductLabel{
+			ProductID: p.ID,
+			BarCode:   p.BarCode,
+			Title:     p.Title,
+			Size:      p.Size,
+			Price:     p.EffectivePrice(now),
+			Copies:    it.Copies,
+		})
+	}
+	return s.renderer.Sheet(labels)
+}
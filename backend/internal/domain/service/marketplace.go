@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"time"
+)
+
+// MarketplaceClient es la API de un marketplace donde se publican los productos. Los
+// errores de red o del marketplace se reintentan en la próxima pasada.
+type MarketplaceClient interface {
+	// Name identifica al marketplace en las publicaciones y las ventas importadas
+	Name() string
+	// CreateItem publica un producto y retorna el ID de la publicación
+	CreateItem(ctx context.Context, item entity.MarketplaceItem) (string, error)
+	// GetItem falla con ErrNotFound si la publicación no existe
+	GetItem(ctx context.Context, externalID string) (entity.MarketplaceItem, error)
+	// UpdateItem fija el stock disponible y el precio de la publicación
+	UpdateItem(ctx context.Context, externalID string, stock int64, price money.Money) error
+	// Orders retorna las ventas pagadas creadas desde since, de la más vieja a la más nueva
+	Orders(ctx context.Context, since time.Time) ([]entity.MarketplaceOrder, error)
+}
+
+// MarketplaceService mantiene sincronizados el stock y el precio de los productos
+// publicados en marketplaces e importa sus ventas
+type MarketplaceService interface {
+	// Marketplaces lista los marketplaces configurados
+	Marketplaces() []string
+
+	// LinkListing vincula el producto con una publicación existente o, sin externalID, lo
+	// publica. Falla con ErrInvalidInput si el marketplace no está configurado y con
+	// ErrConflict si el producto o la publicación ya están vinculados.
+	LinkListing(ctx context.Context, marketplace string, productID int64, externalID string) (*entity.MarketplaceListing, error)
+	GetListing(ctx context.Context, id int64) (*entity.MarketplaceListing, error)
+	ListListings(ctx context.Context, filter entity.MarketplaceListingFilter) ([]entity.MarketplaceListing, error)
+	// SetListingStatus pausa o reanuda la sincronización de una publicación
+	SetListingStatus(ctx context.Context, id int64, status entity.MarketplaceListingStatus) (*entity.MarketplaceListing, error)
+	// UnlinkListing deja de sincronizar la publicación; no la cierra en el marketplace
+	UnlinkListing(ctx context.Context, id int64) error
+
+	// Sync importa las ventas nuevas de cada marketplace y después envía el stock y el
+	// precio de las publicaciones que cambiaron
+	Sync(ctx context.Context) ([]entity.MarketplaceSync, error)
+	// Reconcile compara cada publicación activa con su producto; con fix envía los
+	// valores locales a las que difieren en stock o precio
+	Reconcile(ctx context.Context, fix bool) ([]entity.MarketplaceDiscrepancy, error)
+
+	GetOrder(ctx context.Context, id int64) (*entity.MarketplaceOrder, error)
+	ListOrders(ctx context.Context, filter entity.MarketplaceOrderFilter) ([]entity.MarketplaceOrder, error)
+}
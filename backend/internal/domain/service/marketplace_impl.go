@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+type marketplaceServiceImpl struct {
+	repo      repository.MarketplaceRepository
+	products  repository.ProductRepository
+	images    repository.ProductImageRepository
+	assetsURL string
+	clients   map[string]MarketplaceClient
+	names     []string
+
+	// mu evita que el scheduler y una sincronización manual envíen la misma publicación
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// NewMarketplaceService recibe los marketplaces habilitados; assetsURL vuelve absolutas
+// las imágenes de los productos que se publican
+func NewMarketplaceService(repo repository.MarketplaceRepository, products repository.ProductRepository, images repository.ProductImageRepository, assetsURL string, clients ...MarketplaceClient) MarketplaceService {
+	byName := make(map[string]MarketplaceClient, len(clients))
+	names := make([]string, 0, len(clients))
+	for _, c := range clients {
+		byName[c.Name()] = c
+		names = append(names, c.Name())
+	}
+	return &marketplaceServiceImpl{
+		repo:      repo,
+		products:  products,
+		images:    images,
+		assetsURL: strings.TrimRight(assetsURL, "/"),
+		clients:   byName,
+		names:     names,
+		now:       time.Now,
+	}
+}
+
+// marketplaceOrdersOverlap es cuánto antes del cursor se vuelven a pedir las ventas, para
+// no perder las que el marketplace informa tarde; las repetidas se descartan al importar
+const marketplaceOrdersOverlap = time.Hour
+
+func (s *marketplaceServiceImpl) Marketplaces() []string { return s.names }
+
+func (s *marketplaceServiceImpl) LinkListing(ctx context.Context, marketplace string, productID int64, externalID string) (*entity.MarketplaceListing, error) {
+	client, ok := s.clients[marketplace]
+	if !ok {
+		return nil, errors.ErrInvalidInput
+	}
+	externalID = strings.TrimSpace(externalID)
+	p, err := s.products.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	// Se verifica antes de publicar para no dejar una publicación huérfana
+	existing, err := s.repo.ListListings(ctx, entity.MarketplaceListingFilter{Marketplace: marketplace, ProductID: productID, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, errors.ErrConflict
+	}
+
+	l := &entity.MarketplaceListing{
+		Marketplace: marketplace,
+		ProductID:   productID,
+		ExternalID:  externalID,
+		Status:      entity.MarketplaceListingActive,
+	}
+	if externalID != "" {
+		// Una publicación existente se vincula sin sincronizar; la próxima pasada le envía
+		// el stock y el precio del producto
+		if _, err := client.GetItem(ctx, externalID); err != nil {
+			return nil, err
+		}
+	} else {
+		now := s.now()
+		stock, price := entity.MarketplaceOffer(&p, now)
+		item := entity.MarketplaceItem{Title: p.Title, Description: p.Description, Price: price, Stock: stock}
+		imgs, err := s.images.FindByProductID(ctx, productID)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(imgs, func(i, j int) bool { return imgs[i].IsPrimary && !imgs[j].IsPrimary })
+		for _, img := range imgs {
+			item.Pictures = append(item.Pictures, s.assetURL(img.URL))
+		}
+		if l.ExternalID, err = client.CreateItem(ctx, item); err != nil {
+			return nil, err
+		}
+		l.SyncedStock, l.SyncedPrice, l.SyncedAt = stock, &price, &now
+		log.Printf("[MARKETPLACE] Product %d published on %s as %s", productID, marketplace, l.ExternalID)
+	}
+	if err := s.repo.CreateListing(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (s *marketplaceServiceImpl) assetURL(u string) string {
+	if strings.HasPrefix(u, "/") {
+		return s.assetsURL + u
+	}
+	return u
+}
+
+func (s *marketplaceServiceImpl) GetListing(ctx context.Context, id int64) (*entity.MarketplaceListing, error) {
+	l, err := s.repo.GetListing(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (s *marketplaceServiceImpl) ListListings(ctx context.Context, filter entity.MarketplaceListingFilter) ([]entity.MarketplaceListing, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	return s.repo.ListListings(ctx, filter)
+}
+
+func (s *marketplaceServiceImpl) SetListingStatus(ctx context.Context, id int64, status entity.MarketplaceListingStatus) (*entity.MarketplaceListing, error) {
+	if !status.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	if err := s.repo.UpdateListingStatus(ctx, id, status); err != nil {
+		return nil, err
+	}
+	return s.GetListing(ctx, id)
+}
+
+func (s *marketplaceServiceImpl) UnlinkListing(ctx context.Context, id int64) error {
+	return s.repo.DeleteListing(ctx, id)
+}
+
+func (s *marketplaceServiceImpl) Sync(ctx context.Context) ([]entity.MarketplaceSync, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]entity.MarketplaceSync, 0, len(s.names))
+	for _, name := range s.names {
+		res := entity.MarketplaceSync{Marketplace: name}
+		var err error
+		// Sin las ventas al día el stock local está de más: enviarlo pisaría lo que el
+		// marketplace ya descontó
+		if res.OrdersImported, err = s.pullOrders(ctx, s.clients[name]); err != nil {
+			log.Printf("[MARKETPLACE] Failed to pull %s orders, stock push skipped: %v", name, err)
+			res.Error = err.Error()
+			out = append(out, res)
+			continue
+		}
+		if err := s.push(ctx, s.clients[name], &res); err != nil {
+			return nil, err
+		}
+		if res.OrdersImported > 0 || res.Pushed > 0 || res.Failed > 0 {
+			log.Printf("[MARKETPLACE] %s synced: %d order(s) imported, %d listing(s) pushed, %d failed",
+				name, res.OrdersImported, res.Pushed, res.Failed)
+		}
+		out = append(out, res)
+	}
+	return out, nil
+}
+
+// pullOrders importa las ventas nuevas y retorna cuántas se importaron
+func (s *marketplaceServiceImpl) pullOrders(ctx context.Context, client MarketplaceClient) (int, error) {
+	cursor, err := s.repo.OrdersCursor(ctx, client.Name())
+	if err != nil || cursor == nil {
+		return 0, err
+	}
+	orders, err := client.Orders(ctx, cursor.Add(-marketplaceOrdersOverlap))
+	if err != nil {
+		return 0, err
+	}
+	imported := 0
+	for i := range orders {
+		o := &orders[i]
+		o.Marketplace = client.Name()
+		switch err := s.repo.ImportOrder(ctx, o); err {
+		case nil:
+		case errors.ErrConflict:
+			continue
+		default:
+			return imported, err
+		}
+		imported++
+		if o.Status == entity.MarketplaceOrderReview {
+			log.Printf("[MARKETPLACE] %s order %s needs review: unlinked items or not enough stock", o.Marketplace, o.ExternalID)
+		}
+	}
+	return imported, nil
+}
+
+// push envía el stock y el precio de las publicaciones que no los tienen. Un envío
+// rechazado no frena al resto y se reintenta en la próxima pasada.
+func (s *marketplaceServiceImpl) push(ctx context.Context, client MarketplaceClient, res *entity.MarketplaceSync) error {
+	items, err := s.repo.SyncItems(ctx, client.Name())
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	for _, it := range items {
+		stock, price := entity.MarketplaceOffer(&it.Product, now)
+		if !it.Listing.NeedsPush(stock, price) {
+			continue
+		}
+		if err := client.UpdateItem(ctx, it.Listing.ExternalID, stock, price); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Printf("[MARKETPLACE] Failed to push listing %s of product %d to %s: %v", it.Listing.ExternalID, it.Product.ID, client.Name(), err)
+			if err := s.repo.MarkSyncFailed(ctx, it.Listing.ID, err.Error()); err != nil {
+				return err
+			}
+			res.Failed++
+			continue
+		}
+		if err := s.repo.MarkSynced(ctx, it.Listing.ID, stock, price); err != nil {
+			return err
+		}
+		res.Pushed++
+	}
+	return nil
+}
+
+func (s *marketplaceServiceImpl) Reconcile(ctx context.Context, fix bool) ([]entity.MarketplaceDiscrepancy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []entity.MarketplaceDiscrepancy{}
+	now := s.now()
+	for _, name := range s.names {
+		client := s.clients[name]
+		// Las ventas sin importar se verían como diferencias de stock
+		if _, err := s.pullOrders(ctx, client); err != nil {
+			return nil, err
+		}
+		items, err := s.repo.SyncItems(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, it := range items {
+			d := entity.MarketplaceDiscrepancy{
+				ListingID:   it.Listing.ID,
+				Marketplace: name,
+				ProductID:   it.Product.ID,
+				ExternalID:  it.Listing.ExternalID,
+			}
+			remote, err := client.GetItem(ctx, it.Listing.ExternalID)
+			if err == errors.ErrNotFound {
+				d.Field, d.Local, d.Remote = entity.MarketplaceFieldStatus, string(entity.MarketplaceListingActive), "missing"
+				out = append(out, d)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if remote.Status != string(entity.MarketplaceListingActive) {
+				d.Field, d.Local, d.Remote = entity.MarketplaceFieldStatus, string(entity.MarketplaceListingActive), remote.Status
+				out = append(out, d)
+			}
+
+			stock, price := entity.MarketplaceOffer(&it.Product, now)
+			var found []entity.MarketplaceDiscrepancy
+			if remote.Stock != stock {
+				d.Field, d.Local, d.Remote = entity.MarketplaceFieldStock, strconv.FormatInt(stock, 10), strconv.FormatInt(remote.Stock, 10)
+				found = append(found, d)
+			}
+			if remote.Price != price {
+				d.Field, d.Local, d.Remote = entity.MarketplaceFieldPrice, price.String(), remote.Price.String()
+				found = append(found, d)
+			}
+			if len(found) > 0 && fix {
+				if err := client.UpdateItem(ctx, it.Listing.ExternalID, stock, price); err != nil {
+					return nil, err
+				}
+				if err := s.repo.MarkSynced(ctx, it.Listing.ID, stock, price); err != nil {
+					return nil, err
+				}
+				for i := range found {
+					found[i].Fixed = true
+				}
+			}
+			out = append(out, found...)
+		}
+	}
+	if len(out) > 0 {
+		log.Printf("[MARKETPLACE] Reconciliation found %d discrepancy(ies)", len(out))
+	}
+	return out, nil
+}
+
+func (s *marketplaceServiceImpl) GetOrder(ctx context.Context, id int64) (*entity.MarketplaceOrder, error) {
+	o, err := s.repo.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (s *marketplaceServiceImpl) ListOrders(ctx context.Context, filter entity.MarketplaceOrderFilter) ([]entity.MarketplaceOrder, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	return s.repo.ListOrders(ctx, filter)
+}
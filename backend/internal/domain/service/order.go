@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type OrderService interface {
+	// Quote valúa el carrito sin confirmarlo: precios, promociones aplicadas, cupones rechazados
+	// y envíos disponibles a la dirección de envío (la indicada o la del usuario por defecto)
+	Quote(ctx context.Context, checkout entity.Checkout) (*entity.CheckoutQuote, error)
+	// Place crea una orden en la moneda indicada, descuenta stock y congela precios, descuentos, tasa y envío.
+	// Falla con ErrCouponRejected si alguno de los cupones no se pudo aplicar y con
+	// ErrShippingUnavailable si el método elegido no llega a la dirección o no admite el peso.
+	Place(ctx context.Context, checkout entity.Checkout) (*entity.Order, error)
+	// GetForUser retorna la orden solo si pertenece al usuario
+	GetForUser(ctx context.Context, userID, id int64) (*entity.Order, error)
+	List(ctx context.Context, filter entity.OrderFilter) ([]entity.Order, error)
+	// UpdateStatus marca una orden pendiente como pagada o cancelada; al cancelar se devuelve el stock
+	UpdateStatus(ctx context.Context, id int64, status entity.OrderStatus) (*entity.Order, error)
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+	"core/internal/domain/tax"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+type orderServiceImpl struct {
+	orderRepo     repository.OrderRepository
+	productRepo   repository.ProductRepository
+	inventoryRepo repository.InventoryRepository
+	addressRepo   repository.AddressRepository
+	currency      CurrencyService
+	promotions    PromotionService
+	taxes         TaxService
+	shipping      ShippingService
+}
+
+func NewOrderService(orderRepo repository.OrderRepository, productRepo repository.ProductRepository, inventoryRepo repository.InventoryRepository, addressRepo repository.AddressRepository, currency CurrencyService, promotions PromotionService, taxes TaxService, shipping ShippingService) OrderService {
+	return &orderServiceImpl{orderRepo: orderRepo, productRepo: productRepo, inventoryRepo: inventoryRepo, addressRepo: addressRepo, currency: currency, promotions: promotions, taxes: taxes, shipping: shipping}
+}
+
+func (s *orderServiceImpl) Quote(ctx context.Context, checkout entity.Checkout) (*entity.CheckoutQuote, error) {
+	if checkout.Currency == "" {
+		checkout.Currency = money.Base
+	}
+	lines, err := mergeLines(checkout.Lines)
+	if err != nil {
+		return nil, err
+	}
+	var shipTo, billTo *entity.Address
+	if checkout.LocationID == 0 {
+		if shipTo, billTo, err = s.checkoutAddresses(ctx, checkout); err != nil {
+			return nil, err
+		}
+	}
+	if billTo != nil {
+		// Los datos cargados a mano tienen prioridad sobre la libreta
+		if checkout.Billing.Name == "" {
+			checkout.Billing.Name = billTo.RecipientName
+		}
+		if checkout.Billing.Address == "" {
+			checkout.Billing.Address = billTo.Line()
+		}
+	}
+	billing, err := normalizeBilling(checkout.Billing)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.price(ctx, checkout.UserID, lines, checkout.Currency)
+	if err != nil {
+		return nil, err
+	}
+	order.Billing = billing
+	rejected, err := s.applyPromotions(ctx, order, checkout.Codes)
+	if err != nil {
+		return nil, err
+	}
+	// El envío gratis se evalúa con los descuentos ya aplicados
+	options, err := s.applyShipping(ctx, order, shipTo, checkout.ShippingMethodID)
+	if err != nil {
+		return nil, err
+	}
+	// El IVA se calcula al final, sobre los montos ya descontados
+	if err := s.taxes.ApplyToOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	return &entity.CheckoutQuote{Order: order, Rejected: rejected, ShippingOptions: options}, nil
+}
+
+// checkoutAddresses resuelve las direcciones de envío y facturación: las indicadas o las
+// marcadas por defecto. Una dirección ajena o inexistente es un error de la request.
+func (s *orderServiceImpl) checkoutAddresses(ctx context.Context, checkout entity.Checkout) (shipTo, billTo *entity.Address, err error) {
+	var addresses []entity.Address
+	if checkout.ShippingAddressID == 0 || checkout.BillingAddressID == 0 {
+		if addresses, err = s.addressRepo.ListByUser(ctx, checkout.UserID); err != nil {
+			return nil, nil, err
+		}
+	}
+	pick := func(id int64, isDefault func(a *entity.Address) bool) (*entity.Address, error) {
+		if id == 0 {
+			for i := range addresses {
+				if isDefault(&addresses[i]) {
+					return &addresses[i], nil
+				}
+			}
+			return nil, nil
+		}
+		a, err := s.addressRepo.GetByID(ctx, id)
+		if err == errors.ErrNotFound || (err == nil && a.UserID != checkout.UserID) {
+			return nil, errors.ErrInvalidInput
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &a, nil
+	}
+	if shipTo, err = pick(checkout.ShippingAddressID, func(a *entity.Address) bool { return a.IsDefaultShipping }); err != nil {
+		return nil, nil, err
+	}
+	if billTo, err = pick(checkout.BillingAddressID, func(a *entity.Address) bool { return a.IsDefaultBilling }); err != nil {
+		return nil, nil, err
+	}
+	return shipTo, billTo, nil
+}
+
+// applyShipping cotiza los envíos a la dirección y, si se eligió un método, lo congela en la orden.
+// Sin método la orden queda para retiro en local.
+func (s *orderServiceImpl) applyShipping(ctx context.Context, order *entity.Order, shipTo *entity.Address, methodID int64) ([]entity.ShippingQuote, error) {
+	if shipTo == nil {
+		if methodID != 0 {
+			return nil, errors.ErrShippingUnavailable
+		}
+		return []entity.ShippingQuote{}, nil
+	}
+	options, err := s.shipping.Quote(ctx, order, shipTo.Province)
+	if err != nil {
+		return nil, err
+	}
+	if methodID == 0 {
+		return options, nil
+	}
+	for _, q := range options {
+		if q.MethodID != methodID {
+			continue
+		}
+		order.Shipping = &entity.OrderShipping{
+			MethodID:    q.MethodID,
+			MethodName:  q.Name,
+			Address:     shipTo.Snapshot(),
+			WeightGrams: order.WeightGrams(),
+			Amount:      q.Price,
+		}
+		return options, nil
+	}
+	return nil, errors.ErrShippingUnavailable
+}
+
+func (s *orderServiceImpl) Place(ctx context.Context, checkout entity.Checkout) (*entity.Order, error) {
+	quote, err := s.Quote(ctx, checkout)
+	if err != nil {
+		return nil, err
+	}
+	order := quote.Order
+	order.LocationID = checkout.LocationID
+	// Un cupón ingresado que no aplica corta el checkout para que el cliente no pague de más
+	for _, r := range quote.Rejected {
+		if r.Code != "" {
+			return nil, errors.ErrCouponRejected
+		}
+	}
+
+	// El repositorio asigna el stock de cada línea en la misma transacción que crea la orden
+	if err := s.orderRepo.Create(ctx, order); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	order.CreatedAt, order.UpdatedAt = now, now
+	return order, nil
+}
+
+// price arma la orden pendiente con los precios vigentes en la moneda pedida
+func (s *orderServiceImpl) price(ctx context.Context, userID int64, lines []entity.OrderLine, currency money.Currency) (*entity.Order, error) {
+	now := time.Now()
+	products := make([]entity.Product, 0, len(lines))
+	for _, l := range lines {
+		p, err := s.productRepo.GetByID(ctx, l.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if !p.IsVisible(now) {
+			return nil, errors.ErrNotFound
+		}
+		if p.Stock < l.Quantity {
+			return nil, errors.ErrInsufficientStock
+		}
+		products = append(products, p)
+	}
+
+	localized, snapshot, err := s.currency.Localize(ctx, products, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &entity.Order{
+		UserID:       userID,
+		Status:       entity.OrderStatusPending,
+		Currency:     currency,
+		ExchangeRate: snapshot,
+		Items:        make([]entity.OrderItem, 0, len(lines)),
+		Discounts:    []entity.OrderDiscount{},
+	}
+	totals := make([]money.Money, 0, len(lines))
+	for i, l := range lines {
+		p, lp := products[i], localized[i].Product
+		unitPrice := lp.EffectivePrice(now)
+		lineTotal := unitPrice.Mul(l.Quantity)
+		order.Items = append(order.Items, entity.OrderItem{
+			ProductID:     p.ID,
+			BarCode:       p.BarCode,
+			Title:         p.Title,
+			Size:          p.Size,
+			Category:      p.Category,
+			WeightGrams:   p.WeightGrams,
+			Quantity:      l.Quantity,
+			BaseUnitPrice: p.EffectivePrice(now),
+			UnitPrice:     unitPrice,
+			PriceSource:   localized[i].PriceSource,
+			LineTotal:     lineTotal,
+			Discount:      money.Zero(currency),
+		})
+		totals = append(totals, lineTotal)
+	}
+	if order.Subtotal, err = money.Sum(currency, totals...); err != nil {
+		return nil, err
+	}
+	order.Discount = money.Zero(currency)
+	order.Total = order.Subtotal
+	return order, nil
+}
+
+// applyPromotions evalúa las promociones sobre los ítems de la orden y reparte los descuentos por línea
+func (s *orderServiceImpl) applyPromotions(ctx context.Context, order *entity.Order, codes []string) ([]entity.RejectedPromotion, error) {
+	cart := entity.Cart{UserID: order.UserID, Currency: order.Currency, Codes: codes}
+	for _, it := range order.Items {
+		cart.Items = append(cart.Items, entity.CartItem{
+			ProductID: it.ProductID,
+			Category:  it.Category,
+			Quantity:  it.Quantity,
+			UnitPrice: it.UnitPrice,
+		})
+	}
+	result, err := s.promotions.EvaluateCart(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range order.Items {
+		order.Items[i].Discount = result.LineDiscounts[i]
+	}
+	for _, a := range result.Applied {
+		order.Discounts = append(order.Discounts, entity.OrderDiscount{
+			PromotionID: a.PromotionID,
+			Code:        a.Code,
+			Name:        a.Name,
+			Description: a.Description,
+			Amount:      a.Amount,
+		})
+	}
+	order.Subtotal = result.Subtotal
+	order.Discount = result.Discount
+	order.Total = result.Total
+	return result.Rejected, nil
+}
+
+// normalizeBilling valida el documento del cliente y lo deja solo con dígitos
+func normalizeBilling(b entity.BillingInfo) (entity.BillingInfo, error) {
+	id, idType, err := tax.ParseTaxID(b.TaxID)
+	if err != nil {
+		return entity.BillingInfo{}, errors.ErrInvalidTaxID
+	}
+	b.TaxID, b.TaxIDType = id, idType
+	b.Name = strings.TrimSpace(b.Name)
+	b.Address = strings.TrimSpace(b.Address)
+	return b, nil
+}
+
+// mergeLines agrupa las líneas repetidas del mismo producto y valida cantidades
+func mergeLines(lines []entity.OrderLine) ([]entity.OrderLine, error) {
+	if len(lines) == 0 {
+		return nil, errors.ErrInvalidInput
+	}
+	index := map[int64]int{}
+	var out []entity.OrderLine
+	for _, l := range lines {
+		if l.ProductID <= 0 || l.Quantity <= 0 {
+			return nil, errors.ErrInvalidInput
+		}
+		if i, ok := index[l.ProductID]; ok {
+			out[i].Quantity += l.Quantity
+			continue
+		}
+		index[l.ProductID] = len(out)
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+// restoreStock devuelve las unidades a las ubicaciones de las que salieron. Las órdenes
+// anteriores al stock por ubicación no tienen asignaciones y vuelven a la de por defecto.
+func (s *orderServiceImpl) restoreStock(ctx context.Context, orderID int64, items []entity.OrderItem) {
+	ctx = context.WithoutCancel(ctx)
+	ref := entity.StockMovementRef{Kind: entity.StockMovementSale, Reason: "Orden cancelada", Reference: fmt.Sprintf("order:%d", orderID)}
+	for _, it := range items {
+		var err error
+		if len(it.Allocations) > 0 {
+			err = s.inventoryRepo.Release(ctx, it.ProductID, it.Allocations, ref)
+		} else {
+			_, err = s.productRepo.UpdateStock(ctx, it.ProductID, it.Quantity, ref)
+		}
+		if err != nil {
+			log.Printf("[ORDER] failed to restore stock of product %d (+%d): %v", it.ProductID, it.Quantity, err)
+		}
+	}
+}
+
+func (s *orderServiceImpl) GetForUser(ctx context.Context, userID, id int64) (*entity.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, errors.ErrNotFound
+	}
+	return &order, nil
+}
+
+func (s *orderServiceImpl) List(ctx context.Context, filter entity.OrderFilter) ([]entity.Order, error) {
+	return s.orderRepo.List(ctx, filter)
+}
+
+func (s *orderServiceImpl) UpdateStatus(ctx context.Context, id int64, status entity.OrderStatus) (*entity.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !order.Status.CanTransitionTo(status) {
+		return nil, errors.ErrInvalidTransition
+	}
+	// La transición es condicional al estado leído, así dos cambios concurrentes no devuelven stock dos veces
+	if err := s.orderRepo.UpdateStatus(ctx, id, order.Status, status); err != nil {
+		return nil, err
+	}
+	if status == entity.OrderStatusCancelled {
+		s.restoreStock(ctx, order.ID, order.Items)
+	}
+
+	updated, err := s.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
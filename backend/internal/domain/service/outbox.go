@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+// EventSink es un destino de los eventos del outbox. Publish debe tolerar recibir dos
+// veces el mismo evento: el relay lo reintenta si algún destino falla.
+type EventSink interface {
+	Name() string
+	Publish(ctx context.Context, e entity.OutboxEvent) error
+}
+
+// OutboxService publica los eventos del outbox en los destinos configurados
+type OutboxService interface {
+	// Relay publica los eventos pendientes en todos los destinos, en orden por agregado.
+	// Un evento queda publicado cuando todos los destinos lo aceptan; si alguno falla se
+	// reintenta con espera exponencial y los eventos siguientes del mismo agregado esperan.
+	// Retorna cuántos eventos publicó.
+	Relay(ctx context.Context) (int, error)
+	// Purge borra los eventos publicados hace más que la retención y retorna cuántos
+	Purge(ctx context.Context) (int64, error)
+	List(ctx context.Context, filter entity.OutboxFilter) ([]entity.OutboxEvent, error)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+type outboxServiceImpl struct {
+	repo      repository.OutboxRepository
+	sinks     []EventSink
+	retention time.Duration
+
+	// mu evita que dos pasadas publiquen el mismo evento y lo desordenen
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// NewOutboxService recibe los destinos de los eventos y cuánto se conservan los ya
+// publicados; con retención 0 no se borran
+func NewOutboxService(repo repository.OutboxRepository, sinks []EventSink, retention time.Duration) OutboxService {
+	return &outboxServiceImpl{repo: repo, sinks: sinks, retention: retention, now: time.Now}
+}
+
+// outboxBatchSize es cuántos eventos se leen por vez
+const outboxBatchSize = 200
+
+func (s *outboxServiceImpl) Relay(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	published := 0
+	for {
+		events, err := s.repo.Pending(ctx, s.now(), outboxBatchSize)
+		if err != nil {
+			return published, err
+		}
+		// Tras un fallo, los eventos siguientes del agregado esperan a la próxima pasada
+		blocked := map[string]bool{}
+		for _, e := range events {
+			key := fmt.Sprintf("%s:%d", e.AggregateType, e.AggregateID)
+			if blocked[key] {
+				continue
+			}
+			ok, err := s.publish(ctx, e)
+			if err != nil {
+				return published, err
+			}
+			if !ok {
+				blocked[key] = true
+				continue
+			}
+			published++
+		}
+		if len(events) < outboxBatchSize {
+			return published, nil
+		}
+	}
+}
+
+// publish entrega el evento a todos los destinos y registra el resultado. Retorna si se
+// publicó; el error es solo el de guardar el resultado.
+func (s *outboxServiceImpl) publish(ctx context.Context, e entity.OutboxEvent) (bool, error) {
+	var failures []string
+	for _, sink := range s.sinks {
+		if err := sink.Publish(ctx, e); err != nil {
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			failures = append(failures, sink.Name()+": "+err.Error())
+		}
+	}
+	if len(failures) == 0 {
+		return true, s.repo.MarkPublished(ctx, e.ID)
+	}
+
+	attempts := e.Attempts + 1
+	next := s.now().Add(entity.OutboxRetryDelay(attempts))
+	reason := strings.Join(failures, "; ")
+	log.Printf("[OUTBOX] Event %d (%s of %s %d) failed (attempt %d), retrying at %s: %s",
+		e.ID, e.Event, e.AggregateType, e.AggregateID, attempts, next.Format(time.RFC3339), reason)
+	return false, s.repo.MarkFailed(ctx, e.ID, truncateRunes(reason, 500), next)
+}
+
+func (s *outboxServiceImpl) Purge(ctx context.Context) (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	return s.repo.DeletePublishedBefore(ctx, s.now().Add(-s.retention))
+}
+
+func (s *outboxServiceImpl) List(ctx context.Context, filter entity.OutboxFilter) ([]entity.OutboxEvent, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	return s.repo.List(ctx, filter)
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+)
+
+// PaymentGateway es la pasarela con la que se cobraron las órdenes. Los reembolsos llevan
+// una clave de idempotencia para que un reintento no reintegre dos veces el mismo monto.
+type PaymentGateway interface {
+	Refund(ctx context.Context, order *entity.Order, amount money.Money, idempotencyKey string) (entity.Refund, error)
+}
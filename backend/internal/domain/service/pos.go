@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+)
+
+// ReceiptRenderer dibuja el ticket imprimible de una venta de mostrador
+type ReceiptRenderer interface {
+	Receipt(receipt *entity.PosReceipt) ([]byte, error)
+}
+
+// PosService es el punto de venta. Cada cajero trabaja sobre su turno abierto y solo ve
+// sus propias ventas.
+type PosService interface {
+	// OpenShift abre un turno en una caja de una ubicación activa con el fondo inicial
+	OpenShift(ctx context.Context, shift *entity.RegisterShift) (*entity.RegisterShift, error)
+	// CurrentShift retorna el arqueo parcial del turno abierto del cajero
+	CurrentShift(ctx context.Context, cashierID int64) (*entity.ShiftReport, error)
+	// CloseShift cierra el turno del cajero con el efectivo contado y retorna el arqueo
+	CloseShift(ctx context.Context, cashierID int64, counted money.Money, note string) (*entity.ShiftReport, error)
+	ShiftReport(ctx context.Context, id int64) (*entity.ShiftReport, error)
+	ListShifts(ctx context.Context, filter entity.RegisterShiftFilter) ([]entity.RegisterShift, error)
+
+	// StartSale abre una venta vacía en el turno del cajero
+	StartSale(ctx context.Context, cashierID int64) (*entity.PosSale, error)
+	// GetSale retorna la venta con su valuación vigente o, si ya se cobró, con su orden
+	GetSale(ctx context.Context, cashierID, id int64) (*entity.PosSale, error)
+	// Scan suma qty unidades del producto del código de barras; qty negativo las quita
+	Scan(ctx context.Context, cashierID, saleID int64, code string, qty int64) (*entity.PosSale, error)
+	// Checkout cobra la venta: confirma la orden por el mismo circuito que las ventas
+	// online, la marca pagada y registra los cobros y el vuelto. Falla con
+	// ErrPaymentShort si los cobros no cubren el total.
+	Checkout(ctx context.Context, cashierID, saleID int64, payments []entity.PosPayment, billing entity.BillingInfo) (*entity.PosSale, error)
+	// VoidSale anula una venta sin cobrar
+	VoidSale(ctx context.Context, cashierID, saleID int64) (*entity.PosSale, error)
+	// Receipt retorna el ticket en PDF de una venta cobrada
+	Receipt(ctx context.Context, cashierID, saleID int64) ([]byte, error)
+}
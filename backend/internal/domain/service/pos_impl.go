@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/barcode"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+	"log"
+	"strings"
+	"time"
+)
+
+type posServiceImpl struct {
+	posRepo       repository.PosRepository
+	productRepo   repository.ProductRepository
+	inventoryRepo repository.InventoryRepository
+	orders        OrderService
+	renderer      ReceiptRenderer
+}
+
+func NewPosService(posRepo repository.PosRepository, productRepo repository.ProductRepository, inventoryRepo repository.InventoryRepository, orders OrderService, renderer ReceiptRenderer) PosService {
+	return &posServiceImpl{posRepo: posRepo, productRepo: productRepo, inventoryRepo: inventoryRepo, orders: orders, renderer: renderer}
+}
+
+// Límites de una venta de mostrador
+const (
+	maxPosSaleLines = 200
+	maxPosPayments  = 10
+)
+
+func (s *posServiceImpl) OpenShift(ctx context.Context, sh *entity.RegisterShift) (*entity.RegisterShift, error) {
+	sh.Register = strings.TrimSpace(sh.Register)
+	sh.Note = strings.TrimSpace(sh.Note)
+	if sh.Register == "" || len(sh.Register) > 50 || sh.LocationID <= 0 ||
+		sh.OpeningFloat.Currency() != money.Base || sh.OpeningFloat.IsNegative() {
+		return nil, errors.ErrInvalidInput
+	}
+	loc, err := s.inventoryRepo.GetLocation(ctx, sh.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	// En una ubicación inactiva el stock no suma a la venta
+	if !loc.Active {
+		return nil, errors.ErrInvalidInput
+	}
+	if err := s.posRepo.OpenShift(ctx, sh); err != nil {
+		return nil, err
+	}
+	log.Printf("[POS] Shift %d opened at %s/%s by user %d with %s", sh.ID, loc.Code, sh.Register, sh.CashierID, sh.OpeningFloat.Decimal())
+	updated, err := s.posRepo.GetShift(ctx, sh.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (s *posServiceImpl) CurrentShift(ctx context.Context, cashierID int64) (*entity.ShiftReport, error) {
+	sh, err := s.posRepo.CurrentShift(ctx, cashierID)
+	if err != nil {
+		return nil, err
+	}
+	return s.report(ctx, sh)
+}
+
+func (s *posServiceImpl) CloseShift(ctx context.Context, cashierID int64, counted money.Money, note string) (*entity.ShiftReport, error) {
+	sh, err := s.posRepo.CurrentShift(ctx, cashierID)
+	if err != nil {
+		return nil, err
+	}
+	if counted.Currency() != sh.OpeningFloat.Currency() || counted.IsNegative() {
+		return nil, errors.ErrInvalidInput
+	}
+	sh.CountedCash = &counted
+	if note = strings.TrimSpace(note); note != "" {
+		sh.Note = note
+	}
+	if err := s.posRepo.CloseShift(ctx, &sh); err != nil {
+		return nil, err
+	}
+	closed, err := s.posRepo.GetShift(ctx, sh.ID)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.report(ctx, closed)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[POS] Shift %d closed: %d sales, expected cash %s, counted %s, difference %s",
+		sh.ID, report.Totals.Sales, report.ExpectedCash.Decimal(), counted.Decimal(), report.Difference.Decimal())
+	return report, nil
+}
+
+func (s *posServiceImpl) ShiftReport(ctx context.Context, id int64) (*entity.ShiftReport, error) {
+	sh, err := s.posRepo.GetShift(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.report(ctx, sh)
+}
+
+func (s *posServiceImpl) report(ctx context.Context, sh entity.RegisterShift) (*entity.ShiftReport, error) {
+	totals, err := s.posRepo.ShiftTotals(ctx, sh.ID)
+	if err != nil {
+		return nil, err
+	}
+	report, err := sh.Reconcile(totals)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (s *posServiceImpl) ListShifts(ctx context.Context, filter entity.RegisterShiftFilter) ([]entity.RegisterShift, error) {
+	if filter.Status != "" && filter.Status != entity.RegisterShiftStatusOpen && filter.Status != entity.RegisterShiftStatusClosed {
+		return nil, errors.ErrInvalidInput
+	}
+	return s.posRepo.ListShifts(ctx, filter)
+}
+
+func (s *posServiceImpl) StartSale(ctx context.Context, cashierID int64) (*entity.PosSale, error) {
+	sh, err := s.posRepo.CurrentShift(ctx, cashierID)
+	if err != nil {
+		return nil, err
+	}
+	sale := &entity.PosSale{ShiftID: sh.ID, CashierID: cashierID}
+	if err := s.posRepo.CreateSale(ctx, sale); err != nil {
+		return nil, err
+	}
+	return s.GetSale(ctx, cashierID, sale.ID)
+}
+
+// sale retorna la venta solo si es del cajero, junto con su turno
+func (s *posServiceImpl) sale(ctx context.Context, cashierID, id int64) (*entity.PosSale, *entity.RegisterShift, error) {
+	sale, err := s.posRepo.GetSale(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sale.CashierID != cashierID {
+		return nil, nil, errors.ErrNotFound
+	}
+	sh, err := s.posRepo.GetShift(ctx, sale.ShiftID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &sale, &sh, nil
+}
+
+// checkout arma el checkout de la venta: retiro en el local, a nombre del cajero y con
+// el stock tomado primero de la ubicación de la caja
+func (s *posServiceImpl) checkout(sale *entity.PosSale, sh *entity.RegisterShift, billing entity.BillingInfo) entity.Checkout {
+	return entity.Checkout{
+		UserID:     sale.CashierID,
+		Lines:      sale.OrderLines(),
+		Currency:   sh.OpeningFloat.Currency(),
+		Billing:    billing,
+		LocationID: sh.LocationID,
+	}
+}
+
+func (s *posServiceImpl) GetSale(ctx context.Context, cashierID, id int64) (*entity.PosSale, error) {
+	sale, sh, err := s.sale(ctx, cashierID, id)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case sale.OrderID != nil:
+		if sale.Order, err = s.orders.GetForUser(ctx, sale.CashierID, *sale.OrderID); err != nil {
+			return nil, err
+		}
+	case sale.Status == entity.PosSaleStatusOpen && len(sale.Lines) > 0:
+		quote, err := s.orders.Quote(ctx, s.checkout(sale, sh, entity.BillingInfo{}))
+		if err != nil {
+			return nil, err
+		}
+		sale.Order = quote.Order
+	}
+	return sale, nil
+}
+
+func (s *posServiceImpl) Scan(ctx context.Context, cashierID, saleID int64, code string, qty int64) (*entity.PosSale, error) {
+	if qty == 0 {
+		qty = 1
+	}
+	normalized, err := barcode.Normalize(code)
+	if err != nil {
+		return nil, errors.ErrInvalidInput
+	}
+	sale, _, err := s.sale(ctx, cashierID, saleID)
+	if err != nil {
+		return nil, err
+	}
+	if sale.Status != entity.PosSaleStatusOpen {
+		return nil, errors.ErrInvalidTransition
+	}
+	p, err := s.productRepo.GetByBarCode(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+	if !p.IsVisible(time.Now()) {
+		return nil, errors.ErrNotFound
+	}
+
+	var current int64
+	for _, l := range sale.Lines {
+		if l.ProductID == p.ID {
+			current = l.Quantity
+		}
+	}
+	if current == 0 && qty > 0 && len(sale.Lines) >= maxPosSaleLines {
+		return nil, errors.ErrInvalidInput
+	}
+	if qty > 0 && p.Stock < current+qty {
+		return nil, errors.ErrInsufficientStock
+	}
+	if err := s.posRepo.AddLine(ctx, saleID, entity.PosSaleLine{ProductID: p.ID, Quantity: qty}); err != nil {
+		return nil, err
+	}
+	return s.GetSale(ctx, cashierID, saleID)
+}
+
+func (s *posServiceImpl) Checkout(ctx context.Context, cashierID, saleID int64, payments []entity.PosPayment, billing entity.BillingInfo) (*entity.PosSale, error) {
+	if len(payments) > maxPosPayments {
+		return nil, errors.ErrInvalidInput
+	}
+	for i := range payments {
+		payments[i].Reference = strings.TrimSpace(payments[i].Reference)
+		if len(payments[i].Reference) > 100 {
+			return nil, errors.ErrInvalidInput
+		}
+	}
+	sale, sh, err := s.sale(ctx, cashierID, saleID)
+	if err != nil {
+		return nil, err
+	}
+	if sale.Status != entity.PosSaleStatusOpen || sh.Status != entity.RegisterShiftStatusOpen {
+		return nil, errors.ErrInvalidTransition
+	}
+	if len(sale.Lines) == 0 {
+		return nil, errors.ErrInvalidInput
+	}
+
+	// Se valúa antes de confirmar para no descontar stock si el pago no alcanza
+	checkout := s.checkout(sale, sh, billing)
+	quote, err := s.orders.Quote(ctx, checkout)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := settlePayments(quote.Order.Total, payments); err != nil {
+		return nil, err
+	}
+	order, err := s.orders.Place(ctx, checkout)
+	if err != nil {
+		return nil, err
+	}
+	// Un precio puede haber cambiado entre la valuación y la confirmación
+	change, err := settlePayments(order.Total, payments)
+	if err != nil {
+		s.cancelOrder(ctx, order.ID)
+		return nil, err
+	}
+
+	sale.OrderID, sale.Total, sale.Change = &order.ID, &order.Total, &change
+	sale.Payments = payments
+	if err := s.posRepo.CompleteSale(ctx, sale); err != nil {
+		s.cancelOrder(ctx, order.ID)
+		return nil, err
+	}
+	log.Printf("[POS] Sale %d charged in shift %d: order %d, total %s, change %s",
+		sale.ID, sh.ID, order.ID, order.Total.Decimal(), change.Decimal())
+	return s.GetSale(ctx, cashierID, saleID)
+}
+
+// cancelOrder cancela la orden de un cobro que no se pudo completar, devolviendo el stock
+func (s *posServiceImpl) cancelOrder(ctx context.Context, orderID int64) {
+	if _, err := s.orders.UpdateStatus(context.WithoutCancel(ctx), orderID, entity.OrderStatusCancelled); err != nil {
+		log.Printf("[POS] failed to cancel order %d of an incomplete sale: %v", orderID, err)
+	}
+}
+
+// settlePayments valida los cobros contra el total y retorna el vuelto. Solo el efectivo
+// da vuelto, así que lo cobrado con tarjeta no puede superar el total.
+func settlePayments(total money.Money, payments []entity.PosPayment) (money.Money, error) {
+	paid, card := money.Zero(total.Currency()), money.Zero(total.Currency())
+	for _, p := range payments {
+		if !p.Method.IsValid() || !p.Amount.IsPositive() || p.Amount.Currency() != total.Currency() {
+			return money.Money{}, errors.ErrInvalidInput
+		}
+		var err error
+		if paid, err = paid.Add(p.Amount); err != nil {
+			return money.Money{}, err
+		}
+		if p.Method == entity.PaymentMethodCard {
+			if card, err = card.Add(p.Amount); err != nil {
+				return money.Money{}, err
+			}
+		}
+	}
+	overCard, err := card.Sub(total)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if overCard.IsPositive() {
+		return money.Money{}, errors.ErrInvalidInput
+	}
+	change, err := paid.Sub(total)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if change.IsNegative() {
+		return money.Money{}, errors.ErrPaymentShort
+	}
+	return change, nil
+}
+
+func (s *posServiceImpl) VoidSale(ctx context.Context, cashierID, saleID int64) (*entity.PosSale, error) {
+	if _, _, err := s.sale(ctx, cashierID, saleID); err != nil {
+		return nil, err
+	}
+	if err := s.posRepo.VoidSale(ctx, saleID); err != nil {
+		return nil, err
+	}
+	return s.GetSale(ctx, cashierID, saleID)
+}
+
+func (s *posServiceImpl) Receipt(ctx context.Context, cashierID, saleID int64) ([]byte, error) {
+	sale, err := s.GetSale(ctx, cashierID, saleID)
+	if err != nil {
+		return nil, err
+	}
+	if sale.Status != entity.PosSaleStatusCompleted || sale.Order == nil {
+		return nil, errors.ErrInvalidTransition
+	}
+	sh, err := s.posRepo.GetShift(ctx, sale.ShiftID)
+	if err != nil {
+		return nil, err
+	}
+	return s.renderer.Receipt(&entity.PosReceipt{Sale: *sale, Order: *sale.Order, Shift: sh})
+}
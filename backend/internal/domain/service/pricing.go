@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type PricingService interface {
+	// UpdatePricing reemplaza el precio de referencia y la oferta del producto
+	UpdatePricing(ctx context.Context, p *entity.Product) (*entity.Product, error)
+	SchedulePriceChange(ctx context.Context, change *entity.PriceChange) (*entity.PriceChange, error)
+	ListPriceChanges(ctx context.Context, productID int64) ([]entity.PriceChange, error)
+	CancelPriceChange(ctx context.Context, productID, changeID int64) error
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+	"time"
+)
+
+type pricingServiceImpl struct {
+	productRepo     repository.ProductRepository
+	priceChangeRepo repository.PriceChangeRepository
+}
+
+func NewPricingService(productRepo repository.ProductRepository, priceChangeRepo repository.PriceChangeRepository) PricingService {
+	return &pricingServiceImpl{productRepo: productRepo, priceChangeRepo: priceChangeRepo}
+}
+
+func (s *pricingServiceImpl) UpdatePricing(ctx context.Context, p *entity.Product) (*entity.Product, error) {
+	current, err := s.productRepo.GetByID(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Los precios de referencia y oferta deben estar en la moneda del producto
+	if p.CompareAtPrice != nil && (!p.CompareAtPrice.IsPositive() || p.CompareAtPrice.Currency() != current.UnitPrice.Currency()) {
+		return nil, errors.ErrInvalidInput
+	}
+	if p.SalePrice != nil && (!p.SalePrice.IsPositive() || !p.SalePrice.LessThan(current.UnitPrice)) {
+		return nil, errors.ErrInvalidInput
+	}
+	if p.SaleStartsAt != nil && p.SaleEndsAt != nil && !p.SaleEndsAt.After(*p.SaleStartsAt) {
+		return nil, errors.ErrInvalidInput
+	}
+
+	current.CompareAtPrice = p.CompareAtPrice
+	current.SalePrice = p.SalePrice
+	current.SaleStartsAt = p.SaleStartsAt
+	current.SaleEndsAt = p.SaleEndsAt
+	if err := s.productRepo.UpdatePricing(ctx, &current); err != nil {
+		return nil, err
+	}
+	return &current, nil
+}
+
+func (s *pricingServiceImpl) SchedulePriceChange(ctx context.Context, change *entity.PriceChange) (*entity.PriceChange, error) {
+	if !change.UnitPrice.IsPositive() || !change.EffectiveAt.After(time.Now()) {
+		return nil, errors.ErrInvalidInput
+	}
+	if change.CompareAtPrice != nil && !change.CompareAtPrice.GreaterThan(change.UnitPrice) {
+		return nil, errors.ErrInvalidInput
+	}
+	product, err := s.productRepo.GetByID(ctx, change.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if change.UnitPrice.Currency() != product.UnitPrice.Currency() {
+		return nil, errors.ErrInvalidInput
+	}
+	if err := s.priceChangeRepo.Create(ctx, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (s *pricingServiceImpl) ListPriceChanges(ctx context.Context, productID int64) ([]entity.PriceChange, error) {
+	return s.priceChangeRepo.FindByProductID(ctx, productID)
+}
+
+func (s *pricingServiceImpl) CancelPriceChange(ctx context.Context, productID, changeID int64) error {
+	change, err := s.priceChangeRepo.GetByID(ctx, changeID)
+	if err != nil {
+		return err
+	}
+	if change.ProductID != productID {
+		return errors.ErrNotFound
+	}
+	if change.IsApplied() {
+		return errors.ErrConflict
+	}
+	return s.priceChangeRepo.Delete(ctx, changeID)
+}
@@ -3,12 +3,27 @@ package service
 import (
 	"context"
 	"core/internal/domain/entity"
+	"time"
 )
 
 type ProductService interface {
 	Create(ctx context.Context, p *entity.Product) (*entity.Product, error)
 	GetByID(ctx context.Context, id int64) (*entity.Product, error)
 	Update(ctx context.Context, p *entity.Product) (*entity.Product, error)
+	// Delete borra el producto, o lo archiva si tiene movimientos de stock, compras o
+	// ventas que lo referencian
 	Delete(ctx context.Context, id int64) error
 	List(ctx context.Context, cursor string, num int64) ([]entity.Product, string, error)
+
+	// Catálogo público: solo productos publicados
+	GetVisibleByID(ctx context.Context, id int64) (*entity.Product, error)
+	// GetVisibleByBarCode acepta EAN-13 o UPC-A, con o sin los ceros a la izquierda
+	GetVisibleByBarCode(ctx context.Context, code string) (*entity.Product, error)
+	// Backoffice: todos los estados
+	Search(ctx context.Context, filter entity.ProductFilter) ([]entity.Product, error)
+	// ChangeStatus cambia el estado de publicación. Publicar con publish_at futuro deja
+	// el producto programado hasta esa fecha.
+	ChangeStatus(ctx context.Context, id int64, status entity.ProductStatus, publishAt, unpublishAt *time.Time) (*entity.Product, error)
+	// GenerateBarCodes reserva n códigos internos consecutivos libres para productos nuevos
+	GenerateBarCodes(ctx context.Context, n int) ([]string, error)
 }
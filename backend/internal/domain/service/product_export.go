@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"io"
+)
+
+// RowWriter escribe una exportación fila por fila; Close completa el archivo
+type RowWriter interface {
+	Write(values []any) error
+	Close() error
+}
+
+// SheetWriter crea el escritor de filas de un formato con las columnas dadas
+type SheetWriter interface {
+	NewRowWriter(w io.Writer, format entity.FileFormat, columns []string) (RowWriter, error)
+}
+
+// ProductExportService exporta el catálogo para contabilidad y marketplaces
+type ProductExportService interface {
+	// Columns valida los campos pedidos y retorna las columnas de la exportación; sin
+	// campos se exportan todos. Falla con ErrInvalidInput si algún campo no existe.
+	Columns(fields []string) ([]string, error)
+	// Export escribe en w los productos del filtro a medida que se leen. Las URLs de las
+	// imágenes van absolutas, la principal primero.
+	Export(ctx context.Context, w io.Writer, format entity.FileFormat, filter entity.ProductFilter, columns []string) error
+}
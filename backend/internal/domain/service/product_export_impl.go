@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+type productExportServiceImpl struct {
+	repo    repository.ProductRepository
+	writer  SheetWriter
+	baseURL string
+}
+
+// NewProductExportService usa baseURL para volver absolutas las URLs de las imágenes
+func NewProductExportService(repo repository.ProductRepository, writer SheetWriter, baseURL string) ProductExportService {
+	return &productExportServiceImpl{repo: repo, writer: writer, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (s *productExportServiceImpl) Columns(fields []string) ([]string, error) {
+	if len(fields) == 0 {
+		return entity.ExportFields, nil
+	}
+	known := make(map[string]bool, len(entity.ExportFields))
+	for _, f := range entity.ExportFields {
+		known[f] = true
+	}
+	columns := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if !known[f] {
+			return nil, errors.ErrInvalidInput
+		}
+		if !seen[f] {
+			seen[f] = true
+			columns = append(columns, f)
+		}
+	}
+	return columns, nil
+}
+
+func (s *productExportServiceImpl) Export(ctx context.Context, w io.Writer, format entity.FileFormat, filter entity.ProductFilter, columns []string) error {
+	if !format.IsValid() || (filter.Status != "" && !filter.Status.IsValid()) {
+		return errors.ErrInvalidInput
+	}
+	rw, err := s.writer.NewRowWriter(w, format, columns)
+	if err != nil {
+		return err
+	}
+	values := make([]any, len(columns))
+	err = s.repo.Stream(ctx, filter, func(e entity.CatalogEntry) error {
+		for i, col := range columns {
+			values[i] = s.exportValue(&e, col)
+		}
+		return rw.Write(values)
+	})
+	if err != nil {
+		return err
+	}
+	return rw.Close()
+}
+
+// exportValue retorna el valor del campo; los opcionales vacíos van como nil
+func (s *productExportServiceImpl) exportValue(e *entity.CatalogEntry, field string) any {
+	switch field {
+	case entity.ExportFieldID:
+		return e.ID
+	case entity.ImportFieldBarCode:
+		return e.BarCode
+	case entity.ImportFieldTitle:
+		return e.Title
+	case entity.ImportFieldDescription:
+		return e.Description
+	case entity.ImportFieldStock:
+		return e.Stock
+	case entity.ImportFieldSize:
+		return e.Size
+	case entity.ImportFieldCategory:
+		return e.Category
+	case entity.ImportFieldWeightGrams:
+		return e.WeightGrams
+	case entity.ImportFieldUnitPrice:
+		return e.UnitPrice
+	case entity.ExportFieldCurrency:
+		return string(e.UnitPrice.Currency())
+	case entity.ExportFieldStatus:
+		return string(e.Status)
+	case entity.ImportFieldPublishAt:
+		return optionalTime(e.PublishAt)
+	case entity.ImportFieldUnpublishAt:
+		return optionalTime(e.UnpublishAt)
+	case entity.ExportFieldCompareAtPrice:
+		if e.CompareAtPrice != nil {
+			return *e.CompareAtPrice
+		}
+	case entity.ExportFieldSalePrice:
+		if e.SalePrice != nil {
+			return *e.SalePrice
+		}
+	case entity.ExportFieldSaleStartsAt:
+		return optionalTime(e.SaleStartsAt)
+	case entity.ExportFieldSaleEndsAt:
+		return optionalTime(e.SaleEndsAt)
+	case entity.ExportFieldImageURLs:
+		urls := make([]string, 0, len(e.Images))
+		for _, img := range e.Images {
+			urls = append(urls, s.absoluteURL(img.URL))
+		}
+		return urls
+	case entity.ExportFieldUpdatedAt:
+		return e.UpdatedAt
+	case entity.ExportFieldCreatedAt:
+		return e.CreatedAt
+	}
+	return nil
+}
+
+func (s *productExportServiceImpl) absoluteURL(u string) string {
+	if strings.HasPrefix(u, "/") {
+		return s.baseURL + u
+	}
+	return u
+}
+
+func optionalTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
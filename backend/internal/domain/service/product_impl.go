@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"core/internal/domain/barcode"
 	"core/internal/domain/entity"
+	"core/internal/domain/errors"
 	"core/internal/domain/repository"
+	"time"
 )
 
 type productServiceImpl struct {
@@ -14,7 +17,27 @@ func NewProductService(repo repository.ProductRepository) ProductService {
 	return &productServiceImpl{repo: repo}
 }
 
+// maxGeneratedBarCodes limita los códigos internos generados por pedido
+const maxGeneratedBarCodes = 100
+
 func (s *productServiceImpl) Create(ctx context.Context, p *entity.Product) (*entity.Product, error) {
+	if !p.UnitPrice.Currency().IsValid() || p.UnitPrice.IsNegative() || p.WeightGrams < 0 {
+		return nil, errors.ErrInvalidInput
+	}
+	code, err := barcode.Parse(p.BarCode)
+	if err != nil {
+		return nil, err
+	}
+	p.BarCode = code
+
+	// Los productos nuevos nunca se publican directamente
+	p.Status = entity.ProductStatusDraft
+	if p.PublishAt != nil {
+		p.Status = entity.ProductStatusScheduled
+	}
+	if err := validateSchedule(p.Status, p.PublishAt, p.UnpublishAt, time.Now()); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Create(ctx, p); err != nil {
 		return nil, err
 	}
@@ -30,6 +53,22 @@ func (s *productServiceImpl) GetByID(ctx context.Context, id int64) (*entity.Pro
 }
 
 func (s *productServiceImpl) Update(ctx context.Context, p *entity.Product) (*entity.Product, error) {
+	if p.WeightGrams < 0 {
+		return nil, errors.ErrInvalidInput
+	}
+	// Los códigos cargados antes de la validación se conservan mientras no se cambien
+	current, err := s.repo.GetByID(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	code, err := barcode.Normalize(p.BarCode)
+	if err != nil {
+		return nil, err
+	}
+	if code != current.BarCode && !barcode.Valid(code) {
+		return nil, barcode.ErrInvalidBarCode
+	}
+	p.BarCode = code
 	if err := s.repo.Update(ctx, p); err != nil {
 		return nil, err
 	}
@@ -37,14 +76,22 @@ func (s *productServiceImpl) Update(ctx context.Context, p *entity.Product) (*en
 }
 
 func (s *productServiceImpl) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	err := s.repo.Delete(ctx, id)
+	if err != errors.ErrConflict {
+		return err
+	}
+	// Un producto con historial de stock, compras o ventas no se borra: se archiva
+	_, err = s.ChangeStatus(ctx, id, entity.ProductStatusArchived, nil, nil)
+	return err
 }
 
 func (s *productServiceImpl) List(ctx context.Context, cursor string, num int64) ([]entity.Product, string, error) {
-	// Convertir a ProductFilter
+	// El filtro de visibilidad va en la consulta para que LIMIT cuente solo los visibles
+	now := time.Now()
 	filter := entity.ProductFilter{
-		Limit:  int(num),
-		Offset: 0,
+		VisibleAt: &now,
+		Limit:     int(num),
+		Offset:    0,
 	}
 
 	products, err := s.repo.List(ctx, filter)
@@ -55,3 +102,108 @@ func (s *productServiceImpl) List(ctx context.Context, cursor string, num int64)
 	// Por ahora no implementamos cursor real, devolvemos vacío
 	return products, "", nil
 }
+
+func (s *productServiceImpl) GetVisibleByID(ctx context.Context, id int64) (*entity.Product, error) {
+	product, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsVisible(time.Now()) {
+		return nil, errors.ErrNotFound
+	}
+	return &product, nil
+}
+
+func (s *productServiceImpl) GetVisibleByBarCode(ctx context.Context, code string) (*entity.Product, error) {
+	code, err := barcode.Normalize(code)
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.repo.GetByBarCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsVisible(time.Now()) {
+		return nil, errors.ErrNotFound
+	}
+	return &product, nil
+}
+
+func (s *productServiceImpl) GenerateBarCodes(ctx context.Context, n int) ([]string, error) {
+	if n <= 0 || n > maxGeneratedBarCodes {
+		return nil, errors.ErrInvalidInput
+	}
+	// Se continúa después del mayor código interno en uso. Dos pedidos simultáneos pueden
+	// recibir los mismos códigos; el índice único rechaza el segundo alta con ErrConflict.
+	last, err := s.repo.MaxBarCode(ctx, barcode.InternalPrefix)
+	if err != nil {
+		return nil, err
+	}
+	seq, _ := barcode.InternalSeq(last)
+	codes := make([]string, 0, n)
+	for i := 1; i <= n; i++ {
+		code, err := barcode.Internal(seq + int64(i))
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+func (s *productServiceImpl) Search(ctx context.Context, filter entity.ProductFilter) ([]entity.Product, error) {
+	return s.repo.List(ctx, filter)
+}
+
+func (s *productServiceImpl) ChangeStatus(ctx context.Context, id int64, status entity.ProductStatus, publishAt, unpublishAt *time.Time) (*entity.Product, error) {
+	if !status.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+
+	product, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Publicar con una fecha futura programa la publicación: el producto no se ve
+	// hasta publish_at
+	now := time.Now()
+	if status == entity.ProductStatusPublished && publishAt != nil && publishAt.After(now) {
+		status = entity.ProductStatusScheduled
+	}
+	if product.Status != status && !product.Status.CanTransitionTo(status) {
+		return nil, errors.ErrInvalidTransition
+	}
+
+	if status == entity.ProductStatusPublished && publishAt == nil {
+		publishAt = &now
+	}
+	if err := validateSchedule(status, publishAt, unpublishAt, now); err != nil {
+		return nil, err
+	}
+
+	product.Status = status
+	product.PublishAt = publishAt
+	product.UnpublishAt = unpublishAt
+	if err := s.repo.UpdateStatus(ctx, &product); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// validateSchedule verifica que las fechas sean coherentes con el estado
+func validateSchedule(status entity.ProductStatus, publishAt, unpublishAt *time.Time, now time.Time) error {
+	if status == entity.ProductStatusScheduled && (publishAt == nil || !publishAt.After(now)) {
+		return errors.ErrInvalidInput
+	}
+	if unpublishAt != nil {
+		start := now
+		if publishAt != nil && publishAt.After(now) {
+			start = *publishAt
+		}
+		if !unpublishAt.After(start) {
+			return errors.ErrInvalidInput
+		}
+	}
+	return nil
+}
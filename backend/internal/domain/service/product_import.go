@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+// SheetReader lee las filas de una planilla; la primera fila es el encabezado
+type SheetReader interface {
+	Read(format entity.FileFormat, data []byte) ([][]string, error)
+}
+
+// ProductValidator valida un producto con las reglas del alta por la API. Retorna un
+// errors.ValidationError con los campos inválidos.
+type ProductValidator interface {
+	ValidateProduct(p entity.Product) error
+}
+
+// ProductImportService importa productos desde planillas CSV o XLSX en segundo plano.
+// Cada fila se valida con las mismas reglas que el alta de productos y se da de alta o
+// actualiza el producto con el mismo código de barras.
+type ProductImportService interface {
+	// Submit valida el archivo y el mapeo de columnas y encola la importación. Falla con
+	// ErrInvalidFile si la planilla no se puede leer y con ErrInvalidInput si no tiene
+	// columna bar_code, el mapeo es inválido o supera el máximo de filas.
+	Submit(ctx context.Context, imp *entity.ProductImport, data []byte) (*entity.ProductImport, error)
+	// GetByID retorna la importación con su avance y los errores por fila
+	GetByID(ctx context.Context, id int64) (*entity.ProductImport, error)
+	List(ctx context.Context, filter entity.ProductImportFilter) ([]entity.ProductImport, error)
+	// ProcessPending procesa las importaciones en cola y retorna cuántas terminó
+	ProcessPending(ctx context.Context) (int, error)
+}
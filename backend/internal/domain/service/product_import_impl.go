@@ -0,0 +1,460 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"core/internal/domain/barcode"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+)
+
+type productImportServiceImpl struct {
+	repo       repository.ProductImportRepository
+	products   repository.ProductRepository
+	productSvc ProductService
+	reader     SheetReader
+	validator  ProductValidator
+}
+
+// NewProductImportService valida cada fila con validator y usa el servicio de productos
+// para las altas y modificaciones, así pasa por las mismas validaciones que la API
+func NewProductImportService(repo repository.ProductImportRepository, products repository.ProductRepository, productSvc ProductService, reader SheetReader, validator ProductValidator) ProductImportService {
+	return &productImportServiceImpl{repo: repo, products: products, productSvc: productSvc, reader: reader, validator: validator}
+}
+
+// MaxImportFileSize es el tamaño máximo de la planilla subida
+const MaxImportFileSize = 10 << 20
+
+const (
+	maxImportRows = 10000
+	// Se guardan los primeros errores; el resto solo suma en Failed
+	maxImportErrors = 1000
+	// Cada cuántas filas se guarda el avance
+	importProgressEvery = 100
+	// Una importación que corre sin avanzar este tiempo se considera cortada y se retoma
+	importStaleAfter = 10 * time.Minute
+)
+
+func (s *productImportServiceImpl) Submit(ctx context.Context, imp *entity.ProductImport, data []byte) (*entity.ProductImport, error) {
+	if !imp.Format.IsSpreadsheet() || len(data) == 0 || len(data) > MaxImportFileSize {
+		return nil, errors.ErrInvalidInput
+	}
+	rows, err := s.reader.Read(imp.Format, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.ErrInvalidInput
+	}
+	if _, err := importColumns(rows[0], imp.Mapping); err != nil {
+		return nil, err
+	}
+	total := 0
+	for _, row := range rows[1:] {
+		if !blankRow(row) {
+			total++
+		}
+	}
+	if total == 0 || total > maxImportRows {
+		return nil, errors.ErrInvalidInput
+	}
+
+	imp.Status = entity.ProductImportStatusQueued
+	imp.TotalRows = total
+	if err := s.repo.Create(ctx, imp, data); err != nil {
+		return nil, err
+	}
+	log.Printf("[IMPORT] Product import %d queued: %s, %d rows, dry run %t", imp.ID, imp.Filename, total, imp.DryRun)
+	return imp, nil
+}
+
+func (s *productImportServiceImpl) GetByID(ctx context.Context, id int64) (*entity.ProductImport, error) {
+	imp, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &imp, nil
+}
+
+func (s *productImportServiceImpl) List(ctx context.Context, filter entity.ProductImportFilter) ([]entity.ProductImport, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	return s.repo.List(ctx, filter)
+}
+
+func (s *productImportServiceImpl) ProcessPending(ctx context.Context) (int, error) {
+	done := 0
+	for ctx.Err() == nil {
+		imp, data, err := s.repo.Claim(ctx, time.Now().Add(-importStaleAfter))
+		if err == errors.ErrNotFound {
+			return done, nil
+		}
+		if err != nil {
+			return done, err
+		}
+		// Si se corta a mitad de camino la importación queda corriendo y se retoma cuando
+		// se considera cortada
+		if err := s.process(ctx, &imp, data); err != nil {
+			return done, fmt.Errorf("product import %d: %w", imp.ID, err)
+		}
+		done++
+	}
+	return done, nil
+}
+
+func (s *productImportServiceImpl) process(ctx context.Context, imp *entity.ProductImport, data []byte) error {
+	rows, err := s.reader.Read(imp.Format, data)
+	if err == nil && len(rows) == 0 {
+		err = errors.ErrInvalidInput
+	}
+	var cols map[string]int
+	if err == nil {
+		cols, err = importColumns(rows[0], imp.Mapping)
+	}
+	if err != nil {
+		log.Printf("[IMPORT] Product import %d failed: %v", imp.ID, err)
+		return s.repo.Finish(ctx, imp.ID, entity.ProductImportStatusFailed, err.Error())
+	}
+
+	var progress entity.ProductImportProgress
+	var pending []entity.ImportRowError
+	stored := 0
+	seen := make(map[string]int)
+	for i, row := range rows[1:] {
+		if blankRow(row) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		created, rowErrors, err := s.importProductRow(ctx, imp.DryRun, importRow{num: i + 2, cells: row, cols: cols}, seen)
+		if err != nil {
+			return err
+		}
+		progress.ProcessedRows++
+		switch {
+		case len(rowErrors) > 0:
+			progress.Failed++
+			for _, e := range rowErrors {
+				if stored < maxImportErrors {
+					pending = append(pending, e)
+					stored++
+				}
+			}
+		case created:
+			progress.Created++
+		default:
+			progress.Updated++
+		}
+		if progress.ProcessedRows%importProgressEvery == 0 {
+			if err := s.repo.SaveProgress(ctx, imp.ID, progress, pending); err != nil {
+				return err
+			}
+			pending = nil
+		}
+	}
+	if err := s.repo.SaveProgress(ctx, imp.ID, progress, pending); err != nil {
+		return err
+	}
+	if err := s.repo.Finish(ctx, imp.ID, entity.ProductImportStatusCompleted, ""); err != nil {
+		return err
+	}
+	log.Printf("[IMPORT] Product import %d completed: %d created, %d updated, %d failed, dry run %t",
+		imp.ID, progress.Created, progress.Updated, progress.Failed, imp.DryRun)
+	return nil
+}
+
+// importRow es una fila de datos de la planilla con las columnas de cada campo
+type importRow struct {
+	num   int
+	cells []string
+	cols  map[string]int
+}
+
+// get retorna la celda del campo sin espacios y si la planilla tiene esa columna
+func (r importRow) get(field string) (string, bool) {
+	i, ok := r.cols[field]
+	if !ok {
+		return "", false
+	}
+	if i >= len(r.cells) {
+		return "", true
+	}
+	return strings.TrimSpace(r.cells[i]), true
+}
+
+func (r importRow) fail(field, code, msg string) entity.ImportRowError {
+	if len(code) > 32 {
+		code = code[:32]
+	}
+	return entity.ImportRowError{Row: r.num, Column: field, BarCode: code, Message: msg}
+}
+
+// importProductRow da de alta o actualiza el producto de la fila. Solo retorna error si no se
+// puede seguir con la importación; los rechazos de la fila vuelven en rowErrors.
+func (s *productImportServiceImpl) importProductRow(ctx context.Context, dryRun bool, row importRow, seen map[string]int) (created bool, rowErrors []entity.ImportRowError, err error) {
+	raw, _ := row.get(entity.ImportFieldBarCode)
+	if raw == "" {
+		return false, []entity.ImportRowError{row.fail(entity.ImportFieldBarCode, "", "bar_code is required")}, nil
+	}
+	code, err := barcode.Normalize(raw)
+	if err != nil {
+		return false, []entity.ImportRowError{row.fail(entity.ImportFieldBarCode, raw, err.Error())}, nil
+	}
+	if first, ok := seen[code]; ok {
+		return false, []entity.ImportRowError{row.fail(entity.ImportFieldBarCode, code, fmt.Sprintf("bar_code already used in row %d", first))}, nil
+	}
+	seen[code] = row.num
+
+	current, err := s.products.GetByBarCode(ctx, code)
+	switch {
+	case err == errors.ErrNotFound:
+		created = true
+	case err != nil:
+		return false, nil, err
+	}
+
+	// Una fila nueva necesita los mismos campos que el alta; en una existente las
+	// celdas vacías conservan el valor actual
+	p := &current
+	if created {
+		p = &entity.Product{BarCode: code}
+		if _, err := barcode.Parse(code); err != nil {
+			return false, []entity.ImportRowError{row.fail(entity.ImportFieldBarCode, code, err.Error())}, nil
+		}
+	}
+	rowErrors = applyImportRow(p, row, created)
+	if err := s.validator.ValidateProduct(*p); err != nil {
+		rowErrors = append(rowErrors, validationRowErrors(err, row, p.BarCode, created, rowErrors)...)
+	}
+	if len(rowErrors) > 0 {
+		return false, rowErrors, nil
+	}
+	if created && (p.PublishAt != nil || p.UnpublishAt != nil) {
+		status := entity.ProductStatusDraft
+		if p.PublishAt != nil {
+			status = entity.ProductStatusScheduled
+		}
+		if err := validateSchedule(status, p.PublishAt, p.UnpublishAt, time.Now()); err != nil {
+			return false, []entity.ImportRowError{row.fail(entity.ImportFieldPublishAt, code, "publish_at must be in the future and before unpublish_at")}, nil
+		}
+	}
+	if dryRun {
+		return created, nil, nil
+	}
+
+	if created {
+		_, err = s.productSvc.Create(ctx, p)
+	} else {
+		_, err = s.productSvc.Update(ctx, p)
+	}
+	switch err {
+	case nil:
+		return created, nil, nil
+	case errors.ErrConflict:
+		// Otro alta con el mismo código entre la búsqueda y el alta
+		return false, []entity.ImportRowError{row.fail(entity.ImportFieldBarCode, code, "bar_code already exists")}, nil
+	case errors.ErrInvalidInput, errors.ErrInsufficientStock, barcode.ErrInvalidBarCode:
+		return false, []entity.ImportRowError{row.fail("", code, err.Error())}, nil
+	}
+	if ctx.Err() != nil {
+		return false, nil, ctx.Err()
+	}
+	log.Printf("[IMPORT] Row %d (%s) could not be saved: %v", row.num, code, err)
+	return false, []entity.ImportRowError{row.fail("", code, "product could not be saved")}, nil
+}
+
+// applyImportRow copia al producto las celdas con valor y retorna un error por cada
+// celda que no se puede interpretar. Las reglas de cada campo las aplica después el
+// validador de la API. Las fechas de publicación solo se toman en las altas; los
+// productos existentes cambian de estado por su propio endpoint.
+func applyImportRow(p *entity.Product, row importRow, isNew bool) []entity.ImportRowError {
+	var out []entity.ImportRowError
+	fail := func(field, msg string) { out = append(out, row.fail(field, p.BarCode, msg)) }
+	value := func(field string) (string, bool) {
+		v, _ := row.get(field)
+		return v, v != ""
+	}
+
+	if v, ok := value(entity.ImportFieldTitle); ok {
+		p.Title = v
+	}
+	if v, ok := value(entity.ImportFieldDescription); ok {
+		p.Description = v
+	}
+	if v, ok := value(entity.ImportFieldCategory); ok {
+		p.Category = v
+	}
+	if v, ok := value(entity.ImportFieldSize); ok {
+		p.Size = strings.ToUpper(v)
+	}
+	if v, ok := value(entity.ImportFieldStock); ok {
+		if n, err := parseImportInt(v); err == nil {
+			p.Stock = n
+		} else {
+			fail(entity.ImportFieldStock, "stock must be a whole number")
+		}
+	}
+	if v, ok := value(entity.ImportFieldWeightGrams); ok {
+		if n, err := parseImportInt(v); err == nil {
+			p.WeightGrams = n
+		} else {
+			fail(entity.ImportFieldWeightGrams, "weight_grams must be a whole number")
+		}
+	}
+	if v, ok := value(entity.ImportFieldUnitPrice); ok {
+		// Los productos existentes conservan la moneda de su precio
+		currency := money.Base
+		if !isNew && p.UnitPrice.Currency().IsValid() {
+			currency = p.UnitPrice.Currency()
+		}
+		if price, err := parseImportPrice(v, currency); err == nil {
+			p.UnitPrice = price
+		} else {
+			fail(entity.ImportFieldUnitPrice, "unit_price must be an amount with at most the currency decimals")
+		}
+	}
+	if !isNew {
+		return out
+	}
+	if v, ok := value(entity.ImportFieldPublishAt); ok {
+		if t, err := parseImportTime(v); err == nil {
+			p.PublishAt = &t
+		} else {
+			fail(entity.ImportFieldPublishAt, "publish_at must be a date")
+		}
+	}
+	if v, ok := value(entity.ImportFieldUnpublishAt); ok {
+		if t, err := parseImportTime(v); err == nil {
+			p.UnpublishAt = &t
+		} else {
+			fail(entity.ImportFieldUnpublishAt, "unpublish_at must be a date")
+		}
+	}
+	return out
+}
+
+// validationRowErrors convierte los campos que rechazó el validador en errores de la
+// fila. Se omiten los campos que ya fallaron al interpretar la celda y, en productos
+// existentes, los que la fila no cambia: un dato viejo fuera de regla no frena la fila.
+func validationRowErrors(err error, row importRow, code string, isNew bool, failed []entity.ImportRowError) []entity.ImportRowError {
+	ve, ok := err.(errors.ValidationError)
+	if !ok {
+		return []entity.ImportRowError{row.fail("", code, err.Error())}
+	}
+	var out []entity.ImportRowError
+	for _, f := range ve {
+		if slices.ContainsFunc(failed, func(e entity.ImportRowError) bool { return e.Column == f.Field }) {
+			continue
+		}
+		if v, _ := row.get(f.Field); !isNew && v == "" {
+			continue
+		}
+		out = append(out, row.fail(f.Field, code, f.Message))
+	}
+	return out
+}
+
+// importColumns resuelve la columna de cada campo. Los encabezados se comparan sin
+// distinguir mayúsculas ni espacios; el mapeo tiene prioridad sobre el nombre del campo.
+func importColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	known := make(map[string]bool, len(entity.ImportFields))
+	for _, f := range entity.ImportFields {
+		known[f] = true
+	}
+	targets := make(map[string]string, len(mapping))
+	for from, to := range mapping {
+		to = normalizeHeader(to)
+		if !known[to] {
+			return nil, errors.ErrInvalidInput
+		}
+		targets[normalizeHeader(from)] = to
+	}
+
+	cols := make(map[string]int)
+	for i, h := range header {
+		key := normalizeHeader(h)
+		field, ok := targets[key]
+		if !ok && known[key] {
+			field = key
+		}
+		if field == "" {
+			continue
+		}
+		if _, dup := cols[field]; dup {
+			return nil, errors.ErrInvalidInput
+		}
+		cols[field] = i
+	}
+	if _, ok := cols[entity.ImportFieldBarCode]; !ok {
+		return nil, errors.ErrInvalidInput
+	}
+	return cols, nil
+}
+
+func normalizeHeader(s string) string {
+	s = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(s, "\ufeff")))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(s)
+}
+
+func blankRow(row []string) bool {
+	for _, c := range row {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseImportInt acepta enteros escritos como decimales sin fracción ("50.0"), como
+// los guarda Excel
+func parseImportInt(s string) (int64, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+		return 0, errors.ErrInvalidInput
+	}
+	return int64(f), nil
+}
+
+// parseImportPrice acepta la coma como separador decimal si no hay punto ("2500,50")
+func parseImportPrice(s string, c money.Currency) (money.Money, error) {
+	if strings.Count(s, ",") == 1 && !strings.Contains(s, ".") {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	return money.Parse(s, c, money.RoundUnnecessary)
+}
+
+var importTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02", "02/01/2006 15:04", "02/01/2006"}
+
+// parseImportTime acepta RFC 3339, fechas locales y el número de serie con que Excel
+// guarda las fechas
+func parseImportTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	serial, err := strconv.ParseFloat(s, 64)
+	if err != nil || serial < 1 || serial > 2958465 {
+		return time.Time{}, errors.ErrInvalidInput
+	}
+	days := math.Floor(serial)
+	secs := math.Round((serial - days) * 86400)
+	return time.Date(1899, 12, 30, 0, 0, int(secs), 0, time.Local).AddDate(0, 0, int(days)), nil
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type PromotionService interface {
+	Create(ctx context.Context, p *entity.Promotion) (*entity.Promotion, error)
+	GetByID(ctx context.Context, id int64) (*entity.Promotion, error)
+	// Update reemplaza la configuración completa de la promoción
+	Update(ctx context.Context, p *entity.Promotion) (*entity.Promotion, error)
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, filter entity.PromotionFilter) ([]entity.Promotion, error)
+
+	// EvaluateCart aplica las promociones vigentes y los cupones del carrito y explica el resultado
+	EvaluateCart(ctx context.Context, cart entity.Cart) (entity.PromotionResult, error)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/promotion"
+	"core/internal/domain/repository"
+	"time"
+)
+
+type promotionServiceImpl struct {
+	repo repository.PromotionRepository
+}
+
+func NewPromotionService(repo repository.PromotionRepository) PromotionService {
+	return &promotionServiceImpl{repo: repo}
+}
+
+func (s *promotionServiceImpl) Create(ctx context.Context, p *entity.Promotion) (*entity.Promotion, error) {
+	if err := validatePromotion(p); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *promotionServiceImpl) GetByID(ctx context.Context, id int64) (*entity.Promotion, error) {
+	p, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (s *promotionServiceImpl) Update(ctx context.Context, p *entity.Promotion) (*entity.Promotion, error) {
+	current, err := s.repo.GetByID(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePromotion(p); err != nil {
+		return nil, err
+	}
+	p.CreatedAt = current.CreatedAt
+	if err := s.repo.Update(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *promotionServiceImpl) Delete(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *promotionServiceImpl) List(ctx context.Context, filter entity.PromotionFilter) ([]entity.Promotion, error) {
+	filter.Code = entity.NormalizeCouponCode(filter.Code)
+	return s.repo.List(ctx, filter)
+}
+
+func (s *promotionServiceImpl) EvaluateCart(ctx context.Context, cart entity.Cart) (entity.PromotionResult, error) {
+	codes := make([]string, 0, len(cart.Codes))
+	seen := map[string]bool{}
+	for _, c := range cart.Codes {
+		if c = entity.NormalizeCouponCode(c); c != "" && !seen[c] {
+			seen[c] = true
+			codes = append(codes, c)
+		}
+	}
+	cart.Codes = codes
+
+	now := time.Now()
+	promos, err := s.repo.FindForCart(ctx, codes, now)
+	if err != nil {
+		return entity.PromotionResult{}, err
+	}
+	ids := make([]int64, 0, len(promos))
+	for _, p := range promos {
+		if p.UsageLimit != nil || p.PerCustomerLimit != nil {
+			ids = append(ids, p.ID)
+		}
+	}
+	usage, err := s.repo.Usage(ctx, ids, cart.UserID)
+	if err != nil {
+		return entity.PromotionResult{}, err
+	}
+	return promotion.Evaluate(cart, promos, usage, now)
+}
+
+// validatePromotion normaliza la promoción y verifica que su configuración sea coherente con el tipo
+func validatePromotion(p *entity.Promotion) error {
+	if p.Code != nil {
+		code := entity.NormalizeCouponCode(*p.Code)
+		p.Code = &code
+		if code == "" {
+			p.Code = nil
+		}
+	}
+	if p.Currency == "" {
+		p.Currency = money.Base
+	}
+	if p.Name == "" || !p.Type.IsValid() || !p.Currency.IsValid() {
+		return errors.ErrInvalidInput
+	}
+
+	switch p.Type {
+	case entity.PromotionTypePercentage:
+		if p.PercentOff < 1 || p.PercentOff > 100 || p.AmountOff != nil || p.BuyQuantity != 0 || p.GetQuantity != 0 {
+			return errors.ErrInvalidInput
+		}
+	case entity.PromotionTypeFixed:
+		if p.AmountOff == nil || !p.AmountOff.IsPositive() || p.PercentOff != 0 || p.BuyQuantity != 0 || p.GetQuantity != 0 {
+			return errors.ErrInvalidInput
+		}
+	case entity.PromotionTypeBuyXGetY:
+		if p.BuyQuantity < 1 || p.GetQuantity < 1 || p.PercentOff != 0 || p.AmountOff != nil {
+			return errors.ErrInvalidInput
+		}
+	}
+
+	if p.AmountOff != nil && p.AmountOff.Currency() != p.Currency {
+		return errors.ErrInvalidInput
+	}
+	if p.MinSubtotal != nil && (p.MinSubtotal.Currency() != p.Currency || !p.MinSubtotal.IsPositive()) {
+		return errors.ErrInvalidInput
+	}
+	if p.UsageLimit != nil && *p.UsageLimit < 1 || p.PerCustomerLimit != nil && *p.PerCustomerLimit < 1 {
+		return errors.ErrInvalidInput
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return errors.ErrInvalidInput
+	}
+	return nil
+}
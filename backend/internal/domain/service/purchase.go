@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type PurchaseService interface {
+	CreateSupplier(ctx context.Context, s *entity.Supplier) (*entity.Supplier, error)
+	UpdateSupplier(ctx context.Context, s *entity.Supplier) (*entity.Supplier, error)
+	GetSupplier(ctx context.Context, id int64) (*entity.Supplier, error)
+	ListSuppliers(ctx context.Context) ([]entity.Supplier, error)
+
+	// CreateOrder crea la orden en borrador con el costo pactado de cada línea
+	CreateOrder(ctx context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error)
+	GetOrder(ctx context.Context, id int64) (*entity.PurchaseOrder, error)
+	ListOrders(ctx context.Context, filter entity.PurchaseOrderFilter) ([]entity.PurchaseOrder, error)
+	// UpdateOrderStatus envía la orden al proveedor o la cancela
+	UpdateOrderStatus(ctx context.Context, id int64, status entity.PurchaseOrderStatus) (*entity.PurchaseOrder, error)
+	// Receive ingresa una entrega total o parcial al stock y actualiza el costo promedio
+	Receive(ctx context.Context, receipt *entity.PurchaseReceipt) (*entity.PurchaseOrder, error)
+
+	Cost(ctx context.Context, productID int64) (*entity.ProductCost, error)
+	// Margins reporta el margen del precio de lista sobre el costo promedio
+	Margins(ctx context.Context, filter entity.ProductFilter) ([]entity.ProductMargin, error)
+}
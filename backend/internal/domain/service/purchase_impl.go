@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+	"core/internal/domain/tax"
+	"log"
+	"strings"
+)
+
+type purchaseServiceImpl struct {
+	supplierRepo repository.SupplierRepository
+	purchaseRepo repository.PurchaseOrderRepository
+	productRepo  repository.ProductRepository
+}
+
+func NewPurchaseService(supplierRepo repository.SupplierRepository, purchaseRepo repository.PurchaseOrderRepository, productRepo repository.ProductRepository) PurchaseService {
+	return &purchaseServiceImpl{supplierRepo: supplierRepo, purchaseRepo: purchaseRepo, productRepo: productRepo}
+}
+
+// maxPurchaseLines limita las líneas de una orden de compra
+const maxPurchaseLines = 200
+
+func (s *purchaseServiceImpl) normalizeSupplier(sp *entity.Supplier) error {
+	sp.Name = strings.TrimSpace(sp.Name)
+	sp.Email = strings.TrimSpace(sp.Email)
+	sp.Phone = strings.TrimSpace(sp.Phone)
+	sp.Address = strings.TrimSpace(sp.Address)
+	sp.Notes = strings.TrimSpace(sp.Notes)
+	if sp.Name == "" {
+		return errors.ErrInvalidInput
+	}
+	if sp.TaxID = strings.TrimSpace(sp.TaxID); sp.TaxID != "" {
+		id, kind, err := tax.ParseTaxID(sp.TaxID)
+		if err != nil || kind != tax.IDTypeCUIT {
+			return errors.ErrInvalidInput
+		}
+		sp.TaxID = id
+	}
+	return nil
+}
+
+func (s *purchaseServiceImpl) CreateSupplier(ctx context.Context, sp *entity.Supplier) (*entity.Supplier, error) {
+	if err := s.normalizeSupplier(sp); err != nil {
+		return nil, err
+	}
+	if err := s.supplierRepo.Create(ctx, sp); err != nil {
+		return nil, err
+	}
+	return s.GetSupplier(ctx, sp.ID)
+}
+
+func (s *purchaseServiceImpl) UpdateSupplier(ctx context.Context, sp *entity.Supplier) (*entity.Supplier, error) {
+	if err := s.normalizeSupplier(sp); err != nil {
+		return nil, err
+	}
+	if err := s.supplierRepo.Update(ctx, sp); err != nil {
+		return nil, err
+	}
+	return s.GetSupplier(ctx, sp.ID)
+}
+
+func (s *purchaseServiceImpl) GetSupplier(ctx context.Context, id int64) (*entity.Supplier, error) {
+	sp, err := s.supplierRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &sp, nil
+}
+
+func (s *purchaseServiceImpl) ListSuppliers(ctx context.Context) ([]entity.Supplier, error) {
+	return s.supplierRepo.List(ctx)
+}
+
+func (s *purchaseServiceImpl) CreateOrder(ctx context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error) {
+	po.Note = strings.TrimSpace(po.Note)
+	if po.SupplierID <= 0 || po.LocationID < 0 || len(po.Lines) == 0 || len(po.Lines) > maxPurchaseLines {
+		return nil, errors.ErrInvalidInput
+	}
+	supplier, err := s.supplierRepo.GetByID(ctx, po.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	// A un proveedor dado de baja no se le hacen pedidos nuevos
+	if !supplier.Active {
+		return nil, errors.ErrInvalidInput
+	}
+
+	// Los costos se llevan en la moneda base
+	po.Currency = money.Base
+	po.Total = money.Zero(money.Base)
+	seen := map[int64]bool{}
+	for i := range po.Lines {
+		l := &po.Lines[i]
+		if l.ProductID <= 0 || l.Quantity <= 0 || seen[l.ProductID] {
+			return nil, errors.ErrInvalidInput
+		}
+		seen[l.ProductID] = true
+		if l.UnitCost.Currency() != money.Base || l.UnitCost.IsNegative() {
+			return nil, errors.ErrInvalidInput
+		}
+		if _, err := s.productRepo.GetByID(ctx, l.ProductID); err != nil {
+			return nil, err
+		}
+		l.Received = 0
+		if po.Total, err = po.Total.Add(l.UnitCost.Mul(l.Quantity)); err != nil {
+			return nil, err
+		}
+	}
+	po.Status = entity.PurchaseOrderStatusDraft
+	if err := s.purchaseRepo.Create(ctx, po); err != nil {
+		return nil, err
+	}
+	return s.GetOrder(ctx, po.ID)
+}
+
+func (s *purchaseServiceImpl) GetOrder(ctx context.Context, id int64) (*entity.PurchaseOrder, error) {
+	po, err := s.purchaseRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &po, nil
+}
+
+func (s *purchaseServiceImpl) ListOrders(ctx context.Context, filter entity.PurchaseOrderFilter) ([]entity.PurchaseOrder, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	return s.purchaseRepo.List(ctx, filter)
+}
+
+func (s *purchaseServiceImpl) UpdateOrderStatus(ctx context.Context, id int64, status entity.PurchaseOrderStatus) (*entity.PurchaseOrder, error) {
+	if !status.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	po, err := s.purchaseRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !po.Status.CanTransitionTo(status) {
+		return nil, errors.ErrInvalidTransition
+	}
+	if err := s.purchaseRepo.UpdateStatus(ctx, id, po.Status, status); err != nil {
+		return nil, err
+	}
+	return s.GetOrder(ctx, id)
+}
+
+func (s *purchaseServiceImpl) Receive(ctx context.Context, rc *entity.PurchaseReceipt) (*entity.PurchaseOrder, error) {
+	rc.Note = strings.TrimSpace(rc.Note)
+	if len(rc.Lines) == 0 || len(rc.Lines) > maxPurchaseLines {
+		return nil, errors.ErrInvalidInput
+	}
+	seen := map[int64]bool{}
+	for _, l := range rc.Lines {
+		if l.LineID <= 0 || l.Quantity <= 0 || seen[l.LineID] {
+			return nil, errors.ErrInvalidInput
+		}
+		seen[l.LineID] = true
+		if l.UnitCost != nil && (l.UnitCost.Currency() != money.Base || l.UnitCost.IsNegative()) {
+			return nil, errors.ErrInvalidInput
+		}
+	}
+	if err := s.purchaseRepo.Receive(ctx, rc); err != nil {
+		return nil, err
+	}
+	po, err := s.GetOrder(ctx, rc.PurchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	var units int64
+	for _, l := range rc.Lines {
+		units += l.Quantity
+	}
+	log.Printf("[INVENTORY] Purchase order %d: received %d units at %s (receipt %d), now %s",
+		po.ID, units, po.LocationCode, rc.ID, po.Status)
+	return po, nil
+}
+
+func (s *purchaseServiceImpl) Cost(ctx context.Context, productID int64) (*entity.ProductCost, error) {
+	c, err := s.purchaseRepo.Cost(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (s *purchaseServiceImpl) Margins(ctx context.Context, filter entity.ProductFilter) ([]entity.ProductMargin, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	return s.purchaseRepo.Margins(ctx, filter)
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type ReturnService interface {
+	// Create abre una solicitud de devolución o cambio sobre una orden pagada del usuario.
+	// Si la orden tiene envío, tiene que haber sido entregada. Los cambios deben ser otro
+	// talle del mismo artículo.
+	Create(ctx context.Context, userID, orderID int64, comment string, lines []entity.ReturnLine) (*entity.ReturnRequest, error)
+	GetByID(ctx context.Context, id int64) (*entity.ReturnRequest, error)
+	// GetForUser retorna la solicitud solo si pertenece al usuario
+	GetForUser(ctx context.Context, userID, id int64) (*entity.ReturnRequest, error)
+	List(ctx context.Context, filter entity.ReturnFilter) ([]entity.ReturnRequest, error)
+	// Cancel permite al cliente cancelar su solicitud mientras no se haya recibido la mercadería
+	Cancel(ctx context.Context, userID, id int64) (*entity.ReturnRequest, error)
+	// UpdateStatus avanza la solicitud (staff). Al aprobar se reservan los talles de cambio,
+	// al recibir se reingresa el stock y al completar se reembolsa por la pasarela.
+	UpdateStatus(ctx context.Context, id int64, status entity.ReturnStatus, actorID int64, note string) (*entity.ReturnRequest, error)
+}
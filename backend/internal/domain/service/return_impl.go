@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+	"fmt"
+	"log"
+)
+
+type returnServiceImpl struct {
+	returnRepo   repository.ReturnRepository
+	orderRepo    repository.OrderRepository
+	productRepo  repository.ProductRepository
+	shipmentRepo repository.ShipmentRepository
+	payments     PaymentGateway
+}
+
+func NewReturnService(returnRepo repository.ReturnRepository, orderRepo repository.OrderRepository, productRepo repository.ProductRepository, shipmentRepo repository.ShipmentRepository, payments PaymentGateway) ReturnService {
+	return &returnServiceImpl{
+		returnRepo:   returnRepo,
+		orderRepo:    orderRepo,
+		productRepo:  productRepo,
+		shipmentRepo: shipmentRepo,
+		payments:     payments,
+	}
+}
+
+func (s *returnServiceImpl) Create(ctx context.Context, userID, orderID int64, comment string, lines []entity.ReturnLine) (*entity.ReturnRequest, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, errors.ErrNotFound
+	}
+	if order.Status != entity.OrderStatusPaid {
+		return nil, errors.ErrInvalidTransition
+	}
+	// Lo que se envió a domicilio se puede devolver recién cuando llegó
+	if order.Shipping != nil {
+		sh, err := s.shipmentRepo.GetByOrderID(ctx, orderID)
+		if err == errors.ErrNotFound || (err == nil && sh.Status != entity.ShipmentStatusDelivered) {
+			return nil, errors.ErrInvalidTransition
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(lines) == 0 {
+		return nil, errors.ErrInvalidInput
+	}
+
+	rr := &entity.ReturnRequest{
+		OrderID:     order.ID,
+		UserID:      userID,
+		Status:      entity.ReturnStatusRequested,
+		Comment:     comment,
+		Currency:    order.Currency,
+		RefundTotal: money.Zero(order.Currency),
+	}
+	seen := map[int64]bool{}
+	for _, l := range lines {
+		if seen[l.OrderItemID] {
+			return nil, errors.ErrInvalidInput
+		}
+		seen[l.OrderItemID] = true
+
+		item, err := s.returnItem(ctx, &order, l)
+		if err != nil {
+			return nil, err
+		}
+		if rr.RefundTotal, err = rr.RefundTotal.Add(item.RefundAmount); err != nil {
+			return nil, err
+		}
+		rr.Items = append(rr.Items, item)
+	}
+	rr.History = []entity.ReturnStatusChange{{To: entity.ReturnStatusRequested, ActorID: userID, Note: comment}}
+
+	if err := s.returnRepo.Create(ctx, rr); err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, rr.ID)
+}
+
+// returnItem valida una línea pedida por el cliente y congela los datos de la orden
+func (s *returnServiceImpl) returnItem(ctx context.Context, order *entity.Order, l entity.ReturnLine) (entity.ReturnItem, error) {
+	var orderItem *entity.OrderItem
+	for i := range order.Items {
+		if order.Items[i].ID == l.OrderItemID {
+			orderItem = &order.Items[i]
+			break
+		}
+	}
+	if orderItem == nil || l.Quantity <= 0 || l.Quantity > orderItem.Quantity ||
+		!l.Reason.IsValid() || !l.Resolution.IsValid() {
+		return entity.ReturnItem{}, errors.ErrInvalidInput
+	}
+
+	item := entity.ReturnItem{
+		OrderItemID:  orderItem.ID,
+		ProductID:    orderItem.ProductID,
+		Title:        orderItem.Title,
+		Size:         orderItem.Size,
+		Quantity:     l.Quantity,
+		Reason:       l.Reason,
+		Resolution:   l.Resolution,
+		RefundAmount: money.Zero(order.Currency),
+	}
+	switch l.Resolution {
+	case entity.ReturnResolutionRefund:
+		// Se reintegra lo efectivamente pagado por las unidades: neto con descuentos más IVA
+		paid, err := orderItem.NetAmount.Add(orderItem.TaxAmount)
+		if err != nil {
+			return entity.ReturnItem{}, err
+		}
+		if item.RefundAmount, err = paid.MulFrac(l.Quantity, orderItem.Quantity, money.RoundHalfEven); err != nil {
+			return entity.ReturnItem{}, err
+		}
+	case entity.ReturnResolutionExchange:
+		// Un cambio es otro talle del mismo artículo
+		if l.ExchangeProductID <= 0 || l.ExchangeProductID == orderItem.ProductID {
+			return entity.ReturnItem{}, errors.ErrInvalidInput
+		}
+		p, err := s.productRepo.GetByID(ctx, l.ExchangeProductID)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return entity.ReturnItem{}, errors.ErrInvalidInput
+			}
+			return entity.ReturnItem{}, err
+		}
+		if p.Title != orderItem.Title || p.Category != orderItem.Category || p.Size == orderItem.Size {
+			return entity.ReturnItem{}, errors.ErrInvalidInput
+		}
+		item.ExchangeProductID = p.ID
+		item.ExchangeSize = p.Size
+	}
+	return item, nil
+}
+
+func (s *returnServiceImpl) GetByID(ctx context.Context, id int64) (*entity.ReturnRequest, error) {
+	rr, err := s.returnRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &rr, nil
+}
+
+func (s *returnServiceImpl) GetForUser(ctx context.Context, userID, id int64) (*entity.ReturnRequest, error) {
+	rr, err := s.returnRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rr.UserID != userID {
+		return nil, errors.ErrNotFound
+	}
+	return &rr, nil
+}
+
+func (s *returnServiceImpl) List(ctx context.Context, filter entity.ReturnFilter) ([]entity.ReturnRequest, error) {
+	return s.returnRepo.List(ctx, filter)
+}
+
+func (s *returnServiceImpl) Cancel(ctx context.Context, userID, id int64) (*entity.ReturnRequest, error) {
+	if _, err := s.GetForUser(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	return s.UpdateStatus(ctx, id, entity.ReturnStatusCancelled, userID, "Cancelada por el cliente")
+}
+
+func (s *returnServiceImpl) UpdateStatus(ctx context.Context, id int64, status entity.ReturnStatus, actorID int64, note string) (*entity.ReturnRequest, error) {
+	rr, err := s.returnRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !rr.Status.CanTransitionTo(status) {
+		return nil, errors.ErrInvalidTransition
+	}
+	change := &entity.ReturnStatusChange{ReturnID: rr.ID, From: rr.Status, To: status, ActorID: actorID, Note: note}
+	ref := entity.StockMovementRef{Kind: entity.StockMovementReturn, Reference: fmt.Sprintf("return:%d", rr.ID), ActorID: actorID}
+
+	switch status {
+	case entity.ReturnStatusApproved:
+		// El talle de cambio se reserva antes de aprobar; si la transición falla se libera
+		if err := s.reserveExchanges(ctx, &rr, ref); err != nil {
+			return nil, err
+		}
+		if err := s.returnRepo.Transition(ctx, change); err != nil {
+			s.releaseExchanges(ctx, &rr, ref)
+			return nil, err
+		}
+	case entity.ReturnStatusCancelled:
+		if err := s.returnRepo.Transition(ctx, change); err != nil {
+			return nil, err
+		}
+		if rr.Status == entity.ReturnStatusApproved {
+			s.releaseExchanges(ctx, &rr, ref)
+		}
+	case entity.ReturnStatusReceived:
+		// La transición es condicional al estado leído, así dos recepciones no reingresan el stock dos veces
+		if err := s.returnRepo.Transition(ctx, change); err != nil {
+			return nil, err
+		}
+		s.restock(ctx, &rr, ref)
+	case entity.ReturnStatusCompleted:
+		if err := s.refund(ctx, &rr); err != nil {
+			return nil, err
+		}
+		if err := s.returnRepo.Transition(ctx, change); err != nil {
+			return nil, err
+		}
+	default:
+		if err := s.returnRepo.Transition(ctx, change); err != nil {
+			return nil, err
+		}
+	}
+	return s.GetByID(ctx, id)
+}
+
+func (s *returnServiceImpl) reserveExchanges(ctx context.Context, rr *entity.ReturnRequest, ref entity.StockMovementRef) error {
+	ref.Reason = "Reserva de talle para cambio"
+	for i, it := range rr.Items {
+		if it.Resolution != entity.ReturnResolutionExchange {
+			continue
+		}
+		if _, err := s.productRepo.UpdateStock(ctx, it.ExchangeProductID, -it.Quantity, ref); err != nil {
+			s.releaseExchanges(ctx, &entity.ReturnRequest{ID: rr.ID, Items: rr.Items[:i]}, ref)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *returnServiceImpl) releaseExchanges(ctx context.Context, rr *entity.ReturnRequest, ref entity.StockMovementRef) {
+	ctx = context.WithoutCancel(ctx)
+	ref.Reason = "Liberación de talle para cambio"
+	for _, it := range rr.Items {
+		if it.Resolution != entity.ReturnResolutionExchange {
+			continue
+		}
+		if _, err := s.productRepo.UpdateStock(ctx, it.ExchangeProductID, it.Quantity, ref); err != nil {
+			log.Printf("[RETURN] failed to release exchange stock of product %d (+%d) for return %d: %v", it.ExchangeProductID, it.Quantity, rr.ID, err)
+		}
+	}
+}
+
+// restock reingresa las unidades recibidas; las que vinieron falladas no vuelven a la venta
+func (s *returnServiceImpl) restock(ctx context.Context, rr *entity.ReturnRequest, ref entity.StockMovementRef) {
+	ctx = context.WithoutCancel(ctx)
+	ref.Reason = "Devolución recibida"
+	for _, it := range rr.Items {
+		if !it.Restocks() {
+			continue
+		}
+		if _, err := s.productRepo.UpdateStock(ctx, it.ProductID, it.Quantity, ref); err != nil {
+			log.Printf("[RETURN] failed to restock product %d (+%d) for return %d: %v", it.ProductID, it.Quantity, rr.ID, err)
+		}
+	}
+}
+
+// refund reintegra el total de las líneas con reembolso. La clave de idempotencia es
+// la solicitud, así completar de nuevo tras un error no reembolsa dos veces.
+func (s *returnServiceImpl) refund(ctx context.Context, rr *entity.ReturnRequest) error {
+	if !rr.RefundTotal.IsPositive() || rr.RefundReference != "" {
+		return nil
+	}
+	order, err := s.orderRepo.GetByID(ctx, rr.OrderID)
+	if err != nil {
+		return err
+	}
+	refund, err := s.payments.Refund(ctx, &order, rr.RefundTotal, fmt.Sprintf("return-%d", rr.ID))
+	if err != nil {
+		return fmt.Errorf("refund of return %d failed: %w", rr.ID, err)
+	}
+	return s.returnRepo.SetRefund(ctx, rr.ID, refund)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+// Carrier es una empresa de envíos. Un error en Track indica que no se pudo consultar y
+// se reintenta en la próxima pasada.
+type Carrier interface {
+	// Name identifica al carrier en los envíos y en la URL de su webhook
+	Name() string
+	// CreateLabel da de alta el envío y retorna el número de seguimiento y la etiqueta
+	CreateLabel(ctx context.Context, shipment *entity.Shipment) (entity.CarrierLabel, error)
+	// Track retorna los eventos de seguimiento conocidos del envío
+	Track(ctx context.Context, trackingNumber string) ([]entity.TrackingEvent, error)
+	// ParseWebhook verifica la firma de una notificación del carrier y la decodifica.
+	// Falla con ErrInvalidToken si la firma no es válida.
+	ParseWebhook(payload []byte, signature string) (trackingNumber string, events []entity.TrackingEvent, err error)
+}
+
+type ShipmentService interface {
+	// CreateForOrder genera la etiqueta de una orden pagada con envío a domicilio.
+	// Retorna ErrConflict si la orden ya fue despachada.
+	CreateForOrder(ctx context.Context, orderID int64) (*entity.Shipment, error)
+	GetByID(ctx context.Context, id int64) (*entity.Shipment, error)
+	// GetForOrder retorna el seguimiento del envío de una orden del usuario
+	GetForOrder(ctx context.Context, userID, orderID int64) (*entity.Shipment, error)
+	List(ctx context.Context, filter entity.ShipmentFilter) ([]entity.Shipment, error)
+	Label(ctx context.Context, id int64) ([]byte, error)
+
+	// HandleWebhook aplica una notificación de estado enviada por el carrier
+	HandleWebhook(ctx context.Context, carrier string, payload []byte, signature string) error
+	// Refresh consulta al carrier el seguimiento de un envío
+	Refresh(ctx context.Context, id int64) (*entity.Shipment, error)
+	// RefreshOpen consulta los envíos abiertos que no se actualizaron en el último intervalo
+	RefreshOpen(ctx context.Context) (updated int, err error)
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+	"log"
+	"time"
+)
+
+type shipmentServiceImpl struct {
+	shipmentRepo  repository.ShipmentRepository
+	orderRepo     repository.OrderRepository
+	carriers      map[string]Carrier
+	labelCarrier  Carrier
+	checkInterval time.Duration
+}
+
+// NewShipmentService recibe los carriers habilitados; las etiquetas nuevas se generan con
+// el primero. checkInterval es cada cuánto se consulta el seguimiento de un envío abierto.
+func NewShipmentService(shipmentRepo repository.ShipmentRepository, orderRepo repository.OrderRepository, checkInterval time.Duration, carriers ...Carrier) ShipmentService {
+	byName := make(map[string]Carrier, len(carriers))
+	for _, c := range carriers {
+		byName[c.Name()] = c
+	}
+	return &shipmentServiceImpl{
+		shipmentRepo:  shipmentRepo,
+		orderRepo:     orderRepo,
+		carriers:      byName,
+		labelCarrier:  carriers[0],
+		checkInterval: checkInterval,
+	}
+}
+
+func (s *shipmentServiceImpl) CreateForOrder(ctx context.Context, orderID int64) (*entity.Shipment, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != entity.OrderStatusPaid {
+		return nil, errors.ErrInvalidTransition
+	}
+	// Sin envío la orden se retira en el local
+	if order.Shipping == nil {
+		return nil, errors.ErrInvalidInput
+	}
+	if _, err := s.shipmentRepo.GetByOrderID(ctx, orderID); err == nil {
+		return nil, errors.ErrConflict
+	} else if err != errors.ErrNotFound {
+		return nil, err
+	}
+
+	sh := &entity.Shipment{
+		OrderID:     order.ID,
+		Carrier:     s.labelCarrier.Name(),
+		Status:      entity.ShipmentStatusLabelCreated,
+		Address:     order.Shipping.Address,
+		WeightGrams: order.Shipping.WeightGrams,
+	}
+	label, err := s.labelCarrier.CreateLabel(ctx, sh)
+	if err != nil {
+		return nil, err
+	}
+	sh.TrackingNumber, sh.Label = label.TrackingNumber, label.Document
+	if err := s.shipmentRepo.Create(ctx, sh); err != nil {
+		return nil, err
+	}
+	created := entity.TrackingEvent{Status: entity.ShipmentStatusLabelCreated, Description: "Etiqueta generada", OccurredAt: time.Now()}
+	if _, err := s.shipmentRepo.AddEvents(ctx, sh.ID, []entity.TrackingEvent{created}); err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, sh.ID)
+}
+
+func (s *shipmentServiceImpl) GetByID(ctx context.Context, id int64) (*entity.Shipment, error) {
+	sh, err := s.shipmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &sh, nil
+}
+
+func (s *shipmentServiceImpl) GetForOrder(ctx context.Context, userID, orderID int64) (*entity.Shipment, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, errors.ErrNotFound
+	}
+	sh, err := s.shipmentRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return &sh, nil
+}
+
+func (s *shipmentServiceImpl) List(ctx context.Context, filter entity.ShipmentFilter) ([]entity.Shipment, error) {
+	return s.shipmentRepo.List(ctx, filter)
+}
+
+func (s *shipmentServiceImpl) Label(ctx context.Context, id int64) ([]byte, error) {
+	return s.shipmentRepo.Label(ctx, id)
+}
+
+func (s *shipmentServiceImpl) HandleWebhook(ctx context.Context, carrier string, payload []byte, signature string) error {
+	c, ok := s.carriers[carrier]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	trackingNumber, events, err := c.ParseWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+	sh, err := s.shipmentRepo.GetByTrackingNumber(ctx, carrier, trackingNumber)
+	if err != nil {
+		return err
+	}
+	if err := validEvents(events); err != nil {
+		return err
+	}
+	added, err := s.shipmentRepo.AddEvents(ctx, sh.ID, events)
+	if err != nil {
+		return err
+	}
+	if added > 0 {
+		log.Printf("[SHIPMENT] %d new event(s) for shipment %d (%s %s) via webhook", added, sh.ID, carrier, trackingNumber)
+	}
+	return nil
+}
+
+func validEvents(events []entity.TrackingEvent) error {
+	for _, e := range events {
+		if !e.Status.IsValid() || e.OccurredAt.IsZero() {
+			return errors.ErrInvalidInput
+		}
+	}
+	return nil
+}
+
+func (s *shipmentServiceImpl) Refresh(ctx context.Context, id int64) (*entity.Shipment, error) {
+	sh, err := s.shipmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.refresh(ctx, &sh); err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, id)
+}
+
+// refreshBatch es la cantidad de envíos que se consultan por pasada
+const refreshBatch = 50
+
+func (s *shipmentServiceImpl) RefreshOpen(ctx context.Context) (int, error) {
+	open, err := s.shipmentRepo.Open(ctx, time.Now().Add(-s.checkInterval), refreshBatch)
+	if err != nil {
+		return 0, err
+	}
+	updated := 0
+	for i := range open {
+		added, err := s.refresh(ctx, &open[i])
+		if err != nil {
+			// Un carrier caído no frena al resto; el envío se reintenta en la próxima pasada
+			log.Printf("[SHIPMENT] Tracking of shipment %d (%s %s) failed: %v", open[i].ID, open[i].Carrier, open[i].TrackingNumber, err)
+			continue
+		}
+		if added > 0 {
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+// refresh consulta el carrier del envío y guarda los eventos nuevos
+func (s *shipmentServiceImpl) refresh(ctx context.Context, sh *entity.Shipment) (int, error) {
+	c, ok := s.carriers[sh.Carrier]
+	if !ok {
+		return 0, errors.ErrNotFound
+	}
+	events, err := c.Track(ctx, sh.TrackingNumber)
+	if err != nil {
+		return 0, err
+	}
+	if err := validEvents(events); err != nil {
+		return 0, err
+	}
+	added, err := s.shipmentRepo.AddEvents(ctx, sh.ID, events)
+	if err != nil {
+		return 0, err
+	}
+	return added, s.shipmentRepo.MarkChecked(ctx, sh.ID, time.Now())
+}
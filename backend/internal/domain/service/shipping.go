@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type ShippingService interface {
+	CreateZone(ctx context.Context, z *entity.ShippingZone) (*entity.ShippingZone, error)
+	UpdateZone(ctx context.Context, z *entity.ShippingZone) (*entity.ShippingZone, error)
+	DeleteZone(ctx context.Context, id int64) error
+	ListZones(ctx context.Context) ([]entity.ShippingZone, error)
+
+	CreateMethod(ctx context.Context, m *entity.ShippingMethod) (*entity.ShippingMethod, error)
+	GetMethod(ctx context.Context, id int64) (*entity.ShippingMethod, error)
+	// UpdateMethod reemplaza la configuración y la tabla de tarifas del método
+	UpdateMethod(ctx context.Context, m *entity.ShippingMethod) (*entity.ShippingMethod, error)
+	DeleteMethod(ctx context.Context, id int64) error
+	ListMethods(ctx context.Context, activeOnly bool) ([]entity.ShippingMethod, error)
+
+	// Quote cotiza los métodos activos para enviar los ítems de la orden a la provincia, en la
+	// moneda de la orden. El envío gratis se evalúa sobre el total de la orden con descuentos.
+	// Si la orden está en otra moneda y no tiene tasa congelada, congela la vigente.
+	Quote(ctx context.Context, order *entity.Order, province string) ([]entity.ShippingQuote, error)
+}
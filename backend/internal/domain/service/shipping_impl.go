@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+	"strings"
+)
+
+type shippingServiceImpl struct {
+	repo     repository.ShippingRepository
+	currency CurrencyService
+}
+
+func NewShippingService(repo repository.ShippingRepository, currency CurrencyService) ShippingService {
+	return &shippingServiceImpl{repo: repo, currency: currency}
+}
+
+func (s *shippingServiceImpl) CreateZone(ctx context.Context, z *entity.ShippingZone) (*entity.ShippingZone, error) {
+	if err := normalizeZone(z); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateZone(ctx, z); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+func (s *shippingServiceImpl) UpdateZone(ctx context.Context, z *entity.ShippingZone) (*entity.ShippingZone, error) {
+	current, err := s.repo.GetZone(ctx, z.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := normalizeZone(z); err != nil {
+		return nil, err
+	}
+	z.CreatedAt = current.CreatedAt
+	if err := s.repo.UpdateZone(ctx, z); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+func (s *shippingServiceImpl) DeleteZone(ctx context.Context, id int64) error {
+	return s.repo.DeleteZone(ctx, id)
+}
+
+func (s *shippingServiceImpl) ListZones(ctx context.Context) ([]entity.ShippingZone, error) {
+	return s.repo.ListZones(ctx)
+}
+
+// normalizeZone valida el nombre y deja las provincias como códigos ISO sin repetir
+func normalizeZone(z *entity.ShippingZone) error {
+	z.Name = strings.TrimSpace(z.Name)
+	if z.Name == "" || len(z.Provinces) == 0 {
+		return errors.ErrInvalidInput
+	}
+	seen := map[string]bool{}
+	provinces := make([]string, 0, len(z.Provinces))
+	for _, p := range z.Provinces {
+		code := entity.NormalizeProvince(p)
+		if code == "" {
+			return errors.ErrInvalidInput
+		}
+		if !seen[code] {
+			seen[code] = true
+			provinces = append(provinces, code)
+		}
+	}
+	z.Provinces = provinces
+	return nil
+}
+
+func (s *shippingServiceImpl) CreateMethod(ctx context.Context, m *entity.ShippingMethod) (*entity.ShippingMethod, error) {
+	if err := s.validateMethod(ctx, m); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateMethod(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *shippingServiceImpl) GetMethod(ctx context.Context, id int64) (*entity.ShippingMethod, error) {
+	m, err := s.repo.GetMethod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (s *shippingServiceImpl) UpdateMethod(ctx context.Context, m *entity.ShippingMethod) (*entity.ShippingMethod, error) {
+	current, err := s.repo.GetMethod(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateMethod(ctx, m); err != nil {
+		return nil, err
+	}
+	m.CreatedAt = current.CreatedAt
+	if err := s.repo.UpdateMethod(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *shippingServiceImpl) DeleteMethod(ctx context.Context, id int64) error {
+	return s.repo.DeleteMethod(ctx, id)
+}
+
+func (s *shippingServiceImpl) ListMethods(ctx context.Context, activeOnly bool) ([]entity.ShippingMethod, error) {
+	return s.repo.ListMethods(ctx, activeOnly)
+}
+
+// validateMethod exige precios en la moneda base, plazos coherentes y zonas existentes
+func (s *shippingServiceImpl) validateMethod(ctx context.Context, m *entity.ShippingMethod) error {
+	m.Name = strings.TrimSpace(m.Name)
+	m.Description = strings.TrimSpace(m.Description)
+	if m.Name == "" || m.MinDays < 0 || m.MaxDays < m.MinDays {
+		return errors.ErrInvalidInput
+	}
+	if t := m.FreeShippingThreshold; t != nil && (t.Currency() != money.Base || t.IsNegative()) {
+		return errors.ErrInvalidInput
+	}
+
+	zones := map[int64]bool{}
+	for i := range m.Rates {
+		r := &m.Rates[i]
+		if r.MaxWeightGrams <= 0 || r.Price.Currency() != money.Base || r.Price.IsNegative() {
+			return errors.ErrInvalidInput
+		}
+		if !zones[r.ZoneID] {
+			if _, err := s.repo.GetZone(ctx, r.ZoneID); err != nil {
+				if err == errors.ErrNotFound {
+					return errors.ErrInvalidInput
+				}
+				return err
+			}
+			zones[r.ZoneID] = true
+		}
+	}
+	return nil
+}
+
+func (s *shippingServiceImpl) Quote(ctx context.Context, order *entity.Order, province string) ([]entity.ShippingQuote, error) {
+	zone, err := s.repo.ZoneForProvince(ctx, entity.NormalizeProvince(province))
+	if err == errors.ErrNotFound {
+		return []entity.ShippingQuote{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	methods, err := s.repo.ListMethods(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	weight := order.WeightGrams()
+	quotes := []entity.ShippingQuote{}
+	for i := range methods {
+		m := &methods[i]
+		rate, ok := m.RateFor(zone.ID, weight)
+		if !ok {
+			continue
+		}
+		price, err := s.toOrderCurrency(ctx, order, rate.Price)
+		if err != nil {
+			return nil, err
+		}
+		q := entity.ShippingQuote{
+			MethodID:      m.ID,
+			Name:          m.Name,
+			Description:   m.Description,
+			MinDays:       m.MinDays,
+			MaxDays:       m.MaxDays,
+			Price:         price,
+			OriginalPrice: price,
+		}
+		if m.FreeShippingThreshold != nil {
+			threshold, err := s.toOrderCurrency(ctx, order, *m.FreeShippingThreshold)
+			if err != nil {
+				return nil, err
+			}
+			if !order.Total.LessThan(threshold) {
+				q.Price, q.Free = money.Zero(order.Currency), true
+			}
+		}
+		quotes = append(quotes, q)
+	}
+	return quotes, nil
+}
+
+// toOrderCurrency convierte un monto de la moneda base con la tasa congelada en la orden
+func (s *shippingServiceImpl) toOrderCurrency(ctx context.Context, order *entity.Order, m money.Money) (money.Money, error) {
+	if order.Currency == m.Currency() {
+		return m, nil
+	}
+	if order.ExchangeRate == nil {
+		snapshot, err := s.currency.RateFromBase(ctx, order.Currency)
+		if err != nil {
+			return money.Money{}, err
+		}
+		order.ExchangeRate = snapshot
+	}
+	return money.Convert(m, order.Currency, order.ExchangeRate.Rate, money.RoundHalfUp)
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+// StockAlertNotifier entrega las alertas de stock bajo por un canal. Un error deja los
+// productos pendientes y se reintenta en la próxima pasada.
+type StockAlertNotifier interface {
+	// Channel identifica el canal en el registro de alertas enviadas
+	Channel() string
+	NotifyLowStock(ctx context.Context, items []entity.LowStockItem) error
+}
+
+type StockAlertService interface {
+	// GetReorderPoint retorna el umbral del producto; sin umbral, uno en cero
+	GetReorderPoint(ctx context.Context, productID int64) (*entity.ReorderPoint, error)
+	SetReorderPoint(ctx context.Context, rp *entity.ReorderPoint) (*entity.ReorderPoint, error)
+	// LowStock lista los productos que hoy están por debajo del umbral
+	LowStock(ctx context.Context) ([]entity.LowStockItem, error)
+	// Notify avisa por cada canal los productos que bajaron del umbral desde el último
+	// aviso y retorna cuántos avisos se enviaron
+	Notify(ctx context.Context) (int, error)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+	"log"
+)
+
+type stockAlertServiceImpl struct {
+	alertRepo   repository.StockAlertRepository
+	productRepo repository.ProductRepository
+	notifiers   []StockAlertNotifier
+}
+
+// NewStockAlertService recibe los canales habilitados; sin canales solo se listan los productos
+func NewStockAlertService(alertRepo repository.StockAlertRepository, productRepo repository.ProductRepository, notifiers ...StockAlertNotifier) StockAlertService {
+	return &stockAlertServiceImpl{alertRepo: alertRepo, productRepo: productRepo, notifiers: notifiers}
+}
+
+func (s *stockAlertServiceImpl) GetReorderPoint(ctx context.Context, productID int64) (*entity.ReorderPoint, error) {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+	rp, err := s.alertRepo.GetReorderPoint(ctx, productID)
+	if err == errors.ErrNotFound {
+		return &entity.ReorderPoint{ProductID: productID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rp, nil
+}
+
+func (s *stockAlertServiceImpl) SetReorderPoint(ctx context.Context, rp *entity.ReorderPoint) (*entity.ReorderPoint, error) {
+	if rp.Threshold < 0 || rp.ReorderQuantity < 0 {
+		return nil, errors.ErrInvalidInput
+	}
+	if _, err := s.productRepo.GetByID(ctx, rp.ProductID); err != nil {
+		return nil, err
+	}
+	if err := s.alertRepo.SetReorderPoint(ctx, rp); err != nil {
+		return nil, err
+	}
+	return s.GetReorderPoint(ctx, rp.ProductID)
+}
+
+func (s *stockAlertServiceImpl) LowStock(ctx context.Context) ([]entity.LowStockItem, error) {
+	return s.alertRepo.BelowThreshold(ctx)
+}
+
+func (s *stockAlertServiceImpl) Notify(ctx context.Context) (int, error) {
+	if _, err := s.alertRepo.ResolveRecovered(ctx); err != nil {
+		return 0, err
+	}
+	sent := 0
+	for _, n := range s.notifiers {
+		pending, err := s.alertRepo.Pending(ctx, n.Channel())
+		if err != nil {
+			return sent, err
+		}
+		if len(pending) == 0 {
+			continue
+		}
+		// Un canal caído no frena al resto
+		if err := n.NotifyLowStock(ctx, pending); err != nil {
+			log.Printf("[INVENTORY] Low stock alert via %s failed for %d product(s): %v", n.Channel(), len(pending), err)
+			continue
+		}
+		if err := s.alertRepo.MarkNotified(ctx, n.Channel(), pending); err != nil {
+			return sent, err
+		}
+		sent += len(pending)
+	}
+	return sent, nil
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+type StockCountService interface {
+	// Open abre un recuento de la ubicación, opcionalmente limitado a una categoría
+	Open(ctx context.Context, c *entity.StockCount) (*entity.StockCount, error)
+	GetByID(ctx context.Context, id int64) (*entity.StockCount, error)
+	List(ctx context.Context, filter entity.StockCountFilter) ([]entity.StockCount, error)
+	// Scan suma un lote de códigos escaneados al recuento abierto
+	Scan(ctx context.Context, id int64, scans []entity.StockCountScan) (entity.StockCountScanResult, error)
+	// Approve ajusta el stock con las diferencias y cierra el recuento
+	Approve(ctx context.Context, id, actorID int64) (*entity.StockCount, error)
+	Cancel(ctx context.Context, id int64) (*entity.StockCount, error)
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/barcode"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+	"log"
+	"strings"
+)
+
+type stockCountServiceImpl struct {
+	countRepo   repository.StockCountRepository
+	productRepo repository.ProductRepository
+}
+
+func NewStockCountService(countRepo repository.StockCountRepository, productRepo repository.ProductRepository) StockCountService {
+	return &stockCountServiceImpl{countRepo: countRepo, productRepo: productRepo}
+}
+
+// maxScansPerBatch limita el tamaño de un lote del lector
+const maxScansPerBatch = 500
+
+func (s *stockCountServiceImpl) Open(ctx context.Context, c *entity.StockCount) (*entity.StockCount, error) {
+	c.Category = strings.TrimSpace(c.Category)
+	c.Note = strings.TrimSpace(c.Note)
+	if c.LocationID <= 0 {
+		return nil, errors.ErrInvalidInput
+	}
+	// Un recuento sin productos no tiene nada que ajustar
+	if c.Category != "" {
+		products, err := s.productRepo.List(ctx, entity.ProductFilter{Category: c.Category, Limit: 1})
+		if err != nil {
+			return nil, err
+		}
+		if len(products) == 0 {
+			return nil, errors.ErrInvalidInput
+		}
+	}
+	c.Status = entity.StockCountStatusOpen
+	if err := s.countRepo.Create(ctx, c); err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, c.ID)
+}
+
+func (s *stockCountServiceImpl) GetByID(ctx context.Context, id int64) (*entity.StockCount, error) {
+	c, err := s.countRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (s *stockCountServiceImpl) List(ctx context.Context, filter entity.StockCountFilter) ([]entity.StockCount, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	return s.countRepo.List(ctx, filter)
+}
+
+func (s *stockCountServiceImpl) Scan(ctx context.Context, id int64, scans []entity.StockCountScan) (entity.StockCountScanResult, error) {
+	if len(scans) == 0 || len(scans) > maxScansPerBatch {
+		return entity.StockCountScanResult{}, errors.ErrInvalidInput
+	}
+	for i, sc := range scans {
+		code, err := barcode.Normalize(sc.BarCode)
+		if err != nil || sc.Quantity == 0 {
+			return entity.StockCountScanResult{}, errors.ErrInvalidInput
+		}
+		scans[i].BarCode = code
+	}
+	return s.countRepo.AddScans(ctx, id, scans)
+}
+
+func (s *stockCountServiceImpl) Approve(ctx context.Context, id, actorID int64) (*entity.StockCount, error) {
+	if err := s.countRepo.Approve(ctx, id, actorID); err != nil {
+		return nil, err
+	}
+	c, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sum := c.Summary()
+	log.Printf("[INVENTORY] Stock count %d at %s approved: %d/%d lines scanned, +%d/-%d units adjusted",
+		c.ID, c.LocationCode, sum.Scanned, sum.Lines, sum.UnitsOver, sum.UnitsShort)
+	return c, nil
+}
+
+func (s *stockCountServiceImpl) Cancel(ctx context.Context, id int64) (*entity.StockCount, error) {
+	if err := s.countRepo.Cancel(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, id)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/tax"
+)
+
+type TaxService interface {
+	ListRates(ctx context.Context) ([]entity.TaxRate, error)
+	SetRate(ctx context.Context, category string, rate tax.Rate) (*entity.TaxRate, error)
+	DeleteRate(ctx context.Context, id int64) error
+
+	// ApplyToOrder calcula el IVA de cada línea (ya con descuentos), del envío y los totales de la orden
+	ApplyToOrder(ctx context.Context, order *entity.Order) error
+}
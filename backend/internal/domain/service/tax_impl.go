@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+	"core/internal/domain/tax"
+	"strings"
+)
+
+type taxServiceImpl struct {
+	repo repository.TaxRateRepository
+	mode tax.Mode
+}
+
+// NewTaxService recibe el modo en que se cargan los precios del catálogo (con o sin IVA)
+func NewTaxService(repo repository.TaxRateRepository, mode tax.Mode) TaxService {
+	return &taxServiceImpl{repo: repo, mode: mode}
+}
+
+func (s *taxServiceImpl) ListRates(ctx context.Context) ([]entity.TaxRate, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *taxServiceImpl) SetRate(ctx context.Context, category string, rate tax.Rate) (*entity.TaxRate, error) {
+	if !rate.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	t := &entity.TaxRate{Category: strings.TrimSpace(category), Rate: rate}
+	if err := s.repo.Upsert(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (s *taxServiceImpl) DeleteRate(ctx context.Context, id int64) error {
+	t, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	// La alícuota por defecto se modifica pero no se elimina
+	if t.Category == "" {
+		return errors.ErrConflict
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *taxServiceImpl) ApplyToOrder(ctx context.Context, order *entity.Order) error {
+	rates, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	byCategory := map[string]tax.Rate{}
+	fallback := tax.RateStandard
+	for _, r := range rates {
+		if r.Category == "" {
+			fallback = r.Rate
+			continue
+		}
+		byCategory[strings.ToLower(r.Category)] = r.Rate
+	}
+
+	lines := make([]tax.Line, len(order.Items))
+	for i, it := range order.Items {
+		amount, err := it.LineTotal.Sub(it.Discount)
+		if err != nil {
+			return err
+		}
+		rate, ok := byCategory[strings.ToLower(it.Category)]
+		if !ok {
+			rate = fallback
+		}
+		lines[i] = tax.Line{Amount: amount, Rate: rate}
+	}
+	// El envío va como una línea más con la alícuota por defecto
+	if order.Shipping != nil {
+		lines = append(lines, tax.Line{Amount: order.Shipping.Amount, Rate: fallback})
+	}
+
+	b, err := tax.Calculate(lines, s.mode, order.Currency)
+	if err != nil {
+		return err
+	}
+	for i, lt := range b.Lines[:len(order.Items)] {
+		order.Items[i].TaxRate = lt.Rate
+		order.Items[i].NetAmount = lt.Net
+		order.Items[i].TaxAmount = lt.Tax
+	}
+	if order.Shipping != nil {
+		lt := b.Lines[len(order.Items)]
+		order.Shipping.TaxRate, order.Shipping.Net, order.Shipping.Tax = lt.Rate, lt.Net, lt.Tax
+	}
+	order.TaxMode = s.mode
+	order.Tax = b.Tax
+	// Con precios sin IVA el impuesto se suma al total; con IVA incluido ya está adentro
+	order.Total = b.Gross
+	return nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"core/internal/domain/entity"
+)
+
+// WebhookSender hace un envío HTTP firmado. Retorna el código de la respuesta, o 0 si
+// no la hubo, y un error si no fue 2xx.
+type WebhookSender interface {
+	Send(ctx context.Context, endpoint entity.WebhookEndpoint, delivery entity.WebhookDelivery, msg entity.WebhookMessage) (int, error)
+}
+
+// WebhookService administra los destinos de los webhooks, publica los eventos y los
+// entrega con reintentos
+type WebhookService interface {
+	// CreateEndpoint da de alta un destino; sin secreto genera uno. Falla con
+	// ErrInvalidInput si la URL no es http(s), no hay eventos o alguno no existe.
+	CreateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) (*entity.WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, id int64) (*entity.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context) ([]entity.WebhookEndpoint, error)
+	// UpdateEndpoint reemplaza URL, descripción, eventos y estado; con secreto vacío
+	// conserva el anterior
+	UpdateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) (*entity.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, id int64) error
+
+	// PublishEvent encola un evento del outbox. Es idempotente: un evento ya encolado no
+	// se vuelve a encolar, y los que no son eventos de webhook se ignoran.
+	PublishEvent(ctx context.Context, e entity.OutboxEvent) error
+	// DeliverDue hace los envíos pendientes cuyo intento venció. Un fallo reprograma el
+	// envío con espera exponencial hasta agotar los intentos; mientras tanto los eventos
+	// siguientes del mismo agregado a ese destino esperan. Retorna cuántos se entregaron.
+	DeliverDue(ctx context.Context) (int, error)
+
+	GetDelivery(ctx context.Context, id int64) (*entity.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error)
+	// Redeliver vuelve a enviar el evento de un envío al mismo destino, como un envío
+	// nuevo. Falla con ErrConflict si el destino está desactivado.
+	Redeliver(ctx context.Context, id int64) (*entity.WebhookDelivery, error)
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+type webhookServiceImpl struct {
+	repo        repository.WebhookRepository
+	sender      WebhookSender
+	maxAttempts int
+
+	// mu evita que dos pasadas hagan el mismo envío
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// NewWebhookService recibe cuántos intentos se hacen por envío antes de darlo por fallido
+func NewWebhookService(repo repository.WebhookRepository, sender WebhookSender, maxAttempts int) WebhookService {
+	if maxAttempts <= 0 {
+		maxAttempts = 8
+	}
+	return &webhookServiceImpl{repo: repo, sender: sender, maxAttempts: maxAttempts, now: time.Now}
+}
+
+const (
+	// webhookBatchSize es cuántos envíos se leen por vez
+	webhookBatchSize    = 100
+	minWebhookSecretLen = 16
+)
+
+func (s *webhookServiceImpl) CreateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) (*entity.WebhookEndpoint, error) {
+	if err := normalizeWebhookEndpoint(e); err != nil {
+		return nil, err
+	}
+	if e.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		e.Secret = secret
+	}
+	if err := s.repo.CreateEndpoint(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *webhookServiceImpl) GetEndpoint(ctx context.Context, id int64) (*entity.WebhookEndpoint, error) {
+	e, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (s *webhookServiceImpl) ListEndpoints(ctx context.Context) ([]entity.WebhookEndpoint, error) {
+	return s.repo.ListEndpoints(ctx)
+}
+
+func (s *webhookServiceImpl) UpdateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) (*entity.WebhookEndpoint, error) {
+	if err := normalizeWebhookEndpoint(e); err != nil {
+		return nil, err
+	}
+	if e.Secret == "" {
+		current, err := s.repo.GetEndpoint(ctx, e.ID)
+		if err != nil {
+			return nil, err
+		}
+		e.Secret = current.Secret
+	}
+	if err := s.repo.UpdateEndpoint(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *webhookServiceImpl) DeleteEndpoint(ctx context.Context, id int64) error {
+	return s.repo.DeleteEndpoint(ctx, id)
+}
+
+// normalizeWebhookEndpoint valida el destino y quita los eventos repetidos
+func normalizeWebhookEndpoint(e *entity.WebhookEndpoint) error {
+	e.URL = strings.TrimSpace(e.URL)
+	e.Description = strings.TrimSpace(e.Description)
+	e.Secret = strings.TrimSpace(e.Secret)
+	u, err := url.Parse(e.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(e.URL) > 500 {
+		return errors.ErrInvalidInput
+	}
+	if e.Secret != "" && (len(e.Secret) < minWebhookSecretLen || len(e.Secret) > 128) {
+		return errors.ErrInvalidInput
+	}
+	if len(e.Description) > 255 || len(e.Events) == 0 {
+		return errors.ErrInvalidInput
+	}
+	events := make([]entity.WebhookEvent, 0, len(e.Events))
+	for _, ev := range e.Events {
+		if !ev.IsValid() {
+			return errors.ErrInvalidInput
+		}
+		dup := false
+		for _, seen := range events {
+			dup = dup || seen == ev
+		}
+		if !dup {
+			events = append(events, ev)
+		}
+	}
+	e.Events = events
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *webhookServiceImpl) PublishEvent(ctx context.Context, e entity.OutboxEvent) error {
+	event := entity.WebhookEvent(e.Event)
+	if !event.IsValid() {
+		return nil
+	}
+	id := e.ID
+	_, err := s.repo.Enqueue(ctx, &entity.WebhookMessage{
+		SourceID:      &id,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Event:         event,
+		Data:          e.Data,
+	})
+	return err
+}
+
+func (s *webhookServiceImpl) DeliverDue(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delivered := 0
+	for {
+		jobs, err := s.repo.Due(ctx, s.now(), webhookBatchSize)
+		if err != nil {
+			return delivered, err
+		}
+		for _, job := range jobs {
+			ok, err := s.deliver(ctx, job)
+			if err != nil {
+				return delivered, err
+			}
+			if ok {
+				delivered++
+			}
+		}
+		if len(jobs) < webhookBatchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// deliver hace un intento y lo registra. Retorna si se entregó; el error es solo el de
+// guardar el resultado.
+func (s *webhookServiceImpl) deliver(ctx context.Context, job entity.WebhookJob) (bool, error) {
+	status, sendErr := s.sender.Send(ctx, job.Endpoint, job.Delivery, job.Message)
+	if sendErr == nil {
+		return true, s.repo.MarkDelivered(ctx, job.Delivery.ID, status)
+	}
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	attempts := job.Delivery.Attempts + 1
+	var next *time.Time
+	if attempts < s.maxAttempts {
+		at := s.now().Add(entity.WebhookRetryDelay(attempts))
+		next = &at
+		log.Printf("[WEBHOOK] Delivery %d of %s to %s failed (attempt %d/%d), retrying at %s: %v",
+			job.Delivery.ID, job.Message.Event, job.Endpoint.URL, attempts, s.maxAttempts, at.Format(time.RFC3339), sendErr)
+	} else {
+		log.Printf("[WEBHOOK] Delivery %d of %s to %s failed after %d attempts: %v",
+			job.Delivery.ID, job.Message.Event, job.Endpoint.URL, attempts, sendErr)
+	}
+	return false, s.repo.MarkAttemptFailed(ctx, job.Delivery.ID, status, truncateRunes(sendErr.Error(), 500), next)
+}
+
+func (s *webhookServiceImpl) GetDelivery(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	d, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (s *webhookServiceImpl) ListDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+	return s.repo.ListDeliveries(ctx, filter)
+}
+
+func (s *webhookServiceImpl) Redeliver(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	d, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.repo.GetEndpoint(ctx, d.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !e.Active {
+		return nil, errors.ErrConflict
+	}
+	redelivery, err := s.repo.Redeliver(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &redelivery, nil
+}
@@ -0,0 +1,222 @@
+// Package tax calcula el IVA de una venta a partir de los montos de cada línea.
+//
+// Para que las líneas siempre sumen el total, el impuesto se redondea una sola vez
+// por alícuota (sobre la suma de las líneas que la comparten) y luego se reparte
+// entre esas líneas en proporción a su monto, sin perder centavos.
+package tax
+
+import (
+	"core/internal/domain/money"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidRate = errors.New("invalid tax rate")
+
+// Rate es una alícuota en centésimos de punto porcentual: 2100 = 21%, 1050 = 10,5%
+type Rate int64
+
+const (
+	RateStandard Rate = 2100 // alícuota general
+	RateReduced  Rate = 1050 // alícuota reducida
+	RateExempt   Rate = 0
+)
+
+const rateScale = 10000 // 100% expresado en centésimos de punto
+
+// ParseRate interpreta un porcentaje con hasta dos decimales, ej. "10.5"
+func ParseRate(s string) (Rate, error) {
+	s = strings.TrimSpace(s)
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" || len(fracPart) > 2 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+	fracPart += strings.Repeat("0", 2-len(fracPart))
+	i, err1 := strconv.ParseUint(intPart, 10, 32)
+	f, err2 := strconv.ParseUint(fracPart, 10, 32)
+	if err1 != nil || err2 != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+	r := Rate(i*100 + f)
+	if !r.IsValid() {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+	return r, nil
+}
+
+// IsValid indica si la alícuota está entre 0% y 100%
+func (r Rate) IsValid() bool {
+	return r >= 0 && r <= rateScale
+}
+
+// String retorna el porcentaje sin ceros finales, ej. "21" o "10.5"
+func (r Rate) String() string {
+	s := fmt.Sprintf("%d.%02d", r/100, r%100)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// MarshalJSON serializa la alícuota como número decimal, ej. 10.5
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON acepta la alícuota como número o string
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Mode indica si los precios cargados ya incluyen el impuesto
+type Mode string
+
+const (
+	ModeInclusive Mode = "inclusive" // el precio es final, el IVA se desglosa desde adentro
+	ModeExclusive Mode = "exclusive" // el precio es neto, el IVA se suma encima
+)
+
+// IsValid verifica si el modo es uno de los conocidos
+func (m Mode) IsValid() bool {
+	return m == ModeInclusive || m == ModeExclusive
+}
+
+// Line es el monto de una línea de venta (ya con descuentos) y su alícuota
+type Line struct {
+	Amount money.Money
+	Rate   Rate
+}
+
+// LineTax es el desglose de una línea
+type LineTax struct {
+	Rate  Rate
+	Net   money.Money
+	Tax   money.Money
+	Gross money.Money
+}
+
+// Group es el total de las líneas que comparten alícuota
+type Group struct {
+	Rate  Rate        `json:"rate"`
+	Net   money.Money `json:"net"`
+	Tax   money.Money `json:"tax"`
+	Gross money.Money `json:"gross"`
+}
+
+// Breakdown es el desglose completo de una venta
+type Breakdown struct {
+	Mode   Mode
+	Lines  []LineTax // en el mismo orden que las líneas de entrada
+	Groups []Group   // ordenados por alícuota descendente
+	Net    money.Money
+	Tax    money.Money
+	Gross  money.Money
+}
+
+// Calculate desglosa el impuesto de las líneas. En modo inclusivo el monto de cada línea
+// es el bruto; en modo exclusivo es el neto.
+func Calculate(lines []Line, mode Mode, cur money.Currency) (Breakdown, error) {
+	if !mode.IsValid() {
+		return Breakdown{}, fmt.Errorf("unknown tax mode %q", mode)
+	}
+
+	byRate := map[Rate][]int{}
+	for i, l := range lines {
+		if !l.Rate.IsValid() {
+			return Breakdown{}, fmt.Errorf("%w: %d", ErrInvalidRate, l.Rate)
+		}
+		if l.Amount.Currency() != cur {
+			return Breakdown{}, money.ErrCurrencyMismatch
+		}
+		byRate[l.Rate] = append(byRate[l.Rate], i)
+	}
+	rates := make([]Rate, 0, len(byRate))
+	for r := range byRate {
+		rates = append(rates, r)
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i] > rates[j] })
+
+	b := Breakdown{Mode: mode, Lines: make([]LineTax, len(lines))}
+	var net, tax int64
+	for _, r := range rates {
+		idx := byRate[r]
+		weights := make([]int64, len(idx))
+		var base int64
+		for k, i := range idx {
+			weights[k] = lines[i].Amount.Minor()
+			base += weights[k]
+		}
+
+		// Un único redondeo por alícuota
+		den := int64(rateScale)
+		if mode == ModeInclusive {
+			den += int64(r)
+		}
+		groupTax, err := money.New(base, cur).MulFrac(int64(r), den, money.RoundHalfUp)
+		if err != nil {
+			return Breakdown{}, err
+		}
+		parts, err := groupTax.Allocate(weights...)
+		if err != nil {
+			return Breakdown{}, err
+		}
+
+		for k, part := range parts {
+			i := idx[k]
+			amount := lines[i].Amount.Minor()
+			lt := LineTax{Rate: r, Tax: part}
+			if mode == ModeInclusive {
+				lt.Net, lt.Gross = money.New(amount-part.Minor(), cur), lines[i].Amount
+			} else {
+				lt.Net, lt.Gross = lines[i].Amount, money.New(amount+part.Minor(), cur)
+			}
+			b.Lines[i] = lt
+		}
+
+		g := Group{Rate: r, Tax: groupTax}
+		if mode == ModeInclusive {
+			g.Net, g.Gross = money.New(base-groupTax.Minor(), cur), money.New(base, cur)
+		} else {
+			g.Net, g.Gross = money.New(base, cur), money.New(base+groupTax.Minor(), cur)
+		}
+		b.Groups = append(b.Groups, g)
+		net += g.Net.Minor()
+		tax += g.Tax.Minor()
+	}
+
+	b.Net = money.New(net, cur)
+	b.Tax = money.New(tax, cur)
+	b.Gross = money.New(net+tax, cur)
+	return b, nil
+}
+
+// Summarize agrupa por alícuota líneas ya desglosadas, ordenadas de mayor a menor alícuota.
+// Sirve para reconstruir los grupos de una venta guardada a partir de sus líneas.
+func Summarize(lines []LineTax, cur money.Currency) []Group {
+	byRate := map[Rate]*Group{}
+	var rates []Rate
+	for _, l := range lines {
+		g, ok := byRate[l.Rate]
+		if !ok {
+			g = &Group{Rate: l.Rate, Net: money.Zero(cur), Tax: money.Zero(cur), Gross: money.Zero(cur)}
+			byRate[l.Rate] = g
+			rates = append(rates, l.Rate)
+		}
+		g.Net = money.New(g.Net.Minor()+l.Net.Minor(), cur)
+		g.Tax = money.New(g.Tax.Minor()+l.Tax.Minor(), cur)
+		g.Gross = money.New(g.Gross.Minor()+l.Net.Minor()+l.Tax.Minor(), cur)
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i] > rates[j] })
+	out := make([]Group, 0, len(rates))
+	for _, r := range rates {
+		out = append(out, *byRate[r])
+	}
+	return out
+}
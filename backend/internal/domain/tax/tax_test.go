@@ -0,0 +1,203 @@
+package tax
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"core/internal/domain/money"
+)
+
+func ars(minor int64) money.Money { return money.New(minor, money.ARS) }
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Rate
+		err  bool
+	}{
+		{"21", RateStandard, false},
+		{"10.5", RateReduced, false},
+		{"10.50", RateReduced, false},
+		{" 0 ", RateExempt, false},
+		{"100", 10000, false},
+		{"2.25", 225, false},
+		{"100.01", 0, true},
+		{"10.555", 0, true},
+		{"-1", 0, true},
+		{".5", 0, true},
+		{"", 0, true},
+		{"diez", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRate(tt.in)
+		if tt.err {
+			if !errors.Is(err, ErrInvalidRate) {
+				t.Errorf("ParseRate(%q) error = %v, want %v", tt.in, err, ErrInvalidRate)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseRate(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+		if s := got.String(); s != tt.want.String() {
+			t.Errorf("Rate(%d).String() = %q", got, s)
+		}
+	}
+	for r, want := range map[Rate]string{RateStandard: "21", RateReduced: "10.5", RateExempt: "0", 225: "2.25"} {
+		if got := r.String(); got != want {
+			t.Errorf("Rate(%d).String() = %q, want %q", r, got, want)
+		}
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	type line struct{ net, tax, gross int64 }
+	tests := []struct {
+		name      string
+		lines     []Line
+		mode      Mode
+		wantLines []line
+		wantTax   []int64 // por grupo, de mayor a menor alícuota
+		wantNet   int64
+		wantGross int64
+	}{
+		{
+			name:      "exclusive adds the tax",
+			lines:     []Line{{ars(10000), RateStandard}},
+			mode:      ModeExclusive,
+			wantLines: []line{{10000, 2100, 12100}},
+			wantTax:   []int64{2100},
+			wantNet:   10000,
+			wantGross: 12100,
+		},
+		{
+			name:      "inclusive extracts the tax",
+			lines:     []Line{{ars(12100), RateStandard}},
+			mode:      ModeInclusive,
+			wantLines: []line{{10000, 2100, 12100}},
+			wantTax:   []int64{2100},
+			wantNet:   10000,
+			wantGross: 12100,
+		},
+		{
+			name:      "inclusive rounds half up",
+			lines:     []Line{{ars(1000), RateStandard}},
+			mode:      ModeInclusive,
+			wantLines: []line{{826, 174, 1000}},
+			wantTax:   []int64{174},
+			wantNet:   826,
+			wantGross: 1000,
+		},
+		{
+			// Redondeando por línea serían 3 centavos; por alícuota se redondea 1,5 una vez
+			name:      "one rounding per rate, allocated to the first lines",
+			lines:     []Line{{ars(5), 1000}, {ars(5), 1000}, {ars(5), 1000}},
+			mode:      ModeExclusive,
+			wantLines: []line{{5, 1, 6}, {5, 1, 6}, {5, 0, 5}},
+			wantTax:   []int64{2},
+			wantNet:   15,
+			wantGross: 17,
+		},
+		{
+			name:      "tax allocated in proportion to the lines",
+			lines:     []Line{{ars(333), RateReduced}, {ars(333), RateReduced}, {ars(334), RateReduced}},
+			mode:      ModeExclusive,
+			wantLines: []line{{333, 35, 368}, {333, 35, 368}, {334, 35, 369}},
+			wantTax:   []int64{105},
+			wantNet:   1000,
+			wantGross: 1105,
+		},
+		{
+			name:      "groups by rate in descending order",
+			lines:     []Line{{ars(2000), RateExempt}, {ars(5000), RateReduced}, {ars(10000), RateStandard}},
+			mode:      ModeExclusive,
+			wantLines: []line{{2000, 0, 2000}, {5000, 525, 5525}, {10000, 2100, 12100}},
+			wantTax:   []int64{2100, 525, 0},
+			wantNet:   17000,
+			wantGross: 19625,
+		},
+		{
+			name:      "inclusive with several rates",
+			lines:     []Line{{ars(12100), RateStandard}, {ars(11050), RateReduced}, {ars(999), RateExempt}},
+			mode:      ModeInclusive,
+			wantLines: []line{{10000, 2100, 12100}, {10000, 1050, 11050}, {999, 0, 999}},
+			wantTax:   []int64{2100, 1050, 0},
+			wantNet:   20999,
+			wantGross: 24149,
+		},
+		{
+			name:      "zero total",
+			lines:     []Line{{ars(0), RateStandard}, {ars(0), RateStandard}},
+			mode:      ModeInclusive,
+			wantLines: []line{{0, 0, 0}, {0, 0, 0}},
+			wantTax:   []int64{0},
+		},
+		{
+			name:      "no lines",
+			mode:      ModeExclusive,
+			wantLines: []line{},
+			wantTax:   []int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Calculate(tt.lines, tt.mode, money.ARS)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := []line{}
+			for _, l := range b.Lines {
+				lines = append(lines, line{l.Net.Minor(), l.Tax.Minor(), l.Gross.Minor()})
+			}
+			if !slices.Equal(lines, tt.wantLines) {
+				t.Errorf("lines = %v, want %v", lines, tt.wantLines)
+			}
+			groupTax := []int64{}
+			var tax int64
+			for _, g := range b.Groups {
+				groupTax = append(groupTax, g.Tax.Minor())
+				tax += g.Tax.Minor()
+				if g.Net.Minor()+g.Tax.Minor() != g.Gross.Minor() {
+					t.Errorf("group %s: net %s + tax %s != gross %s", g.Rate, g.Net, g.Tax, g.Gross)
+				}
+			}
+			if !slices.Equal(groupTax, tt.wantTax) {
+				t.Errorf("group tax = %v, want %v", groupTax, tt.wantTax)
+			}
+			if b.Net.Minor() != tt.wantNet || b.Tax.Minor() != tax || b.Gross.Minor() != tt.wantGross {
+				t.Errorf("net/tax/gross = %s/%s/%s, want %d/%d/%d", b.Net, b.Tax, b.Gross, tt.wantNet, tax, tt.wantGross)
+			}
+			if b.Net.Currency() != money.ARS || b.Mode != tt.mode {
+				t.Errorf("breakdown currency/mode = %s/%s", b.Net.Currency(), b.Mode)
+			}
+		})
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	if _, err := Calculate([]Line{{ars(100), RateStandard}}, "gross", money.ARS); err == nil {
+		t.Error("Calculate with an unknown mode should fail")
+	}
+	if _, err := Calculate([]Line{{ars(100), 10001}}, ModeExclusive, money.ARS); !errors.Is(err, ErrInvalidRate) {
+		t.Errorf("Calculate with rate over 100%% error = %v, want %v", err, ErrInvalidRate)
+	}
+	if _, err := Calculate([]Line{{money.New(100, money.USD), RateStandard}}, ModeExclusive, money.ARS); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("Calculate with a USD line error = %v, want %v", err, money.ErrCurrencyMismatch)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	lines := []Line{{ars(5), RateReduced}, {ars(10000), RateStandard}, {ars(7), RateReduced}, {ars(300), RateExempt}}
+	b, err := Calculate(lines, ModeExclusive, money.ARS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Summarize(b.Lines, money.ARS)
+	if !slices.Equal(got, b.Groups) {
+		t.Errorf("Summarize = %v, want the groups of Calculate %v", got, b.Groups)
+	}
+	if got := Summarize(nil, money.ARS); len(got) != 0 {
+		t.Errorf("Summarize(nil) = %v, want no groups", got)
+	}
+}
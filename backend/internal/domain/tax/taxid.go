@@ -0,0 +1,70 @@
+package tax
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidTaxID = errors.New("invalid tax id")
+
+// IDType es el tipo de documento del cliente en un comprobante
+type IDType string
+
+const (
+	IDTypeCUIT IDType = "CUIT" // 11 dígitos con dígito verificador
+	IDTypeDNI  IDType = "DNI"  // 7 u 8 dígitos
+	IDTypeNone IDType = ""     // consumidor final sin identificar
+)
+
+// ParseTaxID normaliza un CUIT/CUIL o DNI (acepta guiones, puntos y espacios) y
+// detecta su tipo. Un CUIT con dígito verificador incorrecto es inválido.
+func ParseTaxID(s string) (string, IDType, error) {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '-' || r == '.' || r == ' ':
+		default:
+			return "", IDTypeNone, ErrInvalidTaxID
+		}
+	}
+	id := b.String()
+	switch len(id) {
+	case 0:
+		return "", IDTypeNone, nil
+	case 7, 8:
+		return id, IDTypeDNI, nil
+	case 11:
+		if !validCUIT(id) {
+			return "", IDTypeNone, ErrInvalidTaxID
+		}
+		return id, IDTypeCUIT, nil
+	}
+	return "", IDTypeNone, ErrInvalidTaxID
+}
+
+// validCUIT verifica el dígito verificador (módulo 11)
+func validCUIT(id string) bool {
+	weights := [10]int{5, 4, 3, 2, 7, 6, 5, 4, 3, 2}
+	sum := 0
+	for i, w := range weights {
+		sum += int(id[i]-'0') * w
+	}
+	check := 11 - sum%11
+	switch check {
+	case 11:
+		check = 0
+	case 10:
+		return false
+	}
+	return int(id[10]-'0') == check
+}
+
+// FormatTaxID agrega los guiones de un CUIT (20-12345678-9); el resto se devuelve igual
+func FormatTaxID(id string, t IDType) string {
+	if t == IDTypeCUIT && len(id) == 11 {
+		return id[:2] + "-" + id[2:10] + "-" + id[10:]
+	}
+	return id
+}
@@ -0,0 +1,61 @@
+package tax
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseTaxID(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     string
+		wantType IDType
+		err      bool
+	}{
+		{"20-12345678-6", "20123456786", IDTypeCUIT, false},
+		{"30-71234567-1", "30712345671", IDTypeCUIT, false},
+		{"27 11111111 7", "27111111117", IDTypeCUIT, false},
+		{"20.00000006.0", "20000000060", IDTypeCUIT, false}, // resto 0: el verificador es 0
+		{"12.345.678", "12345678", IDTypeDNI, false},
+		{"1234567", "1234567", IDTypeDNI, false},
+		{"", "", IDTypeNone, false},
+		{" - ", "", IDTypeNone, false},
+		{"20-12345678-5", "", IDTypeNone, true}, // verificador incorrecto
+		{"30-71234567-2", "", IDTypeNone, true},
+		{"20-00000001-0", "", IDTypeNone, true}, // resto 1: no tiene verificador válido
+		{"20-1234567-8", "", IDTypeNone, true},  // 10 dígitos
+		{"123456", "", IDTypeNone, true},
+		{"201234567861", "", IDTypeNone, true},
+		{"20/12345678/6", "", IDTypeNone, true},
+		{"CUIT 20123456786", "", IDTypeNone, true},
+	}
+	for _, tt := range tests {
+		got, typ, err := ParseTaxID(tt.in)
+		if tt.err {
+			if !errors.Is(err, ErrInvalidTaxID) {
+				t.Errorf("ParseTaxID(%q) error = %v, want %v", tt.in, err, ErrInvalidTaxID)
+			}
+			continue
+		}
+		if err != nil || got != tt.want || typ != tt.wantType {
+			t.Errorf("ParseTaxID(%q) = %q, %q, %v; want %q, %q", tt.in, got, typ, err, tt.want, tt.wantType)
+		}
+	}
+}
+
+func TestFormatTaxID(t *testing.T) {
+	tests := []struct {
+		id   string
+		typ  IDType
+		want string
+	}{
+		{"20123456786", IDTypeCUIT, "20-12345678-6"},
+		{"12345678", IDTypeDNI, "12345678"},
+		{"", IDTypeNone, ""},
+	}
+	for _, tt := range tests {
+		if got := FormatTaxID(tt.id, tt.typ); got != tt.want {
+			t.Errorf("FormatTaxID(%q, %q) = %q, want %q", tt.id, tt.typ, got, tt.want)
+		}
+	}
+}
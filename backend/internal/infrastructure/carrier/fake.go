@@ -0,0 +1,140 @@
+// Package carrier contiene las implementaciones de service.Carrier
+package carrier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/infrastructure/pdf"
+)
+
+// FakeName es el nombre del carrier local en los envíos y en la URL del webhook
+const FakeName = "fake"
+
+// Fake simula un carrier sin conectarse a ningún servicio. El número de seguimiento
+// codifica el momento del alta y los eventos avanzan un estado cada step, así el
+// seguimiento es reproducible sin guardar estado. Sirve para desarrollo y pruebas.
+type Fake struct {
+	sender string
+	secret []byte
+	step   time.Duration
+	seq    atomic.Int64
+	now    func() time.Time
+}
+
+// NewFake recibe el remitente que se imprime en las etiquetas, el secreto con el que se
+// firman los webhooks y cada cuánto avanza el envío
+func NewFake(sender, webhookSecret string, step time.Duration) *Fake {
+	if step <= 0 {
+		step = time.Hour
+	}
+	return &Fake{sender: sender, secret: []byte(webhookSecret), step: step, now: time.Now}
+}
+
+var _ service.Carrier = (*Fake)(nil)
+
+func (f *Fake) Name() string { return FakeName }
+
+func (f *Fake) CreateLabel(ctx context.Context, sh *entity.Shipment) (entity.CarrierLabel, error) {
+	if err := ctx.Err(); err != nil {
+		return entity.CarrierLabel{}, err
+	}
+	// FK + segundos unix del alta (10 dígitos) + secuencia
+	tracking := fmt.Sprintf("FK%010d%04d", f.now().Unix(), f.seq.Add(1)%10000)
+	doc, err := pdf.ShippingLabel("Fake Carrier", tracking, f.sender, sh)
+	if err != nil {
+		return entity.CarrierLabel{}, err
+	}
+	return entity.CarrierLabel{TrackingNumber: tracking, Document: doc}, nil
+}
+
+// fakeTimeline son los estados que recorre un envío después del alta, uno por step
+var fakeTimeline = []struct {
+	status      entity.ShipmentStatus
+	description string
+	location    string
+}{
+	{entity.ShipmentStatusInTransit, "En viaje al centro de distribución", "Centro de distribución"},
+	{entity.ShipmentStatusOutForDelivery, "En reparto", "Sucursal de destino"},
+	{entity.ShipmentStatusDelivered, "Entregado", "Domicilio del destinatario"},
+}
+
+// Track no incluye el alta: el evento de etiqueta generada lo registra quien crea el envío
+func (f *Fake) Track(ctx context.Context, trackingNumber string) ([]entity.TrackingEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(trackingNumber) != 16 || trackingNumber[:2] != "FK" {
+		return nil, errors.ErrNotFound
+	}
+	sec, err := strconv.ParseInt(trackingNumber[2:12], 10, 64)
+	if err != nil {
+		return nil, errors.ErrNotFound
+	}
+
+	created := time.Unix(sec, 0).UTC()
+	now := f.now()
+	events := []entity.TrackingEvent{}
+	for i, t := range fakeTimeline {
+		at := created.Add(time.Duration(i+1) * f.step)
+		if at.After(now) {
+			break
+		}
+		events = append(events, entity.TrackingEvent{
+			Status:      t.status,
+			Description: t.description,
+			Location:    t.location,
+			OccurredAt:  at,
+		})
+	}
+	return events, nil
+}
+
+// fakeWebhook es la notificación que envía el carrier al cambiar de estado un envío
+type fakeWebhook struct {
+	TrackingNumber string `json:"tracking_number"`
+	Events         []struct {
+		Status      string    `json:"status"`
+		Description string    `json:"description"`
+		Location    string    `json:"location"`
+		OccurredAt  time.Time `json:"occurred_at"`
+	} `json:"events"`
+}
+
+// ParseWebhook espera en signature el HMAC-SHA256 del cuerpo en hexadecimal
+func (f *Fake) ParseWebhook(payload []byte, signature string) (string, []entity.TrackingEvent, error) {
+	if !hmac.Equal([]byte(f.Sign(payload)), []byte(signature)) {
+		return "", nil, errors.ErrInvalidToken
+	}
+	var body fakeWebhook
+	if err := json.Unmarshal(payload, &body); err != nil || body.TrackingNumber == "" {
+		return "", nil, errors.ErrInvalidInput
+	}
+	events := make([]entity.TrackingEvent, 0, len(body.Events))
+	for _, e := range body.Events {
+		events = append(events, entity.TrackingEvent{
+			Status:      entity.ShipmentStatus(e.Status),
+			Description: e.Description,
+			Location:    e.Location,
+			OccurredAt:  e.OccurredAt,
+		})
+	}
+	return body.TrackingNumber, events, nil
+}
+
+// Sign firma un cuerpo de webhook como lo haría el carrier
+func (f *Fake) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,25 @@
+// Package eventsink implementa destinos para los eventos del outbox
+package eventsink
+
+import (
+	"context"
+	"log"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/service"
+)
+
+// Log escribe cada evento en el log del proceso. Sirve para desarrollo y para auditar
+// qué se publicó.
+type Log struct{}
+
+func NewLog() *Log { return &Log{} }
+
+var _ service.EventSink = (*Log)(nil)
+
+func (l *Log) Name() string { return "log" }
+
+func (l *Log) Publish(_ context.Context, e entity.OutboxEvent) error {
+	log.Printf("[OUTBOX] Event %d %s %s:%d %s", e.ID, e.Event, e.AggregateType, e.AggregateID, e.Data)
+	return nil
+}
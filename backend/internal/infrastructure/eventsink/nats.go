@@ -0,0 +1,229 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/service"
+)
+
+// MsgIDHeader lleva el ID del evento del outbox. Un stream de JetStream lo usa para
+// descartar los repetidos dentro de su ventana de duplicados.
+const MsgIDHeader = "Nats-Msg-Id"
+
+// NATS publica cada evento como JSON en el subject prefix.<evento>, con el protocolo de
+// texto de NATS sobre TCP. Sirve con cualquier servidor compatible. La conexión se abre
+// al primer envío y se vuelve a abrir después de un error.
+type NATS struct {
+	url     *url.URL
+	prefix  string
+	timeout time.Duration
+
+	mu         sync.Mutex
+	conn       net.Conn
+	r          *bufio.Reader
+	headers    bool
+	maxPayload int
+}
+
+// NewNATS recibe una URL nats:// o tls://, con usuario y contraseña o con el token como
+// usuario
+func NewNATS(rawURL, prefix string) (*NATS, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS URL: %w", err)
+	}
+	if (u.Scheme != "nats" && u.Scheme != "tls") || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid NATS URL %q: expected nats://host:port or tls://host:port", u.Redacted())
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	return &NATS{url: u, prefix: strings.Trim(prefix, "."), timeout: 5 * time.Second}, nil
+}
+
+var _ service.EventSink = (*NATS)(nil)
+
+func (n *NATS) Name() string { return "nats" }
+
+// Subject retorna el subject en el que se publica el evento
+func (n *NATS) Subject(event entity.DomainEvent) string {
+	if n.prefix == "" {
+		return string(event)
+	}
+	return n.prefix + "." + string(event)
+}
+
+// Publish envía el evento y espera el PONG del servidor, que confirma que lo procesó
+func (n *NATS) Publish(ctx context.Context, e entity.OutboxEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		if err := n.connect(ctx); err != nil {
+			return err
+		}
+	}
+	if err := n.publish(ctx, n.Subject(e.Event), strconv.FormatInt(e.ID, 10), body); err != nil {
+		n.close()
+		return err
+	}
+	return nil
+}
+
+// Close cierra la conexión, si está abierta
+func (n *NATS) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.close()
+	return nil
+}
+
+func (n *NATS) close() {
+	if n.conn != nil {
+		n.conn.Close()
+		n.conn, n.r = nil, nil
+	}
+}
+
+func (n *NATS) deadline(ctx context.Context) time.Time {
+	d := time.Now().Add(n.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(d) {
+		return ctxDeadline
+	}
+	return d
+}
+
+func (n *NATS) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: n.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", n.url.Host)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	if n.url.Scheme == "tls" {
+		conn = tls.Client(conn, &tls.Config{ServerName: n.url.Hostname()})
+	}
+	n.conn, n.r = conn, bufio.NewReader(conn)
+	if err := n.handshake(ctx); err != nil {
+		n.close()
+		return err
+	}
+	return nil
+}
+
+// handshake lee el INFO del servidor, manda CONNECT con las credenciales de la URL y
+// espera el PONG que confirma que el servidor las aceptó
+func (n *NATS) handshake(ctx context.Context) error {
+	n.conn.SetDeadline(n.deadline(ctx))
+
+	line, err := n.readLine()
+	if err != nil {
+		return fmt.Errorf("failed to read NATS INFO: %w", err)
+	}
+	op, args, _ := strings.Cut(line, " ")
+	if !strings.EqualFold(op, "INFO") {
+		return fmt.Errorf("unexpected NATS greeting %q", line)
+	}
+	var info struct {
+		Headers    bool `json:"headers"`
+		MaxPayload int  `json:"max_payload"`
+	}
+	if err := json.Unmarshal([]byte(args), &info); err != nil {
+		return fmt.Errorf("invalid NATS INFO: %w", err)
+	}
+	n.headers, n.maxPayload = info.Headers, info.MaxPayload
+
+	opts := map[string]any{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "core-outbox",
+		"lang":     "go",
+		"version":  "1.0.0",
+		"protocol": 1,
+		"headers":  info.Headers,
+	}
+	if user := n.url.User; user != nil {
+		if pass, ok := user.Password(); ok {
+			opts["user"], opts["pass"] = user.Username(), pass
+		} else {
+			opts["auth_token"] = user.Username()
+		}
+	}
+	connect, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(n.conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		return err
+	}
+	return n.awaitPong()
+}
+
+func (n *NATS) publish(ctx context.Context, subject, msgID string, body []byte) error {
+	if n.maxPayload > 0 && len(body) > n.maxPayload {
+		return fmt.Errorf("event of %d bytes exceeds NATS max payload of %d", len(body), n.maxPayload)
+	}
+	n.conn.SetDeadline(n.deadline(ctx))
+
+	var msg []byte
+	if n.headers {
+		hdr := "NATS/1.0\r\n" + MsgIDHeader + ": " + msgID + "\r\n\r\n"
+		msg = fmt.Appendf(nil, "HPUB %s %d %d\r\n%s", subject, len(hdr), len(hdr)+len(body), hdr)
+	} else {
+		msg = fmt.Appendf(nil, "PUB %s %d\r\n", subject, len(body))
+	}
+	msg = append(msg, body...)
+	msg = append(msg, "\r\nPING\r\n"...)
+	if _, err := n.conn.Write(msg); err != nil {
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+	return n.awaitPong()
+}
+
+// awaitPong lee hasta el PONG. Responde los PING del servidor y falla con los -ERR.
+func (n *NATS) awaitPong() error {
+	for {
+		line, err := n.readLine()
+		if err != nil {
+			return fmt.Errorf("failed to read from NATS: %w", err)
+		}
+		op, args, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(op) {
+		case "PONG":
+			return nil
+		case "PING":
+			if _, err := n.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case "-ERR":
+			return errors.New("NATS error: " + strings.Trim(args, "'"))
+		case "+OK", "INFO":
+		default:
+			return fmt.Errorf("unexpected NATS message %q", line)
+		}
+	}
+}
+
+func (n *NATS) readLine() (string, error) {
+	line, err := n.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,168 @@
+// Package feed escribe los productos en los formatos de Google Merchant Center (RSS 2.0
+// con el espacio de nombres g:) y del catálogo de Meta (CSV)
+package feed
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/service"
+)
+
+// metaColumns son las columnas del CSV de Meta, en el orden en que se escriben
+var metaColumns = []string{
+	"id", "title", "description", "availability", "condition", "price", "link", "image_link",
+	"brand", "google_product_category", "fb_product_category", "product_type",
+	"additional_image_link", "sale_price", "sale_price_effective_date", "gtin", "size",
+}
+
+type Renderer struct {
+	storeName string
+	storeURL  string
+}
+
+// NewRenderer usa storeName y storeURL para el encabezado del feed de Google
+func NewRenderer(storeName, storeURL string) *Renderer {
+	return &Renderer{storeName: storeName, storeURL: strings.TrimRight(storeURL, "/")}
+}
+
+var _ service.FeedRenderer = (*Renderer)(nil)
+
+func (r *Renderer) Item(channel entity.FeedChannel, p *entity.FeedProduct) (string, error) {
+	switch channel {
+	case entity.FeedChannelGoogle:
+		return googleItem(p)
+	case entity.FeedChannelMeta:
+		return metaItem(p)
+	}
+	return "", domainerrors.ErrInvalidInput
+}
+
+func (r *Renderer) Header(channel entity.FeedChannel) string {
+	switch channel {
+	case entity.FeedChannelGoogle:
+		var b bytes.Buffer
+		b.WriteString(xml.Header)
+		b.WriteString(`<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0">` + "\n<channel>\n")
+		writeElement(&b, "title", r.storeName)
+		writeElement(&b, "link", r.storeURL)
+		writeElement(&b, "description", "Productos de "+r.storeName)
+		return b.String()
+	case entity.FeedChannelMeta:
+		line, _ := csvLine(metaColumns)
+		return line
+	}
+	return ""
+}
+
+func (r *Renderer) Footer(channel entity.FeedChannel) string {
+	if channel == entity.FeedChannelGoogle {
+		return "</channel>\n</rss>\n"
+	}
+	return ""
+}
+
+func googleItem(p *entity.FeedProduct) (string, error) {
+	var b bytes.Buffer
+	b.WriteString("<item>\n")
+	writeElement(&b, "g:id", strconv.FormatInt(p.ID, 10))
+	writeElement(&b, "g:title", p.Title)
+	writeElement(&b, "g:description", p.Description)
+	writeElement(&b, "g:link", p.Link)
+	writeElement(&b, "g:image_link", p.ImageLink)
+	for _, link := range p.AdditionalImageLinks {
+		writeElement(&b, "g:additional_image_link", link)
+	}
+	writeElement(&b, "g:availability", availability(p, "in_stock", "out_of_stock"))
+	writeElement(&b, "g:condition", "new")
+	writeElement(&b, "g:price", price(p.Price))
+	if p.SalePrice != nil {
+		writeElement(&b, "g:sale_price", price(*p.SalePrice))
+		writeElement(&b, "g:sale_price_effective_date", effectiveDate(p))
+	}
+	writeElement(&b, "g:brand", p.Brand)
+	if p.GTIN != "" {
+		writeElement(&b, "g:gtin", p.GTIN)
+	} else {
+		// Sin GTIN hay que declarar que el producto no tiene identificadores
+		writeElement(&b, "g:identifier_exists", "no")
+	}
+	writeElement(&b, "g:google_product_category", p.GoogleCategory)
+	writeElement(&b, "g:product_type", p.ProductType)
+	writeElement(&b, "g:size", p.Size)
+	b.WriteString("</item>\n")
+	return b.String(), nil
+}
+
+func metaItem(p *entity.FeedProduct) (string, error) {
+	var salePrice string
+	if p.SalePrice != nil {
+		salePrice = price(*p.SalePrice)
+	}
+	return csvLine([]string{
+		strconv.FormatInt(p.ID, 10),
+		p.Title,
+		p.Description,
+		availability(p, "in stock", "out of stock"),
+		"new",
+		price(p.Price),
+		p.Link,
+		p.ImageLink,
+		p.Brand,
+		p.GoogleCategory,
+		p.MetaCategory,
+		p.ProductType,
+		strings.Join(p.AdditionalImageLinks, ","),
+		salePrice,
+		effectiveDate(p),
+		p.GTIN,
+		p.Size,
+	})
+}
+
+// writeElement escribe el elemento escapando su valor; los vacíos se omiten
+func writeElement(b *bytes.Buffer, name, value string) {
+	if value == "" {
+		return
+	}
+	b.WriteString("<" + name + ">")
+	xml.EscapeText(b, []byte(value))
+	b.WriteString("</" + name + ">\n")
+}
+
+func csvLine(values []string) (string, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.Write(values); err != nil {
+		return "", err
+	}
+	w.Flush()
+	return b.String(), w.Error()
+}
+
+func availability(p *entity.FeedProduct, inStock, outOfStock string) string {
+	if p.InStock {
+		return inStock
+	}
+	return outOfStock
+}
+
+// price es el formato que aceptan los dos canales, ej. "2500.00 ARS"
+func price(m money.Money) string {
+	return m.Decimal() + " " + string(m.Currency())
+}
+
+// effectiveDate es la vigencia de la oferta en ISO 8601, "inicio/fin"
+func effectiveDate(p *entity.FeedProduct) string {
+	if p.SaleStartsAt == nil || p.SaleEndsAt == nil {
+		return ""
+	}
+	return p.SaleStartsAt.Format(time.RFC3339) + "/" + p.SaleEndsAt.Format(time.RFC3339)
+}
@@ -0,0 +1,96 @@
+// Package label dibuja códigos de barras EAN-13 en PNG y SVG y hojas de etiquetas en PDF,
+// todo en Go puro
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"core/internal/domain/barcode"
+	"core/internal/domain/entity"
+	"core/internal/domain/service"
+	"core/internal/infrastructure/pdf"
+)
+
+// Medidas del PNG: cada módulo mide pngScale píxeles y las barras pngHeight módulos,
+// la proporción nominal del EAN-13
+const (
+	pngScale  = 3
+	pngHeight = 70
+)
+
+// Medidas del SVG en milímetros, con el módulo nominal de 0,33 mm
+const (
+	svgModule = 0.33
+	svgHeight = 22.85
+	svgText   = 3.0 // alto reservado para los dígitos
+)
+
+type Renderer struct{}
+
+func NewRenderer() *Renderer { return &Renderer{} }
+
+var _ service.LabelRenderer = (*Renderer)(nil)
+
+func (r *Renderer) BarCode(code string, format entity.BarCodeFormat) ([]byte, error) {
+	modules, err := barcode.Encode(code)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case entity.BarCodeFormatPNG:
+		return renderPNG(modules)
+	case entity.BarCodeFormatSVG:
+		return renderSVG(code, modules), nil
+	}
+	return nil, fmt.Errorf("unsupported bar code format %q", format)
+}
+
+func (r *Renderer) Sheet(labels []entity.ProductLabel) ([]byte, error) {
+	return pdf.ProductLabels(labels)
+}
+
+// renderPNG dibuja solo las barras, sin los dígitos, con las zonas de silencio a los lados
+func renderPNG(modules []bool) ([]byte, error) {
+	width := (barcode.QuietLeft + len(modules) + barcode.QuietRight) * pngScale
+	height := pngHeight * pngScale
+	img := image.NewPaletted(image.Rect(0, 0, width, height), color.Palette{color.White, color.Black})
+	for _, b := range barcode.Bars(modules) {
+		x0 := (barcode.QuietLeft + b.Start) * pngScale
+		x1 := x0 + b.Width*pngScale
+		for y := 0; y < height; y++ {
+			for x := x0; x < x1; x++ {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderSVG dibuja las barras en unidades de módulo y los dígitos debajo
+func renderSVG(code string, modules []bool) []byte {
+	total := barcode.QuietLeft + len(modules) + barcode.QuietRight
+	barH := svgHeight / svgModule
+	textH := svgText / svgModule
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.2fmm" height="%.2fmm" viewBox="0 0 %d %.2f">`,
+		float64(total)*svgModule, svgHeight+svgText, total, barH+textH)
+	fmt.Fprintf(&b, `<rect width="%d" height="%.2f" fill="#fff"/>`, total, barH+textH)
+	b.WriteString(`<g fill="#000">`)
+	for _, bar := range barcode.Bars(modules) {
+		fmt.Fprintf(&b, `<rect x="%d" width="%d" height="%.2f"/>`, barcode.QuietLeft+bar.Start, bar.Width, barH)
+	}
+	b.WriteString(`</g>`)
+	fmt.Fprintf(&b, `<text x="%.1f" y="%.2f" font-family="monospace" font-size="%.2f" text-anchor="middle" textLength="%d">%s</text>`,
+		float64(total)/2, barH+textH-1, textH-1, len(modules), code)
+	b.WriteString(`</svg>`)
+	return b.Bytes()
+}
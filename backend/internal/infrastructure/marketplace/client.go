@@ -0,0 +1,267 @@
+// Package marketplace contiene el cliente de la API de publicaciones y ventas, con el
+// estilo de Mercado Libre, y un marketplace local para desarrollo y pruebas
+package marketplace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/service"
+)
+
+// Client habla con un marketplace por HTTP. Las publicaciones son /items y las ventas
+// se buscan en /orders/search, autenticando con un token Bearer.
+type Client struct {
+	name    string
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewClient recibe el nombre con que se guardan las publicaciones, la URL base de la
+// API y el token de acceso del vendedor
+func NewClient(name, baseURL, token string) *Client {
+	return &Client{
+		name:    name,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+var _ service.MarketplaceClient = (*Client)(nil)
+
+func (c *Client) Name() string { return c.name }
+
+// ordersPageSize es cuántas ventas se piden por página
+const ordersPageSize = 50
+
+type apiPicture struct {
+	Source string `json:"source"`
+}
+
+type apiItem struct {
+	ID                string       `json:"id,omitempty"`
+	Title             string       `json:"title,omitempty"`
+	Description       string       `json:"description,omitempty"`
+	Price             json.Number  `json:"price,omitempty"`
+	CurrencyID        string       `json:"currency_id,omitempty"`
+	AvailableQuantity *int64       `json:"available_quantity,omitempty"`
+	Status            string       `json:"status,omitempty"`
+	Pictures          []apiPicture `json:"pictures,omitempty"`
+}
+
+type apiOrder struct {
+	ID          int64          `json:"id"`
+	Status      string         `json:"status"`
+	DateCreated time.Time      `json:"date_created"`
+	TotalAmount json.Number    `json:"total_amount"`
+	CurrencyID  string         `json:"currency_id"`
+	Buyer       apiBuyer       `json:"buyer"`
+	OrderItems  []apiOrderItem `json:"order_items"`
+}
+
+type apiBuyer struct {
+	Nickname string `json:"nickname"`
+}
+
+type apiOrderItem struct {
+	Item      apiOrderItemRef `json:"item"`
+	Quantity  int64           `json:"quantity"`
+	UnitPrice json.Number     `json:"unit_price"`
+}
+
+type apiOrderItemRef struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type apiOrderSearch struct {
+	Results []apiOrder `json:"results"`
+	Paging  struct {
+		Total  int `json:"total"`
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	} `json:"paging"`
+}
+
+func (c *Client) CreateItem(ctx context.Context, item entity.MarketplaceItem) (string, error) {
+	body := apiItem{
+		Title:             item.Title,
+		Description:       item.Description,
+		Price:             json.Number(item.Price.Decimal()),
+		CurrencyID:        string(item.Price.Currency()),
+		AvailableQuantity: &item.Stock,
+	}
+	for _, p := range item.Pictures {
+		body.Pictures = append(body.Pictures, apiPicture{Source: p})
+	}
+	var created apiItem
+	if err := c.do(ctx, http.MethodPost, "/items", body, &created); err != nil {
+		return "", err
+	}
+	if created.ID == "" {
+		return "", fmt.Errorf("marketplace did not return the item id")
+	}
+	return created.ID, nil
+}
+
+func (c *Client) GetItem(ctx context.Context, externalID string) (entity.MarketplaceItem, error) {
+	var it apiItem
+	if err := c.do(ctx, http.MethodGet, "/items/"+url.PathEscape(externalID), nil, &it); err != nil {
+		return entity.MarketplaceItem{}, err
+	}
+	price, err := parseAmount(it.Price, it.CurrencyID)
+	if err != nil {
+		return entity.MarketplaceItem{}, err
+	}
+	out := entity.MarketplaceItem{
+		ExternalID:  it.ID,
+		Title:       it.Title,
+		Description: it.Description,
+		Price:       price,
+		Status:      it.Status,
+	}
+	if it.AvailableQuantity != nil {
+		out.Stock = *it.AvailableQuantity
+	}
+	for _, p := range it.Pictures {
+		out.Pictures = append(out.Pictures, p.Source)
+	}
+	return out, nil
+}
+
+func (c *Client) UpdateItem(ctx context.Context, externalID string, stock int64, price money.Money) error {
+	body := apiItem{
+		Price:             json.Number(price.Decimal()),
+		CurrencyID:        string(price.Currency()),
+		AvailableQuantity: &stock,
+	}
+	return c.do(ctx, http.MethodPut, "/items/"+url.PathEscape(externalID), body, nil)
+}
+
+func (c *Client) Orders(ctx context.Context, since time.Time) ([]entity.MarketplaceOrder, error) {
+	out := []entity.MarketplaceOrder{}
+	for offset := 0; ; {
+		q := url.Values{}
+		q.Set("order.status", "paid")
+		q.Set("order.date_created.from", since.UTC().Format(time.RFC3339))
+		q.Set("sort", "date_asc")
+		q.Set("offset", strconv.Itoa(offset))
+		q.Set("limit", strconv.Itoa(ordersPageSize))
+		var page apiOrderSearch
+		if err := c.do(ctx, http.MethodGet, "/orders/search?"+q.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, o := range page.Results {
+			order, err := toOrder(o)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, order)
+		}
+		offset += len(page.Results)
+		if len(page.Results) == 0 || offset >= page.Paging.Total {
+			return out, nil
+		}
+	}
+}
+
+func toOrder(o apiOrder) (entity.MarketplaceOrder, error) {
+	total, err := parseAmount(o.TotalAmount, o.CurrencyID)
+	if err != nil {
+		return entity.MarketplaceOrder{}, err
+	}
+	order := entity.MarketplaceOrder{
+		ExternalID: strconv.FormatInt(o.ID, 10),
+		Buyer:      o.Buyer.Nickname,
+		Total:      total,
+		OrderedAt:  o.DateCreated,
+		Lines:      make([]entity.MarketplaceOrderLine, 0, len(o.OrderItems)),
+	}
+	for _, it := range o.OrderItems {
+		unit, err := parseAmount(it.UnitPrice, o.CurrencyID)
+		if err != nil {
+			return entity.MarketplaceOrder{}, err
+		}
+		order.Lines = append(order.Lines, entity.MarketplaceOrderLine{
+			ExternalItemID: it.Item.ID,
+			Title:          it.Item.Title,
+			Quantity:       it.Quantity,
+			UnitPrice:      unit,
+		})
+	}
+	return order, nil
+}
+
+// parseAmount convierte un importe de la API, que llega como número JSON, en un monto exacto
+func parseAmount(n json.Number, currency string) (money.Money, error) {
+	return money.Parse(n.String(), money.Currency(currency), money.RoundHalfEven)
+}
+
+// apiError es el cuerpo de las respuestas con error
+type apiError struct {
+	Message string `json:"message"`
+}
+
+// do envía la petición con el token y decodifica la respuesta en out, si no es nil. Un
+// 404 se informa como ErrNotFound.
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e apiError
+		if json.Unmarshal(data, &e) == nil && e.Message != "" {
+			return fmt.Errorf("%s %s: marketplace responded %s: %s", method, path, resp.Status, e.Message)
+		}
+		return fmt.Errorf("%s %s: marketplace responded %s", method, path, resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%s %s: invalid marketplace response: %w", method, path, err)
+	}
+	return nil
+}
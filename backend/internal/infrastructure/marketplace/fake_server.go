@@ -0,0 +1,271 @@
+package marketplace
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"core/internal/domain/money"
+)
+
+// FakeServer es un marketplace en memoria con la misma API que usa Client. Además de
+// /items y /orders/search expone POST /items/{id}/purchase, que simula la compra de un
+// cliente: descuenta el stock de la publicación y registra una venta pagada.
+type FakeServer struct {
+	token string
+	mux   *http.ServeMux
+
+	mu     sync.Mutex
+	items  map[string]*fakeItem
+	orders []apiOrder
+	seq    int64
+	now    func() time.Time
+}
+
+type fakeItem struct {
+	apiItem
+	price money.Money
+	stock int64
+}
+
+// NewFakeServer exige el token en cada petición; sin token acepta cualquiera
+func NewFakeServer(token string) *FakeServer {
+	s := &FakeServer{token: token, mux: http.NewServeMux(), items: map[string]*fakeItem{}, now: time.Now}
+	s.mux.HandleFunc("POST /items", s.createItem)
+	s.mux.HandleFunc("GET /items", s.listItems)
+	s.mux.HandleFunc("GET /items/{id}", s.getItem)
+	s.mux.HandleFunc("PUT /items/{id}", s.updateItem)
+	s.mux.HandleFunc("POST /items/{id}/purchase", s.purchase)
+	s.mux.HandleFunc("GET /orders/search", s.searchOrders)
+	return s
+}
+
+func (s *FakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+		writeJSON(w, http.StatusUnauthorized, apiError{Message: "invalid access token"})
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func badRequest(w http.ResponseWriter, format string, args ...any) {
+	writeJSON(w, http.StatusBadRequest, apiError{Message: fmt.Sprintf(format, args...)})
+}
+
+// view es la publicación como la devuelve la API
+func (it *fakeItem) view() apiItem {
+	v := it.apiItem
+	v.Price = json.Number(it.price.Decimal())
+	v.CurrencyID = string(it.price.Currency())
+	stock := it.stock
+	v.AvailableQuantity = &stock
+	return v
+}
+
+func (s *FakeServer) createItem(w http.ResponseWriter, r *http.Request) {
+	var in apiItem
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		badRequest(w, "invalid body")
+		return
+	}
+	price, err := parseAmount(in.Price, in.CurrencyID)
+	if err != nil || !price.IsPositive() {
+		badRequest(w, "invalid price")
+		return
+	}
+	if strings.TrimSpace(in.Title) == "" {
+		badRequest(w, "title is required")
+		return
+	}
+	var stock int64
+	if in.AvailableQuantity != nil {
+		stock = *in.AvailableQuantity
+	}
+	if stock < 0 {
+		badRequest(w, "invalid available_quantity")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	in.ID = fmt.Sprintf("MLF%010d", s.seq)
+	in.Status = "active"
+	it := &fakeItem{apiItem: in, price: price, stock: stock}
+	s.items[in.ID] = it
+	log.Printf("[FAKE MARKETPLACE] Item %s created: %q, %s, stock %d", in.ID, in.Title, price, stock)
+	writeJSON(w, http.StatusCreated, it.view())
+}
+
+func (s *FakeServer) listItems(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]apiItem, 0, len(s.items))
+	for _, it := range s.items {
+		out = append(out, it.view())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *FakeServer) getItem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, ok := s.items[r.PathValue("id")]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, apiError{Message: "item not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, it.view())
+}
+
+// updateItem cambia precio, stock o estado; los campos ausentes quedan igual
+func (s *FakeServer) updateItem(w http.ResponseWriter, r *http.Request) {
+	var in apiItem
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		badRequest(w, "invalid body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, ok := s.items[r.PathValue("id")]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, apiError{Message: "item not found"})
+		return
+	}
+	if it.Status == "closed" {
+		badRequest(w, "item is closed")
+		return
+	}
+	if in.Price != "" {
+		currency := in.CurrencyID
+		if currency == "" {
+			currency = string(it.price.Currency())
+		}
+		price, err := parseAmount(in.Price, currency)
+		if err != nil || !price.IsPositive() {
+			badRequest(w, "invalid price")
+			return
+		}
+		it.price = price
+	}
+	if in.AvailableQuantity != nil {
+		if *in.AvailableQuantity < 0 {
+			badRequest(w, "invalid available_quantity")
+			return
+		}
+		it.stock = *in.AvailableQuantity
+	}
+	switch in.Status {
+	case "":
+	case "active", "paused", "closed":
+		it.Status = in.Status
+	default:
+		badRequest(w, "invalid status")
+		return
+	}
+	writeJSON(w, http.StatusOK, it.view())
+}
+
+type purchaseRequest struct {
+	Quantity int64  `json:"quantity"`
+	Buyer    string `json:"buyer"`
+}
+
+func (s *FakeServer) purchase(w http.ResponseWriter, r *http.Request) {
+	req := purchaseRequest{Quantity: 1, Buyer: "COMPRADOR_TEST"}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			badRequest(w, "invalid body")
+			return
+		}
+	}
+	if req.Quantity <= 0 {
+		badRequest(w, "invalid quantity")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, ok := s.items[r.PathValue("id")]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, apiError{Message: "item not found"})
+		return
+	}
+	if it.Status != "active" {
+		writeJSON(w, http.StatusConflict, apiError{Message: "item is not active"})
+		return
+	}
+	if it.stock < req.Quantity {
+		writeJSON(w, http.StatusConflict, apiError{Message: "not enough stock"})
+		return
+	}
+	it.stock -= req.Quantity
+
+	o := apiOrder{
+		ID:          2000000000 + int64(len(s.orders)) + 1,
+		Status:      "paid",
+		DateCreated: s.now().UTC().Truncate(time.Second),
+		TotalAmount: json.Number(it.price.Mul(req.Quantity).Decimal()),
+		CurrencyID:  string(it.price.Currency()),
+		Buyer:       apiBuyer{Nickname: req.Buyer},
+		OrderItems: []apiOrderItem{{
+			Item:      apiOrderItemRef{ID: it.ID, Title: it.Title},
+			Quantity:  req.Quantity,
+			UnitPrice: json.Number(it.price.Decimal()),
+		}},
+	}
+	s.orders = append(s.orders, o)
+	log.Printf("[FAKE MARKETPLACE] Order %d: %d x %s, stock left %d", o.ID, req.Quantity, it.ID, it.stock)
+	writeJSON(w, http.StatusCreated, o)
+}
+
+// searchOrders filtra por order.status y order.date_created.from, de la más vieja a la
+// más nueva
+func (s *FakeServer) searchOrders(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var from time.Time
+	if v := q.Get("order.date_created.from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			badRequest(w, "invalid order.date_created.from")
+			return
+		}
+		from = t
+	}
+	status := q.Get("order.status")
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 || limit > 50 {
+		limit = 50
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	matched := []apiOrder{}
+	for _, o := range s.orders {
+		if (status == "" || o.Status == status) && !o.DateCreated.Before(from) {
+			matched = append(matched, o)
+		}
+	}
+	var res apiOrderSearch
+	res.Paging.Total, res.Paging.Offset, res.Paging.Limit = len(matched), offset, limit
+	res.Results = []apiOrder{}
+	if offset < len(matched) {
+		res.Results = matched[offset:min(offset+limit, len(matched))]
+	}
+	writeJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,73 @@
+// Package notify contiene los canales de entrega de avisos a los administradores y de
+// eventos a sistemas externos
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"core/internal/config"
+	"core/internal/domain/entity"
+	"core/internal/domain/service"
+)
+
+// Email envía los avisos por SMTP a una lista fija de destinatarios
+type Email struct {
+	cfg  config.SMTPConfig
+	to   []string
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	now  func() time.Time
+}
+
+func NewEmail(cfg config.SMTPConfig, to []string) *Email {
+	return &Email{cfg: cfg, to: to, send: smtp.SendMail, now: time.Now}
+}
+
+var _ service.StockAlertNotifier = (*Email)(nil)
+
+func (e *Email) Channel() string { return entity.StockAlertChannelEmail }
+
+func (e *Email) NotifyLowStock(ctx context.Context, items []entity.LowStockItem) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "Estos productos quedaron por debajo de su punto de reposición:\n\n")
+	w := tabwriter.NewWriter(&body, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CÓDIGO\tPRODUCTO\tTALLE\tSTOCK\tUMBRAL\tREPONER")
+	for _, it := range items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\n", it.BarCode, it.Title, it.Size, it.Stock, it.Threshold, it.ReorderQuantity)
+	}
+	w.Flush()
+
+	subject := fmt.Sprintf("Stock bajo: %d producto(s)", len(items))
+	return e.sendMail(subject, body.String())
+}
+
+func (e *Email) sendMail(subject, body string) error {
+	from := mail.Address{Name: e.cfg.FromName, Address: e.cfg.FromEmail}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", e.now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if e.cfg.User != "" {
+		auth = smtp.PlainAuth("", e.cfg.User, e.cfg.Password, e.cfg.Host)
+	}
+	addr := e.cfg.Host + ":" + strconv.Itoa(e.cfg.Port)
+	return e.send(addr, auth, e.cfg.FromEmail, e.to, msg.Bytes())
+}
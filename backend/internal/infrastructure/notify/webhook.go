@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/service"
+)
+
+// SignatureHeader lleva el HMAC-SHA256 en hex del cuerpo, firmado con el secreto del webhook
+const SignatureHeader = "X-Signature"
+
+// Sign retorna el HMAC-SHA256 en hex del cuerpo
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Webhook publica los avisos como JSON en una URL
+type Webhook struct {
+	url    string
+	secret []byte
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewWebhook recibe la URL de destino y el secreto de la firma; sin secreto no se firma
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{url: url, secret: []byte(secret), client: &http.Client{Timeout: 10 * time.Second}, now: time.Now}
+}
+
+var _ service.StockAlertNotifier = (*Webhook)(nil)
+
+func (w *Webhook) Channel() string { return entity.StockAlertChannelWebhook }
+
+type lowStockPayload struct {
+	Event  string                `json:"event"`
+	SentAt time.Time             `json:"sent_at"`
+	Items  []entity.LowStockItem `json:"items"`
+}
+
+func (w *Webhook) NotifyLowStock(ctx context.Context, items []entity.LowStockItem) error {
+	body, err := json.Marshal(lowStockPayload{Event: "stock.low", SentAt: w.now().UTC(), Items: items})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(w.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(w.secret, body))
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/service"
+)
+
+// Encabezados de los envíos de webhooks, además de SignatureHeader
+const (
+	EventHeader    = "X-Webhook-Event"
+	EventIDHeader  = "X-Webhook-Id"       // igual en los reintentos y reenvíos, para descartar repetidos
+	DeliveryHeader = "X-Webhook-Delivery" // distinto en cada reenvío manual
+)
+
+// WebhookSender envía los eventos como JSON firmado con el secreto de cada destino
+type WebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender() *WebhookSender {
+	return &WebhookSender{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+var _ service.WebhookSender = (*WebhookSender)(nil)
+
+func (s *WebhookSender) Send(ctx context.Context, endpoint entity.WebhookEndpoint, delivery entity.WebhookDelivery, msg entity.WebhookMessage) (int, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(msg.Event))
+	req.Header.Set(EventIDHeader, strconv.FormatInt(msg.ID, 10))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(SignatureHeader, Sign([]byte(endpoint.Secret), body))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if text := strings.TrimSpace(string(excerpt)); text != "" {
+			return resp.StatusCode, fmt.Errorf("webhook responded %s: %s", resp.Status, text)
+		}
+		return resp.StatusCode, fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
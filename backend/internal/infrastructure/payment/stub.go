@@ -0,0 +1,42 @@
+// Package payment contiene las implementaciones de service.PaymentGateway
+package payment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"core/internal/domain/service"
+)
+
+// Stub acredita los reembolsos localmente, sin conectarse a ninguna pasarela. La referencia
+// sale de la clave de idempotencia, así un reintento devuelve el mismo reembolso.
+// Sirve para desarrollo y pruebas.
+type Stub struct {
+	now func() time.Time
+}
+
+func NewStub() *Stub {
+	return &Stub{now: time.Now}
+}
+
+var _ service.PaymentGateway = (*Stub)(nil)
+
+func (s *Stub) Refund(ctx context.Context, order *entity.Order, amount money.Money, idempotencyKey string) (entity.Refund, error) {
+	if err := ctx.Err(); err != nil {
+		return entity.Refund{}, err
+	}
+	if !amount.IsPositive() || amount.GreaterThan(order.Total) || amount.Currency() != order.Currency {
+		return entity.Refund{}, fmt.Errorf("refund of %s is not valid for order %d (total %s)", amount, order.ID, order.Total)
+	}
+	sum := sha256.Sum256(fmt.Appendf(nil, "%d|%s", order.ID, idempotencyKey))
+	return entity.Refund{
+		Reference: "RF-" + hex.EncodeToString(sum[:8]),
+		Amount:    amount,
+		CreatedAt: s.now().UTC().Truncate(time.Second),
+	}, nil
+}
@@ -0,0 +1,217 @@
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"core/internal/domain/service"
+	"core/internal/domain/tax"
+)
+
+// Márgenes y medidas de la página, en puntos
+const (
+	marginX      = 40.0
+	contentRight = A4Width - marginX
+	rowHeight    = 16.0
+	footerLimit  = 170.0 // debajo de esta altura van los totales y el CAE
+)
+
+// InvoiceRenderer dibuja la factura en una o más páginas A4
+type InvoiceRenderer struct{}
+
+func NewInvoiceRenderer() *InvoiceRenderer { return &InvoiceRenderer{} }
+
+var _ service.InvoiceRenderer = (*InvoiceRenderer)(nil)
+
+// column es una columna de la tabla de ítems; right alinea el texto a la derecha de x+width
+type column struct {
+	title string
+	x     float64
+	width float64
+	right bool
+	value func(l *entity.InvoiceLine) string
+}
+
+func (r *InvoiceRenderer) Render(inv *entity.Invoice) ([]byte, error) {
+	doc := New()
+	doc.SetTitle("Factura " + string(inv.Type) + " " + inv.FullNumber())
+
+	cols := columns(inv.Type)
+	page := doc.AddPage(A4Width, A4Height)
+	y := drawHeader(page, inv)
+	y = drawTableHeader(page, cols, y)
+	for i := range inv.Lines {
+		if y < footerLimit {
+			page = doc.AddPage(A4Width, A4Height)
+			y = drawTableHeader(page, cols, A4Height-60)
+		}
+		for _, c := range cols {
+			text := c.value(&inv.Lines[i])
+			if c.right {
+				page.TextRight(c.x+c.width, y, Helvetica, 9, text)
+			} else {
+				page.Text(c.x, y, Helvetica, 9, fit(text, c.width-6, 9))
+			}
+		}
+		y -= rowHeight
+	}
+	drawTotals(page, inv)
+	drawAuthorization(page, inv)
+	return doc.Bytes()
+}
+
+func columns(t entity.InvoiceType) []column {
+	cols := []column{
+		{title: "Cant.", x: marginX, width: 40, right: true, value: func(l *entity.InvoiceLine) string { return strconv.FormatInt(l.Quantity, 10) }},
+		{title: "Descripción", x: marginX + 50, width: 200, value: func(l *entity.InvoiceLine) string { return l.Description }},
+		{title: "P. unitario", x: marginX + 250, width: 70, right: true, value: func(l *entity.InvoiceLine) string { return amount(l.UnitPrice) }},
+		{title: "Bonif.", x: marginX + 320, width: 55, right: true, value: func(l *entity.InvoiceLine) string { return amount(l.Discount) }},
+	}
+	// En la A el IVA va discriminado por línea; en la B el importe ya lo incluye
+	if t == entity.InvoiceTypeA {
+		return append(cols,
+			column{title: "Alíc.", x: marginX + 375, width: 35, right: true, value: func(l *entity.InvoiceLine) string { return l.TaxRate.String() + "%" }},
+			column{title: "Neto", x: marginX + 410, width: 55, right: true, value: func(l *entity.InvoiceLine) string { return amount(l.Net) }},
+			column{title: "IVA", x: marginX + 465, width: 50, right: true, value: func(l *entity.InvoiceLine) string { return amount(l.Tax) }},
+		)
+	}
+	return append(cols,
+		column{title: "Importe", x: marginX + 415, width: 100, right: true, value: func(l *entity.InvoiceLine) string { return amount(l.Total) }},
+	)
+}
+
+// drawHeader dibuja emisor, letra, número y cliente; retorna la altura donde empieza la tabla
+func drawHeader(p *Page, inv *entity.Invoice) float64 {
+	top := A4Height - 40
+	mid := A4Width / 2
+
+	p.Rect(marginX, top-110, contentRight-marginX, 110, 1, false)
+	p.Line(mid, top-110, mid, top-40, 1)
+
+	// Letra del comprobante en un recuadro centrado
+	p.Gray(1)
+	p.Rect(mid-20, top-40, 40, 40, 1, true)
+	p.Gray(0)
+	p.Rect(mid-20, top-40, 40, 40, 1, false)
+	p.TextCenter(mid, top-30, HelveticaBold, 26, string(inv.Type))
+
+	p.Text(marginX+10, top-25, HelveticaBold, 14, inv.Issuer.Name)
+	p.Text(marginX+10, top-60, Helvetica, 9, inv.Issuer.Address)
+	p.Text(marginX+10, top-75, Helvetica, 9, "CUIT: "+tax.FormatTaxID(inv.Issuer.TaxID, tax.IDTypeCUIT))
+	p.Text(marginX+10, top-90, Helvetica, 9, "IVA Responsable Inscripto")
+
+	x := mid + 30
+	p.Text(x, top-25, HelveticaBold, 16, "FACTURA")
+	p.Text(x, top-60, Helvetica, 10, fmt.Sprintf("Punto de venta: %04d   Comp. Nro: %08d", inv.PointOfSale, inv.Number))
+	p.Text(x, top-75, Helvetica, 10, "Fecha de emisión: "+inv.IssuedAt.Format("02/01/2006"))
+
+	y := top - 130
+	p.Text(marginX, y, HelveticaBold, 10, "Cliente: ")
+	p.Text(marginX+45, y, Helvetica, 10, inv.Customer.Name)
+	condition := "Consumidor Final"
+	if inv.Customer.TaxIDType == tax.IDTypeCUIT {
+		condition = "IVA Responsable Inscripto"
+	}
+	p.Text(mid, y, Helvetica, 10, "Condición frente al IVA: "+condition)
+	y -= 15
+	if inv.Customer.TaxID != "" {
+		p.Text(marginX, y, Helvetica, 10, string(inv.Customer.TaxIDType)+": "+tax.FormatTaxID(inv.Customer.TaxID, inv.Customer.TaxIDType))
+	}
+	if inv.Customer.Address != "" {
+		p.Text(mid, y, Helvetica, 10, "Domicilio: "+fit(inv.Customer.Address, contentRight-mid-50, 10))
+	}
+	y -= 15
+	currency := "Moneda: " + string(inv.Currency)
+	if inv.ExchangeRate != nil {
+		currency += "   Tipo de cambio: " + inv.ExchangeRate.String()
+	}
+	p.Text(marginX, y, Helvetica, 10, currency)
+	p.Text(mid, y, Helvetica, 10, "Orden: #"+strconv.FormatInt(inv.OrderID, 10))
+	return y - 30
+}
+
+func drawTableHeader(p *Page, cols []column, y float64) float64 {
+	p.Gray(0.9)
+	p.Rect(marginX, y-5, contentRight-marginX, rowHeight, 0, true)
+	p.Gray(0)
+	for _, c := range cols {
+		if c.right {
+			p.TextRight(c.x+c.width, y, HelveticaBold, 9, c.title)
+		} else {
+			p.Text(c.x, y, HelveticaBold, 9, c.title)
+		}
+	}
+	return y - rowHeight - 4
+}
+
+func drawTotals(p *Page, inv *entity.Invoice) {
+	y := footerLimit - 20
+	p.Line(marginX, footerLimit-5, contentRight, footerLimit-5, 0.5)
+	labelX := contentRight - 200
+
+	row := func(label, value string, font Font) {
+		p.Text(labelX, y, font, 10, label)
+		p.TextRight(contentRight, y, font, 10, value)
+		y -= 14
+	}
+
+	if inv.Type == entity.InvoiceTypeA {
+		row("Importe neto gravado:", amount(inv.Net), Helvetica)
+		for _, g := range inv.TaxBreakdown() {
+			row("IVA "+g.Rate.String()+"%:", amount(g.Tax), Helvetica)
+		}
+		row("Importe total:", string(inv.Currency)+" "+amount(inv.Total), HelveticaBold)
+		return
+	}
+	row("Importe total:", string(inv.Currency)+" "+amount(inv.Total), HelveticaBold)
+	// Régimen de transparencia fiscal: la B informa el IVA contenido
+	p.Text(marginX, footerLimit-20, Helvetica, 8, "IVA contenido: "+amount(inv.Tax))
+}
+
+func drawAuthorization(p *Page, inv *entity.Invoice) {
+	y := 50.0
+	p.Line(marginX, y+20, contentRight, y+20, 0.5)
+	if inv.Status != entity.InvoiceStatusAuthorized || inv.AuthorizationExpiresAt == nil {
+		p.Text(marginX, y, HelveticaBold, 11, "COMPROBANTE NO AUTORIZADO - SIN VALIDEZ FISCAL")
+		return
+	}
+	p.Text(marginX, y, Helvetica, 10, "Comprobante autorizado")
+	p.TextRight(contentRight, y, HelveticaBold, 10,
+		"CAE: "+inv.AuthorizationCode+"   Vto. CAE: "+inv.AuthorizationExpiresAt.Format("02/01/2006"))
+}
+
+// amount formatea un monto al estilo local: 1.234,56
+func amount(m money.Money) string {
+	d := m.Decimal()
+	sign := ""
+	if strings.HasPrefix(d, "-") {
+		sign, d = "-", d[1:]
+	}
+	intPart, frac, hasFrac := strings.Cut(d, ".")
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	if hasFrac {
+		b.WriteString("," + frac)
+	}
+	return sign + b.String()
+}
+
+// fit recorta el texto con "..." para que no supere el ancho indicado
+func fit(s string, width, size float64) string {
+	if TextWidth(s, Helvetica, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && TextWidth(string(runes)+"...", Helvetica, size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -0,0 +1,51 @@
+package pdf
+
+// Anchos de los caracteres ASCII 32..126 en milésimas del tamaño de fuente (AFM de Adobe)
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// TextWidth retorna el ancho en puntos del texto. Las letras acentuadas se miden como
+// su letra base, que en Helvetica tiene el mismo ancho.
+func TextWidth(s string, font Font, size float64) float64 {
+	widths := &helveticaWidths
+	if font == HelveticaBold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, r := range s {
+		if r > 126 {
+			r = baseLetter(r)
+		}
+		if r < 32 || r > 126 {
+			total += 556
+			continue
+		}
+		total += widths[r-32]
+	}
+	return float64(total) * size / 1000
+}
+
+// latin1Base son las letras base de U+00C0..U+00FF; '?' donde no hay una letra equivalente
+const latin1Base = "AAAAAAACEEEEIIII" + "DNOOOOO?OUUUUYPs" + "aaaaaaaceeeeiiii" + "dnooooo?ouuuuypy"
+
+func baseLetter(r rune) rune {
+	if r >= 0xC0 && r <= 0xFF {
+		return rune(latin1Base[r-0xC0])
+	}
+	return r
+}
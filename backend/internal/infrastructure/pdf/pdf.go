@@ -0,0 +1,186 @@
+// Package pdf genera documentos PDF simples (texto, líneas y rectángulos) sin
+// dependencias externas. Usa las fuentes estándar Helvetica con codificación
+// WinAnsi, suficiente para textos en español.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Tamaños de página en puntos (1/72 de pulgada)
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Font identifica una de las fuentes estándar incluidas
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+func (f Font) resource() string {
+	if f == HelveticaBold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// Document es un PDF en construcción
+type Document struct {
+	pages []*Page
+	title string
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+// SetTitle define el título que muestran los visores
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// Page es una página; el origen (0,0) está en la esquina inferior izquierda
+type Page struct {
+	Width, Height float64
+	content       bytes.Buffer
+}
+
+// AddPage agrega una página del tamaño indicado
+func (d *Document) AddPage(width, height float64) *Page {
+	p := &Page{Width: width, Height: height}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Text escribe texto con la línea base en (x, y)
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		font.resource(), num(size), num(x), num(y), escape(encodeWinAnsi(s)))
+}
+
+// TextRight escribe texto alineado a la derecha terminando en x
+func (p *Page) TextRight(x, y float64, font Font, size float64, s string) {
+	p.Text(x-TextWidth(s, font, size), y, font, size, s)
+}
+
+// TextCenter escribe texto centrado en x
+func (p *Page) TextCenter(x, y float64, font Font, size float64, s string) {
+	p.Text(x-TextWidth(s, font, size)/2, y, font, size, s)
+}
+
+// Line traza una línea con el grosor indicado
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n", num(width), num(x1), num(y1), num(x2), num(y2))
+}
+
+// Rect traza un rectángulo; si fill es true lo rellena en negro
+func (p *Page) Rect(x, y, w, h, lineWidth float64, fill bool) {
+	op := "S"
+	if fill {
+		op = "f"
+	}
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re %s\n", num(lineWidth), num(x), num(y), num(w), num(h), op)
+}
+
+// Gray cambia el color de relleno y trazo a un gris (0 negro, 1 blanco)
+func (p *Page) Gray(level float64) {
+	fmt.Fprintf(&p.content, "%s g %s G\n", num(level), num(level))
+}
+
+// WriteTo serializa el documento
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	var offsets []int
+
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 catálogo, 2 árbol de páginas, 3 y 4 fuentes, 5 info; luego página y contenido por cada página
+	const firstPage = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title (%s) /Producer (core) >>", escape(encodeWinAnsi(d.title))))
+
+	for i, p := range d.pages {
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		if _, err := zw.Write(p.content.Bytes()); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(p.Width), num(p.Height), firstPage+2*i+1))
+		obj(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", z.Len(), z.Bytes()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+// Bytes retorna el documento serializado
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// num formatea un número con hasta dos decimales, sin ceros de más
+func num(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+// encodeWinAnsi convierte el texto a WinAnsi; los caracteres sin equivalente se reemplazan por '?'
+func encodeWinAnsi(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r < 0x80 || r >= 0xA0 && r <= 0xFF:
+			b.WriteByte(byte(r))
+		case r == '€':
+			b.WriteByte(0x80)
+		case r == '–':
+			b.WriteByte(0x96)
+		case r == '—':
+			b.WriteByte(0x97)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", `\r`, "\n", `\n`)
+	return r.Replace(s)
+}
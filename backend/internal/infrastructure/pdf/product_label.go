@@ -0,0 +1,66 @@
+package pdf
+
+import (
+	"core/internal/domain/barcode"
+	"core/internal/domain/entity"
+)
+
+// Hoja A4 de 3x8 etiquetas de 70x37 mm, el formato autoadhesivo más común
+const (
+	labelCols   = 3
+	labelRows   = 8
+	labelWidth  = A4Width / labelCols
+	labelHeight = A4Height / labelRows
+	labelMargin = 8.0
+	moduleWidth = 1.4 // ancho de un módulo del código de barras, en puntos
+	barHeight   = 38.0
+)
+
+// ProductLabels dibuja las etiquetas en hojas A4, Copies veces cada una, con título,
+// talle, precio y código de barras. Las etiquetas con código inválido fallan con
+// barcode.ErrInvalidBarCode.
+func ProductLabels(labels []entity.ProductLabel) ([]byte, error) {
+	doc := New()
+	doc.SetTitle("Etiquetas")
+
+	var page *Page
+	slot := 0
+	for _, l := range labels {
+		modules, err := barcode.Encode(l.BarCode)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < l.Copies; i++ {
+			if slot%(labelCols*labelRows) == 0 {
+				page = doc.AddPage(A4Width, A4Height)
+			}
+			pos := slot % (labelCols * labelRows)
+			x := float64(pos%labelCols) * labelWidth
+			y := A4Height - float64(pos/labelCols+1)*labelHeight
+			drawProductLabel(page, x, y, l, modules)
+			slot++
+		}
+	}
+	if page == nil {
+		doc.AddPage(A4Width, A4Height)
+	}
+	return doc.Bytes()
+}
+
+// drawProductLabel dibuja una etiqueta con su esquina inferior izquierda en (x, y)
+func drawProductLabel(p *Page, x, y float64, l entity.ProductLabel, modules []bool) {
+	width := labelWidth - 2*labelMargin
+	top := y + labelHeight - labelMargin
+
+	p.Text(x+labelMargin, top-10, HelveticaBold, 10, fit(l.Title, width-30, 10))
+	p.TextRight(x+labelWidth-labelMargin, top-10, HelveticaBold, 10, l.Size)
+	p.TextRight(x+labelWidth-labelMargin, top-27, HelveticaBold, 14, string(l.Price.Currency())+" "+amount(l.Price))
+
+	barsWidth := float64(barcode.Modules) * moduleWidth
+	left := x + (labelWidth-barsWidth)/2
+	bottom := y + labelMargin + 10
+	for _, b := range barcode.Bars(modules) {
+		p.Rect(left+float64(b.Start)*moduleWidth, bottom, float64(b.Width)*moduleWidth, barHeight, 0, true)
+	}
+	p.TextCenter(x+labelWidth/2, y+labelMargin, Helvetica, 8, l.BarCode)
+}
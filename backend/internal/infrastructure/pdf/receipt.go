@@ -0,0 +1,129 @@
+package pdf
+
+import (
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/service"
+	"core/internal/domain/tax"
+)
+
+// Ticket para impresoras térmicas de 80 mm; el alto depende de lo que se imprime
+const (
+	receiptWidth   = 226.77
+	receiptMargin  = 12.0
+	receiptRow     = 12.0
+	receiptPadding = 20.0
+)
+
+// ReceiptRenderer dibuja el ticket de una venta de mostrador
+type ReceiptRenderer struct{}
+
+func NewReceiptRenderer() *ReceiptRenderer { return &ReceiptRenderer{} }
+
+var _ service.ReceiptRenderer = (*ReceiptRenderer)(nil)
+
+// receiptLine es un renglón del ticket: texto a la izquierda y, opcional, a la derecha.
+// Un renglón vacío con rule traza una línea divisoria.
+type receiptLine struct {
+	left, right string
+	font        Font
+	size        float64
+	rule        bool
+}
+
+func (r *ReceiptRenderer) Receipt(rc *entity.PosReceipt) ([]byte, error) {
+	lines := receiptLines(rc)
+	height := float64(len(lines))*receiptRow + 2*receiptPadding
+
+	doc := New()
+	doc.SetTitle("Ticket " + strconv.FormatInt(rc.Sale.ID, 10))
+	p := doc.AddPage(receiptWidth, height)
+	y := height - receiptPadding
+	right := receiptWidth - receiptMargin
+	for _, l := range lines {
+		switch {
+		case l.rule:
+			p.Line(receiptMargin, y+receiptRow/3, right, y+receiptRow/3, 0.5)
+		case l.right == "":
+			p.Text(receiptMargin, y, l.font, l.size, fit(l.left, right-receiptMargin, l.size))
+		default:
+			valueWidth := TextWidth(l.right, l.font, l.size)
+			p.Text(receiptMargin, y, l.font, l.size, fit(l.left, right-receiptMargin-valueWidth-6, l.size))
+			p.TextRight(right, y, l.font, l.size, l.right)
+		}
+		y -= receiptRow
+	}
+	return doc.Bytes()
+}
+
+func receiptLines(rc *entity.PosReceipt) []receiptLine {
+	o, sale := &rc.Order, &rc.Sale
+	text := func(s string) receiptLine { return receiptLine{left: s, font: Helvetica, size: 8} }
+	row := func(label, value string) receiptLine {
+		return receiptLine{left: label, right: value, font: Helvetica, size: 8}
+	}
+	rule := receiptLine{rule: true}
+
+	date := o.CreatedAt
+	if sale.CompletedAt != nil {
+		date = *sale.CompletedAt
+	}
+	lines := []receiptLine{
+		{left: "TICKET DE VENTA", font: HelveticaBold, size: 11},
+		text("Caja " + rc.Shift.Register + " - " + rc.Shift.LocationCode),
+		text("Fecha: " + date.Format("02/01/2006 15:04")),
+		text("Venta #" + strconv.FormatInt(sale.ID, 10) + "   Orden #" + strconv.FormatInt(o.ID, 10)),
+		text("Cajero: " + strconv.FormatInt(sale.CashierID, 10)),
+		rule,
+	}
+	if o.Billing.TaxID != "" {
+		lines = append(lines,
+			text("Cliente: "+o.Billing.Name),
+			text(string(o.Billing.TaxIDType)+": "+tax.FormatTaxID(o.Billing.TaxID, o.Billing.TaxIDType)),
+			rule,
+		)
+	}
+
+	for _, it := range o.Items {
+		title := it.Title
+		if it.Size != "" {
+			title += " (" + it.Size + ")"
+		}
+		lines = append(lines,
+			text(title),
+			row("  "+strconv.FormatInt(it.Quantity, 10)+" x "+amount(it.UnitPrice), amount(it.LineTotal)),
+		)
+	}
+	lines = append(lines, rule, row("Subtotal", amount(o.Subtotal)))
+	for _, d := range o.Discounts {
+		lines = append(lines, row(d.Name, "-"+amount(d.Amount)))
+	}
+	if o.TaxMode == tax.ModeExclusive {
+		lines = append(lines, row("IVA", amount(o.Tax)))
+	}
+	lines = append(lines,
+		receiptLine{left: "TOTAL", right: string(o.Currency) + " " + amount(o.Total), font: HelveticaBold, size: 10},
+		rule,
+	)
+
+	for _, pm := range sale.Payments {
+		label := "Efectivo"
+		if pm.Method == entity.PaymentMethodCard {
+			label = "Tarjeta"
+			if pm.Reference != "" {
+				label += " " + pm.Reference
+			}
+		}
+		lines = append(lines, row(label, amount(pm.Amount)))
+	}
+	if sale.Change != nil && sale.Change.IsPositive() {
+		lines = append(lines, row("Vuelto", amount(*sale.Change)))
+	}
+	lines = append(lines, rule)
+	// Régimen de transparencia fiscal: con precios finales se informa el IVA contenido
+	if o.TaxMode != tax.ModeExclusive {
+		lines = append(lines, text("IVA contenido: "+amount(o.Tax)))
+	}
+	return append(lines, text("No válido como factura"), text("Gracias por su compra"))
+}
@@ -0,0 +1,74 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+
+	"core/internal/domain/entity"
+)
+
+// Etiqueta de envío de 10x15 cm
+const (
+	LabelWidth  = 283.46
+	LabelHeight = 425.20
+)
+
+// ShippingLabel dibuja la etiqueta de un envío con remitente, destinatario y número de seguimiento
+func ShippingLabel(carrier, trackingNumber, sender string, sh *entity.Shipment) ([]byte, error) {
+	doc := New()
+	doc.SetTitle("Envío " + trackingNumber)
+	p := doc.AddPage(LabelWidth, LabelHeight)
+
+	const margin = 15.0
+	width := LabelWidth - 2*margin
+	p.Rect(margin, margin, width, LabelHeight-2*margin, 1.5, false)
+
+	y := LabelHeight - margin - 25
+	p.Text(margin+10, y, HelveticaBold, 16, strings.ToUpper(carrier))
+	p.TextRight(LabelWidth-margin-10, y, Helvetica, 9, fmt.Sprintf("Orden #%d", sh.OrderID))
+	y -= 15
+	p.Line(margin, y, LabelWidth-margin, y, 1)
+
+	y -= 20
+	p.Text(margin+10, y, HelveticaBold, 8, "REMITENTE")
+	y -= 13
+	p.Text(margin+10, y, Helvetica, 10, fit(sender, width-20, 10))
+
+	y -= 25
+	p.Text(margin+10, y, HelveticaBold, 8, "DESTINATARIO")
+	a := sh.Address
+	street := strings.TrimSpace(a.Street + " " + a.Number)
+	if a.Apartment != "" {
+		street += " " + a.Apartment
+	}
+	city := a.City
+	if name := entity.Provinces[a.Province]; name != "" && a.Province != "C" {
+		city += ", " + name
+	}
+	for _, line := range []struct {
+		font Font
+		text string
+	}{
+		{HelveticaBold, a.RecipientName},
+		{Helvetica, street},
+		{Helvetica, city},
+		{HelveticaBold, "CP " + a.PostalCode},
+		{Helvetica, "Tel: " + a.Phone},
+	} {
+		y -= 16
+		p.Text(margin+10, y, line.font, 12, fit(line.text, width-20, 12))
+	}
+
+	y -= 25
+	p.Text(margin+10, y, Helvetica, 10, fmt.Sprintf("Peso: %.2f kg", float64(sh.WeightGrams)/1000))
+	y -= 20
+	p.Line(margin, y, LabelWidth-margin, y, 1)
+
+	// Número de seguimiento destacado en el pie
+	p.Gray(0.9)
+	p.Rect(margin+10, margin+20, width-20, 50, 0, true)
+	p.Gray(0)
+	p.TextCenter(LabelWidth/2, margin+55, Helvetica, 8, "N° DE SEGUIMIENTO")
+	p.TextCenter(LabelWidth/2, margin+32, HelveticaBold, 16, trackingNumber)
+	return doc.Bytes()
+}
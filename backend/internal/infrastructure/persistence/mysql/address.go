@@ -0,0 +1,156 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+type AddressRepo struct {
+	DB *sql.DB
+}
+
+func NewAddressRepository(db *sql.DB) *AddressRepo { return &AddressRepo{DB: db} }
+
+var _ repository.AddressRepository = (*AddressRepo)(nil)
+
+const addressColumns = `id, user_id, label, recipient_name, phone, street, number, apartment, city,
+		province, postal_code, is_default_shipping, is_default_billing, updated_at, created_at`
+
+func scanAddress(s rowScanner) (entity.Address, error) {
+	var a entity.Address
+	err := s.Scan(&a.ID, &a.UserID, &a.Label, &a.RecipientName, &a.Phone, &a.Street, &a.Number, &a.Apartment, &a.City,
+		&a.Province, &a.PostalCode, &a.IsDefaultShipping, &a.IsDefaultBilling, &a.UpdatedAt, &a.CreatedAt)
+	return a, err
+}
+
+func (r *AddressRepo) Create(ctx context.Context, a *entity.Address) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Se bloquean las direcciones del usuario para que dos altas simultáneas no queden ambas por defecto
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM addresses WHERE user_id = ? FOR UPDATE`, a.UserID)
+	if err != nil {
+		return err
+	}
+	first := !rows.Next()
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if first {
+		a.IsDefaultShipping, a.IsDefaultBilling = true, true
+	}
+	if err := clearDefaults(ctx, tx, a); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO addresses (user_id, label, recipient_name, phone, street, number, apartment, city,
+			province, postal_code, is_default_shipping, is_default_billing)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?)`,
+		a.UserID, a.Label, a.RecipientName, a.Phone, a.Street, a.Number, a.Apartment, a.City,
+		a.Province, a.PostalCode, a.IsDefaultShipping, a.IsDefaultBilling,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create address: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	a.ID, _ = res.LastInsertId()
+	return nil
+}
+
+// clearDefaults desmarca las otras direcciones del usuario para los defaults que tiene a
+func clearDefaults(ctx context.Context, tx *sql.Tx, a *entity.Address) error {
+	if a.IsDefaultShipping {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE addresses SET is_default_shipping = FALSE WHERE user_id = ? AND id <> ?`, a.UserID, a.ID); err != nil {
+			return err
+		}
+	}
+	if a.IsDefaultBilling {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE addresses SET is_default_billing = FALSE WHERE user_id = ? AND id <> ?`, a.UserID, a.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *AddressRepo) Update(ctx context.Context, a *entity.Address) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := clearDefaults(ctx, tx, a); err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, `
+		UPDATE addresses SET label = ?, recipient_name = ?, phone = ?, street = ?, number = ?, apartment = ?,
+			city = ?, province = ?, postal_code = ?, is_default_shipping = ?, is_default_billing = ?, updated_at = NOW()
+		WHERE id = ? AND user_id = ?`,
+		a.Label, a.RecipientName, a.Phone, a.Street, a.Number, a.Apartment,
+		a.City, a.Province, a.PostalCode, a.IsDefaultShipping, a.IsDefaultBilling,
+		a.ID, a.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update address: %w", err)
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return tx.Commit()
+}
+
+func (r *AddressRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM addresses WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AddressRepo) GetByID(ctx context.Context, id int64) (entity.Address, error) {
+	a, err := scanAddress(r.DB.QueryRowContext(ctx, `SELECT `+addressColumns+` FROM addresses WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Address{}, domainerrors.ErrNotFound
+	}
+	return a, err
+}
+
+func (r *AddressRepo) ListByUser(ctx context.Context, userID int64) ([]entity.Address, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+addressColumns+` FROM addresses WHERE user_id = ?
+		ORDER BY is_default_shipping DESC, is_default_billing DESC, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.Address
+	for rows.Next() {
+		a, err := scanAddress(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
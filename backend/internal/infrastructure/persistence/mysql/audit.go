@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+type AuditRepo struct {
+	DB *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepo { return &AuditRepo{DB: db} }
+
+var _ repository.AuditRepository = (*AuditRepo)(nil)
+
+func (r *AuditRepo) Create(ctx context.Context, e *entity.AuditEntry) error {
+	if e.Changes == nil {
+		e.Changes = []entity.FieldChange{}
+	}
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return err
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO audit_log (entity_type, entity_id, action, changes, actor_id, actor_email, actor_role, ip)
+		VALUES (?,?,?,?,?,?,?,?)`,
+		e.EntityType, e.EntityID, e.Action, changes, e.Actor.UserID, e.Actor.Email, e.Actor.Role, e.Actor.IP,
+	)
+	if err != nil {
+		return err
+	}
+	id, _ := res.LastInsertId()
+	e.ID = id
+	return nil
+}
+
+func (r *AuditRepo) List(ctx context.Context, f entity.AuditFilter) ([]entity.AuditEntry, error) {
+	q := `
+		SELECT id, entity_type, entity_id, action, changes, actor_id, actor_email, actor_role, ip, created_at
+		FROM audit_log WHERE 1=1`
+	args := []any{}
+	if f.EntityType != "" {
+		q += " AND entity_type = ?"
+		args = append(args, f.EntityType)
+	}
+	if f.EntityID > 0 {
+		q += " AND entity_id = ?"
+		args = append(args, f.EntityID)
+	}
+	if f.ActorID > 0 {
+		q += " AND actor_id = ?"
+		args = append(args, f.ActorID)
+	}
+	if f.Action != "" {
+		q += " AND action = ?"
+		args = append(args, f.Action)
+	}
+	if f.From != nil {
+		q += " AND created_at >= ?"
+		args = append(args, *f.From)
+	}
+	if f.To != nil {
+		q += " AND created_at < ?"
+		args = append(args, *f.To)
+	}
+	q += " ORDER BY created_at DESC, id DESC"
+	limit := 50
+	if f.Limit > 0 && f.Limit <= 500 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.AuditEntry
+	for rows.Next() {
+		var e entity.AuditEntry
+		var changes []byte
+		var actorID sql.NullInt64
+		if err := rows.Scan(&e.ID, &e.EntityType, &e.EntityID, &e.Action, &changes, &actorID,
+			&e.Actor.Email, &e.Actor.Role, &e.Actor.IP, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &e.Changes); err != nil {
+			return nil, err
+		}
+		if actorID.Valid {
+			id := actorID.Int64
+			e.Actor.UserID = &id
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,253 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+)
+
+type ExchangeRateRepo struct {
+	DB *sql.DB
+}
+
+func NewExchangeRateRepository(db *sql.DB) *ExchangeRateRepo { return &ExchangeRateRepo{DB: db} }
+
+var _ repository.ExchangeRateRepository = (*ExchangeRateRepo)(nil)
+
+func (r *ExchangeRateRepo) Create(ctx context.Context, t *entity.ExchangeRateTable) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO exchange_rate_tables (base_currency, effective_from, created_by)
+		VALUES (?,?,?)`,
+		t.BaseCurrency, t.EffectiveFrom, t.CreatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	id, _ := res.LastInsertId()
+
+	for cur, rate := range t.Rates {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO exchange_rates (table_id, currency, rate) VALUES (?,?,?)`,
+			id, cur, rate,
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	t.ID = id
+	return nil
+}
+
+func (r *ExchangeRateRepo) Current(ctx context.Context, base money.Currency, at time.Time) (entity.ExchangeRateTable, error) {
+	var t entity.ExchangeRateTable
+	var createdBy sql.NullInt64
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT id, base_currency, effective_from, created_by, created_at
+		FROM exchange_rate_tables
+		WHERE base_currency = ? AND effective_from <= ?
+		ORDER BY effective_from DESC, id DESC
+		LIMIT 1`, base, at).
+		Scan(&t.ID, &t.BaseCurrency, &t.EffectiveFrom, &createdBy, &t.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ExchangeRateTable{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.ExchangeRateTable{}, err
+	}
+	if createdBy.Valid {
+		id := createdBy.Int64
+		t.CreatedBy = &id
+	}
+
+	tables := []*entity.ExchangeRateTable{&t}
+	if err := r.loadRates(ctx, tables); err != nil {
+		return entity.ExchangeRateTable{}, err
+	}
+	return t, nil
+}
+
+func (r *ExchangeRateRepo) List(ctx context.Context, limit int) ([]entity.ExchangeRateTable, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, base_currency, effective_from, created_by, created_at
+		FROM exchange_rate_tables
+		ORDER BY effective_from DESC, id DESC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.ExchangeRateTable
+	for rows.Next() {
+		var t entity.ExchangeRateTable
+		var createdBy sql.NullInt64
+		if err := rows.Scan(&t.ID, &t.BaseCurrency, &t.EffectiveFrom, &createdBy, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		if createdBy.Valid {
+			id := createdBy.Int64
+			t.CreatedBy = &id
+		}
+		out = append(out, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tables := make([]*entity.ExchangeRateTable, len(out))
+	for i := range out {
+		tables[i] = &out[i]
+	}
+	return out, r.loadRates(ctx, tables)
+}
+
+func (r *ExchangeRateRepo) loadRates(ctx context.Context, tables []*entity.ExchangeRateTable) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*entity.ExchangeRateTable, len(tables))
+	args := make([]any, 0, len(tables))
+	for _, t := range tables {
+		t.Rates = map[money.Currency]money.Rate{}
+		byID[t.ID] = t
+		args = append(args, t.ID)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT table_id, currency, rate FROM exchange_rates
+		WHERE table_id IN (`+placeholders(len(args))+`)`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableID int64
+		var cur money.Currency
+		var rate money.Rate
+		if err := rows.Scan(&tableID, &cur, &rate); err != nil {
+			return err
+		}
+		byID[tableID].Rates[cur] = rate
+	}
+	return rows.Err()
+}
+
+// placeholders arma "?,?,?" para cláusulas IN
+func placeholders(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat("?,", n-1) + "?"
+}
+
+type ProductPriceRepo struct {
+	DB *sql.DB
+}
+
+func NewProductPriceRepository(db *sql.DB) *ProductPriceRepo { return &ProductPriceRepo{DB: db} }
+
+var _ repository.ProductPriceRepository = (*ProductPriceRepo)(nil)
+
+func (r *ProductPriceRepo) Upsert(ctx context.Context, p *entity.ProductPrice) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO product_prices (product_id, currency, unit_price)
+		VALUES (?,?,?)
+		ON DUPLICATE KEY UPDATE unit_price = VALUES(unit_price)`,
+		p.ProductID, p.UnitPrice.Currency(), p.UnitPrice,
+	)
+	return err
+}
+
+func (r *ProductPriceRepo) Delete(ctx context.Context, productID int64, currency money.Currency) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM product_prices WHERE product_id = ? AND currency = ?`, productID, currency)
+	if err != nil {
+		return err
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ProductPriceRepo) FindByProductID(ctx context.Context, productID int64) ([]entity.ProductPrice, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT product_id, currency, unit_price, updated_at
+		FROM product_prices WHERE product_id = ?
+		ORDER BY currency`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.ProductPrice
+	for rows.Next() {
+		p, err := scanProductPrice(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (r *ProductPriceRepo) FindByProducts(ctx context.Context, productIDs []int64, currency money.Currency) (map[int64]entity.ProductPrice, error) {
+	out := map[int64]entity.ProductPrice{}
+	if len(productIDs) == 0 {
+		return out, nil
+	}
+	args := make([]any, 0, len(productIDs)+1)
+	args = append(args, currency)
+	for _, id := range productIDs {
+		args = append(args, id)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT product_id, currency, unit_price, updated_at
+		FROM product_prices
+		WHERE currency = ? AND product_id IN (`+placeholders(len(productIDs))+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanProductPrice(rows)
+		if err != nil {
+			return nil, err
+		}
+		out[p.ProductID] = p
+	}
+	return out, rows.Err()
+}
+
+func scanProductPrice(s rowScanner) (entity.ProductPrice, error) {
+	var p entity.ProductPrice
+	var unitPrice, currency string
+	if err := s.Scan(&p.ProductID, &currency, &unitPrice, &p.UpdatedAt); err != nil {
+		return entity.ProductPrice{}, err
+	}
+	var err error
+	p.UnitPrice, err = parseMoney(unitPrice, money.Currency(currency))
+	return p, err
+}
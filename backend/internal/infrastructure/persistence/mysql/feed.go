@@ -0,0 +1,203 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+type FeedRepo struct {
+	DB *sql.DB
+}
+
+func NewFeedRepository(db *sql.DB) *FeedRepo { return &FeedRepo{DB: db} }
+
+var _ repository.FeedRepository = (*FeedRepo)(nil)
+
+const feedCategoryColumns = `id, category, google_category, meta_category, updated_at, created_at`
+
+func scanFeedCategory(s rowScanner) (entity.FeedCategoryMapping, error) {
+	var m entity.FeedCategoryMapping
+	err := s.Scan(&m.ID, &m.Category, &m.GoogleCategory, &m.MetaCategory, &m.UpdatedAt, &m.CreatedAt)
+	return m, err
+}
+
+func (r *FeedRepo) ListCategoryMappings(ctx context.Context) ([]entity.FeedCategoryMapping, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+feedCategoryColumns+` FROM feed_category_mappings ORDER BY category`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.FeedCategoryMapping{}
+	for rows.Next() {
+		m, err := scanFeedCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+func (r *FeedRepo) GetCategoryMapping(ctx context.Context, category string) (entity.FeedCategoryMapping, error) {
+	m, err := scanFeedCategory(r.DB.QueryRowContext(ctx, `
+		SELECT `+feedCategoryColumns+` FROM feed_category_mappings WHERE category = ?`, category))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.FeedCategoryMapping{}, domainerrors.ErrNotFound
+	}
+	return m, err
+}
+
+func (r *FeedRepo) SaveCategoryMapping(ctx context.Context, m *entity.FeedCategoryMapping) error {
+	if _, err := r.DB.ExecContext(ctx, `
+		INSERT INTO feed_category_mappings (category, google_category, meta_category) VALUES (?,?,?)
+		ON DUPLICATE KEY UPDATE google_category = VALUES(google_category), meta_category = VALUES(meta_category), updated_at = NOW()`,
+		m.Category, m.GoogleCategory, m.MetaCategory); err != nil {
+		return fmt.Errorf("failed to save feed category mapping: %w", err)
+	}
+	saved, err := r.GetCategoryMapping(ctx, m.Category)
+	if err != nil {
+		return err
+	}
+	*m = saved
+	return nil
+}
+
+func (r *FeedRepo) DeleteCategoryMapping(ctx context.Context, category string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM feed_category_mappings WHERE category = ?`, category)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *FeedRepo) Fingerprints(ctx context.Context, channel entity.FeedChannel) (map[int64]string, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT product_id, fingerprint FROM feed_items WHERE channel = ?`, channel)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var fp string
+		if err := rows.Scan(&id, &fp); err != nil {
+			return nil, err
+		}
+		out[id] = fp
+	}
+	return out, rows.Err()
+}
+
+func (r *FeedRepo) SaveItems(ctx context.Context, items []entity.FeedItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(items))
+	args := make([]any, 0, 4*len(items))
+	for _, it := range items {
+		values = append(values, "(?,?,?,?)")
+		args = append(args, it.Channel, it.ProductID, it.Fingerprint, it.Content)
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO feed_items (channel, product_id, fingerprint, content) VALUES `+strings.Join(values, ",")+`
+		ON DUPLICATE KEY UPDATE fingerprint = VALUES(fingerprint), content = VALUES(content), updated_at = NOW()`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save feed items: %w", err)
+	}
+	return nil
+}
+
+func (r *FeedRepo) DeleteItems(ctx context.Context, channel entity.FeedChannel, productIDs []int64) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(productIDs)+1)
+	args = append(args, channel)
+	for _, id := range productIDs {
+		args = append(args, id)
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		DELETE FROM feed_items WHERE channel = ? AND product_id IN (`+placeholders(len(productIDs))+`)`, args...)
+	return err
+}
+
+func (r *FeedRepo) MarkChecked(ctx context.Context, channel entity.FeedChannel, changed bool) (entity.FeedState, error) {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO feed_states (channel, items, generated_at, checked_at)
+		SELECT ?, COUNT(*), NOW(), NOW() FROM feed_items WHERE channel = ?
+		ON DUPLICATE KEY UPDATE items = VALUES(items), checked_at = NOW(),
+			generated_at = IF(? OR generated_at IS NULL, NOW(), generated_at)`,
+		channel, channel, changed)
+	if err != nil {
+		return entity.FeedState{}, fmt.Errorf("failed to mark feed as checked: %w", err)
+	}
+	return r.GetState(ctx, channel)
+}
+
+func (r *FeedRepo) GetState(ctx context.Context, channel entity.FeedChannel) (entity.FeedState, error) {
+	st := entity.FeedState{Channel: channel}
+	var generatedAt, checkedAt sql.NullTime
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT items, generated_at, checked_at FROM feed_states WHERE channel = ?`, channel).
+		Scan(&st.Items, &generatedAt, &checkedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Un feed que todavía no se generó está vacío
+		return st, nil
+	}
+	if err != nil {
+		return entity.FeedState{}, err
+	}
+	st.GeneratedAt = nullTimePtr(generatedAt)
+	st.CheckedAt = nullTimePtr(checkedAt)
+	return st, nil
+}
+
+// feedStreamBatch es cuántos productos del feed se leen por consulta
+const feedStreamBatch = 500
+
+func (r *FeedRepo) StreamItems(ctx context.Context, channel entity.FeedChannel, fn func(content string) error) error {
+	var lastID int64
+	for {
+		rows, err := r.DB.QueryContext(ctx, `
+			SELECT product_id, content FROM feed_items
+			WHERE channel = ? AND product_id > ?
+			ORDER BY product_id
+			LIMIT ?`, channel, lastID, feedStreamBatch)
+		if err != nil {
+			return err
+		}
+		var batch []string
+		for rows.Next() {
+			var content string
+			if err := rows.Scan(&lastID, &content); err != nil {
+				rows.Close()
+				return err
+			}
+			batch = append(batch, content)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		for _, content := range batch {
+			if err := fn(content); err != nil {
+				return err
+			}
+		}
+		if len(batch) < feedStreamBatch {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,568 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/repository"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+)
+
+type InventoryRepo struct {
+	DB *sql.DB
+}
+
+func NewInventoryRepository(db *sql.DB) *InventoryRepo { return &InventoryRepo{DB: db} }
+
+var _ repository.InventoryRepository = (*InventoryRepo)(nil)
+
+const locationColumns = `id, code, name, kind, priority, active, is_default, updated_at, created_at`
+
+func scanLocation(s rowScanner) (entity.Location, error) {
+	var l entity.Location
+	err := s.Scan(&l.ID, &l.Code, &l.Name, &l.Kind, &l.Priority, &l.Active, &l.IsDefault, &l.UpdatedAt, &l.CreatedAt)
+	return l, err
+}
+
+func (r *InventoryRepo) CreateLocation(ctx context.Context, l *entity.Location) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if l.IsDefault {
+		if _, err := tx.ExecContext(ctx, `UPDATE locations SET is_default = FALSE WHERE is_default = TRUE`); err != nil {
+			return err
+		}
+	}
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO locations (code, name, kind, priority, active, is_default) VALUES (?,?,?,?,?,?)`,
+		l.Code, l.Name, l.Kind, l.Priority, l.Active, l.IsDefault)
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1062 {
+			return domainerrors.ErrConflict
+		}
+		return fmt.Errorf("failed to create location: %w", err)
+	}
+	id, _ := res.LastInsertId()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	l.ID = id
+	return nil
+}
+
+// UpdateLocation recalcula el disponible de todos los productos si la ubicación
+// se activó o desactivó, porque cambia qué stock suma a la venta
+func (r *InventoryRepo) UpdateLocation(ctx context.Context, l *entity.Location) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var wasActive bool
+	err = tx.QueryRowContext(ctx, `SELECT active FROM locations WHERE id = ? FOR UPDATE`, l.ID).Scan(&wasActive)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if l.IsDefault {
+		if _, err := tx.ExecContext(ctx, `UPDATE locations SET is_default = FALSE WHERE is_default = TRUE AND id <> ?`, l.ID); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE locations SET code = ?, name = ?, kind = ?, priority = ?, active = ?, is_default = ?, updated_at = NOW()
+		WHERE id = ?`,
+		l.Code, l.Name, l.Kind, l.Priority, l.Active, l.IsDefault, l.ID); err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1062 {
+			return domainerrors.ErrConflict
+		}
+		return err
+	}
+	if wasActive != l.Active {
+		if err := recountStock(ctx, tx, `p.id IN (SELECT product_id FROM inventory_levels WHERE location_id = ?)`, l.ID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *InventoryRepo) GetLocation(ctx context.Context, id int64) (entity.Location, error) {
+	l, err := scanLocation(r.DB.QueryRowContext(ctx, `SELECT `+locationColumns+` FROM locations WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Location{}, domainerrors.ErrNotFound
+	}
+	return l, err
+}
+
+func (r *InventoryRepo) ListLocations(ctx context.Context) ([]entity.Location, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+locationColumns+` FROM locations ORDER BY priority, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.Location
+	for rows.Next() {
+		l, err := scanLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, l)
+	}
+	return out, rows.Err()
+}
+
+func (r *InventoryRepo) Levels(ctx context.Context, productID int64) ([]entity.InventoryLevel, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT il.product_id, il.location_id, l.code, l.active, l.priority, il.quantity, il.updated_at
+		FROM inventory_levels il
+		JOIN locations l ON l.id = il.location_id
+		WHERE il.product_id = ?
+		ORDER BY l.priority, l.id`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.InventoryLevel{}
+	for rows.Next() {
+		var lv entity.InventoryLevel
+		if err := rows.Scan(&lv.ProductID, &lv.LocationID, &lv.LocationCode, &lv.LocationActive, &lv.Priority,
+			&lv.Quantity, &lv.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, lv)
+	}
+	return out, rows.Err()
+}
+
+func (r *InventoryRepo) Adjust(ctx context.Context, productID, locationID, delta int64, ref entity.StockMovementRef) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := adjustLevel(ctx, tx, productID, locationID, delta, ref); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *InventoryRepo) Transfer(ctx context.Context, t *entity.StockTransfer) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// La transferencia se inserta primero para que los movimientos la referencien
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO stock_transfers (product_id, from_location_id, to_location_id, quantity, note, actor_id)
+		VALUES (?,?,?,?,?,?)`, t.ProductID, t.FromLocationID, t.ToLocationID, t.Quantity, t.Note, t.ActorID)
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1452 {
+			return domainerrors.ErrNotFound
+		}
+		return fmt.Errorf("failed to record stock transfer: %w", err)
+	}
+	id, _ := res.LastInsertId()
+	ref := entity.StockMovementRef{
+		Kind:      entity.StockMovementTransfer,
+		Reason:    t.Note,
+		Reference: fmt.Sprintf("transfer:%d", id),
+		ActorID:   t.ActorID,
+	}
+	if err := adjustLevel(ctx, tx, t.ProductID, t.FromLocationID, -t.Quantity, ref); err != nil {
+		return err
+	}
+	if err := adjustLevel(ctx, tx, t.ProductID, t.ToLocationID, t.Quantity, ref); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	t.ID = id
+	return nil
+}
+
+func (r *InventoryRepo) ListTransfers(ctx context.Context, f entity.StockTransferFilter) ([]entity.StockTransfer, error) {
+	q := `SELECT id, product_id, from_location_id, to_location_id, quantity, note, actor_id, created_at FROM stock_transfers WHERE 1=1`
+	args := []any{}
+	if f.ProductID > 0 {
+		q += " AND product_id = ?"
+		args = append(args, f.ProductID)
+	}
+	if f.LocationID > 0 {
+		q += " AND (from_location_id = ? OR to_location_id = ?)"
+		args = append(args, f.LocationID, f.LocationID)
+	}
+	q += " ORDER BY id DESC"
+	limit := 20
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.StockTransfer{}
+	for rows.Next() {
+		var t entity.StockTransfer
+		if err := rows.Scan(&t.ID, &t.ProductID, &t.FromLocationID, &t.ToLocationID, &t.Quantity, &t.Note, &t.ActorID, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func (r *InventoryRepo) Allocate(ctx context.Context, productID, qty int64, ref entity.StockMovementRef) ([]entity.StockAllocation, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	allocations, err := allocateStock(ctx, tx, productID, qty, 0, ref)
+	if err != nil {
+		return nil, err
+	}
+	return allocations, tx.Commit()
+}
+
+func (r *InventoryRepo) Release(ctx context.Context, productID int64, allocations []entity.StockAllocation, ref entity.StockMovementRef) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, a := range allocations {
+		if err := adjustLevel(ctx, tx, productID, a.LocationID, a.Quantity, ref); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *InventoryRepo) Movements(ctx context.Context, f entity.StockMovementFilter) ([]entity.StockMovement, error) {
+	q := `
+		SELECT m.id, m.product_id, m.location_id, l.code, m.delta, m.kind, m.reason, m.reference, m.actor_id, m.created_at
+		FROM stock_movements m
+		JOIN locations l ON l.id = m.location_id
+		WHERE 1=1`
+	args := []any{}
+	if f.ProductID > 0 {
+		q += " AND m.product_id = ?"
+		args = append(args, f.ProductID)
+	}
+	if f.LocationID > 0 {
+		q += " AND m.location_id = ?"
+		args = append(args, f.LocationID)
+	}
+	if f.Kind != "" {
+		q += " AND m.kind = ?"
+		args = append(args, f.Kind)
+	}
+	q += " ORDER BY m.id DESC"
+	limit := 50
+	if f.Limit > 0 && f.Limit <= 200 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.StockMovement{}
+	for rows.Next() {
+		var m entity.StockMovement
+		if err := rows.Scan(&m.ID, &m.ProductID, &m.LocationID, &m.LocationCode, &m.Delta, &m.Kind,
+			&m.Reason, &m.Reference, &m.ActorID, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+func (r *InventoryRepo) Discrepancies(ctx context.Context) ([]entity.StockDiscrepancy, error) {
+	var out []entity.StockDiscrepancy
+
+	// Stock por ubicación contra el saldo del libro, en los dos sentidos
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT product_id, location_id, SUM(ledger), SUM(recorded) FROM (
+			SELECT product_id, location_id, SUM(delta) AS ledger, 0 AS recorded
+			FROM stock_movements GROUP BY product_id, location_id
+			UNION ALL
+			SELECT product_id, location_id, 0, quantity FROM inventory_levels
+		) t
+		GROUP BY product_id, location_id
+		HAVING SUM(ledger) <> SUM(recorded)
+		ORDER BY product_id, location_id`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var d entity.StockDiscrepancy
+		if err := rows.Scan(&d.ProductID, &d.LocationID, &d.Expected, &d.Recorded); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Disponible contra la suma de las ubicaciones activas
+	rows, err = r.DB.QueryContext(ctx, `
+		SELECT p.id, COALESCE(SUM(CASE WHEN l.active THEN il.quantity END), 0), p.stock
+		FROM products p
+		LEFT JOIN inventory_levels il ON il.product_id = p.id
+		LEFT JOIN locations l ON l.id = il.location_id
+		GROUP BY p.id, p.stock
+		HAVING COALESCE(SUM(CASE WHEN l.active THEN il.quantity END), 0) <> p.stock
+		ORDER BY p.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var d entity.StockDiscrepancy
+		if err := rows.Scan(&d.ProductID, &d.Expected, &d.Recorded); err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// RebuildFromLedger reemplaza el stock por ubicación de los productos con el saldo del
+// libro y recalcula el disponible
+func (r *InventoryRepo) RebuildFromLedger(ctx context.Context, productIDs []int64) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	args := make([]any, len(productIDs))
+	for i, id := range productIDs {
+		args[i] = id
+	}
+	in := placeholders(len(productIDs))
+	// Mismo orden de bloqueo que adjustLevel: primero las ubicaciones, después el producto
+	if _, err := tx.ExecContext(ctx, `UPDATE inventory_levels SET quantity = 0, updated_at = NOW() WHERE product_id IN (`+in+`)`, args...); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO inventory_levels (product_id, location_id, quantity)
+		SELECT product_id, location_id, SUM(delta) FROM stock_movements
+		WHERE product_id IN (`+in+`)
+		GROUP BY product_id, location_id
+		ON DUPLICATE KEY UPDATE quantity = VALUES(quantity), updated_at = NOW()`, args...); err != nil {
+		return err
+	}
+	if err := recountStock(ctx, tx, `p.id IN (`+in+`)`, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// recountStock recalcula el disponible de los productos que cumplen where con el stock
+// de las ubicaciones activas, y guarda un stock.changed sin ubicación por cada producto
+// cuyo disponible cambió
+func recountStock(ctx context.Context, tx *sql.Tx, where string, args ...any) error {
+	rows, err := tx.QueryContext(ctx, `SELECT p.id, p.stock FROM products p WHERE `+where+` ORDER BY p.id FOR UPDATE`, args...)
+	if err != nil {
+		return err
+	}
+	var ids []int64
+	before := map[int64]int64{}
+	for rows.Next() {
+		var id, stock int64
+		if err := rows.Scan(&id, &stock); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+		before[id] = stock
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE products p SET stock = (
+			SELECT COALESCE(SUM(il.quantity), 0) FROM inventory_levels il
+			JOIN locations l ON l.id = il.location_id
+			WHERE il.product_id = p.id AND l.active = TRUE
+		)
+		WHERE `+where, args...); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		change := entity.StockChange{ProductID: id}
+		if err := tx.QueryRowContext(ctx, `SELECT bar_code, stock FROM products WHERE id = ?`, id).Scan(&change.BarCode, &change.Stock); err != nil {
+			return err
+		}
+		if change.Delta = change.Stock - before[id]; change.Delta == 0 {
+			continue
+		}
+		if err := writeOutbox(ctx, tx, entity.OutboxAggregateProduct, id, entity.EventStockChanged, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// adjustLevel suma delta al stock del producto en la ubicación y, si la ubicación está
+// activa, al disponible en products.stock, y registra el movimiento en el libro.
+// Es la única vía de escritura del stock.
+func adjustLevel(ctx context.Context, tx *sql.Tx, productID, locationID, delta int64, ref entity.StockMovementRef) error {
+	if delta == 0 {
+		return nil
+	}
+	if delta > 0 {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO inventory_levels (product_id, location_id, quantity) VALUES (?,?,?)
+			ON DUPLICATE KEY UPDATE quantity = quantity + VALUES(quantity), updated_at = NOW()`,
+			productID, locationID, delta)
+		if err != nil {
+			var me *mysqlerr.MySQLError
+			if errors.As(err, &me) && me.Number == 1452 {
+				return domainerrors.ErrNotFound
+			}
+			return err
+		}
+	} else {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE inventory_levels SET quantity = quantity + ?, updated_at = NOW()
+			WHERE product_id = ? AND location_id = ? AND quantity + ? >= 0`,
+			delta, productID, locationID, delta)
+		if err != nil {
+			return err
+		}
+		if aff, _ := res.RowsAffected(); aff == 0 {
+			var exists bool
+			if err := tx.QueryRowContext(ctx, `
+				SELECT EXISTS(SELECT 1 FROM products WHERE id = ?) AND EXISTS(SELECT 1 FROM locations WHERE id = ?)`,
+				productID, locationID).Scan(&exists); err != nil {
+				return err
+			}
+			if exists {
+				return domainerrors.ErrInsufficientStock
+			}
+			return domainerrors.ErrNotFound
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE products SET stock = stock + ?
+		WHERE id = ? AND EXISTS(SELECT 1 FROM locations WHERE id = ? AND active = TRUE)`,
+		delta, productID, locationID); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO stock_movements (product_id, location_id, delta, kind, reason, reference, actor_id)
+		VALUES (?,?,?,?,?,?,?)`,
+		productID, locationID, delta, ref.Kind, ref.Reason, ref.Reference, ref.ActorID)
+	if err != nil {
+		return fmt.Errorf("failed to record stock movement: %w", err)
+	}
+	change := entity.StockChange{ProductID: productID, LocationID: locationID, Delta: delta}
+	if err := tx.QueryRowContext(ctx, `
+		SELECT bar_code, stock FROM products WHERE id = ? FOR UPDATE`, productID).Scan(&change.BarCode, &change.Stock); err != nil {
+		return err
+	}
+	return writeOutbox(ctx, tx, entity.OutboxAggregateProduct, productID, entity.EventStockChanged, change)
+}
+
+// allocateStock bloquea el stock del producto en las ubicaciones activas y descuenta
+// qty de las que elige entity.PickLocations. La ubicación preferred, si no es 0, se
+// toma primero sin importar su prioridad.
+func allocateStock(ctx context.Context, tx *sql.Tx, productID, qty, preferred int64, ref entity.StockMovementRef) ([]entity.StockAllocation, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT il.location_id, l.code, il.quantity
+		FROM inventory_levels il
+		JOIN locations l ON l.id = il.location_id
+		WHERE il.product_id = ? AND l.active = TRUE
+		ORDER BY l.id = ? DESC, l.priority, l.id
+		FOR UPDATE`, productID, preferred)
+	if err != nil {
+		return nil, err
+	}
+	var levels []entity.InventoryLevel
+	for rows.Next() {
+		lv := entity.InventoryLevel{ProductID: productID, LocationActive: true}
+		if err := rows.Scan(&lv.LocationID, &lv.LocationCode, &lv.Quantity); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		levels = append(levels, lv)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	allocations, ok := entity.PickLocations(levels, qty)
+	if !ok {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM products WHERE id = ?)`, productID).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, domainerrors.ErrInsufficientStock
+		}
+		return nil, domainerrors.ErrNotFound
+	}
+	for _, a := range allocations {
+		if err := adjustLevel(ctx, tx, productID, a.LocationID, -a.Quantity, ref); err != nil {
+			return nil, err
+		}
+	}
+	return allocations, nil
+}
+
+// defaultLocationID es la ubicación que recibe el stock sin ubicación indicada
+func defaultLocationID(ctx context.Context, tx *sql.Tx) (int64, error) {
+	var id int64
+	err := tx.QueryRowContext(ctx, `SELECT id FROM locations WHERE is_default = TRUE LIMIT 1`).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("no default inventory location configured")
+	}
+	return id, err
+}
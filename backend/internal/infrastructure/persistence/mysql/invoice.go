@@ -0,0 +1,291 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+)
+
+type InvoiceRepo struct {
+	DB *sql.DB
+}
+
+func NewInvoiceRepository(db *sql.DB) *InvoiceRepo { return &InvoiceRepo{DB: db} }
+
+var _ repository.InvoiceRepository = (*InvoiceRepo)(nil)
+
+const invoiceColumns = `id, order_id, point_of_sale, type, number, issuer_name, issuer_tax_id, issuer_address,
+		customer_tax_id, customer_tax_id_type, customer_name, customer_address, currency, exchange_rate,
+		net, tax, total, status, authorization_code, authorization_expires_at, rejection_reason,
+		submit_attempts, issued_at, updated_at`
+
+func scanInvoice(s rowScanner) (entity.Invoice, error) {
+	var inv entity.Invoice
+	var currency, net, taxAmount, total string
+	var rate sql.Null[money.Rate]
+	var expiresAt sql.NullTime
+	if err := s.Scan(&inv.ID, &inv.OrderID, &inv.PointOfSale, &inv.Type, &inv.Number,
+		&inv.Issuer.Name, &inv.Issuer.TaxID, &inv.Issuer.Address,
+		&inv.Customer.TaxID, &inv.Customer.TaxIDType, &inv.Customer.Name, &inv.Customer.Address, &currency, &rate,
+		&net, &taxAmount, &total, &inv.Status, &inv.AuthorizationCode, &expiresAt, &inv.RejectionReason,
+		&inv.SubmitAttempts, &inv.IssuedAt, &inv.UpdatedAt); err != nil {
+		return entity.Invoice{}, err
+	}
+
+	var err error
+	inv.Currency = money.Currency(currency)
+	if inv.Net, err = parseMoney(net, inv.Currency); err != nil {
+		return entity.Invoice{}, err
+	}
+	if inv.Tax, err = parseMoney(taxAmount, inv.Currency); err != nil {
+		return entity.Invoice{}, err
+	}
+	if inv.Total, err = parseMoney(total, inv.Currency); err != nil {
+		return entity.Invoice{}, err
+	}
+	if rate.Valid {
+		inv.ExchangeRate = &rate.V
+	}
+	inv.AuthorizationExpiresAt = nullTimePtr(expiresAt)
+	return inv, nil
+}
+
+func (r *InvoiceRepo) Issue(ctx context.Context, inv *entity.Invoice) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// La fila de la secuencia queda bloqueada hasta el commit: dos emisiones concurrentes
+	// del mismo punto de venta y letra se serializan y nunca repiten ni saltean números
+	if _, err := tx.ExecContext(ctx, `
+		INSERT IGNORE INTO invoice_sequences (point_of_sale, type, last_number) VALUES (?, ?, 0)`,
+		inv.PointOfSale, inv.Type); err != nil {
+		return err
+	}
+	var last int64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT last_number FROM invoice_sequences WHERE point_of_sale = ? AND type = ? FOR UPDATE`,
+		inv.PointOfSale, inv.Type).Scan(&last); err != nil {
+		return err
+	}
+	number := last + 1
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE invoice_sequences SET last_number = ? WHERE point_of_sale = ? AND type = ?`,
+		number, inv.PointOfSale, inv.Type); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO invoices (order_id, point_of_sale, type, number, issuer_name, issuer_tax_id, issuer_address,
+			customer_tax_id, customer_tax_id_type, customer_name, customer_address, currency, exchange_rate,
+			net, tax, total, status)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		inv.OrderID, inv.PointOfSale, inv.Type, number, inv.Issuer.Name, inv.Issuer.TaxID, inv.Issuer.Address,
+		inv.Customer.TaxID, inv.Customer.TaxIDType, inv.Customer.Name, inv.Customer.Address, inv.Currency, inv.ExchangeRate,
+		inv.Net, inv.Tax, inv.Total, inv.Status,
+	)
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1062 {
+			return domainerrors.ErrConflict
+		}
+		return fmt.Errorf("failed to create invoice: %w", err)
+	}
+	id, _ := res.LastInsertId()
+
+	for i := range inv.Lines {
+		l := &inv.Lines[i]
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO invoice_lines (invoice_id, description, quantity, unit_price, discount, tax_rate_bps, net, tax, total)
+			VALUES (?,?,?,?,?,?,?,?,?)`,
+			id, l.Description, l.Quantity, l.UnitPrice, l.Discount, l.TaxRate, l.Net, l.Tax, l.Total,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create invoice line: %w", err)
+		}
+		l.ID, _ = res.LastInsertId()
+		l.InvoiceID = id
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	inv.ID, inv.Number = id, number
+	return nil
+}
+
+func (r *InvoiceRepo) GetByID(ctx context.Context, id int64) (entity.Invoice, error) {
+	return r.get(ctx, `SELECT `+invoiceColumns+` FROM invoices WHERE id = ?`, id)
+}
+
+func (r *InvoiceRepo) GetByOrderID(ctx context.Context, orderID int64) (entity.Invoice, error) {
+	return r.get(ctx, `SELECT `+invoiceColumns+` FROM invoices WHERE order_id = ?`, orderID)
+}
+
+func (r *InvoiceRepo) get(ctx context.Context, q string, arg any) (entity.Invoice, error) {
+	inv, err := scanInvoice(r.DB.QueryRowContext(ctx, q, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Invoice{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.Invoice{}, err
+	}
+	if err := r.loadLines(ctx, []*entity.Invoice{&inv}); err != nil {
+		return entity.Invoice{}, err
+	}
+	return inv, nil
+}
+
+func (r *InvoiceRepo) List(ctx context.Context, f entity.InvoiceFilter) ([]entity.Invoice, error) {
+	q := `SELECT ` + invoiceColumns + ` FROM invoices WHERE 1=1`
+	args := []any{}
+	if f.OrderID > 0 {
+		q += " AND order_id = ?"
+		args = append(args, f.OrderID)
+	}
+	if f.Status != "" {
+		q += " AND status = ?"
+		args = append(args, f.Status)
+	}
+	q += " ORDER BY id DESC"
+	limit := 20
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.Invoice
+	for rows.Next() {
+		inv, err := scanInvoice(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	invoices := make([]*entity.Invoice, len(out))
+	for i := range out {
+		invoices[i] = &out[i]
+	}
+	return out, r.loadLines(ctx, invoices)
+}
+
+func (r *InvoiceRepo) loadLines(ctx context.Context, invoices []*entity.Invoice) error {
+	if len(invoices) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*entity.Invoice, len(invoices))
+	args := make([]any, 0, len(invoices))
+	for _, inv := range invoices {
+		inv.Lines = []entity.InvoiceLine{}
+		byID[inv.ID] = inv
+		args = append(args, inv.ID)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, invoice_id, description, quantity, unit_price, discount, tax_rate_bps, net, tax, total
+		FROM invoice_lines
+		WHERE invoice_id IN (`+placeholders(len(args))+`)
+		ORDER BY id`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l entity.InvoiceLine
+		var unitPrice, discount, net, taxAmount, total string
+		if err := rows.Scan(&l.ID, &l.InvoiceID, &l.Description, &l.Quantity, &unitPrice, &discount,
+			&l.TaxRate, &net, &taxAmount, &total); err != nil {
+			return err
+		}
+		inv := byID[l.InvoiceID]
+		if l.UnitPrice, err = parseMoney(unitPrice, inv.Currency); err != nil {
+			return err
+		}
+		if l.Discount, err = parseMoney(discount, inv.Currency); err != nil {
+			return err
+		}
+		if l.Net, err = parseMoney(net, inv.Currency); err != nil {
+			return err
+		}
+		if l.Tax, err = parseMoney(taxAmount, inv.Currency); err != nil {
+			return err
+		}
+		if l.Total, err = parseMoney(total, inv.Currency); err != nil {
+			return err
+		}
+		inv.Lines = append(inv.Lines, l)
+	}
+	return rows.Err()
+}
+
+func (r *InvoiceRepo) UpdateSubmission(ctx context.Context, inv *entity.Invoice) error {
+	// Cada envío suma un intento: si el contador ya no es el anterior, otro envío
+	// guardó su resultado mientras tanto
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE invoices SET status = ?, authorization_code = ?, authorization_expires_at = ?,
+			rejection_reason = ?, submit_attempts = ?, updated_at = NOW()
+		WHERE id = ? AND submit_attempts = ?`,
+		inv.Status, inv.AuthorizationCode, inv.AuthorizationExpiresAt,
+		inv.RejectionReason, inv.SubmitAttempts, inv.ID, inv.SubmitAttempts-1,
+	)
+	if err != nil {
+		return err
+	}
+	aff, _ := res.RowsAffected()
+	if aff > 0 {
+		return nil
+	}
+	var exists bool
+	if err := r.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM invoices WHERE id = ?)`, inv.ID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return domainerrors.ErrNotFound
+	}
+	return domainerrors.ErrConflict
+}
+
+func (r *InvoiceRepo) PendingOrders(ctx context.Context, limit int) ([]int64, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT o.id FROM orders o
+		LEFT JOIN invoices i ON i.order_id = o.id
+		WHERE o.status = 'paid' AND i.id IS NULL
+		ORDER BY o.paid_at, o.id
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
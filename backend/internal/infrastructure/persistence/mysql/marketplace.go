@@ -0,0 +1,429 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+)
+
+type MarketplaceRepo struct {
+	DB *sql.DB
+}
+
+func NewMarketplaceRepository(db *sql.DB) *MarketplaceRepo { return &MarketplaceRepo{DB: db} }
+
+var _ repository.MarketplaceRepository = (*MarketplaceRepo)(nil)
+
+const marketplaceListingColumns = `id, marketplace, product_id, external_id, status, synced_stock, synced_price, synced_currency,
+		synced_at, sync_error, updated_at, created_at`
+
+func scanMarketplaceListing(s rowScanner) (entity.MarketplaceListing, error) {
+	var l entity.MarketplaceListing
+	var syncedPrice, syncedCurrency sql.NullString
+	var syncedAt sql.NullTime
+	if err := s.Scan(&l.ID, &l.Marketplace, &l.ProductID, &l.ExternalID, &l.Status, &l.SyncedStock, &syncedPrice, &syncedCurrency,
+		&syncedAt, &l.SyncError, &l.UpdatedAt, &l.CreatedAt); err != nil {
+		return entity.MarketplaceListing{}, err
+	}
+	var err error
+	if l.SyncedPrice, err = parseNullMoney(syncedPrice, money.Currency(syncedCurrency.String)); err != nil {
+		return entity.MarketplaceListing{}, err
+	}
+	l.SyncedAt = nullTimePtr(syncedAt)
+	return l, nil
+}
+
+func (r *MarketplaceRepo) CreateListing(ctx context.Context, l *entity.MarketplaceListing) error {
+	var syncedPrice, syncedCurrency any
+	if l.SyncedPrice != nil {
+		syncedPrice, syncedCurrency = *l.SyncedPrice, l.SyncedPrice.Currency()
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO marketplace_listings (marketplace, product_id, external_id, status, synced_stock, synced_price, synced_currency, synced_at)
+		VALUES (?,?,?,?,?,?,?,?)`,
+		l.Marketplace, l.ProductID, l.ExternalID, l.Status, l.SyncedStock, syncedPrice, syncedCurrency, l.SyncedAt)
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) {
+			switch me.Number {
+			case 1062:
+				return domainerrors.ErrConflict
+			case 1452:
+				return domainerrors.ErrNotFound
+			}
+		}
+		return fmt.Errorf("failed to create marketplace listing: %w", err)
+	}
+	id, _ := res.LastInsertId()
+	saved, err := r.GetListing(ctx, id)
+	if err != nil {
+		return err
+	}
+	*l = saved
+	return nil
+}
+
+func (r *MarketplaceRepo) GetListing(ctx context.Context, id int64) (entity.MarketplaceListing, error) {
+	l, err := scanMarketplaceListing(r.DB.QueryRowContext(ctx, `
+		SELECT `+marketplaceListingColumns+` FROM marketplace_listings WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.MarketplaceListing{}, domainerrors.ErrNotFound
+	}
+	return l, err
+}
+
+func (r *MarketplaceRepo) ListListings(ctx context.Context, f entity.MarketplaceListingFilter) ([]entity.MarketplaceListing, error) {
+	q := `SELECT ` + marketplaceListingColumns + ` FROM marketplace_listings WHERE 1=1`
+	args := []any{}
+	if f.Marketplace != "" {
+		q += " AND marketplace = ?"
+		args = append(args, f.Marketplace)
+	}
+	if f.ProductID > 0 {
+		q += " AND product_id = ?"
+		args = append(args, f.ProductID)
+	}
+	if f.Status != "" {
+		q += " AND status = ?"
+		args = append(args, f.Status)
+	}
+	q += " ORDER BY id DESC"
+	limit := 50
+	if f.Limit > 0 && f.Limit <= 500 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.MarketplaceListing{}
+	for rows.Next() {
+		l, err := scanMarketplaceListing(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, l)
+	}
+	return out, rows.Err()
+}
+
+func (r *MarketplaceRepo) UpdateListingStatus(ctx context.Context, id int64, status entity.MarketplaceListingStatus) error {
+	res, err := r.DB.ExecContext(ctx, `UPDATE marketplace_listings SET status = ?, updated_at = NOW() WHERE id = ?`, status, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *MarketplaceRepo) DeleteListing(ctx context.Context, id int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM marketplace_listings WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *MarketplaceRepo) SyncItems(ctx context.Context, marketplace string) ([]entity.MarketplaceSyncItem, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+marketplaceListingColumns+` FROM marketplace_listings
+		WHERE marketplace = ? AND status = ? ORDER BY id`, marketplace, entity.MarketplaceListingActive)
+	if err != nil {
+		return nil, err
+	}
+	var items []entity.MarketplaceSyncItem
+	for rows.Next() {
+		l, err := scanMarketplaceListing(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		items = append(items, entity.MarketplaceSyncItem{Listing: l})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	// Los productos van en una segunda consulta para reusar scanProduct
+	rows, err = r.DB.QueryContext(ctx, `
+		SELECT `+productColumns+` FROM products
+		WHERE id IN (SELECT product_id FROM marketplace_listings WHERE marketplace = ? AND status = ?)`,
+		marketplace, entity.MarketplaceListingActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	products := make(map[int64]entity.Product, len(items))
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products[p.ID] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	out := items[:0]
+	for _, it := range items {
+		// Una publicación vinculada entre las dos consultas queda para la próxima pasada
+		if p, ok := products[it.Listing.ProductID]; ok {
+			it.Product = p
+			out = append(out, it)
+		}
+	}
+	return out, nil
+}
+
+func (r *MarketplaceRepo) MarkSynced(ctx context.Context, listingID, stock int64, price money.Money) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE marketplace_listings
+		SET synced_stock = ?, synced_price = ?, synced_currency = ?, synced_at = NOW(), sync_error = ''
+		WHERE id = ?`, stock, price, price.Currency(), listingID)
+	return err
+}
+
+func (r *MarketplaceRepo) MarkSyncFailed(ctx context.Context, listingID int64, message string) error {
+	if len(message) > 255 {
+		message = message[:255]
+	}
+	_, err := r.DB.ExecContext(ctx, `UPDATE marketplace_listings SET sync_error = ? WHERE id = ?`, message, listingID)
+	return err
+}
+
+func (r *MarketplaceRepo) OrdersCursor(ctx context.Context, marketplace string) (*time.Time, error) {
+	var cursor sql.NullTime
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT COALESCE(
+			(SELECT orders_cursor FROM marketplace_states WHERE marketplace = ?),
+			(SELECT MIN(created_at) FROM marketplace_listings WHERE marketplace = ?))`,
+		marketplace, marketplace).Scan(&cursor)
+	if err != nil {
+		return nil, err
+	}
+	return nullTimePtr(cursor), nil
+}
+
+func (r *MarketplaceRepo) ImportOrder(ctx context.Context, o *entity.MarketplaceOrder) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	o.Status = entity.MarketplaceOrderImported
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO marketplace_orders (marketplace, external_id, status, buyer, total, currency, ordered_at)
+		VALUES (?,?,?,?,?,?,?)`,
+		o.Marketplace, o.ExternalID, o.Status, o.Buyer, o.Total, o.Total.Currency(), o.OrderedAt)
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1062 {
+			return domainerrors.ErrConflict
+		}
+		return fmt.Errorf("failed to create marketplace order: %w", err)
+	}
+	o.ID, _ = res.LastInsertId()
+
+	ref := entity.StockMovementRef{
+		Kind:      entity.StockMovementSale,
+		Reason:    "Venta en " + o.Marketplace,
+		Reference: fmt.Sprintf("marketplace:%s:%s", o.Marketplace, o.ExternalID),
+	}
+	for i := range o.Lines {
+		ln := &o.Lines[i]
+		var productID int64
+		err := tx.QueryRowContext(ctx, `
+			SELECT product_id FROM marketplace_listings WHERE marketplace = ? AND external_id = ?`,
+			o.Marketplace, ln.ExternalItemID).Scan(&productID)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			ln.ProductID = nil
+			o.Status = entity.MarketplaceOrderReview
+		case err != nil:
+			return err
+		default:
+			ln.ProductID = &productID
+			taken, err := takeAvailableStock(ctx, tx, productID, ln.Quantity, ref)
+			if err != nil {
+				return err
+			}
+			if ln.Shortage = ln.Quantity - taken; ln.Shortage > 0 {
+				o.Status = entity.MarketplaceOrderReview
+			}
+		}
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO marketplace_order_lines (order_id, external_item_id, title, product_id, quantity, unit_price, shortage)
+			VALUES (?,?,?,?,?,?,?)`,
+			o.ID, ln.ExternalItemID, ln.Title, ln.ProductID, ln.Quantity, ln.UnitPrice, ln.Shortage)
+		if err != nil {
+			return fmt.Errorf("failed to create marketplace order line: %w", err)
+		}
+		ln.ID, _ = res.LastInsertId()
+	}
+	if o.Status != entity.MarketplaceOrderImported {
+		if _, err := tx.ExecContext(ctx, `UPDATE marketplace_orders SET status = ? WHERE id = ?`, o.Status, o.ID); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO marketplace_states (marketplace, orders_cursor) VALUES (?,?)
+		ON DUPLICATE KEY UPDATE orders_cursor = GREATEST(orders_cursor, VALUES(orders_cursor))`,
+		o.Marketplace, o.OrderedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// takeAvailableStock descuenta hasta qty unidades del producto y retorna cuántas pudo.
+// La venta ya ocurrió en el marketplace: lo que falta queda informado, no se rechaza.
+func takeAvailableStock(ctx context.Context, tx *sql.Tx, productID, qty int64, ref entity.StockMovementRef) (int64, error) {
+	var stock int64
+	if err := tx.QueryRowContext(ctx, `SELECT stock FROM products WHERE id = ? FOR UPDATE`, productID).Scan(&stock); err != nil {
+		return 0, err
+	}
+	taken := min(qty, max(stock, 0))
+	if taken == 0 {
+		return 0, nil
+	}
+	if _, err := allocateStock(ctx, tx, productID, taken, 0, ref); err != nil {
+		return 0, err
+	}
+	return taken, nil
+}
+
+const marketplaceOrderColumns = `id, marketplace, external_id, status, buyer, total, currency, ordered_at, created_at`
+
+func scanMarketplaceOrder(s rowScanner) (entity.MarketplaceOrder, error) {
+	var o entity.MarketplaceOrder
+	var total, currency string
+	if err := s.Scan(&o.ID, &o.Marketplace, &o.ExternalID, &o.Status, &o.Buyer, &total, &currency, &o.OrderedAt, &o.CreatedAt); err != nil {
+		return entity.MarketplaceOrder{}, err
+	}
+	var err error
+	o.Total, err = parseMoney(total, money.Currency(currency))
+	return o, err
+}
+
+func (r *MarketplaceRepo) GetOrder(ctx context.Context, id int64) (entity.MarketplaceOrder, error) {
+	o, err := scanMarketplaceOrder(r.DB.QueryRowContext(ctx, `
+		SELECT `+marketplaceOrderColumns+` FROM marketplace_orders WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.MarketplaceOrder{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.MarketplaceOrder{}, err
+	}
+	orders := []entity.MarketplaceOrder{o}
+	if err := r.loadOrderLines(ctx, orders); err != nil {
+		return entity.MarketplaceOrder{}, err
+	}
+	return orders[0], nil
+}
+
+func (r *MarketplaceRepo) ListOrders(ctx context.Context, f entity.MarketplaceOrderFilter) ([]entity.MarketplaceOrder, error) {
+	q := `SELECT ` + marketplaceOrderColumns + ` FROM marketplace_orders WHERE 1=1`
+	args := []any{}
+	if f.Marketplace != "" {
+		q += " AND marketplace = ?"
+		args = append(args, f.Marketplace)
+	}
+	if f.Status != "" {
+		q += " AND status = ?"
+		args = append(args, f.Status)
+	}
+	q += " ORDER BY id DESC"
+	limit := 20
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	out := []entity.MarketplaceOrder{}
+	for rows.Next() {
+		o, err := scanMarketplaceOrder(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := r.loadOrderLines(ctx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// loadOrderLines carga los artículos de las ventas con una sola consulta
+func (r *MarketplaceRepo) loadOrderLines(ctx context.Context, orders []entity.MarketplaceOrder) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*entity.MarketplaceOrder, len(orders))
+	args := make([]any, 0, len(orders))
+	for i := range orders {
+		orders[i].Lines = []entity.MarketplaceOrderLine{}
+		byID[orders[i].ID] = &orders[i]
+		args = append(args, orders[i].ID)
+	}
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, order_id, external_item_id, title, product_id, quantity, unit_price, shortage
+		FROM marketplace_order_lines
+		WHERE order_id IN (`+placeholders(len(args))+`)
+		ORDER BY id`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ln entity.MarketplaceOrderLine
+		var orderID int64
+		var productID sql.NullInt64
+		var unitPrice string
+		if err := rows.Scan(&ln.ID, &orderID, &ln.ExternalItemID, &ln.Title, &productID, &ln.Quantity, &unitPrice, &ln.Shortage); err != nil {
+			return err
+		}
+		o := byID[orderID]
+		if ln.UnitPrice, err = parseMoney(unitPrice, o.Total.Currency()); err != nil {
+			return err
+		}
+		if productID.Valid {
+			ln.ProductID = &productID.Int64
+		}
+		o.Lines = append(o.Lines, ln)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,459 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+	"core/internal/domain/tax"
+)
+
+type OrderRepo struct {
+	DB *sql.DB
+}
+
+func NewOrderRepository(db *sql.DB) *OrderRepo { return &OrderRepo{DB: db} }
+
+var _ repository.OrderRepository = (*OrderRepo)(nil)
+
+const orderColumns = `id, user_id, status, currency, subtotal, discount, tax_mode, tax, total,
+		rate_table_id, rate_base_currency, exchange_rate, rate_captured_at,
+		billing_tax_id, billing_tax_id_type, billing_name, billing_address,
+		shipping_method_id, shipping_method_name, shipping_address, shipping_weight_grams,
+		shipping_amount, shipping_tax_rate_bps, shipping_net, shipping_tax, paid_at, updated_at, created_at`
+
+func scanOrder(s rowScanner) (entity.Order, error) {
+	var o entity.Order
+	var currency, subtotal, discount, taxAmount, total string
+	var rateTableID sql.NullInt64
+	var rateBase sql.NullString
+	var rate sql.Null[money.Rate]
+	var capturedAt, paidAt sql.NullTime
+	var shipMethodID, shipWeight, shipRate sql.NullInt64
+	var shipMethodName, shipAddress, shipAmount, shipNet, shipTax sql.NullString
+	if err := s.Scan(&o.ID, &o.UserID, &o.Status, &currency, &subtotal, &discount, &o.TaxMode, &taxAmount, &total,
+		&rateTableID, &rateBase, &rate, &capturedAt,
+		&o.Billing.TaxID, &o.Billing.TaxIDType, &o.Billing.Name, &o.Billing.Address,
+		&shipMethodID, &shipMethodName, &shipAddress, &shipWeight,
+		&shipAmount, &shipRate, &shipNet, &shipTax, &paidAt, &o.UpdatedAt, &o.CreatedAt); err != nil {
+		return entity.Order{}, err
+	}
+
+	var err error
+	o.Currency = money.Currency(currency)
+	o.PaidAt = nullTimePtr(paidAt)
+	if o.Subtotal, err = parseMoney(subtotal, o.Currency); err != nil {
+		return entity.Order{}, err
+	}
+	if o.Discount, err = parseMoney(discount, o.Currency); err != nil {
+		return entity.Order{}, err
+	}
+	if o.Tax, err = parseMoney(taxAmount, o.Currency); err != nil {
+		return entity.Order{}, err
+	}
+	if o.Total, err = parseMoney(total, o.Currency); err != nil {
+		return entity.Order{}, err
+	}
+	if rate.Valid {
+		o.ExchangeRate = &entity.RateSnapshot{
+			BaseCurrency: money.Currency(rateBase.String),
+			Currency:     o.Currency,
+			Rate:         rate.V,
+			CapturedAt:   capturedAt.Time,
+		}
+		if rateTableID.Valid {
+			id := rateTableID.Int64
+			o.ExchangeRate.RateTableID = &id
+		}
+	}
+	// Sin método de envío la orden es para retiro en local
+	if shipMethodID.Valid {
+		sh := &entity.OrderShipping{
+			MethodID:    shipMethodID.Int64,
+			MethodName:  shipMethodName.String,
+			WeightGrams: shipWeight.Int64,
+			TaxRate:     tax.Rate(shipRate.Int64),
+		}
+		if err := json.Unmarshal([]byte(shipAddress.String), &sh.Address); err != nil {
+			return entity.Order{}, fmt.Errorf("invalid shipping address of order %d: %w", o.ID, err)
+		}
+		if sh.Amount, err = parseMoney(shipAmount.String, o.Currency); err != nil {
+			return entity.Order{}, err
+		}
+		if sh.Net, err = parseMoney(shipNet.String, o.Currency); err != nil {
+			return entity.Order{}, err
+		}
+		if sh.Tax, err = parseMoney(shipTax.String, o.Currency); err != nil {
+			return entity.Order{}, err
+		}
+		o.Shipping = sh
+	}
+	return o, nil
+}
+
+func (r *OrderRepo) Create(ctx context.Context, o *entity.Order) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkPromotionLimits(ctx, tx, o); err != nil {
+		return err
+	}
+
+	var rateTableID, rateBase, rate, capturedAt any
+	if snap := o.ExchangeRate; snap != nil {
+		rateTableID, rateBase, rate, capturedAt = snap.RateTableID, snap.BaseCurrency, snap.Rate, snap.CapturedAt
+	}
+	var shipMethodID, shipMethodName, shipAddress, shipWeight, shipAmount, shipRate, shipNet, shipTax any
+	if sh := o.Shipping; sh != nil {
+		address, err := json.Marshal(sh.Address)
+		if err != nil {
+			return err
+		}
+		shipMethodID, shipMethodName, shipAddress, shipWeight = sh.MethodID, sh.MethodName, string(address), sh.WeightGrams
+		shipAmount, shipRate, shipNet, shipTax = sh.Amount, sh.TaxRate, sh.Net, sh.Tax
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO orders (user_id, status, currency, subtotal, discount, tax_mode, tax, total,
+			rate_table_id, rate_base_currency, exchange_rate, rate_captured_at,
+			billing_tax_id, billing_tax_id_type, billing_name, billing_address,
+			shipping_method_id, shipping_method_name, shipping_address, shipping_weight_grams,
+			shipping_amount, shipping_tax_rate_bps, shipping_net, shipping_tax)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		o.UserID, o.Status, o.Currency, o.Subtotal, o.Discount, o.TaxMode, o.Tax, o.Total,
+		rateTableID, rateBase, rate, capturedAt,
+		o.Billing.TaxID, o.Billing.TaxIDType, o.Billing.Name, o.Billing.Address,
+		shipMethodID, shipMethodName, shipAddress, shipWeight,
+		shipAmount, shipRate, shipNet, shipTax,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create order: %w", err)
+	}
+	id, _ := res.LastInsertId()
+
+	sale := entity.StockMovementRef{Kind: entity.StockMovementSale, Reason: "Venta", Reference: fmt.Sprintf("order:%d", id), ActorID: o.UserID}
+	for i := range o.Items {
+		it := &o.Items[i]
+		allocations, err := allocateStock(ctx, tx, it.ProductID, it.Quantity, o.LocationID, sale)
+		if err != nil {
+			return err
+		}
+		it.Allocations = allocations
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO order_items (order_id, product_id, bar_code, title, size, category, weight_grams, quantity,
+				base_unit_price, base_currency, unit_price, price_source, line_total, discount,
+				tax_rate_bps, net_amount, tax_amount)
+			VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+			id, it.ProductID, it.BarCode, it.Title, it.Size, it.Category, it.WeightGrams, it.Quantity,
+			it.BaseUnitPrice, it.BaseUnitPrice.Currency(), it.UnitPrice, it.PriceSource, it.LineTotal, it.Discount,
+			it.TaxRate, it.NetAmount, it.TaxAmount,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create order item: %w", err)
+		}
+		it.ID, _ = res.LastInsertId()
+		it.OrderID = id
+
+		for _, a := range it.Allocations {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO order_item_allocations (order_item_id, location_id, quantity) VALUES (?,?,?)`,
+				it.ID, a.LocationID, a.Quantity); err != nil {
+				return fmt.Errorf("failed to create order item allocation: %w", err)
+			}
+		}
+	}
+
+	for i := range o.Discounts {
+		d := &o.Discounts[i]
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO order_discounts (order_id, promotion_id, code, name, description, amount)
+			VALUES (?,?,?,?,?,?)`,
+			id, d.PromotionID, d.Code, d.Name, d.Description, d.Amount,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create order discount: %w", err)
+		}
+		d.ID, _ = res.LastInsertId()
+		d.OrderID = id
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	o.ID = id
+	return nil
+}
+
+// checkPromotionLimits bloquea las promociones usadas por la orden y verifica que no se
+// hayan agotado desde que se evaluó el carrito
+func checkPromotionLimits(ctx context.Context, tx *sql.Tx, o *entity.Order) error {
+	for _, d := range o.Discounts {
+		var usageLimit, perCustomerLimit sql.NullInt64
+		err := tx.QueryRowContext(ctx, `
+			SELECT usage_limit, per_customer_limit FROM promotions WHERE id = ? FOR UPDATE`, d.PromotionID).
+			Scan(&usageLimit, &perCustomerLimit)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domainerrors.ErrPromotionLimit
+		}
+		if err != nil {
+			return err
+		}
+		if !usageLimit.Valid && !perCustomerLimit.Valid {
+			continue
+		}
+
+		usage := map[int64]entity.PromotionUsage{}
+		if err := queryPromotionUsage(ctx, tx, usage, []int64{d.PromotionID}, o.UserID); err != nil {
+			return err
+		}
+		u := usage[d.PromotionID]
+		if usageLimit.Valid && u.Total >= usageLimit.Int64 || perCustomerLimit.Valid && u.Customer >= perCustomerLimit.Int64 {
+			return domainerrors.ErrPromotionLimit
+		}
+	}
+	return nil
+}
+
+func (r *OrderRepo) GetByID(ctx context.Context, id int64) (entity.Order, error) {
+	o, err := scanOrder(r.DB.QueryRowContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Order{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	orders := []*entity.Order{&o}
+	if err := r.loadItems(ctx, r.DB, orders); err != nil {
+		return entity.Order{}, err
+	}
+	return o, nil
+}
+
+func (r *OrderRepo) List(ctx context.Context, f entity.OrderFilter) ([]entity.Order, error) {
+	q := `SELECT ` + orderColumns + ` FROM orders WHERE 1=1`
+	args := []any{}
+	if f.UserID > 0 {
+		q += " AND user_id = ?"
+		args = append(args, f.UserID)
+	}
+	if f.Status != "" {
+		q += " AND status = ?"
+		args = append(args, f.Status)
+	}
+	q += " ORDER BY created_at DESC, id DESC"
+	limit := 20
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.Order
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*entity.Order, len(out))
+	for i := range out {
+		orders[i] = &out[i]
+	}
+	return out, r.loadItems(ctx, r.DB, orders)
+}
+
+func (r *OrderRepo) UpdateStatus(ctx context.Context, id int64, from, to entity.OrderStatus) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.updateStatus(ctx, tx, id, from, to); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// updateStatus pasa la orden de from a to dentro de tx. Al pagarla escribe order.paid
+// en el outbox en la misma transacción.
+func (r *OrderRepo) updateStatus(ctx context.Context, tx *sql.Tx, id int64, from, to entity.OrderStatus) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE orders SET status = ?, paid_at = IF(? = 'paid', NOW(), paid_at), updated_at = NOW()
+		WHERE id = ? AND status = ?`, to, to, id, from)
+	if err != nil {
+		return err
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM orders WHERE id = ?)`, id).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return domainerrors.ErrNotFound
+		}
+		return domainerrors.ErrInvalidTransition
+	}
+	if to == entity.OrderStatusPaid {
+		// order.paid lleva la orden tal como quedó en la transacción
+		o, err := scanOrder(tx.QueryRowContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = ?`, id))
+		if err != nil {
+			return err
+		}
+		if err := r.loadItems(ctx, tx, []*entity.Order{&o}); err != nil {
+			return err
+		}
+		if err := writeOutbox(ctx, tx, entity.OutboxAggregateOrder, id, entity.EventOrderPaid, o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// querier es un *sql.DB o un *sql.Tx
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func (r *OrderRepo) loadItems(ctx context.Context, q querier, orders []*entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*entity.Order, len(orders))
+	args := make([]any, 0, len(orders))
+	for _, o := range orders {
+		o.Items = []entity.OrderItem{}
+		o.Discounts = []entity.OrderDiscount{}
+		byID[o.ID] = o
+		args = append(args, o.ID)
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, order_id, product_id, bar_code, title, size, category, weight_grams, quantity,
+			base_unit_price, base_currency, unit_price, price_source, line_total, discount,
+			tax_rate_bps, net_amount, tax_amount
+		FROM order_items
+		WHERE order_id IN (`+placeholders(len(args))+`)
+		ORDER BY id`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var it entity.OrderItem
+		var baseUnitPrice, baseCurrency, unitPrice, lineTotal, discount, netAmount, taxAmount string
+		if err := rows.Scan(&it.ID, &it.OrderID, &it.ProductID, &it.BarCode, &it.Title, &it.Size, &it.Category, &it.WeightGrams, &it.Quantity,
+			&baseUnitPrice, &baseCurrency, &unitPrice, &it.PriceSource, &lineTotal, &discount,
+			&it.TaxRate, &netAmount, &taxAmount); err != nil {
+			return err
+		}
+		o := byID[it.OrderID]
+		if it.BaseUnitPrice, err = parseMoney(baseUnitPrice, money.Currency(baseCurrency)); err != nil {
+			return err
+		}
+		if it.UnitPrice, err = parseMoney(unitPrice, o.Currency); err != nil {
+			return err
+		}
+		if it.LineTotal, err = parseMoney(lineTotal, o.Currency); err != nil {
+			return err
+		}
+		if it.Discount, err = parseMoney(discount, o.Currency); err != nil {
+			return err
+		}
+		if it.NetAmount, err = parseMoney(netAmount, o.Currency); err != nil {
+			return err
+		}
+		if it.TaxAmount, err = parseMoney(taxAmount, o.Currency); err != nil {
+			return err
+		}
+		o.Items = append(o.Items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := r.loadAllocations(ctx, q, byID, args); err != nil {
+		return err
+	}
+	return r.loadDiscounts(ctx, q, byID, args)
+}
+
+func (r *OrderRepo) loadAllocations(ctx context.Context, q querier, byID map[int64]*entity.Order, orderIDs []any) error {
+	items := map[int64]*entity.OrderItem{}
+	for _, o := range byID {
+		for i := range o.Items {
+			items[o.Items[i].ID] = &o.Items[i]
+		}
+	}
+	rows, err := q.QueryContext(ctx, `
+		SELECT a.order_item_id, a.location_id, l.code, a.quantity
+		FROM order_item_allocations a
+		JOIN order_items oi ON oi.id = a.order_item_id
+		JOIN locations l ON l.id = a.location_id
+		WHERE oi.order_id IN (`+placeholders(len(orderIDs))+`)
+		ORDER BY a.id`, orderIDs...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID int64
+		var a entity.StockAllocation
+		if err := rows.Scan(&itemID, &a.LocationID, &a.LocationCode, &a.Quantity); err != nil {
+			return err
+		}
+		if it, ok := items[itemID]; ok {
+			it.Allocations = append(it.Allocations, a)
+		}
+	}
+	return rows.Err()
+}
+
+func (r *OrderRepo) loadDiscounts(ctx context.Context, q querier, byID map[int64]*entity.Order, orderIDs []any) error {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, order_id, promotion_id, code, name, description, amount
+		FROM order_discounts
+		WHERE order_id IN (`+placeholders(len(orderIDs))+`)
+		ORDER BY id`, orderIDs...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d entity.OrderDiscount
+		var amount string
+		if err := rows.Scan(&d.ID, &d.OrderID, &d.PromotionID, &d.Code, &d.Name, &d.Description, &amount); err != nil {
+			return err
+		}
+		o := byID[d.OrderID]
+		if d.Amount, err = parseMoney(amount, o.Currency); err != nil {
+			return err
+		}
+		o.Discounts = append(o.Discounts, d)
+	}
+	return rows.Err()
+}
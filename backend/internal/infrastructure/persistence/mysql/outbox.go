@@ -0,0 +1,154 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+)
+
+type OutboxRepo struct {
+	DB *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepo { return &OutboxRepo{DB: db} }
+
+var _ repository.OutboxRepository = (*OutboxRepo)(nil)
+
+// writeOutbox guarda un evento en el outbox dentro de la transacción de la mutación. Se
+// llama con la fila del agregado ya bloqueada, así los IDs de un mismo agregado siguen el
+// orden en que se confirman sus transacciones.
+func writeOutbox(ctx context.Context, tx *sql.Tx, aggregate string, aggregateID int64, event entity.DomainEvent, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO outbox_events (aggregate_type, aggregate_id, event, payload) VALUES (?,?,?,?)`,
+		aggregate, aggregateID, event, string(payload)); err != nil {
+		return fmt.Errorf("failed to write outbox event: %w", err)
+	}
+	return nil
+}
+
+// writeProductOutbox guarda el evento con el producto tal como quedó en la transacción
+func writeProductOutbox(ctx context.Context, tx *sql.Tx, id int64, event entity.DomainEvent) error {
+	p, err := scanProduct(tx.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE id = ?`, id))
+	if err != nil {
+		return err
+	}
+	return writeOutbox(ctx, tx, entity.OutboxAggregateProduct, id, event, p)
+}
+
+const outboxColumns = `id, aggregate_type, aggregate_id, event, payload, attempts, last_error,
+		next_attempt_at, published_at, created_at`
+
+func scanOutboxEvent(s rowScanner) (entity.OutboxEvent, error) {
+	var e entity.OutboxEvent
+	var payload string
+	var nextAttemptAt, publishedAt sql.NullTime
+	if err := s.Scan(&e.ID, &e.AggregateType, &e.AggregateID, &e.Event, &payload, &e.Attempts, &e.LastError,
+		&nextAttemptAt, &publishedAt, &e.CreatedAt); err != nil {
+		return entity.OutboxEvent{}, err
+	}
+	e.Data = json.RawMessage(payload)
+	e.NextAttemptAt = nullTimePtr(nextAttemptAt)
+	e.PublishedAt = nullTimePtr(publishedAt)
+	return e, nil
+}
+
+func (r *OutboxRepo) Pending(ctx context.Context, now time.Time, limit int) ([]entity.OutboxEvent, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+outboxColumns+`
+		FROM outbox_events o
+		WHERE o.published_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox_events b
+				WHERE b.aggregate_type = o.aggregate_type AND b.aggregate_id = o.aggregate_id
+					AND b.id <= o.id AND b.published_at IS NULL AND b.next_attempt_at > ?
+			)
+		ORDER BY o.id
+		LIMIT ?`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.OutboxEvent{}
+	for rows.Next() {
+		e, err := scanOutboxEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func (r *OutboxRepo) MarkPublished(ctx context.Context, id int64) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE outbox_events SET published_at = NOW(), next_attempt_at = NULL WHERE id = ?`, id)
+	return err
+}
+
+func (r *OutboxRepo) MarkFailed(ctx context.Context, id int64, reason string, next time.Time) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE outbox_events SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?
+		WHERE id = ?`, reason, next, id)
+	return err
+}
+
+func (r *OutboxRepo) List(ctx context.Context, f entity.OutboxFilter) ([]entity.OutboxEvent, error) {
+	q := `SELECT ` + outboxColumns + ` FROM outbox_events WHERE 1=1`
+	args := []any{}
+	switch f.Status {
+	case entity.OutboxPending:
+		q += " AND published_at IS NULL"
+	case entity.OutboxPublished:
+		q += " AND published_at IS NOT NULL"
+	}
+	if f.AggregateType != "" {
+		q += " AND aggregate_type = ?"
+		args = append(args, f.AggregateType)
+	}
+	if f.AggregateID > 0 {
+		q += " AND aggregate_id = ?"
+		args = append(args, f.AggregateID)
+	}
+	q += " ORDER BY id DESC"
+	limit := 50
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.OutboxEvent{}
+	for rows.Next() {
+		e, err := scanOutboxEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func (r *OutboxRepo) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.DB.ExecContext(ctx, `
+		DELETE FROM outbox_events WHERE published_at IS NOT NULL AND published_at < ?`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,498 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+)
+
+type PosRepo struct {
+	DB *sql.DB
+}
+
+func NewPosRepository(db *sql.DB) *PosRepo { return &PosRepo{DB: db} }
+
+var _ repository.PosRepository = (*PosRepo)(nil)
+
+const registerShiftColumns = `rs.id, rs.register, rs.location_id, l.code, rs.cashier_id, rs.status, rs.currency,
+		rs.opening_float, rs.expected_cash, rs.counted_cash, rs.note, rs.opened_at, rs.closed_at`
+
+const registerShiftFrom = `
+		FROM register_shifts rs
+		JOIN locations l ON l.id = rs.location_id`
+
+func scanRegisterShift(s rowScanner) (entity.RegisterShift, error) {
+	var sh entity.RegisterShift
+	var currency, openingFloat string
+	var expected, counted sql.NullString
+	var closedAt sql.NullTime
+	if err := s.Scan(&sh.ID, &sh.Register, &sh.LocationID, &sh.LocationCode, &sh.CashierID, &sh.Status, &currency,
+		&openingFloat, &expected, &counted, &sh.Note, &sh.OpenedAt, &closedAt); err != nil {
+		return entity.RegisterShift{}, err
+	}
+	var err error
+	cur := money.Currency(currency)
+	if sh.OpeningFloat, err = parseMoney(openingFloat, cur); err != nil {
+		return entity.RegisterShift{}, err
+	}
+	if sh.ExpectedCash, err = parseNullMoney(expected, cur); err != nil {
+		return entity.RegisterShift{}, err
+	}
+	if sh.CountedCash, err = parseNullMoney(counted, cur); err != nil {
+		return entity.RegisterShift{}, err
+	}
+	sh.ClosedAt = nullTimePtr(closedAt)
+	return sh, nil
+}
+
+func (r *PosRepo) OpenShift(ctx context.Context, sh *entity.RegisterShift) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Se bloquean la ubicación y el cajero para que no se abran dos turnos a la vez
+	var code string
+	if err := tx.QueryRowContext(ctx, `SELECT code FROM locations WHERE id = ? FOR UPDATE`, sh.LocationID).Scan(&code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domainerrors.ErrNotFound
+		}
+		return err
+	}
+	var userID int64
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM users WHERE id = ? FOR UPDATE`, sh.CashierID).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domainerrors.ErrNotFound
+		}
+		return err
+	}
+	var busy bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM register_shifts
+			WHERE status = ? AND (cashier_id = ? OR (location_id = ? AND register = ?)))`,
+		entity.RegisterShiftStatusOpen, sh.CashierID, sh.LocationID, sh.Register).Scan(&busy); err != nil {
+		return err
+	}
+	if busy {
+		return domainerrors.ErrConflict
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO register_shifts (register, location_id, cashier_id, status, currency, opening_float, note)
+		VALUES (?,?,?,?,?,?,?)`,
+		sh.Register, sh.LocationID, sh.CashierID, entity.RegisterShiftStatusOpen, sh.OpeningFloat.Currency(), sh.OpeningFloat, sh.Note)
+	if err != nil {
+		return fmt.Errorf("failed to open register shift: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	sh.ID, _ = res.LastInsertId()
+	sh.LocationCode = code
+	sh.Status = entity.RegisterShiftStatusOpen
+	return nil
+}
+
+func (r *PosRepo) GetShift(ctx context.Context, id int64) (entity.RegisterShift, error) {
+	sh, err := scanRegisterShift(r.DB.QueryRowContext(ctx, `SELECT `+registerShiftColumns+registerShiftFrom+` WHERE rs.id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.RegisterShift{}, domainerrors.ErrNotFound
+	}
+	return sh, err
+}
+
+func (r *PosRepo) CurrentShift(ctx context.Context, cashierID int64) (entity.RegisterShift, error) {
+	sh, err := scanRegisterShift(r.DB.QueryRowContext(ctx, `
+		SELECT `+registerShiftColumns+registerShiftFrom+`
+		WHERE rs.cashier_id = ? AND rs.status = ?
+		ORDER BY rs.id DESC LIMIT 1`, cashierID, entity.RegisterShiftStatusOpen))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.RegisterShift{}, domainerrors.ErrNotFound
+	}
+	return sh, err
+}
+
+func (r *PosRepo) ListShifts(ctx context.Context, f entity.RegisterShiftFilter) ([]entity.RegisterShift, error) {
+	q := `SELECT ` + registerShiftColumns + registerShiftFrom + ` WHERE 1=1`
+	args := []any{}
+	if f.CashierID > 0 {
+		q += " AND rs.cashier_id = ?"
+		args = append(args, f.CashierID)
+	}
+	if f.LocationID > 0 {
+		q += " AND rs.location_id = ?"
+		args = append(args, f.LocationID)
+	}
+	if f.Status != "" {
+		q += " AND rs.status = ?"
+		args = append(args, f.Status)
+	}
+	q += " ORDER BY rs.id DESC"
+	limit := 20
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.RegisterShift{}
+	for rows.Next() {
+		sh, err := scanRegisterShift(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sh)
+	}
+	return out, rows.Err()
+}
+
+func (r *PosRepo) ShiftTotals(ctx context.Context, shiftID int64) (entity.ShiftTotals, error) {
+	var currency string
+	err := r.DB.QueryRowContext(ctx, `SELECT currency FROM register_shifts WHERE id = ?`, shiftID).Scan(&currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ShiftTotals{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.ShiftTotals{}, err
+	}
+	return shiftTotals(ctx, r.DB, shiftID, money.Currency(currency))
+}
+
+// shiftTotals suma las ventas cobradas del turno y sus cobros por medio de pago
+func shiftTotals(ctx context.Context, q interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}, shiftID int64, cur money.Currency) (entity.ShiftTotals, error) {
+	var t entity.ShiftTotals
+	var total, change, cash, card string
+	if err := q.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(total), 0), COALESCE(SUM(change_given), 0)
+		FROM pos_sales WHERE shift_id = ? AND status = ?`,
+		shiftID, entity.PosSaleStatusCompleted).Scan(&t.Sales, &total, &change); err != nil {
+		return entity.ShiftTotals{}, err
+	}
+	if err := q.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(IF(p.method = 'cash', p.amount, 0)), 0), COALESCE(SUM(IF(p.method = 'card', p.amount, 0)), 0)
+		FROM pos_payments p
+		JOIN pos_sales s ON s.id = p.sale_id
+		WHERE s.shift_id = ? AND s.status = ?`,
+		shiftID, entity.PosSaleStatusCompleted).Scan(&cash, &card); err != nil {
+		return entity.ShiftTotals{}, err
+	}
+
+	var err error
+	if t.Total, err = parseMoney(total, cur); err != nil {
+		return entity.ShiftTotals{}, err
+	}
+	if t.Card, err = parseMoney(card, cur); err != nil {
+		return entity.ShiftTotals{}, err
+	}
+	cashIn, err := parseMoney(cash, cur)
+	if err != nil {
+		return entity.ShiftTotals{}, err
+	}
+	changeGiven, err := parseMoney(change, cur)
+	if err != nil {
+		return entity.ShiftTotals{}, err
+	}
+	if t.Cash, err = cashIn.Sub(changeGiven); err != nil {
+		return entity.ShiftTotals{}, err
+	}
+	return t, nil
+}
+
+func (r *PosRepo) CloseShift(ctx context.Context, sh *entity.RegisterShift) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := scanRegisterShift(tx.QueryRowContext(ctx, `
+		SELECT `+registerShiftColumns+registerShiftFrom+` WHERE rs.id = ? FOR UPDATE`, sh.ID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if current.Status != entity.RegisterShiftStatusOpen {
+		return domainerrors.ErrInvalidTransition
+	}
+
+	// Lo que quedó sin cobrar no llegó a descontar stock, se anula sin más
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE pos_sales SET status = ?, updated_at = NOW() WHERE shift_id = ? AND status = ?`,
+		entity.PosSaleStatusVoided, sh.ID, entity.PosSaleStatusOpen); err != nil {
+		return err
+	}
+	totals, err := shiftTotals(ctx, tx, sh.ID, current.OpeningFloat.Currency())
+	if err != nil {
+		return err
+	}
+	report, err := current.Reconcile(totals)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE register_shifts SET status = ?, expected_cash = ?, counted_cash = ?, note = ?, closed_at = NOW()
+		WHERE id = ?`,
+		entity.RegisterShiftStatusClosed, report.ExpectedCash, sh.CountedCash, sh.Note, sh.ID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	sh.Status = entity.RegisterShiftStatusClosed
+	sh.ExpectedCash = &report.ExpectedCash
+	return nil
+}
+
+func (r *PosRepo) CreateSale(ctx context.Context, sale *entity.PosSale) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status entity.RegisterShiftStatus
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM register_shifts WHERE id = ? FOR SHARE`, sale.ShiftID).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domainerrors.ErrNotFound
+		}
+		return err
+	}
+	if status != entity.RegisterShiftStatusOpen {
+		return domainerrors.ErrInvalidTransition
+	}
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO pos_sales (shift_id, cashier_id, status) VALUES (?,?,?)`,
+		sale.ShiftID, sale.CashierID, entity.PosSaleStatusOpen)
+	if err != nil {
+		return fmt.Errorf("failed to create pos sale: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	sale.ID, _ = res.LastInsertId()
+	sale.Status = entity.PosSaleStatusOpen
+	return nil
+}
+
+func (r *PosRepo) GetSale(ctx context.Context, id int64) (entity.PosSale, error) {
+	var s entity.PosSale
+	var currency string
+	var orderID sql.NullInt64
+	var total, change sql.NullString
+	var completedAt sql.NullTime
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT ps.id, ps.shift_id, ps.cashier_id, ps.status, rs.currency, ps.order_id, ps.total, ps.change_given,
+			ps.completed_at, ps.updated_at, ps.created_at
+		FROM pos_sales ps
+		JOIN register_shifts rs ON rs.id = ps.shift_id
+		WHERE ps.id = ?`, id).
+		Scan(&s.ID, &s.ShiftID, &s.CashierID, &s.Status, &currency, &orderID, &total, &change,
+			&completedAt, &s.UpdatedAt, &s.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.PosSale{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.PosSale{}, err
+	}
+	cur := money.Currency(currency)
+	if orderID.Valid {
+		s.OrderID = &orderID.Int64
+	}
+	if s.Total, err = parseNullMoney(total, cur); err != nil {
+		return entity.PosSale{}, err
+	}
+	if s.Change, err = parseNullMoney(change, cur); err != nil {
+		return entity.PosSale{}, err
+	}
+	s.CompletedAt = nullTimePtr(completedAt)
+
+	if err := r.loadSaleLines(ctx, &s); err != nil {
+		return entity.PosSale{}, err
+	}
+	if err := r.loadSalePayments(ctx, &s, cur); err != nil {
+		return entity.PosSale{}, err
+	}
+	return s, nil
+}
+
+func (r *PosRepo) loadSaleLines(ctx context.Context, s *entity.PosSale) error {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT l.product_id, p.bar_code, p.title, p.size, l.quantity
+		FROM pos_sale_lines l
+		JOIN products p ON p.id = l.product_id
+		WHERE l.sale_id = ?
+		ORDER BY l.seq`, s.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	s.Lines = []entity.PosSaleLine{}
+	for rows.Next() {
+		var l entity.PosSaleLine
+		if err := rows.Scan(&l.ProductID, &l.BarCode, &l.Title, &l.Size, &l.Quantity); err != nil {
+			return err
+		}
+		s.Lines = append(s.Lines, l)
+	}
+	return rows.Err()
+}
+
+func (r *PosRepo) loadSalePayments(ctx context.Context, s *entity.PosSale, cur money.Currency) error {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, method, amount, reference FROM pos_payments WHERE sale_id = ? ORDER BY id`, s.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	s.Payments = []entity.PosPayment{}
+	for rows.Next() {
+		var p entity.PosPayment
+		var amount string
+		if err := rows.Scan(&p.ID, &p.Method, &amount, &p.Reference); err != nil {
+			return err
+		}
+		if p.Amount, err = parseMoney(amount, cur); err != nil {
+			return err
+		}
+		s.Payments = append(s.Payments, p)
+	}
+	return rows.Err()
+}
+
+// lockOpenSale bloquea la venta y verifica que siga abierta
+func lockOpenSale(ctx context.Context, tx *sql.Tx, id int64) error {
+	var status entity.PosSaleStatus
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM pos_sales WHERE id = ? FOR UPDATE`, id).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domainerrors.ErrNotFound
+		}
+		return err
+	}
+	if status != entity.PosSaleStatusOpen {
+		return domainerrors.ErrInvalidTransition
+	}
+	return nil
+}
+
+func (r *PosRepo) AddLine(ctx context.Context, saleID int64, line entity.PosSaleLine) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockOpenSale(ctx, tx, saleID); err != nil {
+		return err
+	}
+	var current int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT quantity FROM pos_sale_lines WHERE sale_id = ? AND product_id = ?`, saleID, line.ProductID).Scan(&current)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	qty := current + line.Quantity
+	switch {
+	case qty < 0:
+		return domainerrors.ErrInvalidInput
+	case qty == 0:
+		_, err = tx.ExecContext(ctx, `DELETE FROM pos_sale_lines WHERE sale_id = ? AND product_id = ?`, saleID, line.ProductID)
+	case current == 0:
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO pos_sale_lines (sale_id, product_id, quantity, seq)
+			SELECT ?, ?, ?, COALESCE(MAX(seq), 0) + 1 FROM pos_sale_lines WHERE sale_id = ?`,
+			saleID, line.ProductID, qty, saleID)
+	default:
+		_, err = tx.ExecContext(ctx, `
+			UPDATE pos_sale_lines SET quantity = ? WHERE sale_id = ? AND product_id = ?`, qty, saleID, line.ProductID)
+	}
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1452 {
+			return domainerrors.ErrNotFound
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE pos_sales SET updated_at = NOW() WHERE id = ?`, saleID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PosRepo) CompleteSale(ctx context.Context, sale *entity.PosSale) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockOpenSale(ctx, tx, sale.ID); err != nil {
+		return err
+	}
+	for i := range sale.Payments {
+		p := &sale.Payments[i]
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO pos_payments (sale_id, method, amount, reference) VALUES (?,?,?,?)`,
+			sale.ID, p.Method, p.Amount, p.Reference)
+		if err != nil {
+			return fmt.Errorf("failed to create pos payment: %w", err)
+		}
+		p.ID, _ = res.LastInsertId()
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE pos_sales SET status = ?, order_id = ?, total = ?, change_given = ?, completed_at = NOW(), updated_at = NOW()
+		WHERE id = ?`,
+		entity.PosSaleStatusCompleted, sale.OrderID, sale.Total, sale.Change, sale.ID); err != nil {
+		return err
+	}
+	// La orden queda pagada junto con la venta: no hay cobro sin orden pagada
+	orders := &OrderRepo{DB: r.DB}
+	if err := orders.updateStatus(ctx, tx, *sale.OrderID, entity.OrderStatusPending, entity.OrderStatusPaid); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	sale.Status = entity.PosSaleStatusCompleted
+	return nil
+}
+
+func (r *PosRepo) VoidSale(ctx context.Context, id int64) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE pos_sales SET status = ?, updated_at = NOW() WHERE id = ? AND status = ?`,
+		entity.PosSaleStatusVoided, id, entity.PosSaleStatusOpen)
+	if err != nil {
+		return err
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		var exists bool
+		if err := r.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM pos_sales WHERE id = ?)`, id).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return domainerrors.ErrNotFound
+		}
+		return domainerrors.ErrInvalidTransition
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+)
+
+type PriceChangeRepo struct {
+	DB *sql.DB
+}
+
+func NewPriceChangeRepository(db *sql.DB) *PriceChangeRepo { return &PriceChangeRepo{DB: db} }
+
+var _ repository.PriceChangeRepository = (*PriceChangeRepo)(nil)
+
+const priceChangeColumns = `id, product_id, unit_price, compare_at_price, currency, effective_at, applied_at,
+		failed_at, failure, created_at`
+
+func scanPriceChange(s rowScanner) (entity.PriceChange, error) {
+	var c entity.PriceChange
+	var unitPrice, currency string
+	var compareAtPrice sql.NullString
+	var appliedAt, failedAt sql.NullTime
+	if err := s.Scan(&c.ID, &c.ProductID, &unitPrice, &compareAtPrice, &currency, &c.EffectiveAt, &appliedAt,
+		&failedAt, &c.Failure, &c.CreatedAt); err != nil {
+		return entity.PriceChange{}, err
+	}
+	var err error
+	cur := money.Currency(currency)
+	if c.UnitPrice, err = parseMoney(unitPrice, cur); err != nil {
+		return entity.PriceChange{}, err
+	}
+	if c.CompareAtPrice, err = parseNullMoney(compareAtPrice, cur); err != nil {
+		return entity.PriceChange{}, err
+	}
+	c.AppliedAt = nullTimePtr(appliedAt)
+	c.FailedAt = nullTimePtr(failedAt)
+	return c, nil
+}
+
+func (r *PriceChangeRepo) Create(ctx context.Context, c *entity.PriceChange) error {
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO product_price_changes (product_id, unit_price, compare_at_price, currency, effective_at)
+		VALUES (?,?,?,?,?)`,
+		c.ProductID, c.UnitPrice, c.CompareAtPrice, c.UnitPrice.Currency(), c.EffectiveAt,
+	)
+	if err != nil {
+		return err
+	}
+	id, _ := res.LastInsertId()
+	c.ID = id
+	return nil
+}
+
+func (r *PriceChangeRepo) GetByID(ctx context.Context, id int64) (entity.PriceChange, error) {
+	c, err := scanPriceChange(r.DB.QueryRowContext(ctx, `
+		SELECT `+priceChangeColumns+`
+		FROM product_price_changes WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.PriceChange{}, domainerrors.ErrNotFound
+	}
+	return c, err
+}
+
+func (r *PriceChangeRepo) FindByProductID(ctx context.Context, productID int64) ([]entity.PriceChange, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+priceChangeColumns+`
+		FROM product_price_changes
+		WHERE product_id = ?
+		ORDER BY effective_at ASC, id ASC`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.PriceChange
+	for rows.Next() {
+		c, err := scanPriceChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// Delete elimina un cambio pendiente; los ya aplicados se conservan como historial
+func (r *PriceChangeRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM product_price_changes WHERE id = ? AND applied_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PriceChangeRepo) ApplyDue(ctx context.Context, now time.Time) ([]entity.AppliedPriceChange, []entity.PriceChange, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	// Se bloquean las filas para que dos instancias no apliquen el mismo cambio
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+priceChangeColumns+`
+		FROM product_price_changes
+		WHERE applied_at IS NULL AND failed_at IS NULL AND effective_at <= ?
+		ORDER BY effective_at ASC, id ASC
+		FOR UPDATE`, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	var due []entity.PriceChange
+	for rows.Next() {
+		c, err := scanPriceChange(rows)
+		if err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		due = append(due, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	// Se aplican en orden: si hay varios vencidos para un producto gana el último
+	applied := []entity.AppliedPriceChange{}
+	failed := []entity.PriceChange{}
+	for _, c := range due {
+		before, err := scanProduct(tx.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE id = ? FOR UPDATE`, c.ProductID))
+		if errors.Is(err, sql.ErrNoRows) {
+			c.Failure = "product not found"
+		} else if err != nil {
+			return nil, nil, err
+		} else if cur := before.UnitPrice.Currency(); cur != c.UnitPrice.Currency() {
+			c.Failure = fmt.Sprintf("product is priced in %s, change is in %s", cur, c.UnitPrice.Currency())
+		}
+		if c.Failure != "" {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE product_price_changes SET failed_at = ?, failure = ? WHERE id = ?`, now, c.Failure, c.ID); err != nil {
+				return nil, nil, err
+			}
+			c.FailedAt = &now
+			failed = append(failed, c)
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE products SET unit_price = ?, compare_at_price = COALESCE(?, compare_at_price), updated_at = NOW()
+			WHERE id = ?`,
+			c.UnitPrice, c.CompareAtPrice, c.ProductID,
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to apply price change %d: %w", c.ID, err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE product_price_changes SET applied_at = ? WHERE id = ?`, now, c.ID); err != nil {
+			return nil, nil, err
+		}
+		after, err := scanProduct(tx.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE id = ?`, c.ProductID))
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := writeOutbox(ctx, tx, entity.OutboxAggregateProduct, c.ProductID, entity.EventProductUpdated, after); err != nil {
+			return nil, nil, err
+		}
+		c.AppliedAt = &now
+		applied = append(applied, entity.AppliedPriceChange{Change: c, Before: before, After: after})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	return applied, failed, nil
+}
@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"core/internal/domain/entity"
 	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
 	"core/internal/domain/repository"
 
 	mysqlerr "github.com/go-sql-driver/mysql"
@@ -21,11 +23,83 @@ func NewProductRepository(db *sql.DB) *ProductRepo { return &ProductRepo{DB: db}
 
 var _ repository.ProductRepository = (*ProductRepo)(nil)
 
+// productColumns es la lista de columnas que lee scanProduct, en el mismo orden
+const productColumns = `id, bar_code, title, description, stock, size, category, weight_grams, unit_price, currency,
+		status, publish_at, unpublish_at, updated_at, created_at,
+		compare_at_price, sale_price, sale_starts_at, sale_ends_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProduct(s rowScanner) (entity.Product, error) {
+	var p entity.Product
+	var publishAt, unpublishAt, saleStartsAt, saleEndsAt sql.NullTime
+	var unitPrice, currency string
+	var compareAtPrice, salePrice sql.NullString
+	err := s.Scan(&p.ID, &p.BarCode, &p.Title, &p.Description, &p.Stock, &p.Size, &p.Category, &p.WeightGrams, &unitPrice, &currency,
+		&p.Status, &publishAt, &unpublishAt, &p.UpdatedAt, &p.CreatedAt,
+		&compareAtPrice, &salePrice, &saleStartsAt, &saleEndsAt)
+	if err != nil {
+		return entity.Product{}, err
+	}
+	cur := money.Currency(currency)
+	if p.UnitPrice, err = parseMoney(unitPrice, cur); err != nil {
+		return entity.Product{}, err
+	}
+	if p.CompareAtPrice, err = parseNullMoney(compareAtPrice, cur); err != nil {
+		return entity.Product{}, err
+	}
+	if p.SalePrice, err = parseNullMoney(salePrice, cur); err != nil {
+		return entity.Product{}, err
+	}
+	p.PublishAt = nullTimePtr(publishAt)
+	p.UnpublishAt = nullTimePtr(unpublishAt)
+	p.SaleStartsAt = nullTimePtr(saleStartsAt)
+	p.SaleEndsAt = nullTimePtr(saleEndsAt)
+	return p, nil
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
+
+// parseMoney convierte una columna DECIMAL leída como texto en un monto exacto
+func parseMoney(s string, cur money.Currency) (money.Money, error) {
+	return money.Parse(s, cur, money.RoundHalfEven)
+}
+
+func parseNullMoney(s sql.NullString, cur money.Currency) (*money.Money, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	m, err := parseMoney(s.String, cur)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Create da de alta el producto con su stock inicial en la ubicación por defecto. El
+// outbox recibe product.created con stock 0 y después el stock.changed del alta.
 func (r *ProductRepo) Create(ctx context.Context, p *entity.Product) error {
-	res, err := r.DB.ExecContext(ctx, `
-		INSERT INTO products (bar_code, title, description, stock, size, category, unit_price)
-		VALUES (?,?,?,?,?,?,?)`,
-		p.BarCode, p.Title, p.Description, p.Stock, p.Size, p.Category, p.UnitPrice,
+	if p.Status == "" {
+		p.Status = entity.ProductStatusDraft
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO products (bar_code, title, description, stock, size, category, weight_grams, unit_price, currency, status, publish_at, unpublish_at)
+		VALUES (?,?,?,0,?,?,?,?,?,?,?,?)`,
+		p.BarCode, p.Title, p.Description, p.Size, p.Category, p.WeightGrams, p.UnitPrice, p.UnitPrice.Currency(), p.Status, p.PublishAt, p.UnpublishAt,
 	)
 	if err != nil {
 		var me *mysqlerr.MySQLError
@@ -35,13 +109,28 @@ func (r *ProductRepo) Create(ctx context.Context, p *entity.Product) error {
 		return err
 	}
 	id, _ := res.LastInsertId()
+	if err := writeProductOutbox(ctx, tx, id, entity.EventProductCreated); err != nil {
+		return err
+	}
+	if p.Stock > 0 {
+		locationID, err := defaultLocationID(ctx, tx)
+		if err != nil {
+			return err
+		}
+		ref := entity.StockMovementRef{Kind: entity.StockMovementAdjustment, Reason: "Alta del producto", Reference: fmt.Sprintf("product:%d", id)}
+		if err := adjustLevel(ctx, tx, id, locationID, p.Stock, ref); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 	p.ID = id
 	return nil
 }
 
 func (r *ProductRepo) Read(ctx context.Context) ([]entity.Product, error) {
-	q := `SELECT id, bar_code, title, description, stock, size, category, unit_price, updated_at, created_at 
-		FROM products`
+	q := `SELECT ` + productColumns + ` FROM products`
 
 	rows, err := r.DB.QueryContext(ctx, q)
 	if err != nil {
@@ -51,8 +140,8 @@ func (r *ProductRepo) Read(ctx context.Context) ([]entity.Product, error) {
 
 	var out []entity.Product
 	for rows.Next() {
-		var p entity.Product
-		if err := rows.Scan(&p.ID, &p.BarCode, &p.Title, &p.Description, &p.Stock, &p.Size, &p.Category, &p.UnitPrice, &p.UpdatedAt, &p.CreatedAt); err != nil {
+		p, err := scanProduct(rows)
+		if err != nil {
 			return nil, err
 		}
 		out = append(out, p)
@@ -60,20 +149,29 @@ func (r *ProductRepo) Read(ctx context.Context) ([]entity.Product, error) {
 	return out, rows.Err()
 }
 
+// Update guarda los datos del producto. Stock es el total de las ubicaciones: si cambia,
+// la diferencia se ajusta en la ubicación por defecto.
 func (r *ProductRepo) Update(ctx context.Context, product *entity.Product) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE products
-		SET bar_code = ?, title = ?, description = ?, stock = ?, size = ?, category = ?, unit_price = ?, updated_at = NOW()
+		SET bar_code = ?, title = ?, description = ?, size = ?, category = ?, weight_grams = ?, unit_price = ?, currency = ?, updated_at = NOW()
 		WHERE id = ?
 	`
-	result, err := r.DB.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		product.BarCode,
 		product.Title,
 		product.Description,
-		product.Stock,
 		product.Size,
 		product.Category,
+		product.WeightGrams,
 		product.UnitPrice,
+		product.UnitPrice.Currency(),
 		product.ID,
 	)
 	if err != nil {
@@ -89,59 +187,98 @@ func (r *ProductRepo) Update(ctx context.Context, product *entity.Product) error
 		return fmt.Errorf("product not found")
 	}
 
-	return nil
+	var current int64
+	if err := tx.QueryRowContext(ctx, `SELECT stock FROM products WHERE id = ? FOR UPDATE`, product.ID).Scan(&current); err != nil {
+		return err
+	}
+	// Como en UpdateStock, las entradas van a la ubicación por defecto y las salidas se
+	// toman de las ubicaciones activas por prioridad
+	ref := entity.StockMovementRef{Kind: entity.StockMovementAdjustment, Reason: "Edición del producto", Reference: fmt.Sprintf("product:%d", product.ID)}
+	delta := product.Stock - current
+	switch {
+	case delta < 0:
+		if _, err := allocateStock(ctx, tx, product.ID, -delta, 0, ref); err != nil {
+			return err
+		}
+	case delta > 0:
+		locationID, err := defaultLocationID(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if err := adjustLevel(ctx, tx, product.ID, locationID, delta, ref); err != nil {
+			return err
+		}
+	}
+	if err := writeProductOutbox(ctx, tx, product.ID, entity.EventProductUpdated); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *ProductRepo) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM products WHERE id = ?`
-
-	result, err := r.DB.ExecContext(ctx, query, id)
+	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete product: %w", err)
+		return err
 	}
+	defer tx.Rollback()
 
-	rows, err := result.RowsAffected()
+	deleted := entity.ProductDeleted{ID: id}
+	err = tx.QueryRowContext(ctx, `SELECT bar_code FROM products WHERE id = ? FOR UPDATE`, id).Scan(&deleted.BarCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domainerrors.ErrNotFound
+	}
 	if err != nil {
 		return err
 	}
-
-	if rows == 0 {
-		return fmt.Errorf("product not found")
+	if _, err := tx.ExecContext(ctx, `DELETE FROM products WHERE id = ?`, id); err != nil {
+		// Movimientos de stock, compras o ventas de caja lo siguen referenciando
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1451 {
+			return domainerrors.ErrConflict
+		}
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+	if err := writeOutbox(ctx, tx, entity.OutboxAggregateProduct, id, entity.EventProductDeleted, deleted); err != nil {
+		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 func (r *ProductRepo) GetByID(ctx context.Context, id int64) (entity.Product, error) {
-	var p entity.Product
-	err := r.DB.QueryRowContext(ctx, `
-		SELECT id, bar_code, title, description, stock, size, category, unit_price, updated_at, created_at
-		FROM products WHERE id = ?`, id).
-		Scan(&p.ID, &p.BarCode, &p.Title, &p.Description, &p.Stock, &p.Size, &p.Category, &p.UnitPrice, &p.UpdatedAt, &p.CreatedAt)
+	p, err := scanProduct(r.DB.QueryRowContext(ctx, `
+		SELECT `+productColumns+`
+		FROM products WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Product{}, domainerrors.ErrNotFound
+	}
+	return p, err
+}
+
+func (r *ProductRepo) GetByBarCode(ctx context.Context, code string) (entity.Product, error) {
+	p, err := scanProduct(r.DB.QueryRowContext(ctx, `
+		SELECT `+productColumns+`
+		FROM products WHERE bar_code = ?`, code))
 	if errors.Is(err, sql.ErrNoRows) {
 		return entity.Product{}, domainerrors.ErrNotFound
 	}
 	return p, err
 }
 
+func (r *ProductRepo) MaxBarCode(ctx context.Context, prefix string) (string, error) {
+	var code sql.NullString
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT MAX(bar_code) FROM products WHERE bar_code LIKE ? AND CHAR_LENGTH(bar_code) = 13`,
+		prefix+"%").Scan(&code)
+	return code.String, err
+}
+
 func (r *ProductRepo) List(ctx context.Context, f entity.ProductFilter) ([]entity.Product, error) {
+	where, args := productFilterWhere(f)
 	q := `
-		SELECT id, bar_code, title, description, stock, size, category, unit_price, updated_at, created_at
-		FROM products`
-	args := []any{}
-	if f.Category != "" {
-		q += " AND category = ?"
-		args = append(args, f.Category)
-	}
-	if f.Size != "" {
-		q += " AND size = ?"
-		args = append(args, f.Size)
-	}
-	if f.Query != "" {
-		q += " AND (title LIKE ? OR description LIKE ?)"
-		like := "%" + f.Query + "%"
-		args = append(args, like, like)
-	}
+		SELECT ` + productColumns + `
+		FROM products WHERE 1=1` + where
 	q += " ORDER BY created_at DESC"
 	limit := 20
 	if f.Limit > 0 && f.Limit <= 100 {
@@ -159,8 +296,8 @@ func (r *ProductRepo) List(ctx context.Context, f entity.ProductFilter) ([]entit
 
 	var out []entity.Product
 	for rows.Next() {
-		var p entity.Product
-		if err := rows.Scan(&p.ID, &p.BarCode, &p.Title, &p.Description, &p.Stock, &p.Size, &p.Category, &p.UnitPrice, &p.UpdatedAt, &p.CreatedAt); err != nil {
+		p, err := scanProduct(rows)
+		if err != nil {
 			return nil, err
 		}
 		out = append(out, p)
@@ -168,14 +305,265 @@ func (r *ProductRepo) List(ctx context.Context, f entity.ProductFilter) ([]entit
 	return out, rows.Err()
 }
 
-func (r *ProductRepo) UpdateStock(ctx context.Context, id int64, delta int64) error {
-	res, err := r.DB.ExecContext(ctx, `UPDATE products SET stock = stock + ? WHERE id = ?`, delta, id)
+// productFilterWhere arma las condiciones del filtro para agregar a "WHERE 1=1"
+func productFilterWhere(f entity.ProductFilter) (string, []any) {
+	var where string
+	args := []any{}
+	if f.Status != "" {
+		where += " AND status = ?"
+		args = append(args, f.Status)
+	}
+	if f.VisibleAt != nil {
+		// Misma regla que entity.Product.IsVisible: el scheduler publica y archiva con
+		// cierto retraso
+		where += " AND status = ? AND (publish_at IS NULL OR publish_at <= ?) AND (unpublish_at IS NULL OR unpublish_at > ?)"
+		args = append(args, entity.ProductStatusPublished, *f.VisibleAt, *f.VisibleAt)
+	}
+	if f.Category != "" {
+		where += " AND category = ?"
+		args = append(args, f.Category)
+	}
+	if f.Size != "" {
+		where += " AND size = ?"
+		args = append(args, f.Size)
+	}
+	if f.Query != "" {
+		where += " AND (title LIKE ? OR description LIKE ?)"
+		like := "%" + f.Query + "%"
+		args = append(args, like, like)
+	}
+	return where, args
+}
+
+// productStreamBatch es cuántos productos se leen por consulta al recorrer el catálogo
+const productStreamBatch = 500
+
+// Stream lee el catálogo en lotes por ID en lugar de mantener un cursor abierto mientras
+// fn escribe, así una descarga lenta no retiene una conexión con la consulta a medias
+func (r *ProductRepo) Stream(ctx context.Context, f entity.ProductFilter, fn func(entity.CatalogEntry) error) error {
+	where, filterArgs := productFilterWhere(f)
+	var lastID int64
+	for {
+		batch, err := r.streamBatch(ctx, where, append([]any{lastID}, filterArgs...))
+		if err != nil {
+			return err
+		}
+		for _, e := range batch {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		if len(batch) < productStreamBatch {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+func (r *ProductRepo) streamBatch(ctx context.Context, where string, args []any) ([]entity.CatalogEntry, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+productColumns+`
+		FROM products WHERE id > ?`+where+`
+		ORDER BY id
+		LIMIT ?`, append(args, productStreamBatch)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batch []entity.CatalogEntry
+	index := make(map[int64]int)
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		index[p.ID] = len(batch)
+		batch = append(batch, entity.CatalogEntry{Product: p})
+	}
+	if err := rows.Err(); err != nil || len(batch) == 0 {
+		return batch, err
+	}
+
+	ids := make([]any, 0, len(batch))
+	for _, e := range batch {
+		ids = append(ids, e.ID)
+	}
+	imgRows, err := r.DB.QueryContext(ctx, `
+		SELECT id, product_id, url, is_primary, position, created_at
+		FROM product_images
+		WHERE product_id IN (`+placeholders(len(ids))+`)
+		ORDER BY product_id, is_primary DESC, position, id`, ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer imgRows.Close()
+	for imgRows.Next() {
+		var img entity.ProductImage
+		if err := imgRows.Scan(&img.ID, &img.ProductID, &img.URL, &img.IsPrimary, &img.Position, &img.CreatedAt); err != nil {
+			return nil, err
+		}
+		e := &batch[index[img.ProductID]]
+		e.Images = append(e.Images, img)
+	}
+	return batch, imgRows.Err()
+}
+
+// UpdateStock suma delta al stock. Las entradas van a la ubicación por defecto y las
+// salidas se toman de las ubicaciones activas por prioridad. Un descuento que deje el
+// stock negativo falla con ErrInsufficientStock sin modificar nada.
+func (r *ProductRepo) UpdateStock(ctx context.Context, id int64, delta int64, ref entity.StockMovementRef) (entity.StockUpdate, error) {
+	if delta == 0 {
+		return entity.StockUpdate{}, nil
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return entity.StockUpdate{}, err
+	}
+	defer tx.Rollback()
+
+	// applied es lo que cambió el disponible: una entrada en una ubicación por defecto
+	// inactiva no suma a la venta
+	applied := delta
+	if delta < 0 {
+		if _, err := allocateStock(ctx, tx, id, -delta, 0, ref); err != nil {
+			return entity.StockUpdate{}, err
+		}
+	} else {
+		locationID, err := defaultLocationID(ctx, tx)
+		if err != nil {
+			return entity.StockUpdate{}, err
+		}
+		if err := adjustLevel(ctx, tx, id, locationID, delta, ref); err != nil {
+			return entity.StockUpdate{}, err
+		}
+		var active bool
+		if err := tx.QueryRowContext(ctx, `SELECT active FROM locations WHERE id = ?`, locationID).Scan(&active); err != nil {
+			return entity.StockUpdate{}, err
+		}
+		if !active {
+			applied = 0
+		}
+	}
+
+	// adjustLevel dejó bloqueada la fila del producto: nadie más cambió el disponible
+	// entre el ajuste y esta lectura, así que el anterior se deriva sin carrera
+	var after int64
+	if err := tx.QueryRowContext(ctx, `SELECT stock FROM products WHERE id = ? FOR UPDATE`, id).Scan(&after); err != nil {
+		return entity.StockUpdate{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return entity.StockUpdate{}, err
+	}
+	return entity.StockUpdate{Before: after - applied, After: after}, nil
+}
+
+// UpdateStatus persiste el estado de publicación y sus fechas
+func (r *ProductRepo) UpdateStatus(ctx context.Context, p *entity.Product) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE products SET status = ?, publish_at = ?, unpublish_at = ?, updated_at = NOW()
+		WHERE id = ?`,
+		p.Status, p.PublishAt, p.UnpublishAt, p.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update product status: %w", err)
+	}
 	aff, _ := res.RowsAffected()
 	if aff == 0 {
 		return domainerrors.ErrNotFound
 	}
-	return nil
+	if err := writeProductOutbox(ctx, tx, p.ID, entity.EventProductUpdated); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdatePricing persiste el precio de referencia y la oferta del producto
+func (r *ProductRepo) UpdatePricing(ctx context.Context, p *entity.Product) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE products SET compare_at_price = ?, sale_price = ?, sale_starts_at = ?, sale_ends_at = ?, updated_at = NOW()
+		WHERE id = ?`,
+		p.CompareAtPrice, p.SalePrice, p.SaleStartsAt, p.SaleEndsAt, p.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update product pricing: %w", err)
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	if err := writeProductOutbox(ctx, tx, p.ID, entity.EventProductUpdated); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// PublishDue publica los productos programados cuya fecha de publicación ya pasó
+func (r *ProductRepo) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	return r.transitionDue(ctx, entity.ProductStatusScheduled, entity.ProductStatusPublished, "publish_at", now)
+}
+
+// ArchiveExpired archiva los productos publicados cuya fecha de despublicación ya pasó
+func (r *ProductRepo) ArchiveExpired(ctx context.Context, now time.Time) (int64, error) {
+	return r.transitionDue(ctx, entity.ProductStatusPublished, entity.ProductStatusArchived, "unpublish_at", now)
+}
+
+// transitionDue pasa de from a to los productos cuya fecha en column ya pasó y guarda un
+// product.updated por cada uno
+func (r *ProductRepo) transitionDue(ctx context.Context, from, to entity.ProductStatus, column string, now time.Time) (int64, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id FROM products
+		WHERE status = ? AND `+column+` IS NOT NULL AND `+column+` <= ?
+		ORDER BY id
+		FOR UPDATE`, from, now)
+	if err != nil {
+		return 0, err
+	}
+	var ids []any
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	args := append([]any{to}, ids...)
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE products SET status = ?, updated_at = NOW()
+		WHERE id IN (`+placeholders(len(ids))+`)`, args...); err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		if err := writeProductOutbox(ctx, tx, id.(int64), entity.EventProductUpdated); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(ids)), tx.Commit()
 }
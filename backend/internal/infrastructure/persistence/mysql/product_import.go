@@ -0,0 +1,210 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+type ProductImportRepo struct {
+	DB *sql.DB
+}
+
+func NewProductImportRepository(db *sql.DB) *ProductImportRepo { return &ProductImportRepo{DB: db} }
+
+var _ repository.ProductImportRepository = (*ProductImportRepo)(nil)
+
+const productImportColumns = `id, filename, format, dry_run, mapping, status, total_rows, processed_rows,
+		created_count, updated_count, failed_count, error, created_by, started_at, finished_at, updated_at, created_at`
+
+func scanProductImport(s rowScanner) (entity.ProductImport, error) {
+	var imp entity.ProductImport
+	var mapping string
+	var startedAt, finishedAt sql.NullTime
+	if err := s.Scan(&imp.ID, &imp.Filename, &imp.Format, &imp.DryRun, &mapping, &imp.Status, &imp.TotalRows, &imp.ProcessedRows,
+		&imp.Created, &imp.Updated, &imp.Failed, &imp.Error, &imp.CreatedBy, &startedAt, &finishedAt, &imp.UpdatedAt, &imp.CreatedAt); err != nil {
+		return entity.ProductImport{}, err
+	}
+	if err := json.Unmarshal([]byte(mapping), &imp.Mapping); err != nil {
+		return entity.ProductImport{}, fmt.Errorf("invalid mapping of product import %d: %w", imp.ID, err)
+	}
+	imp.StartedAt = nullTimePtr(startedAt)
+	imp.FinishedAt = nullTimePtr(finishedAt)
+	return imp, nil
+}
+
+func (r *ProductImportRepo) Create(ctx context.Context, imp *entity.ProductImport, data []byte) error {
+	mapping, err := json.Marshal(imp.Mapping)
+	if err != nil {
+		return err
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO product_imports (filename, format, dry_run, mapping, status, total_rows, payload, created_by)
+		VALUES (?,?,?,?,?,?,?,?)`,
+		imp.Filename, imp.Format, imp.DryRun, string(mapping), imp.Status, imp.TotalRows, data, imp.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create product import: %w", err)
+	}
+	imp.ID, _ = res.LastInsertId()
+	return nil
+}
+
+func (r *ProductImportRepo) GetByID(ctx context.Context, id int64) (entity.ProductImport, error) {
+	imp, err := scanProductImport(r.DB.QueryRowContext(ctx, `
+		SELECT `+productImportColumns+` FROM product_imports WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ProductImport{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.ProductImport{}, err
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT row_num, column_name, bar_code, message
+		FROM product_import_errors
+		WHERE import_id = ?
+		ORDER BY row_num, id`, id)
+	if err != nil {
+		return entity.ProductImport{}, err
+	}
+	defer rows.Close()
+
+	imp.Errors = []entity.ImportRowError{}
+	for rows.Next() {
+		var e entity.ImportRowError
+		if err := rows.Scan(&e.Row, &e.Column, &e.BarCode, &e.Message); err != nil {
+			return entity.ProductImport{}, err
+		}
+		imp.Errors = append(imp.Errors, e)
+	}
+	return imp, rows.Err()
+}
+
+func (r *ProductImportRepo) List(ctx context.Context, f entity.ProductImportFilter) ([]entity.ProductImport, error) {
+	q := `SELECT ` + productImportColumns + ` FROM product_imports WHERE 1=1`
+	args := []any{}
+	if f.Status != "" {
+		q += " AND status = ?"
+		args = append(args, f.Status)
+	}
+	q += " ORDER BY id DESC"
+	limit := 20
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.ProductImport{}
+	for rows.Next() {
+		imp, err := scanProductImport(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, imp)
+	}
+	return out, rows.Err()
+}
+
+func (r *ProductImportRepo) Claim(ctx context.Context, staleBefore time.Time) (entity.ProductImport, []byte, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return entity.ProductImport{}, nil, err
+	}
+	defer tx.Rollback()
+
+	// SKIP LOCKED deja que otra instancia tome la siguiente importación sin esperar
+	var id int64
+	var data []byte
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, payload FROM product_imports
+		WHERE status = ? OR (status = ? AND updated_at < ?)
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`,
+		entity.ProductImportStatusQueued, entity.ProductImportStatusRunning, staleBefore).Scan(&id, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ProductImport{}, nil, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.ProductImport{}, nil, err
+	}
+
+	// Una importación retomada vuelve a empezar; las filas ya aplicadas se actualizan de nuevo
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_import_errors WHERE import_id = ?`, id); err != nil {
+		return entity.ProductImport{}, nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE product_imports
+		SET status = ?, processed_rows = 0, created_count = 0, updated_count = 0, failed_count = 0,
+			started_at = NOW(), updated_at = NOW()
+		WHERE id = ?`, entity.ProductImportStatusRunning, id); err != nil {
+		return entity.ProductImport{}, nil, fmt.Errorf("failed to claim product import: %w", err)
+	}
+	imp, err := scanProductImport(tx.QueryRowContext(ctx, `
+		SELECT `+productImportColumns+` FROM product_imports WHERE id = ?`, id))
+	if err != nil {
+		return entity.ProductImport{}, nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return entity.ProductImport{}, nil, err
+	}
+	return imp, data, nil
+}
+
+func (r *ProductImportRepo) SaveProgress(ctx context.Context, id int64, p entity.ProductImportProgress, rowErrors []entity.ImportRowError) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE product_imports
+		SET processed_rows = ?, created_count = ?, updated_count = ?, failed_count = ?, updated_at = NOW()
+		WHERE id = ?`, p.ProcessedRows, p.Created, p.Updated, p.Failed, id); err != nil {
+		return fmt.Errorf("failed to save product import progress: %w", err)
+	}
+	if len(rowErrors) > 0 {
+		values := make([]string, 0, len(rowErrors))
+		args := make([]any, 0, 5*len(rowErrors))
+		for _, e := range rowErrors {
+			values = append(values, "(?,?,?,?,?)")
+			args = append(args, id, e.Row, e.Column, e.BarCode, e.Message)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO product_import_errors (import_id, row_num, column_name, bar_code, message)
+			VALUES `+strings.Join(values, ","), args...); err != nil {
+			return fmt.Errorf("failed to save product import errors: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *ProductImportRepo) Finish(ctx context.Context, id int64, status entity.ProductImportStatus, reason string) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE product_imports
+		SET status = ?, error = ?, payload = NULL, finished_at = NOW(), updated_at = NOW()
+		WHERE id = ? AND status = ?`, status, reason, id, entity.ProductImportStatusRunning)
+	if err != nil {
+		return fmt.Errorf("failed to finish product import: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+)
+
+type PromotionRepo struct {
+	DB *sql.DB
+}
+
+func NewPromotionRepository(db *sql.DB) *PromotionRepo { return &PromotionRepo{DB: db} }
+
+var _ repository.PromotionRepository = (*PromotionRepo)(nil)
+
+const promotionColumns = `id, name, code, type, percent_off, amount_off, buy_quantity, get_quantity,
+		category, min_subtotal, currency, usage_limit, per_customer_limit, starts_at, ends_at,
+		priority, exclusive, active, updated_at, created_at`
+
+func scanPromotion(s rowScanner) (entity.Promotion, error) {
+	var p entity.Promotion
+	var code, amountOff, minSubtotal sql.NullString
+	var currency string
+	var usageLimit, perCustomerLimit sql.NullInt64
+	var startsAt, endsAt sql.NullTime
+	if err := s.Scan(&p.ID, &p.Name, &code, &p.Type, &p.PercentOff, &amountOff, &p.BuyQuantity, &p.GetQuantity,
+		&p.Category, &minSubtotal, &currency, &usageLimit, &perCustomerLimit, &startsAt, &endsAt,
+		&p.Priority, &p.Exclusive, &p.Active, &p.UpdatedAt, &p.CreatedAt); err != nil {
+		return entity.Promotion{}, err
+	}
+
+	var err error
+	p.Currency = money.Currency(currency)
+	if p.AmountOff, err = parseNullMoney(amountOff, p.Currency); err != nil {
+		return entity.Promotion{}, err
+	}
+	if p.MinSubtotal, err = parseNullMoney(minSubtotal, p.Currency); err != nil {
+		return entity.Promotion{}, err
+	}
+	if code.Valid {
+		p.Code = &code.String
+	}
+	p.UsageLimit = nullInt64Ptr(usageLimit)
+	p.PerCustomerLimit = nullInt64Ptr(perCustomerLimit)
+	p.StartsAt = nullTimePtr(startsAt)
+	p.EndsAt = nullTimePtr(endsAt)
+	return p, nil
+}
+
+func nullInt64Ptr(n sql.NullInt64) *int64 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Int64
+	return &v
+}
+
+func (r *PromotionRepo) Create(ctx context.Context, p *entity.Promotion) error {
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO promotions (name, code, type, percent_off, amount_off, buy_quantity, get_quantity,
+			category, min_subtotal, currency, usage_limit, per_customer_limit, starts_at, ends_at,
+			priority, exclusive, active)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		p.Name, p.Code, p.Type, p.PercentOff, p.AmountOff, p.BuyQuantity, p.GetQuantity,
+		p.Category, p.MinSubtotal, p.Currency, p.UsageLimit, p.PerCustomerLimit, p.StartsAt, p.EndsAt,
+		p.Priority, p.Exclusive, p.Active,
+	)
+	if err != nil {
+		return promotionWriteError(err)
+	}
+	id, _ := res.LastInsertId()
+	p.ID = id
+	return nil
+}
+
+func (r *PromotionRepo) GetByID(ctx context.Context, id int64) (entity.Promotion, error) {
+	p, err := scanPromotion(r.DB.QueryRowContext(ctx, `SELECT `+promotionColumns+` FROM promotions WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Promotion{}, domainerrors.ErrNotFound
+	}
+	return p, err
+}
+
+func (r *PromotionRepo) Update(ctx context.Context, p *entity.Promotion) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE promotions SET name = ?, code = ?, type = ?, percent_off = ?, amount_off = ?, buy_quantity = ?,
+			get_quantity = ?, category = ?, min_subtotal = ?, currency = ?, usage_limit = ?, per_customer_limit = ?,
+			starts_at = ?, ends_at = ?, priority = ?, exclusive = ?, active = ?, updated_at = NOW()
+		WHERE id = ?`,
+		p.Name, p.Code, p.Type, p.PercentOff, p.AmountOff, p.BuyQuantity,
+		p.GetQuantity, p.Category, p.MinSubtotal, p.Currency, p.UsageLimit, p.PerCustomerLimit,
+		p.StartsAt, p.EndsAt, p.Priority, p.Exclusive, p.Active,
+		p.ID,
+	)
+	if err != nil {
+		return promotionWriteError(err)
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+// promotionWriteError traduce el código duplicado a un conflicto
+func promotionWriteError(err error) error {
+	var me *mysqlerr.MySQLError
+	if errors.As(err, &me) && me.Number == 1062 {
+		return domainerrors.ErrConflict
+	}
+	return err
+}
+
+func (r *PromotionRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM promotions WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PromotionRepo) List(ctx context.Context, f entity.PromotionFilter) ([]entity.Promotion, error) {
+	q := `SELECT ` + promotionColumns + ` FROM promotions WHERE 1=1`
+	args := []any{}
+	if f.Active != nil {
+		q += " AND active = ?"
+		args = append(args, *f.Active)
+	}
+	if f.Code != "" {
+		q += " AND code = ?"
+		args = append(args, f.Code)
+	}
+	q += " ORDER BY priority DESC, id ASC"
+	limit := 20
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+	return r.query(ctx, q, args...)
+}
+
+func (r *PromotionRepo) FindForCart(ctx context.Context, codes []string, now time.Time) ([]entity.Promotion, error) {
+	q := `SELECT ` + promotionColumns + ` FROM promotions
+		WHERE (code IS NULL AND active = TRUE
+			AND (starts_at IS NULL OR starts_at <= ?) AND (ends_at IS NULL OR ends_at > ?))`
+	args := []any{now, now}
+	if len(codes) > 0 {
+		q += ` OR code IN (` + placeholders(len(codes)) + `)`
+		for _, c := range codes {
+			args = append(args, c)
+		}
+	}
+	q += " ORDER BY priority DESC, id ASC"
+	return r.query(ctx, q, args...)
+}
+
+func (r *PromotionRepo) query(ctx context.Context, q string, args ...any) ([]entity.Promotion, error) {
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.Promotion
+	for rows.Next() {
+		p, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (r *PromotionRepo) Usage(ctx context.Context, promotionIDs []int64, userID int64) (map[int64]entity.PromotionUsage, error) {
+	out := map[int64]entity.PromotionUsage{}
+	if len(promotionIDs) == 0 {
+		return out, nil
+	}
+	return out, queryPromotionUsage(ctx, r.DB, out, promotionIDs, userID)
+}
+
+// queryPromotionUsage cuenta los usos; se comparte con la creación de órdenes, que lo corre dentro de su transacción
+func queryPromotionUsage(ctx context.Context, q interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}, out map[int64]entity.PromotionUsage, promotionIDs []int64, userID int64) error {
+	args := make([]any, 0, len(promotionIDs)+1)
+	args = append(args, userID)
+	for _, id := range promotionIDs {
+		args = append(args, id)
+	}
+	rows, err := q.QueryContext(ctx, `
+		SELECT od.promotion_id, COUNT(DISTINCT od.order_id), COUNT(DISTINCT CASE WHEN o.user_id = ? THEN od.order_id END)
+		FROM order_discounts od
+		JOIN orders o ON o.id = od.order_id
+		WHERE o.status <> 'cancelled' AND od.promotion_id IN (`+placeholders(len(promotionIDs))+`)
+		GROUP BY od.promotion_id`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var u entity.PromotionUsage
+		if err := rows.Scan(&id, &u.Total, &u.Customer); err != nil {
+			return err
+		}
+		out[id] = u
+	}
+	return rows.Err()
+}
@@ -0,0 +1,543 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+)
+
+type SupplierRepo struct {
+	DB *sql.DB
+}
+
+func NewSupplierRepository(db *sql.DB) *SupplierRepo { return &SupplierRepo{DB: db} }
+
+var _ repository.SupplierRepository = (*SupplierRepo)(nil)
+
+const supplierColumns = `id, name, tax_id, email, phone, address, notes, active, updated_at, created_at`
+
+func scanSupplier(s rowScanner) (entity.Supplier, error) {
+	var sp entity.Supplier
+	err := s.Scan(&sp.ID, &sp.Name, &sp.TaxID, &sp.Email, &sp.Phone, &sp.Address, &sp.Notes, &sp.Active, &sp.UpdatedAt, &sp.CreatedAt)
+	return sp, err
+}
+
+func (r *SupplierRepo) Create(ctx context.Context, s *entity.Supplier) error {
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO suppliers (name, tax_id, email, phone, address, notes, active) VALUES (?,?,?,?,?,?,?)`,
+		s.Name, s.TaxID, s.Email, s.Phone, s.Address, s.Notes, s.Active)
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1062 {
+			return domainerrors.ErrConflict
+		}
+		return fmt.Errorf("failed to create supplier: %w", err)
+	}
+	s.ID, _ = res.LastInsertId()
+	return nil
+}
+
+func (r *SupplierRepo) Update(ctx context.Context, s *entity.Supplier) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE suppliers SET name = ?, tax_id = ?, email = ?, phone = ?, address = ?, notes = ?, active = ?, updated_at = NOW()
+		WHERE id = ?`,
+		s.Name, s.TaxID, s.Email, s.Phone, s.Address, s.Notes, s.Active, s.ID)
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1062 {
+			return domainerrors.ErrConflict
+		}
+		return err
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *SupplierRepo) GetByID(ctx context.Context, id int64) (entity.Supplier, error) {
+	s, err := scanSupplier(r.DB.QueryRowContext(ctx, `SELECT `+supplierColumns+` FROM suppliers WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Supplier{}, domainerrors.ErrNotFound
+	}
+	return s, err
+}
+
+func (r *SupplierRepo) List(ctx context.Context) ([]entity.Supplier, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+supplierColumns+` FROM suppliers ORDER BY name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.Supplier{}
+	for rows.Next() {
+		s, err := scanSupplier(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+type PurchaseOrderRepo struct {
+	DB *sql.DB
+}
+
+func NewPurchaseOrderRepository(db *sql.DB) *PurchaseOrderRepo { return &PurchaseOrderRepo{DB: db} }
+
+var _ repository.PurchaseOrderRepository = (*PurchaseOrderRepo)(nil)
+
+const purchaseOrderColumns = `po.id, po.supplier_id, s.name, po.location_id, l.code, po.status, po.currency, po.total,
+		po.note, po.expected_at, po.ordered_at, po.created_by, po.updated_at, po.created_at`
+
+const purchaseOrderFrom = `
+		FROM purchase_orders po
+		JOIN suppliers s ON s.id = po.supplier_id
+		JOIN locations l ON l.id = po.location_id`
+
+func scanPurchaseOrder(s rowScanner) (entity.PurchaseOrder, error) {
+	var po entity.PurchaseOrder
+	var currency, total string
+	var expectedAt, orderedAt sql.NullTime
+	if err := s.Scan(&po.ID, &po.SupplierID, &po.SupplierName, &po.LocationID, &po.LocationCode, &po.Status, &currency, &total,
+		&po.Note, &expectedAt, &orderedAt, &po.CreatedBy, &po.UpdatedAt, &po.CreatedAt); err != nil {
+		return entity.PurchaseOrder{}, err
+	}
+	var err error
+	po.Currency = money.Currency(currency)
+	if po.Total, err = parseMoney(total, po.Currency); err != nil {
+		return entity.PurchaseOrder{}, err
+	}
+	po.ExpectedAt = nullTimePtr(expectedAt)
+	po.OrderedAt = nullTimePtr(orderedAt)
+	return po, nil
+}
+
+func (r *PurchaseOrderRepo) Create(ctx context.Context, po *entity.PurchaseOrder) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if po.LocationID == 0 {
+		if po.LocationID, err = defaultLocationID(ctx, tx); err != nil {
+			return err
+		}
+	}
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO purchase_orders (supplier_id, location_id, status, currency, total, note, expected_at, created_by)
+		VALUES (?,?,?,?,?,?,?,?)`,
+		po.SupplierID, po.LocationID, po.Status, po.Currency, po.Total, po.Note, po.ExpectedAt, po.CreatedBy)
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1452 {
+			return domainerrors.ErrNotFound
+		}
+		return fmt.Errorf("failed to create purchase order: %w", err)
+	}
+	id, _ := res.LastInsertId()
+
+	for i := range po.Lines {
+		l := &po.Lines[i]
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO purchase_order_lines (purchase_order_id, product_id, quantity, unit_cost) VALUES (?,?,?,?)`,
+			id, l.ProductID, l.Quantity, l.UnitCost)
+		if err != nil {
+			var me *mysqlerr.MySQLError
+			if errors.As(err, &me) && me.Number == 1452 {
+				return domainerrors.ErrNotFound
+			}
+			return fmt.Errorf("failed to create purchase order line: %w", err)
+		}
+		l.ID, _ = res.LastInsertId()
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	po.ID = id
+	return nil
+}
+
+func (r *PurchaseOrderRepo) GetByID(ctx context.Context, id int64) (entity.PurchaseOrder, error) {
+	po, err := scanPurchaseOrder(r.DB.QueryRowContext(ctx, `SELECT `+purchaseOrderColumns+purchaseOrderFrom+` WHERE po.id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.PurchaseOrder{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.PurchaseOrder{}, err
+	}
+	if err := r.loadLines(ctx, &po); err != nil {
+		return entity.PurchaseOrder{}, err
+	}
+	if err := r.loadReceipts(ctx, &po); err != nil {
+		return entity.PurchaseOrder{}, err
+	}
+	return po, nil
+}
+
+func (r *PurchaseOrderRepo) loadLines(ctx context.Context, po *entity.PurchaseOrder) error {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT pol.id, pol.product_id, p.title, p.size, pol.quantity, pol.received, pol.unit_cost
+		FROM purchase_order_lines pol
+		JOIN products p ON p.id = pol.product_id
+		WHERE pol.purchase_order_id = ?
+		ORDER BY pol.id`, po.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	po.Lines = []entity.PurchaseOrderLine{}
+	for rows.Next() {
+		var l entity.PurchaseOrderLine
+		var unitCost string
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.Title, &l.Size, &l.Quantity, &l.Received, &unitCost); err != nil {
+			return err
+		}
+		if l.UnitCost, err = parseMoney(unitCost, po.Currency); err != nil {
+			return err
+		}
+		po.Lines = append(po.Lines, l)
+	}
+	return rows.Err()
+}
+
+func (r *PurchaseOrderRepo) loadReceipts(ctx context.Context, po *entity.PurchaseOrder) error {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT pr.id, pr.actor_id, pr.note, pr.created_at, prl.line_id, prl.product_id, prl.quantity, prl.unit_cost
+		FROM purchase_receipts pr
+		JOIN purchase_receipt_lines prl ON prl.receipt_id = pr.id
+		WHERE pr.purchase_order_id = ?
+		ORDER BY pr.id, prl.line_id`, po.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	po.Receipts = []entity.PurchaseReceipt{}
+	for rows.Next() {
+		var rc entity.PurchaseReceipt
+		var l entity.PurchaseReceiptLine
+		var unitCost string
+		if err := rows.Scan(&rc.ID, &rc.ActorID, &rc.Note, &rc.CreatedAt, &l.LineID, &l.ProductID, &l.Quantity, &unitCost); err != nil {
+			return err
+		}
+		cost, err := parseMoney(unitCost, po.Currency)
+		if err != nil {
+			return err
+		}
+		l.UnitCost = &cost
+		if n := len(po.Receipts); n == 0 || po.Receipts[n-1].ID != rc.ID {
+			rc.PurchaseOrderID = po.ID
+			po.Receipts = append(po.Receipts, rc)
+		}
+		last := &po.Receipts[len(po.Receipts)-1]
+		last.Lines = append(last.Lines, l)
+	}
+	return rows.Err()
+}
+
+// List no carga las líneas ni las recepciones
+func (r *PurchaseOrderRepo) List(ctx context.Context, f entity.PurchaseOrderFilter) ([]entity.PurchaseOrder, error) {
+	q := `SELECT ` + purchaseOrderColumns + purchaseOrderFrom + ` WHERE 1=1`
+	args := []any{}
+	if f.SupplierID > 0 {
+		q += " AND po.supplier_id = ?"
+		args = append(args, f.SupplierID)
+	}
+	if f.Status != "" {
+		q += " AND po.status = ?"
+		args = append(args, f.Status)
+	}
+	q += " ORDER BY po.id DESC"
+	limit := 20
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.PurchaseOrder{}
+	for rows.Next() {
+		po, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, po)
+	}
+	return out, rows.Err()
+}
+
+func (r *PurchaseOrderRepo) UpdateStatus(ctx context.Context, id int64, from, to entity.PurchaseOrderStatus) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE purchase_orders SET status = ?, ordered_at = IF(? = 'ordered', NOW(), ordered_at), updated_at = NOW()
+		WHERE id = ? AND status = ?`, to, to, id, from)
+	if err != nil {
+		return err
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		var exists bool
+		if err := r.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM purchase_orders WHERE id = ?)`, id).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return domainerrors.ErrNotFound
+		}
+		return domainerrors.ErrInvalidTransition
+	}
+	return nil
+}
+
+func (r *PurchaseOrderRepo) Receive(ctx context.Context, rc *entity.PurchaseReceipt) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status entity.PurchaseOrderStatus
+	var locationID int64
+	var currency string
+	err = tx.QueryRowContext(ctx, `
+		SELECT status, location_id, currency FROM purchase_orders WHERE id = ? FOR UPDATE`, rc.PurchaseOrderID).
+		Scan(&status, &locationID, &currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !status.CanReceive() {
+		return domainerrors.ErrInvalidTransition
+	}
+	cur := money.Currency(currency)
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, product_id, quantity, received, unit_cost FROM purchase_order_lines
+		WHERE purchase_order_id = ? FOR UPDATE`, rc.PurchaseOrderID)
+	if err != nil {
+		return err
+	}
+	lines := map[int64]*entity.PurchaseOrderLine{}
+	for rows.Next() {
+		var l entity.PurchaseOrderLine
+		var unitCost string
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.Quantity, &l.Received, &unitCost); err != nil {
+			rows.Close()
+			return err
+		}
+		if l.UnitCost, err = parseMoney(unitCost, cur); err != nil {
+			rows.Close()
+			return err
+		}
+		lines[l.ID] = &l
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO purchase_receipts (purchase_order_id, actor_id, note) VALUES (?,?,?)`,
+		rc.PurchaseOrderID, rc.ActorID, rc.Note)
+	if err != nil {
+		return fmt.Errorf("failed to create purchase receipt: %w", err)
+	}
+	receiptID, _ := res.LastInsertId()
+
+	ref := entity.StockMovementRef{
+		Kind:      entity.StockMovementPurchase,
+		Reason:    "Recepción de compra",
+		Reference: fmt.Sprintf("purchase:%d", rc.PurchaseOrderID),
+		ActorID:   rc.ActorID,
+	}
+	for i := range rc.Lines {
+		rl := &rc.Lines[i]
+		l, ok := lines[rl.LineID]
+		if !ok {
+			return domainerrors.ErrInvalidInput
+		}
+		if rl.Quantity > l.Remaining() {
+			return domainerrors.ErrConflict
+		}
+		if rl.UnitCost == nil {
+			cost := l.UnitCost
+			rl.UnitCost = &cost
+		}
+		rl.ProductID = l.ProductID
+
+		if err := updateAverageCost(ctx, tx, l.ProductID, *rl.UnitCost, rl.Quantity); err != nil {
+			return err
+		}
+		if err := adjustLevel(ctx, tx, l.ProductID, locationID, rl.Quantity, ref); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE purchase_order_lines SET received = received + ? WHERE id = ?`, rl.Quantity, l.ID); err != nil {
+			return err
+		}
+		l.Received += rl.Quantity
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO purchase_receipt_lines (receipt_id, line_id, product_id, quantity, unit_cost) VALUES (?,?,?,?,?)`,
+			receiptID, l.ID, l.ProductID, rl.Quantity, *rl.UnitCost); err != nil {
+			return fmt.Errorf("failed to create purchase receipt line: %w", err)
+		}
+	}
+
+	next := entity.PurchaseOrderStatusReceived
+	for _, l := range lines {
+		if l.Remaining() > 0 {
+			next = entity.PurchaseOrderStatusPartiallyReceived
+			break
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE purchase_orders SET status = ?, updated_at = NOW() WHERE id = ?`, next, rc.PurchaseOrderID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	rc.ID = receiptID
+	return nil
+}
+
+// updateAverageCost pondera el costo promedio del producto con qty unidades a cost. El
+// stock previo es el de todas las ubicaciones, activas o no, porque todas tienen costo.
+// Se lee antes de sumar lo recibido. Un costo en otra moneda que la del promedio falla
+// con money.ErrCurrencyMismatch mientras quede stock valuado en esa moneda.
+func updateAverageCost(ctx context.Context, tx *sql.Tx, productID int64, cost money.Money, qty int64) error {
+	var onHand int64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(quantity), 0) FROM inventory_levels WHERE product_id = ? FOR UPDATE`,
+		productID).Scan(&onHand); err != nil {
+		return err
+	}
+	var avg money.Money
+	var currency, averageCost string
+	err := tx.QueryRowContext(ctx, `
+		SELECT currency, average_cost FROM product_costs WHERE product_id = ? FOR UPDATE`, productID).
+		Scan(&currency, &averageCost)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return err
+	case money.Currency(currency) != cost.Currency():
+		if onHand > 0 {
+			return money.ErrCurrencyMismatch
+		}
+	default:
+		if avg, err = parseMoney(averageCost, cost.Currency()); err != nil {
+			return err
+		}
+	}
+
+	next, err := entity.WeightedAverageCost(avg, onHand, cost, qty)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO product_costs (product_id, currency, average_cost) VALUES (?,?,?)
+		ON DUPLICATE KEY UPDATE currency = VALUES(currency), average_cost = VALUES(average_cost), updated_at = NOW()`,
+		productID, next.Currency(), next)
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1452 {
+			return domainerrors.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *PurchaseOrderRepo) Cost(ctx context.Context, productID int64) (entity.ProductCost, error) {
+	var c entity.ProductCost
+	var currency, averageCost string
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT product_id, currency, average_cost, updated_at FROM product_costs WHERE product_id = ?`, productID).
+		Scan(&c.ProductID, &currency, &averageCost, &c.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ProductCost{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.ProductCost{}, err
+	}
+	if c.AverageCost, err = parseMoney(averageCost, money.Currency(currency)); err != nil {
+		return entity.ProductCost{}, err
+	}
+	return c, nil
+}
+
+func (r *PurchaseOrderRepo) Margins(ctx context.Context, f entity.ProductFilter) ([]entity.ProductMargin, error) {
+	q := `
+		SELECT p.id, p.bar_code, p.title, p.size, p.category, p.unit_price, p.currency, pc.average_cost, pc.currency
+		FROM products p
+		LEFT JOIN product_costs pc ON pc.product_id = p.id
+		WHERE 1=1`
+	args := []any{}
+	if f.Status != "" {
+		q += " AND p.status = ?"
+		args = append(args, f.Status)
+	}
+	if f.Category != "" {
+		q += " AND p.category = ?"
+		args = append(args, f.Category)
+	}
+	if f.Size != "" {
+		q += " AND p.size = ?"
+		args = append(args, f.Size)
+	}
+	if f.Query != "" {
+		q += " AND p.title LIKE ?"
+		args = append(args, "%"+f.Query+"%")
+	}
+	q += " ORDER BY p.category, p.title, p.size, p.id"
+	limit := 50
+	if f.Limit > 0 && f.Limit <= 200 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.ProductMargin{}
+	for rows.Next() {
+		var m entity.ProductMargin
+		var unitPrice, currency string
+		var averageCost, costCurrency sql.NullString
+		if err := rows.Scan(&m.ProductID, &m.BarCode, &m.Title, &m.Size, &m.Category, &unitPrice, &currency,
+			&averageCost, &costCurrency); err != nil {
+			return nil, err
+		}
+		if m.UnitPrice, err = parseMoney(unitPrice, money.Currency(currency)); err != nil {
+			return nil, err
+		}
+		if m.AverageCost, err = parseNullMoney(averageCost, money.Currency(costCurrency.String)); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
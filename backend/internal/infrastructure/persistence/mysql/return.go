@@ -0,0 +1,311 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+)
+
+type ReturnRepo struct {
+	DB *sql.DB
+}
+
+func NewReturnRepository(db *sql.DB) *ReturnRepo { return &ReturnRepo{DB: db} }
+
+var _ repository.ReturnRepository = (*ReturnRepo)(nil)
+
+const returnColumns = `id, order_id, user_id, status, comment, currency, refund_total,
+		refund_reference, refunded_at, updated_at, created_at`
+
+func scanReturn(s rowScanner) (entity.ReturnRequest, error) {
+	var r entity.ReturnRequest
+	var currency, refundTotal string
+	var refundedAt sql.NullTime
+	if err := s.Scan(&r.ID, &r.OrderID, &r.UserID, &r.Status, &r.Comment, &currency, &refundTotal,
+		&r.RefundReference, &refundedAt, &r.UpdatedAt, &r.CreatedAt); err != nil {
+		return entity.ReturnRequest{}, err
+	}
+	var err error
+	r.Currency = money.Currency(currency)
+	r.RefundedAt = nullTimePtr(refundedAt)
+	if r.RefundTotal, err = parseMoney(refundTotal, r.Currency); err != nil {
+		return entity.ReturnRequest{}, err
+	}
+	return r, nil
+}
+
+func (r *ReturnRepo) Create(ctx context.Context, rr *entity.ReturnRequest) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Se bloquea la orden para que dos solicitudes simultáneas no devuelvan las mismas unidades
+	var locked int64
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM orders WHERE id = ? FOR UPDATE`, rr.OrderID).Scan(&locked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domainerrors.ErrNotFound
+		}
+		return err
+	}
+	for _, it := range rr.Items {
+		var available int64
+		err := tx.QueryRowContext(ctx, `
+			SELECT oi.quantity - COALESCE((
+				SELECT SUM(ri.quantity) FROM return_items ri
+				JOIN return_requests rr ON rr.id = ri.return_id
+				WHERE ri.order_item_id = oi.id AND rr.status NOT IN ('rejected', 'cancelled')
+			), 0)
+			FROM order_items oi WHERE oi.id = ? AND oi.order_id = ?`, it.OrderItemID, rr.OrderID).Scan(&available)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domainerrors.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if it.Quantity > available {
+			return domainerrors.ErrConflict
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO return_requests (order_id, user_id, status, comment, currency, refund_total)
+		VALUES (?,?,?,?,?,?)`,
+		rr.OrderID, rr.UserID, rr.Status, rr.Comment, rr.Currency, rr.RefundTotal,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create return request: %w", err)
+	}
+	id, _ := res.LastInsertId()
+
+	for i := range rr.Items {
+		it := &rr.Items[i]
+		var exchangeID sql.NullInt64
+		if it.ExchangeProductID > 0 {
+			exchangeID = sql.NullInt64{Int64: it.ExchangeProductID, Valid: true}
+		}
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO return_items (return_id, order_item_id, product_id, title, size, quantity,
+				reason, resolution, exchange_product_id, exchange_size, refund_amount)
+			VALUES (?,?,?,?,?,?,?,?,?,?,?)`,
+			id, it.OrderItemID, it.ProductID, it.Title, it.Size, it.Quantity,
+			it.Reason, it.Resolution, exchangeID, it.ExchangeSize, it.RefundAmount,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create return item: %w", err)
+		}
+		it.ID, _ = res.LastInsertId()
+		it.ReturnID = id
+	}
+
+	for i := range rr.History {
+		rr.History[i].ReturnID = id
+		if err := insertReturnChange(ctx, tx, &rr.History[i]); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	rr.ID = id
+	return nil
+}
+
+func insertReturnChange(ctx context.Context, tx *sql.Tx, ch *entity.ReturnStatusChange) error {
+	var from sql.NullString
+	if ch.From != "" {
+		from = sql.NullString{String: string(ch.From), Valid: true}
+	}
+	var actor sql.NullInt64
+	if ch.ActorID > 0 {
+		actor = sql.NullInt64{Int64: ch.ActorID, Valid: true}
+	}
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO return_status_history (return_id, from_status, to_status, actor_id, note)
+		VALUES (?,?,?,?,?)`, ch.ReturnID, from, ch.To, actor, ch.Note)
+	if err != nil {
+		return fmt.Errorf("failed to record return status change: %w", err)
+	}
+	ch.ID, _ = res.LastInsertId()
+	return nil
+}
+
+func (r *ReturnRepo) GetByID(ctx context.Context, id int64) (entity.ReturnRequest, error) {
+	rr, err := scanReturn(r.DB.QueryRowContext(ctx, `SELECT `+returnColumns+` FROM return_requests WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ReturnRequest{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.ReturnRequest{}, err
+	}
+	if err := r.loadDetails(ctx, []*entity.ReturnRequest{&rr}); err != nil {
+		return entity.ReturnRequest{}, err
+	}
+	return rr, nil
+}
+
+func (r *ReturnRepo) List(ctx context.Context, f entity.ReturnFilter) ([]entity.ReturnRequest, error) {
+	q := `SELECT ` + returnColumns + ` FROM return_requests WHERE 1=1`
+	args := []any{}
+	if f.UserID > 0 {
+		q += " AND user_id = ?"
+		args = append(args, f.UserID)
+	}
+	if f.OrderID > 0 {
+		q += " AND order_id = ?"
+		args = append(args, f.OrderID)
+	}
+	if f.Status != "" {
+		q += " AND status = ?"
+		args = append(args, f.Status)
+	}
+	q += " ORDER BY id DESC"
+	limit := 20
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.ReturnRequest
+	for rows.Next() {
+		rr, err := scanReturn(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	requests := make([]*entity.ReturnRequest, len(out))
+	for i := range out {
+		requests[i] = &out[i]
+	}
+	return out, r.loadDetails(ctx, requests)
+}
+
+// loadDetails carga las líneas y el historial de las solicitudes
+func (r *ReturnRepo) loadDetails(ctx context.Context, requests []*entity.ReturnRequest) error {
+	if len(requests) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*entity.ReturnRequest, len(requests))
+	args := make([]any, 0, len(requests))
+	for _, rr := range requests {
+		rr.Items = []entity.ReturnItem{}
+		rr.History = []entity.ReturnStatusChange{}
+		byID[rr.ID] = rr
+		args = append(args, rr.ID)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, return_id, order_item_id, product_id, title, size, quantity,
+			reason, resolution, exchange_product_id, exchange_size, refund_amount
+		FROM return_items
+		WHERE return_id IN (`+placeholders(len(args))+`)
+		ORDER BY id`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var it entity.ReturnItem
+		var exchangeID sql.NullInt64
+		var amount string
+		if err := rows.Scan(&it.ID, &it.ReturnID, &it.OrderItemID, &it.ProductID, &it.Title, &it.Size, &it.Quantity,
+			&it.Reason, &it.Resolution, &exchangeID, &it.ExchangeSize, &amount); err != nil {
+			return err
+		}
+		rr := byID[it.ReturnID]
+		it.ExchangeProductID = exchangeID.Int64
+		if it.RefundAmount, err = parseMoney(amount, rr.Currency); err != nil {
+			return err
+		}
+		rr.Items = append(rr.Items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	hrows, err := r.DB.QueryContext(ctx, `
+		SELECT id, return_id, from_status, to_status, actor_id, note, created_at
+		FROM return_status_history
+		WHERE return_id IN (`+placeholders(len(args))+`)
+		ORDER BY id`, args...)
+	if err != nil {
+		return err
+	}
+	defer hrows.Close()
+
+	for hrows.Next() {
+		var ch entity.ReturnStatusChange
+		var from sql.NullString
+		var actor sql.NullInt64
+		if err := hrows.Scan(&ch.ID, &ch.ReturnID, &from, &ch.To, &actor, &ch.Note, &ch.CreatedAt); err != nil {
+			return err
+		}
+		ch.From = entity.ReturnStatus(from.String)
+		ch.ActorID = actor.Int64
+		rr := byID[ch.ReturnID]
+		rr.History = append(rr.History, ch)
+	}
+	return hrows.Err()
+}
+
+func (r *ReturnRepo) Transition(ctx context.Context, ch *entity.ReturnStatusChange) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE return_requests SET status = ?, updated_at = NOW()
+		WHERE id = ? AND status = ?`, ch.To, ch.ReturnID, ch.From)
+	if err != nil {
+		return err
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM return_requests WHERE id = ?)`, ch.ReturnID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return domainerrors.ErrNotFound
+		}
+		return domainerrors.ErrInvalidTransition
+	}
+	if err := insertReturnChange(ctx, tx, ch); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *ReturnRepo) SetRefund(ctx context.Context, id int64, refund entity.Refund) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE return_requests SET refund_reference = ?, refunded_at = ?, updated_at = NOW()
+		WHERE id = ?`, refund.Reference, refund.CreatedAt, id)
+	if err != nil {
+		return err
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/repository"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+)
+
+type ShipmentRepo struct {
+	DB *sql.DB
+}
+
+func NewShipmentRepository(db *sql.DB) *ShipmentRepo { return &ShipmentRepo{DB: db} }
+
+var _ repository.ShipmentRepository = (*ShipmentRepo)(nil)
+
+const shipmentColumns = `id, order_id, carrier, tracking_number, status, address, weight_grams,
+		delivered_at, last_checked_at, updated_at, created_at`
+
+func scanShipment(s rowScanner) (entity.Shipment, error) {
+	var sh entity.Shipment
+	var address string
+	var deliveredAt, checkedAt sql.NullTime
+	if err := s.Scan(&sh.ID, &sh.OrderID, &sh.Carrier, &sh.TrackingNumber, &sh.Status, &address, &sh.WeightGrams,
+		&deliveredAt, &checkedAt, &sh.UpdatedAt, &sh.CreatedAt); err != nil {
+		return entity.Shipment{}, err
+	}
+	if err := json.Unmarshal([]byte(address), &sh.Address); err != nil {
+		return entity.Shipment{}, fmt.Errorf("invalid address of shipment %d: %w", sh.ID, err)
+	}
+	sh.DeliveredAt = nullTimePtr(deliveredAt)
+	sh.LastCheckedAt = nullTimePtr(checkedAt)
+	return sh, nil
+}
+
+func (r *ShipmentRepo) Create(ctx context.Context, sh *entity.Shipment) error {
+	address, err := json.Marshal(sh.Address)
+	if err != nil {
+		return err
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO shipments (order_id, carrier, tracking_number, status, address, weight_grams, label)
+		VALUES (?,?,?,?,?,?,?)`,
+		sh.OrderID, sh.Carrier, sh.TrackingNumber, sh.Status, string(address), sh.WeightGrams, sh.Label,
+	)
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1062 {
+			return domainerrors.ErrConflict
+		}
+		return fmt.Errorf("failed to create shipment: %w", err)
+	}
+	sh.ID, _ = res.LastInsertId()
+	return nil
+}
+
+func (r *ShipmentRepo) GetByID(ctx context.Context, id int64) (entity.Shipment, error) {
+	return r.getOne(ctx, `WHERE id = ?`, id)
+}
+
+func (r *ShipmentRepo) GetByOrderID(ctx context.Context, orderID int64) (entity.Shipment, error) {
+	return r.getOne(ctx, `WHERE order_id = ?`, orderID)
+}
+
+func (r *ShipmentRepo) GetByTrackingNumber(ctx context.Context, carrier, trackingNumber string) (entity.Shipment, error) {
+	return r.getOne(ctx, `WHERE carrier = ? AND tracking_number = ?`, carrier, trackingNumber)
+}
+
+func (r *ShipmentRepo) getOne(ctx context.Context, where string, args ...any) (entity.Shipment, error) {
+	sh, err := scanShipment(r.DB.QueryRowContext(ctx, `SELECT `+shipmentColumns+` FROM shipments `+where, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Shipment{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.Shipment{}, err
+	}
+	if err := r.loadEvents(ctx, []*entity.Shipment{&sh}); err != nil {
+		return entity.Shipment{}, err
+	}
+	return sh, nil
+}
+
+func (r *ShipmentRepo) List(ctx context.Context, f entity.ShipmentFilter) ([]entity.Shipment, error) {
+	q := `SELECT ` + shipmentColumns + ` FROM shipments WHERE 1=1`
+	args := []any{}
+	if f.Status != "" {
+		q += " AND status = ?"
+		args = append(args, f.Status)
+	}
+	if f.Carrier != "" {
+		q += " AND carrier = ?"
+		args = append(args, f.Carrier)
+	}
+	q += " ORDER BY id DESC"
+	limit := 20
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	out, err := r.query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	shipments := make([]*entity.Shipment, len(out))
+	for i := range out {
+		shipments[i] = &out[i]
+	}
+	return out, r.loadEvents(ctx, shipments)
+}
+
+func (r *ShipmentRepo) query(ctx context.Context, q string, args ...any) ([]entity.Shipment, error) {
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.Shipment
+	for rows.Next() {
+		sh, err := scanShipment(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sh)
+	}
+	return out, rows.Err()
+}
+
+func (r *ShipmentRepo) Label(ctx context.Context, id int64) ([]byte, error) {
+	var label []byte
+	err := r.DB.QueryRowContext(ctx, `SELECT label FROM shipments WHERE id = ?`, id).Scan(&label)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainerrors.ErrNotFound
+	}
+	return label, err
+}
+
+func (r *ShipmentRepo) loadEvents(ctx context.Context, shipments []*entity.Shipment) error {
+	if len(shipments) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*entity.Shipment, len(shipments))
+	args := make([]any, 0, len(shipments))
+	for _, sh := range shipments {
+		sh.Events = []entity.TrackingEvent{}
+		byID[sh.ID] = sh
+		args = append(args, sh.ID)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, shipment_id, status, description, location, occurred_at
+		FROM shipment_events
+		WHERE shipment_id IN (`+placeholders(len(args))+`)
+		ORDER BY occurred_at, id`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e entity.TrackingEvent
+		if err := rows.Scan(&e.ID, &e.ShipmentID, &e.Status, &e.Description, &e.Location, &e.OccurredAt); err != nil {
+			return err
+		}
+		sh := byID[e.ShipmentID]
+		sh.Events = append(sh.Events, e)
+	}
+	return rows.Err()
+}
+
+func (r *ShipmentRepo) AddEvents(ctx context.Context, shipmentID int64, events []entity.TrackingEvent) (int, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// El webhook y la consulta periódica pueden traer el mismo evento: la clave única lo descarta
+	added := 0
+	for _, e := range events {
+		res, err := tx.ExecContext(ctx, `
+			INSERT IGNORE INTO shipment_events (shipment_id, status, description, location, occurred_at)
+			VALUES (?,?,?,?,?)`,
+			shipmentID, e.Status, e.Description, e.Location, e.OccurredAt.UTC().Truncate(time.Second))
+		if err != nil {
+			return 0, err
+		}
+		if aff, _ := res.RowsAffected(); aff > 0 {
+			added++
+		}
+	}
+	if added == 0 {
+		return 0, nil
+	}
+
+	// El estado sale de los eventos guardados, así no importa en qué orden lleguen
+	res, err := tx.ExecContext(ctx, `
+		UPDATE shipments SET
+			status = (SELECT status FROM shipment_events WHERE shipment_id = ? ORDER BY occurred_at DESC, id DESC LIMIT 1),
+			delivered_at = (SELECT MIN(occurred_at) FROM shipment_events WHERE shipment_id = ? AND status = 'delivered'),
+			updated_at = NOW()
+		WHERE id = ?`, shipmentID, shipmentID, shipmentID)
+	if err != nil {
+		return 0, err
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		return 0, domainerrors.ErrNotFound
+	}
+	return added, tx.Commit()
+}
+
+func (r *ShipmentRepo) MarkChecked(ctx context.Context, shipmentID int64, at time.Time) error {
+	_, err := r.DB.ExecContext(ctx, `UPDATE shipments SET last_checked_at = ?, updated_at = updated_at WHERE id = ?`, at, shipmentID)
+	return err
+}
+
+func (r *ShipmentRepo) Open(ctx context.Context, checkedBefore time.Time, limit int) ([]entity.Shipment, error) {
+	return r.query(ctx, `
+		SELECT `+shipmentColumns+` FROM shipments
+		WHERE status NOT IN ('delivered', 'returned')
+			AND (last_checked_at IS NULL OR last_checked_at < ?)
+		ORDER BY last_checked_at IS NOT NULL, last_checked_at, id
+		LIMIT ?`, checkedBefore, limit)
+}
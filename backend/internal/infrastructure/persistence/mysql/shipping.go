@@ -0,0 +1,335 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/repository"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+)
+
+type ShippingRepo struct {
+	DB *sql.DB
+}
+
+func NewShippingRepository(db *sql.DB) *ShippingRepo { return &ShippingRepo{DB: db} }
+
+var _ repository.ShippingRepository = (*ShippingRepo)(nil)
+
+// shippingWriteError traduce claves duplicadas (provincia en dos zonas, tarifa repetida) a un conflicto
+func shippingWriteError(err error) error {
+	var me *mysqlerr.MySQLError
+	if errors.As(err, &me) && (me.Number == 1062 || me.Number == 1451 || me.Number == 1452) {
+		return domainerrors.ErrConflict
+	}
+	return err
+}
+
+func (r *ShippingRepo) CreateZone(ctx context.Context, z *entity.ShippingZone) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO shipping_zones (name) VALUES (?)`, z.Name)
+	if err != nil {
+		return fmt.Errorf("failed to create shipping zone: %w", err)
+	}
+	id, _ := res.LastInsertId()
+	if err := insertZoneProvinces(ctx, tx, id, z.Provinces); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	z.ID = id
+	return nil
+}
+
+func insertZoneProvinces(ctx context.Context, tx *sql.Tx, zoneID int64, provinces []string) error {
+	for _, p := range provinces {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO shipping_zone_provinces (zone_id, province) VALUES (?, ?)`, zoneID, p); err != nil {
+			return shippingWriteError(err)
+		}
+	}
+	return nil
+}
+
+func (r *ShippingRepo) UpdateZone(ctx context.Context, z *entity.ShippingZone) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE shipping_zones SET name = ?, updated_at = NOW() WHERE id = ?`, z.Name, z.ID)
+	if err != nil {
+		return err
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM shipping_zone_provinces WHERE zone_id = ?`, z.ID); err != nil {
+		return err
+	}
+	if err := insertZoneProvinces(ctx, tx, z.ID, z.Provinces); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteZone falla con ErrConflict si algún método todavía tiene tarifas para la zona
+func (r *ShippingRepo) DeleteZone(ctx context.Context, id int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM shipping_zones WHERE id = ?`, id)
+	if err != nil {
+		return shippingWriteError(err)
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ShippingRepo) GetZone(ctx context.Context, id int64) (entity.ShippingZone, error) {
+	zones, err := r.queryZones(ctx, `WHERE z.id = ?`, id)
+	if err != nil {
+		return entity.ShippingZone{}, err
+	}
+	if len(zones) == 0 {
+		return entity.ShippingZone{}, domainerrors.ErrNotFound
+	}
+	return zones[0], nil
+}
+
+func (r *ShippingRepo) ListZones(ctx context.Context) ([]entity.ShippingZone, error) {
+	return r.queryZones(ctx, ``)
+}
+
+func (r *ShippingRepo) ZoneForProvince(ctx context.Context, province string) (entity.ShippingZone, error) {
+	zones, err := r.queryZones(ctx, `WHERE z.id = (SELECT zone_id FROM shipping_zone_provinces WHERE province = ?)`, province)
+	if err != nil {
+		return entity.ShippingZone{}, err
+	}
+	if len(zones) == 0 {
+		return entity.ShippingZone{}, domainerrors.ErrNotFound
+	}
+	return zones[0], nil
+}
+
+// queryZones lee las zonas con sus provincias; where filtra sobre el alias z
+func (r *ShippingRepo) queryZones(ctx context.Context, where string, args ...any) ([]entity.ShippingZone, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT z.id, z.name, z.updated_at, z.created_at, p.province
+		FROM shipping_zones z
+		LEFT JOIN shipping_zone_provinces p ON p.zone_id = z.id
+		`+where+`
+		ORDER BY z.id, p.province`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.ShippingZone
+	for rows.Next() {
+		var z entity.ShippingZone
+		var province sql.NullString
+		if err := rows.Scan(&z.ID, &z.Name, &z.UpdatedAt, &z.CreatedAt, &province); err != nil {
+			return nil, err
+		}
+		if n := len(out); n == 0 || out[n-1].ID != z.ID {
+			z.Provinces = []string{}
+			out = append(out, z)
+		}
+		if province.Valid {
+			last := &out[len(out)-1]
+			last.Provinces = append(last.Provinces, province.String)
+		}
+	}
+	return out, rows.Err()
+}
+
+const shippingMethodColumns = `id, name, description, min_days, max_days, free_shipping_threshold, active, updated_at, created_at`
+
+func scanShippingMethod(s rowScanner) (entity.ShippingMethod, error) {
+	var m entity.ShippingMethod
+	var threshold sql.NullString
+	if err := s.Scan(&m.ID, &m.Name, &m.Description, &m.MinDays, &m.MaxDays, &threshold, &m.Active,
+		&m.UpdatedAt, &m.CreatedAt); err != nil {
+		return entity.ShippingMethod{}, err
+	}
+	var err error
+	if m.FreeShippingThreshold, err = parseNullMoney(threshold, money.Base); err != nil {
+		return entity.ShippingMethod{}, err
+	}
+	return m, nil
+}
+
+func (r *ShippingRepo) CreateMethod(ctx context.Context, m *entity.ShippingMethod) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO shipping_methods (name, description, min_days, max_days, free_shipping_threshold, active)
+		VALUES (?,?,?,?,?,?)`,
+		m.Name, m.Description, m.MinDays, m.MaxDays, m.FreeShippingThreshold, m.Active,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create shipping method: %w", err)
+	}
+	id, _ := res.LastInsertId()
+	if err := insertShippingRates(ctx, tx, id, m.Rates); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	m.ID = id
+	for i := range m.Rates {
+		m.Rates[i].MethodID = id
+	}
+	return nil
+}
+
+func insertShippingRates(ctx context.Context, tx *sql.Tx, methodID int64, rates []entity.ShippingRate) error {
+	for i := range rates {
+		rt := &rates[i]
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO shipping_rates (method_id, zone_id, max_weight_grams, price) VALUES (?,?,?,?)`,
+			methodID, rt.ZoneID, rt.MaxWeightGrams, rt.Price)
+		if err != nil {
+			return shippingWriteError(err)
+		}
+		rt.ID, _ = res.LastInsertId()
+	}
+	return nil
+}
+
+func (r *ShippingRepo) UpdateMethod(ctx context.Context, m *entity.ShippingMethod) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE shipping_methods SET name = ?, description = ?, min_days = ?, max_days = ?,
+			free_shipping_threshold = ?, active = ?, updated_at = NOW()
+		WHERE id = ?`,
+		m.Name, m.Description, m.MinDays, m.MaxDays, m.FreeShippingThreshold, m.Active, m.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM shipping_rates WHERE method_id = ?`, m.ID); err != nil {
+		return err
+	}
+	if err := insertShippingRates(ctx, tx, m.ID, m.Rates); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *ShippingRepo) DeleteMethod(ctx context.Context, id int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM shipping_methods WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ShippingRepo) GetMethod(ctx context.Context, id int64) (entity.ShippingMethod, error) {
+	m, err := scanShippingMethod(r.DB.QueryRowContext(ctx, `SELECT `+shippingMethodColumns+` FROM shipping_methods WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ShippingMethod{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.ShippingMethod{}, err
+	}
+	if err := r.loadRates(ctx, []*entity.ShippingMethod{&m}); err != nil {
+		return entity.ShippingMethod{}, err
+	}
+	return m, nil
+}
+
+func (r *ShippingRepo) ListMethods(ctx context.Context, activeOnly bool) ([]entity.ShippingMethod, error) {
+	q := `SELECT ` + shippingMethodColumns + ` FROM shipping_methods`
+	if activeOnly {
+		q += ` WHERE active = TRUE`
+	}
+	rows, err := r.DB.QueryContext(ctx, q+` ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.ShippingMethod
+	for rows.Next() {
+		m, err := scanShippingMethod(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	methods := make([]*entity.ShippingMethod, len(out))
+	for i := range out {
+		methods[i] = &out[i]
+	}
+	return out, r.loadRates(ctx, methods)
+}
+
+func (r *ShippingRepo) loadRates(ctx context.Context, methods []*entity.ShippingMethod) error {
+	if len(methods) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*entity.ShippingMethod, len(methods))
+	args := make([]any, 0, len(methods))
+	for _, m := range methods {
+		m.Rates = []entity.ShippingRate{}
+		byID[m.ID] = m
+		args = append(args, m.ID)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, method_id, zone_id, max_weight_grams, price
+		FROM shipping_rates
+		WHERE method_id IN (`+placeholders(len(args))+`)
+		ORDER BY zone_id, max_weight_grams`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rt entity.ShippingRate
+		var price string
+		if err := rows.Scan(&rt.ID, &rt.MethodID, &rt.ZoneID, &rt.MaxWeightGrams, &price); err != nil {
+			return err
+		}
+		if rt.Price, err = parseMoney(price, money.Base); err != nil {
+			return err
+		}
+		m := byID[rt.MethodID]
+		m.Rates = append(m.Rates, rt)
+	}
+	return rows.Err()
+}
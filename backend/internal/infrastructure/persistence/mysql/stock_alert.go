@@ -0,0 +1,119 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/repository"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+)
+
+type StockAlertRepo struct {
+	DB *sql.DB
+}
+
+func NewStockAlertRepository(db *sql.DB) *StockAlertRepo { return &StockAlertRepo{DB: db} }
+
+var _ repository.StockAlertRepository = (*StockAlertRepo)(nil)
+
+func (r *StockAlertRepo) GetReorderPoint(ctx context.Context, productID int64) (entity.ReorderPoint, error) {
+	var rp entity.ReorderPoint
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT product_id, threshold, reorder_quantity, updated_at FROM reorder_points WHERE product_id = ?`,
+		productID).Scan(&rp.ProductID, &rp.Threshold, &rp.ReorderQuantity, &rp.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ReorderPoint{}, domainerrors.ErrNotFound
+	}
+	return rp, err
+}
+
+func (r *StockAlertRepo) SetReorderPoint(ctx context.Context, rp *entity.ReorderPoint) error {
+	if rp.Threshold == 0 {
+		_, err := r.DB.ExecContext(ctx, `DELETE FROM reorder_points WHERE product_id = ?`, rp.ProductID)
+		return err
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO reorder_points (product_id, threshold, reorder_quantity) VALUES (?,?,?)
+		ON DUPLICATE KEY UPDATE threshold = VALUES(threshold), reorder_quantity = VALUES(reorder_quantity), updated_at = NOW()`,
+		rp.ProductID, rp.Threshold, rp.ReorderQuantity)
+	if err != nil {
+		var me *mysqlerr.MySQLError
+		if errors.As(err, &me) && me.Number == 1452 {
+			return domainerrors.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// lowStockQuery arma el listado de productos por debajo del umbral; los archivados no se avisan
+const lowStockQuery = `
+	SELECT p.id, p.bar_code, p.title, p.size, p.category, p.stock, rp.threshold, rp.reorder_quantity,
+		(SELECT MIN(a.notified_at) FROM low_stock_alerts a WHERE a.product_id = p.id)
+	FROM reorder_points rp
+	JOIN products p ON p.id = rp.product_id
+	WHERE p.stock < rp.threshold AND p.status <> 'archived'`
+
+func (r *StockAlertRepo) queryLowStock(ctx context.Context, q string, args ...any) ([]entity.LowStockItem, error) {
+	rows, err := r.DB.QueryContext(ctx, q+` ORDER BY p.stock - rp.threshold, p.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.LowStockItem{}
+	for rows.Next() {
+		var it entity.LowStockItem
+		var alertedAt sql.NullTime
+		if err := rows.Scan(&it.ProductID, &it.BarCode, &it.Title, &it.Size, &it.Category, &it.Stock,
+			&it.Threshold, &it.ReorderQuantity, &alertedAt); err != nil {
+			return nil, err
+		}
+		it.AlertedAt = nullTimePtr(alertedAt)
+		out = append(out, it)
+	}
+	return out, rows.Err()
+}
+
+func (r *StockAlertRepo) BelowThreshold(ctx context.Context) ([]entity.LowStockItem, error) {
+	return r.queryLowStock(ctx, lowStockQuery)
+}
+
+func (r *StockAlertRepo) Pending(ctx context.Context, channel string) ([]entity.LowStockItem, error) {
+	return r.queryLowStock(ctx, lowStockQuery+`
+		AND NOT EXISTS(SELECT 1 FROM low_stock_alerts a WHERE a.product_id = p.id AND a.channel = ?)`, channel)
+}
+
+func (r *StockAlertRepo) MarkNotified(ctx context.Context, channel string, items []entity.LowStockItem) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, it := range items {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO low_stock_alerts (product_id, channel, stock, threshold) VALUES (?,?,?,?)
+			ON DUPLICATE KEY UPDATE stock = VALUES(stock), threshold = VALUES(threshold), notified_at = NOW()`,
+			it.ProductID, channel, it.Stock, it.Threshold); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *StockAlertRepo) ResolveRecovered(ctx context.Context) (int64, error) {
+	res, err := r.DB.ExecContext(ctx, `
+		DELETE a FROM low_stock_alerts a
+		LEFT JOIN reorder_points rp ON rp.product_id = a.product_id
+		JOIN products p ON p.id = a.product_id
+		WHERE rp.product_id IS NULL OR p.stock >= rp.threshold OR p.status = 'archived'`)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
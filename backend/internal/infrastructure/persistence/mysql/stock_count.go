@@ -0,0 +1,346 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+type StockCountRepo struct {
+	DB *sql.DB
+}
+
+func NewStockCountRepository(db *sql.DB) *StockCountRepo { return &StockCountRepo{DB: db} }
+
+var _ repository.StockCountRepository = (*StockCountRepo)(nil)
+
+const stockCountColumns = `sc.id, sc.location_id, l.code, sc.category, sc.status, sc.note, sc.opened_by,
+		sc.approved_by, sc.approved_at, sc.updated_at, sc.created_at`
+
+func scanStockCount(s rowScanner) (entity.StockCount, error) {
+	var c entity.StockCount
+	var approvedBy sql.NullInt64
+	var approvedAt sql.NullTime
+	if err := s.Scan(&c.ID, &c.LocationID, &c.LocationCode, &c.Category, &c.Status, &c.Note, &c.OpenedBy,
+		&approvedBy, &approvedAt, &c.UpdatedAt, &c.CreatedAt); err != nil {
+		return entity.StockCount{}, err
+	}
+	if approvedBy.Valid {
+		c.ApprovedBy = &approvedBy.Int64
+	}
+	c.ApprovedAt = nullTimePtr(approvedAt)
+	return c, nil
+}
+
+func (r *StockCountRepo) Create(ctx context.Context, c *entity.StockCount) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Se bloquea la ubicación para que no se abran dos recuentos a la vez
+	var code string
+	if err := tx.QueryRowContext(ctx, `SELECT code FROM locations WHERE id = ? FOR UPDATE`, c.LocationID).Scan(&code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domainerrors.ErrNotFound
+		}
+		return err
+	}
+	var open bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM stock_counts WHERE location_id = ? AND status = ?)`,
+		c.LocationID, entity.StockCountStatusOpen).Scan(&open); err != nil {
+		return err
+	}
+	if open {
+		return domainerrors.ErrConflict
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO stock_counts (location_id, category, status, note, opened_by) VALUES (?,?,?,?,?)`,
+		c.LocationID, c.Category, c.Status, c.Note, c.OpenedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create stock count: %w", err)
+	}
+	id, _ := res.LastInsertId()
+
+	// El esperado es el stock de la ubicación al abrir; los productos sin stock también
+	// entran para poder registrar lo que aparezca
+	q := `
+		INSERT INTO stock_count_lines (count_id, product_id, expected)
+		SELECT ?, p.id, COALESCE(il.quantity, 0)
+		FROM products p
+		LEFT JOIN inventory_levels il ON il.product_id = p.id AND il.location_id = ?`
+	args := []any{id, c.LocationID}
+	if c.Category != "" {
+		q += " WHERE p.category = ?"
+		args = append(args, c.Category)
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return fmt.Errorf("failed to snapshot stock count lines: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	c.ID, c.LocationCode = id, code
+	return nil
+}
+
+func (r *StockCountRepo) GetByID(ctx context.Context, id int64) (entity.StockCount, error) {
+	c, err := scanStockCount(r.DB.QueryRowContext(ctx, `
+		SELECT `+stockCountColumns+`
+		FROM stock_counts sc JOIN locations l ON l.id = sc.location_id
+		WHERE sc.id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.StockCount{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.StockCount{}, err
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT scl.product_id, p.bar_code, p.title, p.size, scl.expected, scl.counted, scl.scanned_at
+		FROM stock_count_lines scl
+		JOIN products p ON p.id = scl.product_id
+		WHERE scl.count_id = ?
+		ORDER BY p.category, p.title, p.size, p.id`, id)
+	if err != nil {
+		return entity.StockCount{}, err
+	}
+	defer rows.Close()
+
+	c.Lines = []entity.StockCountLine{}
+	for rows.Next() {
+		var l entity.StockCountLine
+		var scannedAt sql.NullTime
+		if err := rows.Scan(&l.ProductID, &l.BarCode, &l.Title, &l.Size, &l.Expected, &l.Counted, &scannedAt); err != nil {
+			return entity.StockCount{}, err
+		}
+		l.ScannedAt = nullTimePtr(scannedAt)
+		c.Lines = append(c.Lines, l)
+	}
+	return c, rows.Err()
+}
+
+func (r *StockCountRepo) List(ctx context.Context, f entity.StockCountFilter) ([]entity.StockCount, error) {
+	q := `
+		SELECT ` + stockCountColumns + `
+		FROM stock_counts sc JOIN locations l ON l.id = sc.location_id
+		WHERE 1=1`
+	args := []any{}
+	if f.Status != "" {
+		q += " AND sc.status = ?"
+		args = append(args, f.Status)
+	}
+	if f.LocationID > 0 {
+		q += " AND sc.location_id = ?"
+		args = append(args, f.LocationID)
+	}
+	q += " ORDER BY sc.id DESC"
+	limit := 20
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.StockCount{}
+	for rows.Next() {
+		c, err := scanStockCount(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// lockOpenCount bloquea el recuento y verifica que siga abierto
+func lockOpenCount(ctx context.Context, tx *sql.Tx, id int64) (locationID int64, err error) {
+	var status entity.StockCountStatus
+	err = tx.QueryRowContext(ctx, `SELECT location_id, status FROM stock_counts WHERE id = ? FOR UPDATE`, id).Scan(&locationID, &status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	if status != entity.StockCountStatusOpen {
+		return 0, domainerrors.ErrInvalidTransition
+	}
+	return locationID, nil
+}
+
+func (r *StockCountRepo) AddScans(ctx context.Context, id int64, scans []entity.StockCountScan) (entity.StockCountScanResult, error) {
+	result := entity.StockCountScanResult{Unknown: []string{}}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	locationID, err := lockOpenCount(ctx, tx, id)
+	if err != nil {
+		return result, err
+	}
+
+	// Un mismo código puede venir varias veces en el lote
+	order := []string{}
+	totals := map[string]int64{}
+	times := map[string]int{}
+	for _, s := range scans {
+		if _, ok := totals[s.BarCode]; !ok {
+			order = append(order, s.BarCode)
+		}
+		totals[s.BarCode] += s.Quantity
+		times[s.BarCode]++
+	}
+	for _, code := range order {
+		var productID, counted, expected int64
+		var scanned bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT scl.product_id, scl.counted, scl.expected, scl.scanned_at IS NOT NULL
+			FROM stock_count_lines scl
+			JOIN products p ON p.id = scl.product_id
+			WHERE scl.count_id = ? AND p.bar_code = ?
+			FOR UPDATE`, id, code).Scan(&productID, &counted, &expected, &scanned)
+		if errors.Is(err, sql.ErrNoRows) {
+			result.Unknown = append(result.Unknown, code)
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		if counted+totals[code] < 0 {
+			return entity.StockCountScanResult{}, domainerrors.ErrInvalidInput
+		}
+		// Al primer escaneo el esperado pasa a ser el nivel de la ubicación en ese
+		// momento: lo que se venda después se descuenta de lo contado al aprobar
+		if !scanned {
+			err := tx.QueryRowContext(ctx, `
+				SELECT quantity FROM inventory_levels WHERE product_id = ? AND location_id = ? FOR UPDATE`,
+				productID, locationID).Scan(&expected)
+			if errors.Is(err, sql.ErrNoRows) {
+				expected = 0
+			} else if err != nil {
+				return result, err
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE stock_count_lines SET counted = counted + ?, expected = ?, scanned_at = NOW()
+			WHERE count_id = ? AND product_id = ?`, totals[code], expected, id, productID); err != nil {
+			return result, err
+		}
+		result.Accepted += times[code]
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE stock_counts SET updated_at = NOW() WHERE id = ?`, id); err != nil {
+		return result, err
+	}
+	return result, tx.Commit()
+}
+
+func (r *StockCountRepo) Approve(ctx context.Context, id, actorID int64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	locationID, err := lockOpenCount(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	// Una línea escaneada se ajusta por lo contado menos el nivel al escanearla, así las
+	// ventas y entradas posteriores al escaneo se conservan. Una sin escanear cuenta como
+	// cero y se ajusta contra el nivel actual. Los niveles quedan bloqueados hasta
+	// ajustar, así nada se cuela entre la lectura y el ajuste.
+	rows, err := tx.QueryContext(ctx, `
+		SELECT scl.product_id, scl.counted, scl.expected, scl.scanned_at IS NOT NULL, COALESCE(il.quantity, 0)
+		FROM stock_count_lines scl
+		LEFT JOIN inventory_levels il ON il.product_id = scl.product_id AND il.location_id = ?
+		WHERE scl.count_id = ?
+		ORDER BY scl.product_id
+		FOR UPDATE`, locationID, id)
+	if err != nil {
+		return err
+	}
+	type variance struct{ productID, delta int64 }
+	var variances []variance
+	for rows.Next() {
+		var productID, counted, expected, level int64
+		var scanned bool
+		if err := rows.Scan(&productID, &counted, &expected, &scanned, &level); err != nil {
+			rows.Close()
+			return err
+		}
+		base := level
+		if scanned {
+			base = expected
+		}
+		// Si después del escaneo salió más de lo contado, la ubicación queda en cero
+		delta := max(counted-base, -level)
+		if delta != 0 {
+			variances = append(variances, variance{productID: productID, delta: delta})
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// Las líneas sin escanear guardan como esperado el nivel contra el que se ajustaron
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE stock_count_lines scl
+		LEFT JOIN inventory_levels il ON il.product_id = scl.product_id AND il.location_id = ?
+		SET scl.expected = COALESCE(il.quantity, 0)
+		WHERE scl.count_id = ? AND scl.scanned_at IS NULL`, locationID, id); err != nil {
+		return err
+	}
+
+	ref := entity.StockMovementRef{
+		Kind:      entity.StockMovementCount,
+		Reason:    "Recuento físico",
+		Reference: fmt.Sprintf("count:%d", id),
+		ActorID:   actorID,
+	}
+	for _, v := range variances {
+		if err := adjustLevel(ctx, tx, v.productID, locationID, v.delta, ref); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE stock_counts SET status = ?, approved_by = ?, approved_at = NOW(), updated_at = NOW()
+		WHERE id = ?`, entity.StockCountStatusApproved, actorID, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *StockCountRepo) Cancel(ctx context.Context, id int64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := lockOpenCount(ctx, tx, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE stock_counts SET status = ?, updated_at = NOW() WHERE id = ?`,
+		entity.StockCountStatusCancelled, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
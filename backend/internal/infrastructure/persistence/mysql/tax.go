@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/repository"
+)
+
+type TaxRateRepo struct {
+	DB *sql.DB
+}
+
+func NewTaxRateRepository(db *sql.DB) *TaxRateRepo { return &TaxRateRepo{DB: db} }
+
+var _ repository.TaxRateRepository = (*TaxRateRepo)(nil)
+
+func (r *TaxRateRepo) List(ctx context.Context) ([]entity.TaxRate, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT id, category, rate_bps, updated_at FROM tax_rates ORDER BY category`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.TaxRate
+	for rows.Next() {
+		var t entity.TaxRate
+		if err := rows.Scan(&t.ID, &t.Category, &t.Rate, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func (r *TaxRateRepo) GetByID(ctx context.Context, id int64) (entity.TaxRate, error) {
+	var t entity.TaxRate
+	err := r.DB.QueryRowContext(ctx, `SELECT id, category, rate_bps, updated_at FROM tax_rates WHERE id = ?`, id).
+		Scan(&t.ID, &t.Category, &t.Rate, &t.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.TaxRate{}, domainerrors.ErrNotFound
+	}
+	return t, err
+}
+
+func (r *TaxRateRepo) Upsert(ctx context.Context, t *entity.TaxRate) error {
+	if _, err := r.DB.ExecContext(ctx, `
+		INSERT INTO tax_rates (category, rate_bps) VALUES (?,?)
+		ON DUPLICATE KEY UPDATE rate_bps = VALUES(rate_bps)`,
+		t.Category, t.Rate,
+	); err != nil {
+		return err
+	}
+	// LastInsertId no es confiable cuando el upsert actualiza, se relee por categoría
+	return r.DB.QueryRowContext(ctx, `SELECT id, updated_at FROM tax_rates WHERE category = ?`, t.Category).
+		Scan(&t.ID, &t.UpdatedAt)
+}
+
+func (r *TaxRateRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM tax_rates WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
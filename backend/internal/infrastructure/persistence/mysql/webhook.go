@@ -0,0 +1,330 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/repository"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+)
+
+type WebhookRepo struct {
+	DB *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepo { return &WebhookRepo{DB: db} }
+
+var _ repository.WebhookRepository = (*WebhookRepo)(nil)
+
+const webhookEndpointColumns = `id, url, description, secret, events, active, updated_at, created_at`
+
+func scanWebhookEndpoint(s rowScanner) (entity.WebhookEndpoint, error) {
+	var e entity.WebhookEndpoint
+	var events string
+	if err := s.Scan(&e.ID, &e.URL, &e.Description, &e.Secret, &events, &e.Active, &e.UpdatedAt, &e.CreatedAt); err != nil {
+		return entity.WebhookEndpoint{}, err
+	}
+	if err := json.Unmarshal([]byte(events), &e.Events); err != nil {
+		return entity.WebhookEndpoint{}, fmt.Errorf("invalid events of webhook endpoint %d: %w", e.ID, err)
+	}
+	return e, nil
+}
+
+func (r *WebhookRepo) CreateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	events, err := json.Marshal(e.Events)
+	if err != nil {
+		return err
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO webhook_endpoints (url, description, secret, events, active)
+		VALUES (?,?,?,?,?)`,
+		e.URL, e.Description, e.Secret, string(events), e.Active)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+	id, _ := res.LastInsertId()
+	saved, err := r.GetEndpoint(ctx, id)
+	if err != nil {
+		return err
+	}
+	*e = saved
+	return nil
+}
+
+func (r *WebhookRepo) GetEndpoint(ctx context.Context, id int64) (entity.WebhookEndpoint, error) {
+	e, err := scanWebhookEndpoint(r.DB.QueryRowContext(ctx, `
+		SELECT `+webhookEndpointColumns+` FROM webhook_endpoints WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.WebhookEndpoint{}, domainerrors.ErrNotFound
+	}
+	return e, err
+}
+
+func (r *WebhookRepo) ListEndpoints(ctx context.Context) ([]entity.WebhookEndpoint, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+webhookEndpointColumns+` FROM webhook_endpoints ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.WebhookEndpoint{}
+	for rows.Next() {
+		e, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func (r *WebhookRepo) UpdateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	events, err := json.Marshal(e.Events)
+	if err != nil {
+		return err
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE webhook_endpoints
+		SET url = ?, description = ?, secret = ?, events = ?, active = ?, updated_at = NOW()
+		WHERE id = ?`,
+		e.URL, e.Description, e.Secret, string(events), e.Active, e.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domainerrors.ErrNotFound
+	}
+	saved, err := r.GetEndpoint(ctx, e.ID)
+	if err != nil {
+		return err
+	}
+	*e = saved
+	return nil
+}
+
+func (r *WebhookRepo) DeleteEndpoint(ctx context.Context, id int64) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM webhook_endpoints WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepo) Enqueue(ctx context.Context, msg *entity.WebhookMessage) (int, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id FROM webhook_endpoints
+		WHERE active = TRUE AND JSON_CONTAINS(events, JSON_QUOTE(?))`, msg.Event)
+	if err != nil {
+		return 0, err
+	}
+	var endpointIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		endpointIDs = append(endpointIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(endpointIDs) == 0 {
+		return 0, nil
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO webhook_events (event, payload, source_id, aggregate_type, aggregate_id) VALUES (?,?,?,?,?)`,
+		msg.Event, string(msg.Data), msg.SourceID, msg.AggregateType, msg.AggregateID)
+	if err != nil {
+		// El evento del outbox ya se encoló en un intento anterior del relay
+		var me *mysqlerr.MySQLError
+		if msg.SourceID != nil && errors.As(err, &me) && me.Number == 1062 {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to save webhook event: %w", err)
+	}
+	msg.ID, _ = res.LastInsertId()
+	if err := tx.QueryRowContext(ctx, `SELECT created_at FROM webhook_events WHERE id = ?`, msg.ID).Scan(&msg.CreatedAt); err != nil {
+		return 0, err
+	}
+	for _, id := range endpointIDs {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO webhook_deliveries (event_id, endpoint_id, next_attempt_at) VALUES (?,?,NOW())`,
+			msg.ID, id); err != nil {
+			return 0, fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+		}
+	}
+	return len(endpointIDs), tx.Commit()
+}
+
+const webhookDeliveryColumns = `d.id, d.event_id, ev.event, d.endpoint_id, d.status, d.attempts, d.next_attempt_at,
+		d.response_status, d.last_error, d.redelivery_of, d.delivered_at, d.updated_at, d.created_at`
+
+func scanWebhookDelivery(s rowScanner, extra ...any) (entity.WebhookDelivery, error) {
+	var d entity.WebhookDelivery
+	var nextAttemptAt, deliveredAt sql.NullTime
+	var redeliveryOf sql.NullInt64
+	dest := []any{&d.ID, &d.EventID, &d.Event, &d.EndpointID, &d.Status, &d.Attempts, &nextAttemptAt,
+		&d.ResponseStatus, &d.LastError, &redeliveryOf, &deliveredAt, &d.UpdatedAt, &d.CreatedAt}
+	if err := s.Scan(append(dest, extra...)...); err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+	d.NextAttemptAt = nullTimePtr(nextAttemptAt)
+	d.DeliveredAt = nullTimePtr(deliveredAt)
+	if redeliveryOf.Valid {
+		d.RedeliveryOf = &redeliveryOf.Int64
+	}
+	return d, nil
+}
+
+func (r *WebhookRepo) Due(ctx context.Context, now time.Time, limit int) ([]entity.WebhookJob, error) {
+	// Un envío espera mientras el destino tenga pendiente un evento anterior del mismo
+	// agregado, aunque ese esté esperando un reintento: así un product.updated viejo no
+	// llega después de uno nuevo. Los envíos fallidos ya no frenan a los siguientes.
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`, ev.payload, ev.created_at,
+			e.id, e.url, e.description, e.secret, e.events, e.active, e.updated_at, e.created_at
+		FROM webhook_deliveries d
+		JOIN webhook_events ev ON ev.id = d.event_id
+		JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		WHERE d.status = ? AND d.next_attempt_at <= ? AND e.active = TRUE
+			AND (ev.aggregate_type = '' OR NOT EXISTS (
+				SELECT 1 FROM webhook_deliveries d2
+				JOIN webhook_events ev2 ON ev2.id = d2.event_id
+				WHERE d2.endpoint_id = d.endpoint_id AND d2.status = ?
+					AND ev2.aggregate_type = ev.aggregate_type AND ev2.aggregate_id = ev.aggregate_id
+					AND ev2.id < ev.id))
+		ORDER BY d.next_attempt_at, d.id
+		LIMIT ?`, entity.WebhookDeliveryPending, now, entity.WebhookDeliveryPending, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.WebhookJob{}
+	for rows.Next() {
+		var job entity.WebhookJob
+		var payload, events string
+		e := &job.Endpoint
+		job.Delivery, err = scanWebhookDelivery(rows, &payload, &job.Message.CreatedAt,
+			&e.ID, &e.URL, &e.Description, &e.Secret, &events, &e.Active, &e.UpdatedAt, &e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(events), &e.Events); err != nil {
+			return nil, fmt.Errorf("invalid events of webhook endpoint %d: %w", e.ID, err)
+		}
+		job.Message.ID, job.Message.Event, job.Message.Data = job.Delivery.EventID, job.Delivery.Event, json.RawMessage(payload)
+		out = append(out, job)
+	}
+	return out, rows.Err()
+}
+
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, id int64, responseStatus int) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = attempts + 1, response_status = ?, last_error = '',
+			next_attempt_at = NULL, delivered_at = NOW(), updated_at = NOW()
+		WHERE id = ?`, entity.WebhookDeliveryDelivered, responseStatus, id)
+	return err
+}
+
+func (r *WebhookRepo) MarkAttemptFailed(ctx context.Context, id int64, responseStatus int, reason string, next *time.Time) error {
+	status := entity.WebhookDeliveryPending
+	if next == nil {
+		status = entity.WebhookDeliveryFailed
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = attempts + 1, response_status = ?, last_error = ?,
+			next_attempt_at = ?, updated_at = NOW()
+		WHERE id = ?`, status, responseStatus, reason, next, id)
+	return err
+}
+
+func (r *WebhookRepo) GetDelivery(ctx context.Context, id int64) (entity.WebhookDelivery, error) {
+	d, err := scanWebhookDelivery(r.DB.QueryRowContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries d
+		JOIN webhook_events ev ON ev.id = d.event_id
+		WHERE d.id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.WebhookDelivery{}, domainerrors.ErrNotFound
+	}
+	return d, err
+}
+
+func (r *WebhookRepo) ListDeliveries(ctx context.Context, f entity.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error) {
+	q := `SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries d
+		JOIN webhook_events ev ON ev.id = d.event_id
+		WHERE 1=1`
+	args := []any{}
+	if f.EndpointID > 0 {
+		q += " AND d.endpoint_id = ?"
+		args = append(args, f.EndpointID)
+	}
+	if f.EventID > 0 {
+		q += " AND d.event_id = ?"
+		args = append(args, f.EventID)
+	}
+	if f.Status != "" {
+		q += " AND d.status = ?"
+		args = append(args, f.Status)
+	}
+	q += " ORDER BY d.id DESC"
+	limit := 50
+	if f.Limit > 0 && f.Limit <= 100 {
+		limit = f.Limit
+	}
+	q += " LIMIT ? OFFSET ?"
+	args = append(args, limit, f.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []entity.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (r *WebhookRepo) Redeliver(ctx context.Context, id int64) (entity.WebhookDelivery, error) {
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (event_id, endpoint_id, next_attempt_at, redelivery_of)
+		SELECT event_id, endpoint_id, NOW(), id FROM webhook_deliveries WHERE id = ?`, id)
+	if err != nil {
+		return entity.WebhookDelivery{}, fmt.Errorf("failed to redeliver webhook: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return entity.WebhookDelivery{}, domainerrors.ErrNotFound
+	}
+	newID, _ := res.LastInsertId()
+	return r.GetDelivery(ctx, newID)
+}
@@ -0,0 +1,293 @@
+// Package spreadsheet lee planillas CSV y XLSX y escribe exportaciones CSV, XLSX y JSON
+// Lines en Go puro. Del XLSX solo se lee la primera hoja, con los valores ya calculados
+// de las fórmulas.
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/service"
+)
+
+// Límites de lectura: un XLSX chico puede descomprimir a mucho más que el archivo subido
+const (
+	maxRows     = 1 << 20
+	maxColumns  = 16384 // columna XFD, la última de Excel
+	maxUnzipped = 100 << 20
+)
+
+// Reader lee las filas de una planilla subida
+type Reader struct{}
+
+func NewReader() *Reader { return &Reader{} }
+
+var _ service.SheetReader = (*Reader)(nil)
+
+// Read retorna las filas de la planilla tal como están, con el encabezado como primera
+// fila. Las filas vacías del XLSX se conservan para que los números de fila coincidan.
+func (r *Reader) Read(format entity.FileFormat, data []byte) ([][]string, error) {
+	var rows [][]string
+	var err error
+	switch format {
+	case entity.FileFormatCSV:
+		rows, err = readCSV(data)
+	case entity.FileFormatXLSX:
+		rows, err = readXLSX(data)
+	default:
+		return nil, domainerrors.ErrInvalidFile
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidFile, err)
+	}
+	return rows, nil
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	rd := csv.NewReader(bytes.NewReader(data))
+	rd.Comma = csvDelimiter(data)
+	rd.FieldsPerRecord = -1
+	rd.LazyQuotes = true
+	var rows [][]string
+	for {
+		rec, err := rd.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == maxRows {
+			return nil, errors.New("too many rows")
+		}
+		rows = append(rows, rec)
+	}
+}
+
+// csvDelimiter elige entre coma y punto y coma según el encabezado; Excel en español
+// exporta con punto y coma porque la coma es el separador decimal
+func csvDelimiter(data []byte) rune {
+	header := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		header = data[:i]
+	}
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText es un texto con formato: el texto plano o la concatenación de sus tramos
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var sb strings.Builder
+	for _, r := range t.Runs {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string   `xml:"r,attr"`
+			T      string   `xml:"t,attr"`
+			V      string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+	var shared xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXML(f, &shared); err != nil {
+			return nil, err
+		}
+	}
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, errors.New("missing worksheet " + sheetPath)
+	}
+	var sheet xlsxSheet
+	if err := decodeXML(f, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		idx := len(rows)
+		if row.R > 0 {
+			idx = row.R - 1
+		}
+		if idx < len(rows) || idx >= maxRows {
+			return nil, fmt.Errorf("invalid row number %d", row.R)
+		}
+		for len(rows) < idx {
+			rows = append(rows, nil)
+		}
+		var values []string
+		for _, c := range row.Cells {
+			col := len(values)
+			if c.R != "" {
+				if col, err = columnIndex(c.R); err != nil {
+					return nil, err
+				}
+			}
+			var v string
+			switch c.T {
+			case "s":
+				i, err := strconv.Atoi(c.V)
+				if err != nil || i < 0 || i >= len(shared.Items) {
+					return nil, fmt.Errorf("invalid shared string in %s", c.R)
+				}
+				v = shared.Items[i].String()
+			case "inlineStr":
+				v = c.Inline.String()
+			case "n", "":
+				v = formatNumber(c.V)
+			default: // str, b, e: el valor calculado tal cual
+				v = c.V
+			}
+			for len(values) < col {
+				values = append(values, "")
+			}
+			if col < len(values) {
+				values[col] = v
+			} else {
+				values = append(values, v)
+			}
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+// firstSheetPath resuelve el archivo de la primera hoja del libro a través de sus relaciones
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	var wb xlsxWorkbook
+	f, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", errors.New("missing workbook")
+	}
+	if err := decodeXML(f, &wb); err != nil {
+		return "", err
+	}
+	if len(wb.Sheets) == 0 {
+		return "", errors.New("workbook has no sheets")
+	}
+	var rels xlsxRelationships
+	if f, ok := files["xl/_rels/workbook.xml.rels"]; ok {
+		if err := decodeXML(f, &rels); err != nil {
+			return "", err
+		}
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return "xl/worksheets/sheet1.xml", nil
+}
+
+func decodeXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	lr := &io.LimitedReader{R: rc, N: maxUnzipped}
+	if err := xml.NewDecoder(lr).Decode(v); err != nil {
+		return fmt.Errorf("%s: %w", f.Name, err)
+	}
+	if lr.N <= 0 {
+		return errors.New(f.Name + " is too large")
+	}
+	return nil
+}
+
+// columnIndex convierte la referencia de una celda ("AB12") en el índice de su columna
+func columnIndex(ref string) (int, error) {
+	col := 0
+	for _, ch := range strings.ToUpper(ref) {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+		if col > maxColumns {
+			return 0, fmt.Errorf("invalid cell reference %q", ref)
+		}
+	}
+	if col == 0 {
+		return 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return col - 1, nil
+}
+
+// formatNumber escribe sin exponente los números que Excel guarda en notación
+// científica, como los códigos de barras cargados como número
+func formatNumber(v string) string {
+	if !strings.ContainsAny(v, "eE") {
+		return v
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return v
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,230 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"core/internal/domain/entity"
+	domainerrors "core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/service"
+)
+
+// Writer crea escritores que vuelcan las filas a medida que llegan, sin armar el
+// archivo en memoria. Los valores pueden ser nil, string, int64, bool, money.Money,
+// time.Time o []string.
+type Writer struct{}
+
+func NewWriter() *Writer { return &Writer{} }
+
+var _ service.SheetWriter = (*Writer)(nil)
+
+func (w *Writer) NewRowWriter(out io.Writer, format entity.FileFormat, columns []string) (service.RowWriter, error) {
+	switch format {
+	case entity.FileFormatCSV:
+		return newCSVWriter(out, columns)
+	case entity.FileFormatXLSX:
+		return newXLSXWriter(out, columns)
+	case entity.FileFormatJSONL:
+		return &jsonlWriter{w: bufio.NewWriter(out), columns: columns}, nil
+	}
+	return nil, domainerrors.ErrInvalidInput
+}
+
+// cellText es el valor de una celda de texto
+func cellText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case money.Money:
+		return v.Decimal()
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ",")
+	}
+	return fmt.Sprint(v)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(out io.Writer, columns []string) (*csvWriter, error) {
+	// El BOM hace que Excel abra el archivo como UTF-8
+	if _, err := io.WriteString(out, "\ufeff"); err != nil {
+		return nil, err
+	}
+	w := &csvWriter{w: csv.NewWriter(out)}
+	if err := w.w.Write(columns); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *csvWriter) Write(values []any) error {
+	rec := make([]string, len(values))
+	for i, v := range values {
+		rec[i] = cellText(v)
+	}
+	return w.w.Write(rec)
+}
+
+func (w *csvWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type jsonlWriter struct {
+	w       *bufio.Writer
+	columns []string
+}
+
+// Write escribe la fila como un objeto con las claves en el orden de las columnas. Los
+// textos van sin escapar "<", ">" y "&", que los consumidores no necesitan.
+func (w *jsonlWriter) Write(values []any) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	buf.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := enc.Encode(w.columns[i]); err != nil {
+			return err
+		}
+		buf.Truncate(buf.Len() - 1) // Encode agrega un salto de línea
+		buf.WriteByte(':')
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		buf.Truncate(buf.Len() - 1)
+	}
+	buf.WriteString("}\n")
+	_, err := w.w.Write(buf.Bytes())
+	return err
+}
+
+func (w *jsonlWriter) Close() error { return w.w.Flush() }
+
+// Partes fijas del libro: una sola hoja, los textos van en línea en cada celda para no
+// tener que juntar la tabla de textos compartidos antes de escribir
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Catalogo" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts><fills count="1"><fill><patternFill patternType="none"/></fill></fills><borders count="1"><border/></borders><cellStyleXfs count="1"><xf/></cellStyleXfs><cellXfs count="1"><xf/></cellXfs></styleSheet>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	row   int
+}
+
+func newXLSXWriter(out io.Writer, columns []string) (*xlsxWriter, error) {
+	zw := zip.NewWriter(out)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbookXML},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return nil, err
+		}
+	}
+	// La hoja va última: el zip se escribe en orden y queda abierta hasta Close
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	w := &xlsxWriter{zw: zw, sheet: bufio.NewWriter(f)}
+	w.sheet.WriteString(xlsxSheetStart)
+	header := make([]any, len(columns))
+	for i, c := range columns {
+		header[i] = c
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *xlsxWriter) Write(values []any) error {
+	w.row++
+	fmt.Fprintf(w.sheet, `<row r="%d">`, w.row)
+	for i, v := range values {
+		ref := columnName(i) + strconv.Itoa(w.row)
+		switch v := v.(type) {
+		case nil:
+			continue
+		case int64:
+			fmt.Fprintf(w.sheet, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case money.Money:
+			fmt.Fprintf(w.sheet, `<c r="%s"><v>%s</v></c>`, ref, v.Decimal())
+		case bool:
+			b := 0
+			if v {
+				b = 1
+			}
+			fmt.Fprintf(w.sheet, `<c r="%s" t="b"><v>%d</v></c>`, ref, b)
+		default:
+			fmt.Fprintf(w.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			if err := xml.EscapeText(w.sheet, []byte(cellText(v))); err != nil {
+				return err
+			}
+			w.sheet.WriteString(`</t></is></c>`)
+		}
+	}
+	_, err := w.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (w *xlsxWriter) Close() error {
+	w.sheet.WriteString(xlsxSheetEnd)
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+// columnName convierte el índice de una columna en su nombre ("A", "B", ..., "AA")
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,67 @@
+// Package taxauthority contiene las implementaciones de service.TaxAuthority
+package taxauthority
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/service"
+	"core/internal/domain/tax"
+)
+
+// caeValidity es la vigencia de un CAE desde la autorización
+const caeValidity = 10 * 24 * time.Hour
+
+// Stub autoriza facturas localmente, sin conectarse al organismo. Hace las mismas
+// validaciones básicas que el servicio real y genera un CAE determinístico a partir
+// del número de comprobante. Sirve para desarrollo y pruebas.
+type Stub struct {
+	now func() time.Time
+}
+
+func NewStub() *Stub {
+	return &Stub{now: time.Now}
+}
+
+var _ service.TaxAuthority = (*Stub)(nil)
+
+func (s *Stub) Authorize(ctx context.Context, inv *entity.Invoice) (entity.InvoiceAuthorization, error) {
+	if err := ctx.Err(); err != nil {
+		return entity.InvoiceAuthorization{}, err
+	}
+	if reason := validate(inv); reason != "" {
+		return entity.InvoiceAuthorization{Reason: reason}, nil
+	}
+
+	sum := sha256.Sum256(fmt.Appendf(nil, "%d|%s|%d|%s", inv.PointOfSale, inv.Type, inv.Number, inv.Issuer.TaxID))
+	code := fmt.Sprintf("%014d", binary.BigEndian.Uint64(sum[:8])%100000000000000)
+	return entity.InvoiceAuthorization{
+		Approved:  true,
+		Code:      code,
+		ExpiresAt: s.now().Add(caeValidity).UTC().Truncate(time.Second),
+	}, nil
+}
+
+// validate retorna el motivo de rechazo o "" si la factura es válida
+func validate(inv *entity.Invoice) string {
+	switch {
+	case inv.Number <= 0:
+		return "invoice number must be positive"
+	case inv.PointOfSale <= 0:
+		return "point of sale must be positive"
+	case inv.Type == entity.InvoiceTypeA && inv.Customer.TaxIDType != tax.IDTypeCUIT:
+		return "invoice type A requires a customer CUIT"
+	}
+	if _, idType, err := tax.ParseTaxID(inv.Issuer.TaxID); err != nil || idType != tax.IDTypeCUIT {
+		return "issuer CUIT is invalid"
+	}
+	total, err := inv.Net.Add(inv.Tax)
+	if err != nil || total != inv.Total {
+		return "net plus tax does not match total"
+	}
+	return ""
+}
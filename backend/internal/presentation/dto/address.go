@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"time"
+)
+
+// AddressRequest crea o reemplaza una dirección de la libreta. La provincia es el código
+// ISO 3166-2 (con o sin "AR-"). La primera dirección queda por defecto para envío y facturación.
+type AddressRequest struct {
+	Label             string `json:"label" example:"Casa"`
+	RecipientName     string `json:"recipient_name" example:"Juan Pérez" validate:"required"`
+	Phone             string `json:"phone" example:"+54 11 5555-1234"`
+	Street            string `json:"street" example:"Av. Corrientes" validate:"required"`
+	Number            string `json:"number" example:"1234"`
+	Apartment         string `json:"apartment,omitempty" example:"5B"`
+	City              string `json:"city" example:"CABA" validate:"required"`
+	Province          string `json:"province" example:"C" validate:"required"`
+	PostalCode        string `json:"postal_code" example:"C1043"`
+	IsDefaultShipping bool   `json:"is_default_shipping" example:"true"`
+	IsDefaultBilling  bool   `json:"is_default_billing" example:"true"`
+}
+
+func (r *AddressRequest) ToEntity(userID int64) *entity.Address {
+	return &entity.Address{
+		UserID:            userID,
+		Label:             r.Label,
+		RecipientName:     r.RecipientName,
+		Phone:             r.Phone,
+		Street:            r.Street,
+		Number:            r.Number,
+		Apartment:         r.Apartment,
+		City:              r.City,
+		Province:          r.Province,
+		PostalCode:        r.PostalCode,
+		IsDefaultShipping: r.IsDefaultShipping,
+		IsDefaultBilling:  r.IsDefaultBilling,
+	}
+}
+
+type AddressResponse struct {
+	ID                int64     `json:"id" example:"3"`
+	Label             string    `json:"label" example:"Casa"`
+	RecipientName     string    `json:"recipient_name" example:"Juan Pérez"`
+	Phone             string    `json:"phone" example:"+54 11 5555-1234"`
+	Street            string    `json:"street" example:"Av. Corrientes"`
+	Number            string    `json:"number" example:"1234"`
+	Apartment         string    `json:"apartment,omitempty" example:"5B"`
+	City              string    `json:"city" example:"CABA"`
+	Province          string    `json:"province" example:"C"`
+	ProvinceName      string    `json:"province_name" example:"Ciudad Autónoma de Buenos Aires"`
+	PostalCode        string    `json:"postal_code" example:"C1043"`
+	IsDefaultShipping bool      `json:"is_default_shipping" example:"true"`
+	IsDefaultBilling  bool      `json:"is_default_billing" example:"true"`
+	UpdatedAt         time.Time `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+	CreatedAt         time.Time `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromAddressEntity(a entity.Address) AddressResponse {
+	return AddressResponse{
+		ID:                a.ID,
+		Label:             a.Label,
+		RecipientName:     a.RecipientName,
+		Phone:             a.Phone,
+		Street:            a.Street,
+		Number:            a.Number,
+		Apartment:         a.Apartment,
+		City:              a.City,
+		Province:          a.Province,
+		ProvinceName:      entity.Provinces[a.Province],
+		PostalCode:        a.PostalCode,
+		IsDefaultShipping: a.IsDefaultShipping,
+		IsDefaultBilling:  a.IsDefaultBilling,
+		UpdatedAt:         a.UpdatedAt,
+		CreatedAt:         a.CreatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"time"
+)
+
+type AuditChangeResponse struct {
+	Field string `json:"field" example:"unit_price"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+type AuditEntryResponse struct {
+	ID         int64                 `json:"id" example:"1"`
+	EntityType string                `json:"entity_type" example:"product"`
+	EntityID   int64                 `json:"entity_id" example:"1"`
+	Action     string                `json:"action" example:"update"`
+	Changes    []AuditChangeResponse `json:"changes"`
+	ActorID    *int64                `json:"actor_id,omitempty" example:"1"`
+	ActorEmail string                `json:"actor_email,omitempty" example:"admin@example.com"`
+	ActorRole  string                `json:"actor_role,omitempty" example:"admin"`
+	IP         string                `json:"ip,omitempty" example:"127.0.0.1"`
+	CreatedAt  time.Time             `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromAuditEntity(e entity.AuditEntry) AuditEntryResponse {
+	changes := make([]AuditChangeResponse, 0, len(e.Changes))
+	for _, ch := range e.Changes {
+		changes = append(changes, AuditChangeResponse{Field: ch.Field, Old: ch.Old, New: ch.New})
+	}
+	return AuditEntryResponse{
+		ID:         e.ID,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID,
+		Action:     string(e.Action),
+		Changes:    changes,
+		ActorID:    e.Actor.UserID,
+		ActorEmail: e.Actor.Email,
+		ActorRole:  e.Actor.Role,
+		IP:         e.Actor.IP,
+		CreatedAt:  e.CreatedAt,
+	}
+}
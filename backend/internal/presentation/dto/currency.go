@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"encoding/json"
+	"time"
+)
+
+// CreateExchangeRateTableRequest carga una tabla de tasas. Las tasas son unidades
+// de cada moneda por 1 unidad de la moneda base (ARS).
+type CreateExchangeRateTableRequest struct {
+	Rates         map[string]money.Rate `json:"rates" swaggertype:"object,string" example:"UYU:0.0425,CLP:0.98" validate:"required"`
+	EffectiveFrom *time.Time            `json:"effective_from,omitempty" example:"2025-01-15T00:00:00Z"`
+}
+
+// ToEntity convierte el request en una tabla; CreatedBy lo completa el handler.
+func (r *CreateExchangeRateTableRequest) ToEntity() (*entity.ExchangeRateTable, error) {
+	t := &entity.ExchangeRateTable{
+		BaseCurrency: money.Base,
+		Rates:        make(map[money.Currency]money.Rate, len(r.Rates)),
+	}
+	for code, rate := range r.Rates {
+		cur, err := money.ParseCurrency(code)
+		if err != nil {
+			return nil, err
+		}
+		t.Rates[cur] = rate
+	}
+	if r.EffectiveFrom != nil {
+		t.EffectiveFrom = *r.EffectiveFrom
+	}
+	return t, nil
+}
+
+type ExchangeRateTableResponse struct {
+	ID            int64             `json:"id" example:"1"`
+	BaseCurrency  string            `json:"base_currency" example:"ARS"`
+	Rates         map[string]string `json:"rates"`
+	EffectiveFrom time.Time         `json:"effective_from" example:"2025-01-15T00:00:00Z"`
+	CreatedBy     *int64            `json:"created_by,omitempty" example:"1"`
+	CreatedAt     time.Time         `json:"created_at" example:"2025-01-14T18:00:00Z"`
+}
+
+func FromExchangeRateTableEntity(t entity.ExchangeRateTable) ExchangeRateTableResponse {
+	rates := make(map[string]string, len(t.Rates))
+	for cur, rate := range t.Rates {
+		rates[string(cur)] = rate.String()
+	}
+	return ExchangeRateTableResponse{
+		ID:            t.ID,
+		BaseCurrency:  string(t.BaseCurrency),
+		Rates:         rates,
+		EffectiveFrom: t.EffectiveFrom,
+		CreatedBy:     t.CreatedBy,
+		CreatedAt:     t.CreatedAt,
+	}
+}
+
+// SetProductPriceRequest fija el precio de un producto en otra moneda.
+// El monto se interpreta con los decimales de esa moneda.
+type SetProductPriceRequest struct {
+	UnitPrice json.Number `json:"unit_price" example:"850.00" swaggertype:"number" validate:"required"`
+}
+
+func (r *SetProductPriceRequest) ToEntity(productID int64, currency money.Currency) (*entity.ProductPrice, error) {
+	price, err := money.Parse(r.UnitPrice.String(), currency, money.RoundUnnecessary)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.ProductPrice{ProductID: productID, UnitPrice: price}, nil
+}
+
+type ProductPriceResponse struct {
+	ProductID int64       `json:"product_id" example:"1"`
+	Currency  string      `json:"currency" example:"UYU"`
+	UnitPrice money.Money `json:"unit_price" example:"850.00" swaggertype:"number"`
+	UpdatedAt time.Time   `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromProductPriceEntity(p entity.ProductPrice) ProductPriceResponse {
+	return ProductPriceResponse{
+		ProductID: p.ProductID,
+		Currency:  string(p.UnitPrice.Currency()),
+		UnitPrice: p.UnitPrice,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+type RateSnapshotResponse struct {
+	RateTableID  *int64    `json:"rate_table_id,omitempty" example:"3"`
+	BaseCurrency string    `json:"base_currency" example:"ARS"`
+	Currency     string    `json:"currency" example:"UYU"`
+	Rate         string    `json:"rate" example:"0.0425"`
+	CapturedAt   time.Time `json:"captured_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromRateSnapshotEntity(s *entity.RateSnapshot) *RateSnapshotResponse {
+	if s == nil {
+		return nil
+	}
+	return &RateSnapshotResponse{
+		RateTableID:  s.RateTableID,
+		BaseCurrency: string(s.BaseCurrency),
+		Currency:     string(s.Currency),
+		Rate:         s.Rate.String(),
+		CapturedAt:   s.CapturedAt,
+	}
+}
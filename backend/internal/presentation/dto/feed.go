@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"time"
+)
+
+// SaveFeedCategoryRequest mapea una categoría del catálogo a las taxonomías de los canales
+type SaveFeedCategoryRequest struct {
+	Category       string `json:"category" example:"Remeras" validate:"required"`
+	GoogleCategory string `json:"google_category" example:"212" validate:"required"`
+	MetaCategory   string `json:"meta_category" example:"Ropa y accesorios > Ropa > Camisetas"`
+}
+
+type FeedCategoryResponse struct {
+	ID             int64     `json:"id" example:"1"`
+	Category       string    `json:"category" example:"Remeras"`
+	GoogleCategory string    `json:"google_category" example:"212"`
+	MetaCategory   string    `json:"meta_category,omitempty" example:"Ropa y accesorios > Ropa > Camisetas"`
+	UpdatedAt      time.Time `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromFeedCategoryEntity(m entity.FeedCategoryMapping) FeedCategoryResponse {
+	return FeedCategoryResponse{
+		ID:             m.ID,
+		Category:       m.Category,
+		GoogleCategory: m.GoogleCategory,
+		MetaCategory:   m.MetaCategory,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// FeedStateResponse es el estado de un feed y la URL fija donde se publica
+type FeedStateResponse struct {
+	Channel     string     `json:"channel" example:"google"`
+	URL         string     `json:"url" example:"http://localhost:8080/feeds/google.xml"`
+	Items       int        `json:"items" example:"120"`
+	GeneratedAt *time.Time `json:"generated_at,omitempty" example:"2025-01-15T10:00:00Z"`
+	CheckedAt   *time.Time `json:"checked_at,omitempty" example:"2025-01-15T10:15:00Z"`
+}
+
+func FromFeedStateEntity(st entity.FeedState, url string) FeedStateResponse {
+	return FeedStateResponse{
+		Channel:     string(st.Channel),
+		URL:         url,
+		Items:       st.Items,
+		GeneratedAt: st.GeneratedAt,
+		CheckedAt:   st.CheckedAt,
+	}
+}
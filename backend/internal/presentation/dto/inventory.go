@@ -0,0 +1,160 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"time"
+)
+
+// LocationRequest crea o reemplaza una ubicación de stock
+type LocationRequest struct {
+	Code      string `json:"code" example:"DEP" validate:"required"`
+	Name      string `json:"name" example:"Depósito central" validate:"required"`
+	Kind      string `json:"kind" example:"warehouse" validate:"required,oneof=store warehouse"`
+	Priority  int    `json:"priority" example:"1"`                 // menor = se usa primero al preparar órdenes
+	Active    *bool  `json:"active,omitempty" example:"true"`      // por defecto true
+	IsDefault bool   `json:"is_default,omitempty" example:"false"` // recibe altas y devoluciones
+}
+
+func (r *LocationRequest) ToEntity() *entity.Location {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return &entity.Location{
+		Code:      r.Code,
+		Name:      r.Name,
+		Kind:      entity.LocationKind(r.Kind),
+		Priority:  r.Priority,
+		Active:    active,
+		IsDefault: r.IsDefault,
+	}
+}
+
+type LocationResponse struct {
+	ID        int64     `json:"id" example:"1"`
+	Code      string    `json:"code" example:"DEP"`
+	Name      string    `json:"name" example:"Depósito central"`
+	Kind      string    `json:"kind" example:"warehouse"`
+	Priority  int       `json:"priority" example:"1"`
+	Active    bool      `json:"active" example:"true"`
+	IsDefault bool      `json:"is_default" example:"true"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromLocationEntity(l entity.Location) LocationResponse {
+	return LocationResponse{
+		ID:        l.ID,
+		Code:      l.Code,
+		Name:      l.Name,
+		Kind:      string(l.Kind),
+		Priority:  l.Priority,
+		Active:    l.Active,
+		IsDefault: l.IsDefault,
+		UpdatedAt: l.UpdatedAt,
+		CreatedAt: l.CreatedAt,
+	}
+}
+
+type InventoryLevelResponse struct {
+	LocationID     int64     `json:"location_id" example:"1"`
+	LocationCode   string    `json:"location_code" example:"DEP"`
+	LocationActive bool      `json:"location_active" example:"true"`
+	Quantity       int64     `json:"quantity" example:"40"`
+	UpdatedAt      time.Time `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+type ProductInventoryResponse struct {
+	ProductID int64                    `json:"product_id" example:"42"`
+	Available int64                    `json:"available" example:"50"`
+	OnHand    int64                    `json:"on_hand" example:"50"`
+	Levels    []InventoryLevelResponse `json:"levels"`
+}
+
+func FromProductInventoryEntity(inv entity.ProductInventory) ProductInventoryResponse {
+	levels := make([]InventoryLevelResponse, 0, len(inv.Levels))
+	for _, lv := range inv.Levels {
+		levels = append(levels, InventoryLevelResponse{
+			LocationID:     lv.LocationID,
+			LocationCode:   lv.LocationCode,
+			LocationActive: lv.LocationActive,
+			Quantity:       lv.Quantity,
+			UpdatedAt:      lv.UpdatedAt,
+		})
+	}
+	return ProductInventoryResponse{
+		ProductID: inv.ProductID,
+		Available: inv.Available,
+		OnHand:    inv.OnHand,
+		Levels:    levels,
+	}
+}
+
+// AdjustStockRequest suma (o resta, con delta negativo) unidades en una ubicación
+type AdjustStockRequest struct {
+	LocationID int64  `json:"location_id" example:"1" validate:"required"`
+	Delta      int64  `json:"delta" example:"-2" validate:"required"`
+	Reason     string `json:"reason" example:"Rotura en el local" validate:"required"`
+}
+
+// StockTransferRequest mueve unidades de un producto entre dos ubicaciones
+type StockTransferRequest struct {
+	ProductID      int64  `json:"product_id" example:"42" validate:"required"`
+	FromLocationID int64  `json:"from_location_id" example:"1" validate:"required"`
+	ToLocationID   int64  `json:"to_location_id" example:"2" validate:"required"`
+	Quantity       int64  `json:"quantity" example:"10" validate:"required,min=1"`
+	Note           string `json:"note,omitempty" example:"Reposición del local"`
+}
+
+func (r *StockTransferRequest) ToEntity() *entity.StockTransfer {
+	return &entity.StockTransfer{
+		ProductID:      r.ProductID,
+		FromLocationID: r.FromLocationID,
+		ToLocationID:   r.ToLocationID,
+		Quantity:       r.Quantity,
+		Note:           r.Note,
+	}
+}
+
+type StockTransferResponse struct {
+	ID             int64     `json:"id" example:"1"`
+	ProductID      int64     `json:"product_id" example:"42"`
+	FromLocationID int64     `json:"from_location_id" example:"1"`
+	ToLocationID   int64     `json:"to_location_id" example:"2"`
+	Quantity       int64     `json:"quantity" example:"10"`
+	Note           string    `json:"note,omitempty" example:"Reposición del local"`
+	ActorID        int64     `json:"actor_id,omitempty" example:"1"`
+	CreatedAt      time.Time `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromStockTransferEntity(t entity.StockTransfer) StockTransferResponse {
+	return StockTransferResponse(t)
+}
+
+type StockMovementResponse struct {
+	ID           int64     `json:"id" example:"120"`
+	ProductID    int64     `json:"product_id" example:"42"`
+	LocationID   int64     `json:"location_id" example:"1"`
+	LocationCode string    `json:"location_code" example:"DEP"`
+	Delta        int64     `json:"delta" example:"-2"`
+	Kind         string    `json:"kind" example:"sale"` // sale, return, adjustment, transfer, count, purchase
+	Reason       string    `json:"reason,omitempty" example:"Venta"`
+	Reference    string    `json:"reference,omitempty" example:"order:15"`
+	ActorID      int64     `json:"actor_id,omitempty" example:"7"` // vacío = sistema
+	CreatedAt    time.Time `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromStockMovementEntity(m entity.StockMovement) StockMovementResponse {
+	return StockMovementResponse{
+		ID:           m.ID,
+		ProductID:    m.ProductID,
+		LocationID:   m.LocationID,
+		LocationCode: m.LocationCode,
+		Delta:        m.Delta,
+		Kind:         string(m.Kind),
+		Reason:       m.Reason,
+		Reference:    m.Reference,
+		ActorID:      m.ActorID,
+		CreatedAt:    m.CreatedAt,
+	}
+}
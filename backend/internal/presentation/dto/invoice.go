@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"core/internal/domain/tax"
+	"time"
+)
+
+type InvoiceLineResponse struct {
+	Description string      `json:"description" example:"Remera Básica Negra - Talle M"`
+	Quantity    int64       `json:"quantity" example:"2"`
+	UnitPrice   money.Money `json:"unit_price" example:"2500.00" swaggertype:"number"`
+	Discount    money.Money `json:"discount" example:"500.00" swaggertype:"number"`
+	TaxRate     tax.Rate    `json:"tax_rate" example:"21" swaggertype:"number"`
+	Net         money.Money `json:"net" example:"3719.01" swaggertype:"number"`
+	Tax         money.Money `json:"tax" example:"780.99" swaggertype:"number"`
+	Total       money.Money `json:"total" example:"4500.00" swaggertype:"number"`
+}
+
+type InvoiceResponse struct {
+	ID                     int64                 `json:"id" example:"1"`
+	OrderID                int64                 `json:"order_id" example:"12"`
+	PointOfSale            int                   `json:"point_of_sale" example:"1"`
+	Type                   string                `json:"type" example:"B"`
+	Number                 int64                 `json:"number" example:"42"`
+	FullNumber             string                `json:"full_number" example:"0001-00000042"`
+	Issuer                 entity.InvoiceIssuer  `json:"issuer"`
+	Customer               BillingResponse       `json:"customer"`
+	Currency               string                `json:"currency" example:"ARS"`
+	ExchangeRate           *money.Rate           `json:"exchange_rate,omitempty" swaggertype:"string" example:"1050"`
+	Lines                  []InvoiceLineResponse `json:"lines"`
+	Net                    money.Money           `json:"net" example:"3719.01" swaggertype:"number"`
+	Tax                    money.Money           `json:"tax" example:"780.99" swaggertype:"number"`
+	TaxBreakdown           []TaxGroupResponse    `json:"tax_breakdown"`
+	Total                  money.Money           `json:"total" example:"4500.00" swaggertype:"number"`
+	Status                 string                `json:"status" example:"authorized"`
+	AuthorizationCode      string                `json:"authorization_code,omitempty" example:"74123456789012"`
+	AuthorizationExpiresAt *time.Time            `json:"authorization_expires_at,omitempty" example:"2025-01-25T00:00:00Z"`
+	RejectionReason        string                `json:"rejection_reason,omitempty"`
+	SubmitAttempts         int                   `json:"submit_attempts" example:"1"`
+	IssuedAt               time.Time             `json:"issued_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromInvoiceEntity(inv entity.Invoice) InvoiceResponse {
+	lines := make([]InvoiceLineResponse, 0, len(inv.Lines))
+	for _, l := range inv.Lines {
+		lines = append(lines, InvoiceLineResponse{
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			Discount:    l.Discount,
+			TaxRate:     l.TaxRate,
+			Net:         l.Net,
+			Tax:         l.Tax,
+			Total:       l.Total,
+		})
+	}
+	return InvoiceResponse{
+		ID:                     inv.ID,
+		OrderID:                inv.OrderID,
+		PointOfSale:            inv.PointOfSale,
+		Type:                   string(inv.Type),
+		Number:                 inv.Number,
+		FullNumber:             inv.FullNumber(),
+		Issuer:                 inv.Issuer,
+		Customer:               FromBillingEntity(inv.Customer),
+		Currency:               string(inv.Currency),
+		ExchangeRate:           inv.ExchangeRate,
+		Lines:                  lines,
+		Net:                    inv.Net,
+		Tax:                    inv.Tax,
+		TaxBreakdown:           fromTaxGroups(inv.TaxBreakdown()),
+		Total:                  inv.Total,
+		Status:                 string(inv.Status),
+		AuthorizationCode:      inv.AuthorizationCode,
+		AuthorizationExpiresAt: inv.AuthorizationExpiresAt,
+		RejectionReason:        inv.RejectionReason,
+		SubmitAttempts:         inv.SubmitAttempts,
+		IssuedAt:               inv.IssuedAt,
+	}
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "core/internal/domain/entity"
+
+// LabelSheetRequest pide una hoja de etiquetas para imprimir
+type LabelSheetRequest struct {
+	Items []LabelItemRequest `json:"items" validate:"required,min=1"`
+}
+
+type LabelItemRequest struct {
+	ProductID int64 `json:"product_id" example:"42" validate:"required"`
+	Copies    int   `json:"copies,omitempty" example:"3"` // por defecto 1
+}
+
+func (r *LabelSheetRequest) ToEntities() []entity.ProductLabel {
+	items := make([]entity.ProductLabel, 0, len(r.Items))
+	for _, it := range r.Items {
+		items = append(items, entity.ProductLabel{ProductID: it.ProductID, Copies: it.Copies})
+	}
+	return items
+}
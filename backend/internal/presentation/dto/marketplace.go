@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+)
+
+// LinkMarketplaceListingRequest vincula un producto con una publicación. Sin external_id
+// el producto se publica con su stock y su precio.
+type LinkMarketplaceListingRequest struct {
+	Marketplace string `json:"marketplace" example:"mercadolibre" validate:"required"`
+	ProductID   int64  `json:"product_id" example:"1" validate:"required"`
+	ExternalID  string `json:"external_id,omitempty" example:"MLA1234567890"`
+}
+
+// UpdateMarketplaceListingStatusRequest pausa o reanuda la sincronización
+type UpdateMarketplaceListingStatusRequest struct {
+	Status string `json:"status" example:"paused" validate:"required,oneof=active paused"`
+}
+
+type MarketplaceListingResponse struct {
+	ID          int64        `json:"id" example:"1"`
+	Marketplace string       `json:"marketplace" example:"mercadolibre"`
+	ProductID   int64        `json:"product_id" example:"1"`
+	ExternalID  string       `json:"external_id" example:"MLA1234567890"`
+	Status      string       `json:"status" example:"active"`
+	SyncedStock int64        `json:"synced_stock" example:"12"`
+	SyncedPrice *money.Money `json:"synced_price,omitempty" example:"2500.00" swaggertype:"number"`
+	SyncedAt    *time.Time   `json:"synced_at,omitempty" example:"2025-01-15T10:00:00Z"`
+	SyncError   string       `json:"sync_error,omitempty" example:""`
+	UpdatedAt   time.Time    `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+	CreatedAt   time.Time    `json:"created_at" example:"2025-01-15T09:00:00Z"`
+}
+
+func FromMarketplaceListingEntity(l entity.MarketplaceListing) MarketplaceListingResponse {
+	return MarketplaceListingResponse{
+		ID:          l.ID,
+		Marketplace: l.Marketplace,
+		ProductID:   l.ProductID,
+		ExternalID:  l.ExternalID,
+		Status:      string(l.Status),
+		SyncedStock: l.SyncedStock,
+		SyncedPrice: l.SyncedPrice,
+		SyncedAt:    l.SyncedAt,
+		SyncError:   l.SyncError,
+		UpdatedAt:   l.UpdatedAt,
+		CreatedAt:   l.CreatedAt,
+	}
+}
+
+type MarketplaceOrderLineResponse struct {
+	ExternalItemID string      `json:"external_item_id" example:"MLA1234567890"`
+	Title          string      `json:"title" example:"Remera lisa"`
+	ProductID      *int64      `json:"product_id,omitempty" example:"1"`
+	Quantity       int64       `json:"quantity" example:"2"`
+	UnitPrice      money.Money `json:"unit_price" example:"2500.00" swaggertype:"number"`
+	Shortage       int64       `json:"shortage,omitempty" example:"0"` // unidades vendidas sin stock
+}
+
+// MarketplaceOrderResponse es una venta importada; con estado review necesita revisión
+// manual porque tiene artículos sin vincular o sin stock
+type MarketplaceOrderResponse struct {
+	ID          int64                          `json:"id" example:"1"`
+	Marketplace string                         `json:"marketplace" example:"mercadolibre"`
+	ExternalID  string                         `json:"external_id" example:"2000000001"`
+	Status      string                         `json:"status" example:"imported"`
+	Buyer       string                         `json:"buyer,omitempty" example:"COMPRADOR_TEST"`
+	Total       money.Money                    `json:"total" example:"5000.00" swaggertype:"number"`
+	Lines       []MarketplaceOrderLineResponse `json:"lines"`
+	OrderedAt   time.Time                      `json:"ordered_at" example:"2025-01-15T10:00:00Z"`
+	CreatedAt   time.Time                      `json:"created_at" example:"2025-01-15T10:00:30Z"`
+}
+
+func FromMarketplaceOrderEntity(o entity.MarketplaceOrder) MarketplaceOrderResponse {
+	resp := MarketplaceOrderResponse{
+		ID:          o.ID,
+		Marketplace: o.Marketplace,
+		ExternalID:  o.ExternalID,
+		Status:      string(o.Status),
+		Buyer:       o.Buyer,
+		Total:       o.Total,
+		Lines:       make([]MarketplaceOrderLineResponse, 0, len(o.Lines)),
+		OrderedAt:   o.OrderedAt,
+		CreatedAt:   o.CreatedAt,
+	}
+	for _, l := range o.Lines {
+		resp.Lines = append(resp.Lines, MarketplaceOrderLineResponse{
+			ExternalItemID: l.ExternalItemID,
+			Title:          l.Title,
+			ProductID:      l.ProductID,
+			Quantity:       l.Quantity,
+			UnitPrice:      l.UnitPrice,
+			Shortage:       l.Shortage,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,276 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"core/internal/domain/tax"
+	"time"
+)
+
+// CreateOrderRequest es el checkout de un carrito. La moneda es opcional;
+// si no se indica se usa la de visualización de la request. Sin direcciones se usan
+// las del usuario por defecto; sin método de envío la orden es para retiro en local.
+type CreateOrderRequest struct {
+	Currency          string             `json:"currency,omitempty" example:"UYU"`
+	Items             []OrderLineRequest `json:"items" validate:"required,min=1"`
+	Coupons           []string           `json:"coupons,omitempty" example:"VERANO10"`
+	Billing           *BillingRequest    `json:"billing,omitempty"`
+	ShippingAddressID int64              `json:"shipping_address_id,omitempty" example:"3"`
+	BillingAddressID  int64              `json:"billing_address_id,omitempty" example:"3"`
+	ShippingMethodID  int64              `json:"shipping_method_id,omitempty" example:"1"`
+}
+
+// BillingRequest son los datos para la factura; sin documento se factura a consumidor final
+type BillingRequest struct {
+	TaxID   string `json:"tax_id,omitempty" example:"20-12345678-6"` // CUIT/CUIL o DNI
+	Name    string `json:"name,omitempty" example:"Juan Pérez"`
+	Address string `json:"address,omitempty" example:"Av. Corrientes 1234, CABA"`
+}
+
+type OrderLineRequest struct {
+	ProductID int64 `json:"product_id" example:"1" validate:"required"`
+	Quantity  int64 `json:"quantity" example:"2" validate:"required,min=1"`
+}
+
+func (r *CreateOrderRequest) ToCheckout(userID int64, currency money.Currency) entity.Checkout {
+	lines := make([]entity.OrderLine, 0, len(r.Items))
+	for _, it := range r.Items {
+		lines = append(lines, entity.OrderLine{ProductID: it.ProductID, Quantity: it.Quantity})
+	}
+	checkout := entity.Checkout{
+		UserID:            userID,
+		Lines:             lines,
+		Currency:          currency,
+		Codes:             r.Coupons,
+		ShippingAddressID: r.ShippingAddressID,
+		BillingAddressID:  r.BillingAddressID,
+		ShippingMethodID:  r.ShippingMethodID,
+	}
+	if r.Billing != nil {
+		checkout.Billing = entity.BillingInfo{TaxID: r.Billing.TaxID, Name: r.Billing.Name, Address: r.Billing.Address}
+	}
+	return checkout
+}
+
+// UpdateOrderStatusRequest cambia el estado de una orden pendiente
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" example:"paid" validate:"required,oneof=paid cancelled"`
+}
+
+type BillingResponse struct {
+	TaxID     string `json:"tax_id,omitempty" example:"20123456786"`
+	TaxIDType string `json:"tax_id_type,omitempty" example:"CUIT"`
+	Name      string `json:"name,omitempty" example:"Juan Pérez"`
+	Address   string `json:"address,omitempty" example:"Av. Corrientes 1234, CABA"`
+}
+
+func FromBillingEntity(b entity.BillingInfo) BillingResponse {
+	return BillingResponse{TaxID: b.TaxID, TaxIDType: string(b.TaxIDType), Name: b.Name, Address: b.Address}
+}
+
+// OrderShippingResponse es el envío elegido en la orden
+type OrderShippingResponse struct {
+	MethodID    int64                   `json:"method_id" example:"1"`
+	MethodName  string                  `json:"method_name" example:"Correo a domicilio"`
+	Address     ShippingAddressResponse `json:"address"`
+	WeightGrams int64                   `json:"weight_grams" example:"500"`
+	Amount      money.Money             `json:"amount" example:"4500.00" swaggertype:"number"`
+	TaxRate     tax.Rate                `json:"tax_rate" example:"21" swaggertype:"number"`
+	Net         money.Money             `json:"net" example:"3719.01" swaggertype:"number"`
+	Tax         money.Money             `json:"tax" example:"780.99" swaggertype:"number"`
+}
+
+type ShippingAddressResponse struct {
+	RecipientName string `json:"recipient_name" example:"Juan Pérez"`
+	Phone         string `json:"phone" example:"+54 11 5555-1234"`
+	Street        string `json:"street" example:"Av. Corrientes"`
+	Number        string `json:"number" example:"1234"`
+	Apartment     string `json:"apartment,omitempty" example:"5B"`
+	City          string `json:"city" example:"CABA"`
+	Province      string `json:"province" example:"C"`
+	PostalCode    string `json:"postal_code" example:"C1043"`
+}
+
+func fromOrderShipping(sh *entity.OrderShipping) *OrderShippingResponse {
+	if sh == nil {
+		return nil
+	}
+	return &OrderShippingResponse{
+		MethodID:    sh.MethodID,
+		MethodName:  sh.MethodName,
+		Address:     ShippingAddressResponse(sh.Address),
+		WeightGrams: sh.WeightGrams,
+		Amount:      sh.Amount,
+		TaxRate:     sh.TaxRate,
+		Net:         sh.Net,
+		Tax:         sh.Tax,
+	}
+}
+
+type OrderItemResponse struct {
+	ID            int64       `json:"id" example:"1"`
+	ProductID     int64       `json:"product_id" example:"1"`
+	BarCode       string      `json:"bar_code" example:"7501234567890"`
+	Title         string      `json:"title" example:"Remera Básica Negra"`
+	Size          string      `json:"size" example:"M"`
+	Category      string      `json:"category" example:"Remeras"`
+	WeightGrams   int64       `json:"weight_grams" example:"250"`
+	Quantity      int64       `json:"quantity" example:"2"`
+	BaseCurrency  string      `json:"base_currency" example:"ARS"`
+	BaseUnitPrice money.Money `json:"base_unit_price" example:"2500.00" swaggertype:"number"`
+	UnitPrice     money.Money `json:"unit_price" example:"106.25" swaggertype:"number"`
+	PriceSource   string      `json:"price_source" example:"rate_table"`
+	LineTotal     money.Money `json:"line_total" example:"212.50" swaggertype:"number"`
+	Discount      money.Money `json:"discount" example:"21.25" swaggertype:"number"`
+	TaxRate       tax.Rate    `json:"tax_rate" example:"21" swaggertype:"number"`
+	NetAmount     money.Money `json:"net_amount" example:"158.06" swaggertype:"number"`
+	TaxAmount     money.Money `json:"tax_amount" example:"33.19" swaggertype:"number"`
+	// Ubicaciones de las que se prepara la línea
+	Allocations []StockAllocationResponse `json:"allocations,omitempty"`
+}
+
+type StockAllocationResponse struct {
+	LocationID   int64  `json:"location_id" example:"1"`
+	LocationCode string `json:"location_code" example:"DEP"`
+	Quantity     int64  `json:"quantity" example:"2"`
+}
+
+type OrderDiscountResponse struct {
+	PromotionID int64       `json:"promotion_id" example:"4"`
+	Code        string      `json:"code,omitempty" example:"VERANO10"`
+	Name        string      `json:"name" example:"Verano 10%"`
+	Description string      `json:"description" example:"10% off on Buzos"`
+	Amount      money.Money `json:"amount" example:"21.25" swaggertype:"number"`
+}
+
+type OrderResponse struct {
+	ID           int64                   `json:"id" example:"1"`
+	UserID       int64                   `json:"user_id" example:"1"`
+	Status       string                  `json:"status" example:"pending"`
+	Currency     string                  `json:"currency" example:"UYU"`
+	Subtotal     money.Money             `json:"subtotal" example:"212.50" swaggertype:"number"`
+	Discount     money.Money             `json:"discount" example:"21.25" swaggertype:"number"`
+	TaxMode      string                  `json:"tax_mode" example:"inclusive"`
+	Net          money.Money             `json:"net" example:"158.06" swaggertype:"number"`
+	Tax          money.Money             `json:"tax" example:"33.19" swaggertype:"number"`
+	TaxBreakdown []TaxGroupResponse      `json:"tax_breakdown"`
+	Total        money.Money             `json:"total" example:"191.25" swaggertype:"number"`
+	ExchangeRate *RateSnapshotResponse   `json:"exchange_rate,omitempty"`
+	Billing      BillingResponse         `json:"billing"`
+	Shipping     *OrderShippingResponse  `json:"shipping,omitempty"`
+	Items        []OrderItemResponse     `json:"items"`
+	Discounts    []OrderDiscountResponse `json:"discounts"`
+	PaidAt       *time.Time              `json:"paid_at,omitempty" example:"2025-01-15T10:05:00Z"`
+	UpdatedAt    time.Time               `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+	CreatedAt    time.Time               `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromOrderEntity(o entity.Order) OrderResponse {
+	items := make([]OrderItemResponse, 0, len(o.Items))
+	for _, it := range o.Items {
+		items = append(items, OrderItemResponse{
+			ID:            it.ID,
+			ProductID:     it.ProductID,
+			BarCode:       it.BarCode,
+			Title:         it.Title,
+			Size:          it.Size,
+			Category:      it.Category,
+			WeightGrams:   it.WeightGrams,
+			Quantity:      it.Quantity,
+			BaseCurrency:  string(it.BaseUnitPrice.Currency()),
+			BaseUnitPrice: it.BaseUnitPrice,
+			UnitPrice:     it.UnitPrice,
+			PriceSource:   it.PriceSource,
+			LineTotal:     it.LineTotal,
+			Discount:      it.Discount,
+			TaxRate:       it.TaxRate,
+			NetAmount:     it.NetAmount,
+			TaxAmount:     it.TaxAmount,
+			Allocations:   fromStockAllocations(it.Allocations),
+		})
+	}
+	discounts := make([]OrderDiscountResponse, 0, len(o.Discounts))
+	for _, d := range o.Discounts {
+		discounts = append(discounts, OrderDiscountResponse{
+			PromotionID: d.PromotionID,
+			Code:        d.Code,
+			Name:        d.Name,
+			Description: d.Description,
+			Amount:      d.Amount,
+		})
+	}
+	return OrderResponse{
+		ID:           o.ID,
+		UserID:       o.UserID,
+		Status:       string(o.Status),
+		Currency:     string(o.Currency),
+		Subtotal:     o.Subtotal,
+		Discount:     o.Discount,
+		TaxMode:      string(o.TaxMode),
+		Net:          o.Net(),
+		Tax:          o.Tax,
+		TaxBreakdown: fromTaxGroups(o.TaxBreakdown()),
+		Total:        o.Total,
+		ExchangeRate: FromRateSnapshotEntity(o.ExchangeRate),
+		Billing:      FromBillingEntity(o.Billing),
+		Shipping:     fromOrderShipping(o.Shipping),
+		Items:        items,
+		Discounts:    discounts,
+		PaidAt:       o.PaidAt,
+		UpdatedAt:    o.UpdatedAt,
+		CreatedAt:    o.CreatedAt,
+	}
+}
+
+func fromStockAllocations(allocations []entity.StockAllocation) []StockAllocationResponse {
+	if len(allocations) == 0 {
+		return nil
+	}
+	out := make([]StockAllocationResponse, 0, len(allocations))
+	for _, a := range allocations {
+		out = append(out, StockAllocationResponse(a))
+	}
+	return out
+}
+
+// RejectedPromotionResponse explica por qué un cupón o promoción no se aplicó
+type RejectedPromotionResponse struct {
+	PromotionID int64  `json:"promotion_id,omitempty" example:"7"`
+	Code        string `json:"code,omitempty" example:"BUZOS20"`
+	Name        string `json:"name,omitempty" example:"Buzos 20%"`
+	Reason      string `json:"reason" example:"minimum subtotal of ARS 20000.00 not reached"`
+}
+
+// CartQuoteResponse es la valuación de un carrito sin confirmar
+type CartQuoteResponse struct {
+	OrderResponse
+	Rejected        []RejectedPromotionResponse `json:"rejected"`
+	ShippingOptions []ShippingQuoteResponse     `json:"shipping_options"`
+}
+
+// ShippingQuoteResponse es un método de envío disponible para el carrito, en la moneda de la orden
+type ShippingQuoteResponse struct {
+	MethodID      int64       `json:"method_id" example:"1"`
+	Name          string      `json:"name" example:"Correo a domicilio"`
+	Description   string      `json:"description" example:"Entrega en el domicilio"`
+	MinDays       int         `json:"min_days" example:"3"`
+	MaxDays       int         `json:"max_days" example:"6"`
+	Price         money.Money `json:"price" example:"0.00" swaggertype:"number"`
+	OriginalPrice money.Money `json:"original_price" example:"4500.00" swaggertype:"number"`
+	Free          bool        `json:"free" example:"true"`
+}
+
+func FromCartQuote(q entity.CheckoutQuote) CartQuoteResponse {
+	resp := CartQuoteResponse{
+		OrderResponse:   FromOrderEntity(*q.Order),
+		Rejected:        make([]RejectedPromotionResponse, 0, len(q.Rejected)),
+		ShippingOptions: make([]ShippingQuoteResponse, 0, len(q.ShippingOptions)),
+	}
+	for _, r := range q.Rejected {
+		resp.Rejected = append(resp.Rejected, RejectedPromotionResponse(r))
+	}
+	for _, o := range q.ShippingOptions {
+		resp.ShippingOptions = append(resp.ShippingOptions, ShippingQuoteResponse(o))
+	}
+	return resp
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"core/internal/domain/entity"
+)
+
+type OutboxEventResponse struct {
+	ID            int64           `json:"id" example:"42"`
+	AggregateType string          `json:"aggregate_type" example:"product"`
+	AggregateID   int64           `json:"aggregate_id" example:"7"`
+	Event         string          `json:"event" example:"stock.changed"`
+	Data          json.RawMessage `json:"data" swaggertype:"object"`
+	Status        string          `json:"status" example:"pending"`
+	Attempts      int             `json:"attempts" example:"3"`
+	LastError     string          `json:"last_error,omitempty" example:"nats: failed to connect to NATS: connection refused"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at,omitempty" example:"2025-01-15T10:00:04Z"`
+	PublishedAt   *time.Time      `json:"published_at,omitempty" example:"2025-01-15T10:00:01Z"`
+	CreatedAt     time.Time       `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromOutboxEventEntity(e entity.OutboxEvent) OutboxEventResponse {
+	status := entity.OutboxPending
+	if e.PublishedAt != nil {
+		status = entity.OutboxPublished
+	}
+	return OutboxEventResponse{
+		ID:            e.ID,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Event:         string(e.Event),
+		Data:          e.Data,
+		Status:        string(status),
+		Attempts:      e.Attempts,
+		LastError:     e.LastError,
+		NextAttemptAt: e.NextAttemptAt,
+		PublishedAt:   e.PublishedAt,
+		CreatedAt:     e.CreatedAt,
+	}
+}
@@ -0,0 +1,193 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+)
+
+// OpenShiftRequest abre un turno de caja con el fondo inicial en la moneda base
+type OpenShiftRequest struct {
+	Register     string      `json:"register" example:"CAJA-1" validate:"required,max=50"`
+	LocationID   int64       `json:"location_id" example:"2" validate:"required"`
+	OpeningFloat json.Number `json:"opening_float" example:"20000.00" swaggertype:"number" validate:"required"`
+	Note         string      `json:"note,omitempty" example:"Turno mañana"`
+}
+
+func (r *OpenShiftRequest) ToEntity(cashierID int64) (*entity.RegisterShift, error) {
+	float, err := money.Parse(r.OpeningFloat.String(), money.Base, money.RoundUnnecessary)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.RegisterShift{
+		Register:     r.Register,
+		LocationID:   r.LocationID,
+		CashierID:    cashierID,
+		OpeningFloat: float,
+		Note:         r.Note,
+	}, nil
+}
+
+// CloseShiftRequest cierra el turno con el efectivo contado en la caja
+type CloseShiftRequest struct {
+	CountedCash json.Number `json:"counted_cash" example:"152300.00" swaggertype:"number" validate:"required"`
+	Note        string      `json:"note,omitempty" example:"Faltante por vuelto mal dado"`
+}
+
+type RegisterShiftResponse struct {
+	ID           int64        `json:"id" example:"1"`
+	Register     string       `json:"register" example:"CAJA-1"`
+	LocationID   int64        `json:"location_id" example:"2"`
+	LocationCode string       `json:"location_code" example:"LOCAL"`
+	CashierID    int64        `json:"cashier_id" example:"7"`
+	Status       string       `json:"status" example:"open"`
+	OpeningFloat money.Money  `json:"opening_float" example:"20000.00" swaggertype:"number"`
+	ExpectedCash *money.Money `json:"expected_cash,omitempty" example:"152500.00" swaggertype:"number"`
+	CountedCash  *money.Money `json:"counted_cash,omitempty" example:"152300.00" swaggertype:"number"`
+	Note         string       `json:"note,omitempty" example:"Turno mañana"`
+	OpenedAt     time.Time    `json:"opened_at" example:"2025-01-15T09:00:00Z"`
+	ClosedAt     *time.Time   `json:"closed_at,omitempty" example:"2025-01-15T17:00:00Z"`
+}
+
+func FromRegisterShiftEntity(sh entity.RegisterShift) RegisterShiftResponse {
+	return RegisterShiftResponse{
+		ID:           sh.ID,
+		Register:     sh.Register,
+		LocationID:   sh.LocationID,
+		LocationCode: sh.LocationCode,
+		CashierID:    sh.CashierID,
+		Status:       string(sh.Status),
+		OpeningFloat: sh.OpeningFloat,
+		ExpectedCash: sh.ExpectedCash,
+		CountedCash:  sh.CountedCash,
+		Note:         sh.Note,
+		OpenedAt:     sh.OpenedAt,
+		ClosedAt:     sh.ClosedAt,
+	}
+}
+
+// ShiftReportResponse es el arqueo: el efectivo esperado es el fondo más lo cobrado en
+// efectivo neto del vuelto. Una diferencia negativa es faltante.
+type ShiftReportResponse struct {
+	Shift        RegisterShiftResponse `json:"shift"`
+	Sales        int64                 `json:"sales" example:"42"`
+	Total        money.Money           `json:"total" example:"310000.00" swaggertype:"number"`
+	CashSales    money.Money           `json:"cash_sales" example:"132500.00" swaggertype:"number"`
+	CardSales    money.Money           `json:"card_sales" example:"177500.00" swaggertype:"number"`
+	ExpectedCash money.Money           `json:"expected_cash" example:"152500.00" swaggertype:"number"`
+	CountedCash  *money.Money          `json:"counted_cash,omitempty" example:"152300.00" swaggertype:"number"`
+	Difference   *money.Money          `json:"difference,omitempty" example:"-200.00" swaggertype:"number"`
+}
+
+func FromShiftReportEntity(r entity.ShiftReport) ShiftReportResponse {
+	return ShiftReportResponse{
+		Shift:        FromRegisterShiftEntity(r.Shift),
+		Sales:        r.Totals.Sales,
+		Total:        r.Totals.Total,
+		CashSales:    r.Totals.Cash,
+		CardSales:    r.Totals.Card,
+		ExpectedCash: r.ExpectedCash,
+		CountedCash:  r.CountedCash,
+		Difference:   r.Difference,
+	}
+}
+
+// PosScanRequest carga un código escaneado en la venta
+type PosScanRequest struct {
+	BarCode  string `json:"bar_code" example:"7791234567896" validate:"required"`
+	Quantity int64  `json:"quantity,omitempty" example:"1"` // vacío = 1, negativo quita unidades
+}
+
+// PosCheckoutRequest cobra la venta. En efectivo el monto es lo que entrega el cliente
+// y el vuelto se calcula; la tarjeta no puede superar el total.
+type PosCheckoutRequest struct {
+	Payments []PosPaymentRequest `json:"payments" validate:"required,min=1,max=10"`
+	Billing  *BillingRequest     `json:"billing,omitempty"`
+}
+
+type PosPaymentRequest struct {
+	Method    string      `json:"method" example:"cash" validate:"required,oneof=cash card"`
+	Amount    json.Number `json:"amount" example:"20000.00" swaggertype:"number" validate:"required"`
+	Reference string      `json:"reference,omitempty" example:"AUT 123456"` // cupón de la tarjeta
+}
+
+func (r *PosCheckoutRequest) ToEntities() ([]entity.PosPayment, entity.BillingInfo, error) {
+	payments := make([]entity.PosPayment, 0, len(r.Payments))
+	for _, p := range r.Payments {
+		amount, err := money.Parse(p.Amount.String(), money.Base, money.RoundUnnecessary)
+		if err != nil {
+			return nil, entity.BillingInfo{}, err
+		}
+		payments = append(payments, entity.PosPayment{Method: entity.PaymentMethod(p.Method), Amount: amount, Reference: p.Reference})
+	}
+	var billing entity.BillingInfo
+	if r.Billing != nil {
+		billing = entity.BillingInfo{TaxID: r.Billing.TaxID, Name: r.Billing.Name, Address: r.Billing.Address}
+	}
+	return payments, billing, nil
+}
+
+type PosSaleLineResponse struct {
+	ProductID int64  `json:"product_id" example:"42"`
+	BarCode   string `json:"bar_code" example:"7791234567896"`
+	Title     string `json:"title" example:"Remera Básica Negra"`
+	Size      string `json:"size" example:"M"`
+	Quantity  int64  `json:"quantity" example:"2"`
+}
+
+type PosPaymentResponse struct {
+	ID        int64       `json:"id" example:"1"`
+	Method    string      `json:"method" example:"cash"`
+	Amount    money.Money `json:"amount" example:"20000.00" swaggertype:"number"`
+	Reference string      `json:"reference,omitempty" example:"AUT 123456"`
+}
+
+// PosSaleResponse es la venta con su valuación vigente mientras está abierta o con la
+// orden confirmada una vez cobrada
+type PosSaleResponse struct {
+	ID          int64                 `json:"id" example:"1"`
+	ShiftID     int64                 `json:"shift_id" example:"1"`
+	CashierID   int64                 `json:"cashier_id" example:"7"`
+	Status      string                `json:"status" example:"open"`
+	OrderID     *int64                `json:"order_id,omitempty" example:"120"`
+	Lines       []PosSaleLineResponse `json:"lines"`
+	Payments    []PosPaymentResponse  `json:"payments"`
+	Total       *money.Money          `json:"total,omitempty" example:"18500.00" swaggertype:"number"`
+	Change      *money.Money          `json:"change,omitempty" example:"1500.00" swaggertype:"number"`
+	Order       *OrderResponse        `json:"order,omitempty"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty" example:"2025-01-15T10:05:00Z"`
+	UpdatedAt   time.Time             `json:"updated_at" example:"2025-01-15T10:05:00Z"`
+	CreatedAt   time.Time             `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromPosSaleEntity(s entity.PosSale) PosSaleResponse {
+	lines := make([]PosSaleLineResponse, 0, len(s.Lines))
+	for _, l := range s.Lines {
+		lines = append(lines, PosSaleLineResponse(l))
+	}
+	payments := make([]PosPaymentResponse, 0, len(s.Payments))
+	for _, p := range s.Payments {
+		payments = append(payments, PosPaymentResponse{ID: p.ID, Method: string(p.Method), Amount: p.Amount, Reference: p.Reference})
+	}
+	resp := PosSaleResponse{
+		ID:          s.ID,
+		ShiftID:     s.ShiftID,
+		CashierID:   s.CashierID,
+		Status:      string(s.Status),
+		OrderID:     s.OrderID,
+		Lines:       lines,
+		Payments:    payments,
+		Total:       s.Total,
+		Change:      s.Change,
+		CompletedAt: s.CompletedAt,
+		UpdatedAt:   s.UpdatedAt,
+		CreatedAt:   s.CreatedAt,
+	}
+	if s.Order != nil {
+		order := FromOrderEntity(*s.Order)
+		resp.Order = &order
+	}
+	return resp
+}
@@ -0,0 +1,101 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"time"
+)
+
+// UpdatePricingRequest reemplaza el precio de referencia y la oferta de un producto.
+// Enviar un campo en null lo elimina.
+type UpdatePricingRequest struct {
+	CompareAtPrice *money.Money `json:"compare_at_price" example:"3000.00" swaggertype:"number"`
+	SalePrice      *money.Money `json:"sale_price" example:"2000.00" swaggertype:"number"`
+	SaleStartsAt   *time.Time   `json:"sale_starts_at" example:"2025-01-17T00:00:00Z"`
+	SaleEndsAt     *time.Time   `json:"sale_ends_at" example:"2025-01-19T23:59:59Z"`
+}
+
+// ToEntity convierte el request en una entidad Product con solo los campos de precio.
+func (r *UpdatePricingRequest) ToEntity(productID int64) *entity.Product {
+	return &entity.Product{
+		ID:             productID,
+		CompareAtPrice: r.CompareAtPrice,
+		SalePrice:      r.SalePrice,
+		SaleStartsAt:   r.SaleStartsAt,
+		SaleEndsAt:     r.SaleEndsAt,
+	}
+}
+
+// PricingResponse muestra la configuración de precios de un producto (backoffice).
+type PricingResponse struct {
+	ProductID          int64        `json:"product_id" example:"1"`
+	Currency           string       `json:"currency" example:"ARS"`
+	UnitPrice          money.Money  `json:"unit_price" example:"2500.00" swaggertype:"number"`
+	CompareAtPrice     *money.Money `json:"compare_at_price,omitempty" example:"3000.00" swaggertype:"number"`
+	SalePrice          *money.Money `json:"sale_price,omitempty" example:"2000.00" swaggertype:"number"`
+	SaleStartsAt       *time.Time   `json:"sale_starts_at,omitempty" example:"2025-01-17T00:00:00Z"`
+	SaleEndsAt         *time.Time   `json:"sale_ends_at,omitempty" example:"2025-01-19T23:59:59Z"`
+	EffectivePrice     money.Money  `json:"effective_price" example:"2000.00" swaggertype:"number"`
+	OriginalPrice      money.Money  `json:"original_price" example:"3000.00" swaggertype:"number"`
+	DiscountPercentage float64      `json:"discount_percentage" example:"33.33"`
+}
+
+func FromPricingEntity(p entity.Product) PricingResponse {
+	now := time.Now()
+	return PricingResponse{
+		ProductID:          p.ID,
+		Currency:           string(p.UnitPrice.Currency()),
+		UnitPrice:          p.UnitPrice,
+		CompareAtPrice:     p.CompareAtPrice,
+		SalePrice:          p.SalePrice,
+		SaleStartsAt:       p.SaleStartsAt,
+		SaleEndsAt:         p.SaleEndsAt,
+		EffectivePrice:     p.EffectivePrice(now),
+		OriginalPrice:      p.OriginalPrice(),
+		DiscountPercentage: p.DiscountPercentage(now),
+	}
+}
+
+// CreatePriceChangeRequest programa un nuevo precio de lista para un producto.
+type CreatePriceChangeRequest struct {
+	UnitPrice      money.Money  `json:"unit_price" example:"2800.00" swaggertype:"number" validate:"required,min=0"`
+	CompareAtPrice *money.Money `json:"compare_at_price,omitempty" example:"3200.00" swaggertype:"number"`
+	EffectiveAt    time.Time    `json:"effective_at" example:"2025-02-01T00:00:00Z" validate:"required"`
+}
+
+func (r *CreatePriceChangeRequest) ToEntity(productID int64) *entity.PriceChange {
+	return &entity.PriceChange{
+		ProductID:      productID,
+		UnitPrice:      r.UnitPrice,
+		CompareAtPrice: r.CompareAtPrice,
+		EffectiveAt:    r.EffectiveAt,
+	}
+}
+
+type PriceChangeResponse struct {
+	ID             int64        `json:"id" example:"1"`
+	ProductID      int64        `json:"product_id" example:"1"`
+	Currency       string       `json:"currency" example:"ARS"`
+	UnitPrice      money.Money  `json:"unit_price" example:"2800.00" swaggertype:"number"`
+	CompareAtPrice *money.Money `json:"compare_at_price,omitempty" example:"3200.00" swaggertype:"number"`
+	EffectiveAt    time.Time    `json:"effective_at" example:"2025-02-01T00:00:00Z"`
+	AppliedAt      *time.Time   `json:"applied_at,omitempty" example:"2025-02-01T00:01:00Z"`
+	FailedAt       *time.Time   `json:"failed_at,omitempty" example:"2025-02-01T00:01:00Z"`
+	Failure        string       `json:"failure,omitempty" example:"product is priced in USD, change is in ARS"`
+	CreatedAt      time.Time    `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromPriceChangeEntity(c entity.PriceChange) PriceChangeResponse {
+	return PriceChangeResponse{
+		ID:             c.ID,
+		ProductID:      c.ProductID,
+		Currency:       string(c.UnitPrice.Currency()),
+		UnitPrice:      c.UnitPrice,
+		CompareAtPrice: c.CompareAtPrice,
+		EffectiveAt:    c.EffectiveAt,
+		AppliedAt:      c.AppliedAt,
+		FailedAt:       c.FailedAt,
+		Failure:        c.Failure,
+		CreatedAt:      c.CreatedAt,
+	}
+}
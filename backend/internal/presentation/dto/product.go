@@ -1,17 +1,25 @@
 package dto
 
-import "core/internal/domain/entity"
+import (
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"time"
+)
 
 // CreateProductRequest representa el cuerpo de la petición para crear un producto.
 // No incluye campos generados por el servidor como ID, UpdatedAt, CreatedAt.
 type CreateProductRequest struct {
-	BarCode     int64   `json:"bar_code" example:"7501234567890" validate:"required"`
-	Title       string  `json:"title" example:"Remera Básica Negra" validate:"required"`
-	Description string  `json:"description" example:"Remera de algodón 100% color negro, cuello redondo" validate:"required"`
-	Stock       int64   `json:"stock" example:"50" validate:"required,min=0"`
-	Size        string  `json:"size" example:"M" validate:"required,oneof=S M L XL XXL"` // S,M,L,XL,XXL
-	Category    string  `json:"category" example:"Remeras" validate:"required"`
-	UnitPrice   float64 `json:"unit_price" example:"2500.00" validate:"required,min=0"`
+	BarCode     string      `json:"bar_code" example:"7501234567890" validate:"required"` // EAN-13 o UPC-A
+	Title       string      `json:"title" example:"Remera Básica Negra" validate:"required"`
+	Description string      `json:"description" example:"Remera de algodón 100% color negro, cuello redondo" validate:"required"`
+	Stock       int64       `json:"stock" example:"50" validate:"required,min=0"`
+	Size        string      `json:"size" example:"M" validate:"required,oneof=S M L XL XXL"` // S,M,L,XL,XXL
+	Category    string      `json:"category" example:"Remeras" validate:"required"`
+	WeightGrams int64       `json:"weight_grams,omitempty" example:"250" validate:"min=0"`
+	UnitPrice   money.Money `json:"unit_price" example:"2500.00" swaggertype:"number" validate:"required,min=0"`
+	// Si se indica PublishAt el producto queda programado, si no queda en borrador
+	PublishAt   *time.Time `json:"publish_at,omitempty" example:"2025-01-15T10:00:00Z"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty" example:"2025-02-15T10:00:00Z"`
 }
 
 // ToEntity convierte un CreateProductRequest a una entidad Product.
@@ -23,20 +31,24 @@ func (r *CreateProductRequest) ToEntity() *entity.Product {
 		Stock:       r.Stock,
 		Size:        r.Size,
 		Category:    r.Category,
+		WeightGrams: r.WeightGrams,
 		UnitPrice:   r.UnitPrice,
+		PublishAt:   r.PublishAt,
+		UnpublishAt: r.UnpublishAt,
 	}
 }
 
 // UpdateProductRequest representa el cuerpo de la petición para actualizar un producto.
 // Todos los campos son opcionales para permitir actualizaciones parciales.
 type UpdateProductRequest struct {
-	BarCode     *int64   `json:"bar_code,omitempty" example:"7501234567890"`
-	Title       *string  `json:"title,omitempty" example:"Remera Básica Negra"`
-	Description *string  `json:"description,omitempty" example:"Remera de algodón 100% color negro, cuello redondo"`
-	Stock       *int64   `json:"stock,omitempty" example:"50"`
-	Size        *string  `json:"size,omitempty" example:"M"` // S,M,L,XL,XXL
-	Category    *string  `json:"category,omitempty" example:"Remeras"`
-	UnitPrice   *float64 `json:"unit_price,omitempty" example:"2500.00"`
+	BarCode     *string      `json:"bar_code,omitempty" example:"7501234567890"`
+	Title       *string      `json:"title,omitempty" example:"Remera Básica Negra"`
+	Description *string      `json:"description,omitempty" example:"Remera de algodón 100% color negro, cuello redondo"`
+	Stock       *int64       `json:"stock,omitempty" example:"50"`
+	Size        *string      `json:"size,omitempty" example:"M"` // S,M,L,XL,XXL
+	Category    *string      `json:"category,omitempty" example:"Remeras"`
+	WeightGrams *int64       `json:"weight_grams,omitempty" example:"250"`
+	UnitPrice   *money.Money `json:"unit_price,omitempty" example:"2500.00" swaggertype:"number"`
 }
 
 // ApplyToEntity aplica los campos no nulos del DTO a una entidad Product existente.
@@ -59,6 +71,9 @@ func (r *UpdateProductRequest) ApplyToEntity(p *entity.Product) {
 	if r.Category != nil {
 		p.Category = *r.Category
 	}
+	if r.WeightGrams != nil {
+		p.WeightGrams = *r.WeightGrams
+	}
 	if r.UnitPrice != nil {
 		p.UnitPrice = *r.UnitPrice
 	}
@@ -67,21 +82,34 @@ func (r *UpdateProductRequest) ApplyToEntity(p *entity.Product) {
 // ProductResponse representa la estructura de un producto en las respuestas de la API.
 // Podría ser idéntico a entity.Product o tener campos adicionales/omitidos.
 type ProductResponse struct {
-	ID          int64   `json:"id" example:"1"`
-	BarCode     int64   `json:"bar_code" example:"7501234567890"`
-	Title       string  `json:"title" example:"Remera Básica Negra"`
-	Description string  `json:"description" example:"Remera de algodón 100% color negro, cuello redondo"`
-	Stock       int64   `json:"stock" example:"50"`
-	Size        string  `json:"size" example:"M"`
-	Category    string  `json:"category" example:"Remeras"`
-	UnitPrice   float64 `json:"unit_price" example:"2500.00"`
+	ID          int64       `json:"id" example:"1"`
+	BarCode     string      `json:"bar_code" example:"7501234567890"`
+	Title       string      `json:"title" example:"Remera Básica Negra"`
+	Description string      `json:"description" example:"Remera de algodón 100% color negro, cuello redondo"`
+	Stock       int64       `json:"stock" example:"50"`
+	Size        string      `json:"size" example:"M"`
+	Category    string      `json:"category" example:"Remeras"`
+	WeightGrams int64       `json:"weight_grams" example:"250"`
+	UnitPrice   money.Money `json:"unit_price" example:"2500.00" swaggertype:"number"`
+	Currency    string      `json:"currency" example:"ARS"`
+	PriceSource string      `json:"price_source,omitempty" example:"rate_table"` // base, price_list o rate_table
+	Status      string      `json:"status" example:"published"`
+	PublishAt   *time.Time  `json:"publish_at,omitempty" example:"2025-01-15T10:00:00Z"`
+	UnpublishAt *time.Time  `json:"unpublish_at,omitempty" example:"2025-02-15T10:00:00Z"`
+	// Precio vigente, precio de referencia y descuento calculados al momento de la respuesta
+	EffectivePrice     money.Money `json:"effective_price" example:"2000.00" swaggertype:"number"`
+	OriginalPrice      money.Money `json:"original_price" example:"2500.00" swaggertype:"number"`
+	DiscountPercentage float64     `json:"discount_percentage" example:"20"`
+	OnSale             bool        `json:"on_sale" example:"true"`
+	SaleEndsAt         *time.Time  `json:"sale_ends_at,omitempty" example:"2025-01-19T23:59:59Z"`
 	// UpdatedAt   time.Time `json:"updated_at"` // Podrías omitirlos si no son relevantes para el cliente
 	// CreatedAt   time.Time `json:"created_at"`
 }
 
 // FromEntity convierte una entidad Product a un ProductResponse.
 func FromEntity(p entity.Product) ProductResponse {
-	return ProductResponse{
+	now := time.Now()
+	resp := ProductResponse{
 		ID:          p.ID,
 		BarCode:     p.BarCode,
 		Title:       p.Title,
@@ -89,6 +117,40 @@ func FromEntity(p entity.Product) ProductResponse {
 		Stock:       p.Stock,
 		Size:        p.Size,
 		Category:    p.Category,
+		WeightGrams: p.WeightGrams,
 		UnitPrice:   p.UnitPrice,
+		Currency:    string(p.UnitPrice.Currency()),
+		Status:      string(p.Status),
+		PublishAt:   p.PublishAt,
+		UnpublishAt: p.UnpublishAt,
+
+		EffectivePrice:     p.EffectivePrice(now),
+		OriginalPrice:      p.OriginalPrice(),
+		DiscountPercentage: p.DiscountPercentage(now),
+		OnSale:             p.IsOnSale(now),
+	}
+	if resp.OnSale {
+		resp.SaleEndsAt = p.SaleEndsAt
 	}
+	return resp
+}
+
+// FromLocalizedEntity convierte un producto con precios ya expresados en la moneda
+// de visualización, indicando de dónde salió el precio.
+func FromLocalizedEntity(p entity.Product, priceSource string) ProductResponse {
+	resp := FromEntity(p)
+	resp.PriceSource = priceSource
+	return resp
+}
+
+// ChangeProductStatusRequest representa el cambio de estado de publicación de un producto.
+type ChangeProductStatusRequest struct {
+	Status      string     `json:"status" example:"scheduled" validate:"required,oneof=draft scheduled published archived"`
+	PublishAt   *time.Time `json:"publish_at,omitempty" example:"2025-01-15T10:00:00Z"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty" example:"2025-02-15T10:00:00Z"`
+}
+
+// GeneratedBarCodesResponse son códigos internos libres para productos nuevos
+type GeneratedBarCodesResponse struct {
+	BarCodes []string `json:"bar_codes" example:"2000000000015"`
 }
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"core/internal/domain/entity"
+)
+
+// ProductImportResponse es el estado de una importación. Created y Updated cuentan lo
+// que se haría cuando la importación es de prueba.
+type ProductImportResponse struct {
+	ID            int64                    `json:"id" example:"1"`
+	Filename      string                   `json:"filename" example:"catalogo.xlsx"`
+	Format        string                   `json:"format" example:"xlsx"`
+	DryRun        bool                     `json:"dry_run" example:"false"`
+	Mapping       map[string]string        `json:"mapping,omitempty"`
+	Status        string                   `json:"status" example:"running"`
+	TotalRows     int                      `json:"total_rows" example:"1200"`
+	ProcessedRows int                      `json:"processed_rows" example:"400"`
+	Progress      float64                  `json:"progress" example:"33.3"` // porcentaje
+	Created       int                      `json:"created" example:"350"`
+	Updated       int                      `json:"updated" example:"45"`
+	Failed        int                      `json:"failed" example:"5"`
+	Error         string                   `json:"error,omitempty" example:""`
+	CreatedBy     int64                    `json:"created_by" example:"1"`
+	Errors        []ImportRowErrorResponse `json:"errors,omitempty"`
+	StartedAt     *time.Time               `json:"started_at,omitempty" example:"2025-01-15T10:00:05Z"`
+	FinishedAt    *time.Time               `json:"finished_at,omitempty" example:"2025-01-15T10:02:00Z"`
+	CreatedAt     time.Time                `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+type ImportRowErrorResponse struct {
+	Row     int    `json:"row" example:"14"`
+	Column  string `json:"column,omitempty" example:"unit_price"`
+	BarCode string `json:"bar_code,omitempty" example:"7791234567896"`
+	Message string `json:"message" example:"unit_price must be an amount >= 0 with at most the currency decimals"`
+}
+
+func FromProductImportEntity(imp entity.ProductImport) ProductImportResponse {
+	resp := ProductImportResponse{
+		ID:            imp.ID,
+		Filename:      imp.Filename,
+		Format:        string(imp.Format),
+		DryRun:        imp.DryRun,
+		Mapping:       imp.Mapping,
+		Status:        string(imp.Status),
+		TotalRows:     imp.TotalRows,
+		ProcessedRows: imp.ProcessedRows,
+		Created:       imp.Created,
+		Updated:       imp.Updated,
+		Failed:        imp.Failed,
+		Error:         imp.Error,
+		CreatedBy:     imp.CreatedBy,
+		StartedAt:     imp.StartedAt,
+		FinishedAt:    imp.FinishedAt,
+		CreatedAt:     imp.CreatedAt,
+	}
+	if imp.TotalRows > 0 {
+		resp.Progress = float64(imp.ProcessedRows*1000/imp.TotalRows) / 10
+	}
+	for _, e := range imp.Errors {
+		resp.Errors = append(resp.Errors, ImportRowErrorResponse(e))
+	}
+	return resp
+}
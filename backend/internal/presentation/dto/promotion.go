@@ -0,0 +1,131 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"encoding/json"
+	"time"
+)
+
+// PromotionRequest crea o reemplaza una promoción. Sin code se aplica automáticamente;
+// con code es un cupón. Los montos se interpretan en currency (ARS por defecto).
+type PromotionRequest struct {
+	Name             string      `json:"name" example:"Buzos 20%" validate:"required"`
+	Code             *string     `json:"code,omitempty" example:"BUZOS20"`
+	Type             string      `json:"type" example:"percentage" validate:"required,oneof=percentage fixed buy_x_get_y"`
+	PercentOff       int64       `json:"percent_off,omitempty" example:"20"`
+	AmountOff        json.Number `json:"amount_off,omitempty" example:"500.00" swaggertype:"number"`
+	BuyQuantity      int64       `json:"buy_quantity,omitempty" example:"2"`
+	GetQuantity      int64       `json:"get_quantity,omitempty" example:"1"`
+	Category         string      `json:"category,omitempty" example:"Buzos"`
+	MinSubtotal      json.Number `json:"min_subtotal,omitempty" example:"20000.00" swaggertype:"number"`
+	Currency         string      `json:"currency,omitempty" example:"ARS"`
+	UsageLimit       *int64      `json:"usage_limit,omitempty" example:"100"`
+	PerCustomerLimit *int64      `json:"per_customer_limit,omitempty" example:"1"`
+	StartsAt         *time.Time  `json:"starts_at,omitempty" example:"2025-06-01T00:00:00Z"`
+	EndsAt           *time.Time  `json:"ends_at,omitempty" example:"2025-06-30T23:59:59Z"`
+	Priority         int         `json:"priority" example:"10"`
+	Exclusive        bool        `json:"exclusive" example:"false"`
+	Active           *bool       `json:"active,omitempty" example:"true"` // por defecto true
+}
+
+func (r *PromotionRequest) ToEntity() (*entity.Promotion, error) {
+	cur := money.Base
+	if r.Currency != "" {
+		var err error
+		if cur, err = money.ParseCurrency(r.Currency); err != nil {
+			return nil, err
+		}
+	}
+	amountOff, err := parseOptionalMoney(r.AmountOff, cur)
+	if err != nil {
+		return nil, err
+	}
+	minSubtotal, err := parseOptionalMoney(r.MinSubtotal, cur)
+	if err != nil {
+		return nil, err
+	}
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return &entity.Promotion{
+		Name:             r.Name,
+		Code:             r.Code,
+		Type:             entity.PromotionType(r.Type),
+		PercentOff:       r.PercentOff,
+		AmountOff:        amountOff,
+		BuyQuantity:      r.BuyQuantity,
+		GetQuantity:      r.GetQuantity,
+		Category:         r.Category,
+		MinSubtotal:      minSubtotal,
+		Currency:         cur,
+		UsageLimit:       r.UsageLimit,
+		PerCustomerLimit: r.PerCustomerLimit,
+		StartsAt:         r.StartsAt,
+		EndsAt:           r.EndsAt,
+		Priority:         r.Priority,
+		Exclusive:        r.Exclusive,
+		Active:           active,
+	}, nil
+}
+
+// parseOptionalMoney interpreta un monto opcional con los decimales de la moneda
+func parseOptionalMoney(n json.Number, cur money.Currency) (*money.Money, error) {
+	if n == "" {
+		return nil, nil
+	}
+	m, err := money.Parse(n.String(), cur, money.RoundUnnecessary)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+type PromotionResponse struct {
+	ID               int64        `json:"id" example:"4"`
+	Name             string       `json:"name" example:"Buzos 20%"`
+	Code             *string      `json:"code,omitempty" example:"BUZOS20"`
+	Type             string       `json:"type" example:"percentage"`
+	PercentOff       int64        `json:"percent_off,omitempty" example:"20"`
+	AmountOff        *money.Money `json:"amount_off,omitempty" example:"500.00" swaggertype:"number"`
+	BuyQuantity      int64        `json:"buy_quantity,omitempty" example:"2"`
+	GetQuantity      int64        `json:"get_quantity,omitempty" example:"1"`
+	Category         string       `json:"category,omitempty" example:"Buzos"`
+	MinSubtotal      *money.Money `json:"min_subtotal,omitempty" example:"20000.00" swaggertype:"number"`
+	Currency         string       `json:"currency" example:"ARS"`
+	UsageLimit       *int64       `json:"usage_limit,omitempty" example:"100"`
+	PerCustomerLimit *int64       `json:"per_customer_limit,omitempty" example:"1"`
+	StartsAt         *time.Time   `json:"starts_at,omitempty" example:"2025-06-01T00:00:00Z"`
+	EndsAt           *time.Time   `json:"ends_at,omitempty" example:"2025-06-30T23:59:59Z"`
+	Priority         int          `json:"priority" example:"10"`
+	Exclusive        bool         `json:"exclusive" example:"false"`
+	Active           bool         `json:"active" example:"true"`
+	UpdatedAt        time.Time    `json:"updated_at" example:"2025-05-20T10:00:00Z"`
+	CreatedAt        time.Time    `json:"created_at" example:"2025-05-20T10:00:00Z"`
+}
+
+func FromPromotionEntity(p entity.Promotion) PromotionResponse {
+	return PromotionResponse{
+		ID:               p.ID,
+		Name:             p.Name,
+		Code:             p.Code,
+		Type:             string(p.Type),
+		PercentOff:       p.PercentOff,
+		AmountOff:        p.AmountOff,
+		BuyQuantity:      p.BuyQuantity,
+		GetQuantity:      p.GetQuantity,
+		Category:         p.Category,
+		MinSubtotal:      p.MinSubtotal,
+		Currency:         string(p.Currency),
+		UsageLimit:       p.UsageLimit,
+		PerCustomerLimit: p.PerCustomerLimit,
+		StartsAt:         p.StartsAt,
+		EndsAt:           p.EndsAt,
+		Priority:         p.Priority,
+		Exclusive:        p.Exclusive,
+		Active:           p.Active,
+		UpdatedAt:        p.UpdatedAt,
+		CreatedAt:        p.CreatedAt,
+	}
+}
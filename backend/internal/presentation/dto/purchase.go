@@ -0,0 +1,258 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+)
+
+type SupplierRequest struct {
+	Name    string `json:"name" example:"Textil del Sur SRL" validate:"required"`
+	TaxID   string `json:"tax_id,omitempty" example:"30-71234567-1"` // CUIT
+	Email   string `json:"email,omitempty" example:"ventas@textildelsur.com.ar"`
+	Phone   string `json:"phone,omitempty" example:"+54 11 4321-0000"`
+	Address string `json:"address,omitempty" example:"Av. Warnes 1234, CABA"`
+	Notes   string `json:"notes,omitempty" example:"Entrega martes y jueves"`
+	Active  *bool  `json:"active,omitempty" example:"true"` // por defecto true
+}
+
+func (r *SupplierRequest) ToEntity() *entity.Supplier {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return &entity.Supplier{
+		Name:    r.Name,
+		TaxID:   r.TaxID,
+		Email:   r.Email,
+		Phone:   r.Phone,
+		Address: r.Address,
+		Notes:   r.Notes,
+		Active:  active,
+	}
+}
+
+type SupplierResponse struct {
+	ID        int64     `json:"id" example:"1"`
+	Name      string    `json:"name" example:"Textil del Sur SRL"`
+	TaxID     string    `json:"tax_id,omitempty" example:"30712345671"`
+	Email     string    `json:"email,omitempty" example:"ventas@textildelsur.com.ar"`
+	Phone     string    `json:"phone,omitempty" example:"+54 11 4321-0000"`
+	Address   string    `json:"address,omitempty" example:"Av. Warnes 1234, CABA"`
+	Notes     string    `json:"notes,omitempty" example:"Entrega martes y jueves"`
+	Active    bool      `json:"active" example:"true"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromSupplierEntity(s entity.Supplier) SupplierResponse {
+	return SupplierResponse(s)
+}
+
+// CreatePurchaseOrderRequest crea una orden de compra en borrador. Los costos son en la moneda base.
+type CreatePurchaseOrderRequest struct {
+	SupplierID int64                      `json:"supplier_id" example:"1" validate:"required"`
+	LocationID int64                      `json:"location_id,omitempty" example:"2"` // vacío = ubicación por defecto
+	Note       string                     `json:"note,omitempty" example:"Temporada invierno"`
+	ExpectedAt *time.Time                 `json:"expected_at,omitempty" example:"2025-02-01T00:00:00Z"`
+	Lines      []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1,max=200"`
+}
+
+type PurchaseOrderLineRequest struct {
+	ProductID int64       `json:"product_id" example:"42" validate:"required"`
+	Quantity  int64       `json:"quantity" example:"24" validate:"required,min=1"`
+	UnitCost  json.Number `json:"unit_cost" example:"4200.00" swaggertype:"number" validate:"required"`
+}
+
+func (r *CreatePurchaseOrderRequest) ToEntity() (*entity.PurchaseOrder, error) {
+	po := &entity.PurchaseOrder{
+		SupplierID: r.SupplierID,
+		LocationID: r.LocationID,
+		Note:       r.Note,
+		ExpectedAt: r.ExpectedAt,
+		Lines:      make([]entity.PurchaseOrderLine, 0, len(r.Lines)),
+	}
+	for _, l := range r.Lines {
+		cost, err := money.Parse(l.UnitCost.String(), money.Base, money.RoundUnnecessary)
+		if err != nil {
+			return nil, err
+		}
+		po.Lines = append(po.Lines, entity.PurchaseOrderLine{ProductID: l.ProductID, Quantity: l.Quantity, UnitCost: cost})
+	}
+	return po, nil
+}
+
+type UpdatePurchaseOrderStatusRequest struct {
+	Status string `json:"status" example:"ordered" validate:"required,oneof=ordered cancelled"`
+}
+
+// ReceivePurchaseRequest registra una entrega del proveedor
+type ReceivePurchaseRequest struct {
+	Note  string                       `json:"note,omitempty" example:"Remito 0001-00004567"`
+	Lines []ReceivePurchaseLineRequest `json:"lines" validate:"required,min=1,max=200"`
+}
+
+type ReceivePurchaseLineRequest struct {
+	LineID   int64       `json:"line_id" example:"7" validate:"required"`
+	Quantity int64       `json:"quantity" example:"12" validate:"required,min=1"`
+	UnitCost json.Number `json:"unit_cost,omitempty" example:"4350.00" swaggertype:"number"` // vacío = el costo de la orden
+}
+
+func (r *ReceivePurchaseRequest) ToEntity(orderID, actorID int64) (*entity.PurchaseReceipt, error) {
+	rc := &entity.PurchaseReceipt{
+		PurchaseOrderID: orderID,
+		ActorID:         actorID,
+		Note:            r.Note,
+		Lines:           make([]entity.PurchaseReceiptLine, 0, len(r.Lines)),
+	}
+	for _, l := range r.Lines {
+		line := entity.PurchaseReceiptLine{LineID: l.LineID, Quantity: l.Quantity}
+		if l.UnitCost != "" {
+			cost, err := money.Parse(l.UnitCost.String(), money.Base, money.RoundUnnecessary)
+			if err != nil {
+				return nil, err
+			}
+			line.UnitCost = &cost
+		}
+		rc.Lines = append(rc.Lines, line)
+	}
+	return rc, nil
+}
+
+type PurchaseOrderLineResponse struct {
+	ID        int64       `json:"id" example:"7"`
+	ProductID int64       `json:"product_id" example:"42"`
+	Title     string      `json:"title" example:"Remera Básica Negra"`
+	Size      string      `json:"size" example:"M"`
+	Quantity  int64       `json:"quantity" example:"24"`
+	Received  int64       `json:"received" example:"12"`
+	Remaining int64       `json:"remaining" example:"12"`
+	UnitCost  money.Money `json:"unit_cost" example:"4200.00" swaggertype:"number"`
+}
+
+type PurchaseReceiptLineResponse struct {
+	LineID    int64       `json:"line_id" example:"7"`
+	ProductID int64       `json:"product_id" example:"42"`
+	Quantity  int64       `json:"quantity" example:"12"`
+	UnitCost  money.Money `json:"unit_cost" example:"4350.00" swaggertype:"number"`
+}
+
+type PurchaseReceiptResponse struct {
+	ID        int64                         `json:"id" example:"3"`
+	ActorID   int64                         `json:"actor_id" example:"1"`
+	Note      string                        `json:"note,omitempty" example:"Remito 0001-00004567"`
+	Lines     []PurchaseReceiptLineResponse `json:"lines"`
+	CreatedAt time.Time                     `json:"created_at" example:"2025-01-20T10:00:00Z"`
+}
+
+type PurchaseOrderResponse struct {
+	ID           int64                       `json:"id" example:"1"`
+	SupplierID   int64                       `json:"supplier_id" example:"1"`
+	SupplierName string                      `json:"supplier_name" example:"Textil del Sur SRL"`
+	LocationID   int64                       `json:"location_id" example:"2"`
+	LocationCode string                      `json:"location_code" example:"DEP"`
+	Status       string                      `json:"status" example:"partially_received"`
+	Currency     string                      `json:"currency" example:"ARS"`
+	Total        money.Money                 `json:"total" example:"100800.00" swaggertype:"number"`
+	Note         string                      `json:"note,omitempty" example:"Temporada invierno"`
+	ExpectedAt   *time.Time                  `json:"expected_at,omitempty" example:"2025-02-01T00:00:00Z"`
+	OrderedAt    *time.Time                  `json:"ordered_at,omitempty" example:"2025-01-15T12:00:00Z"`
+	CreatedBy    int64                       `json:"created_by" example:"1"`
+	Lines        []PurchaseOrderLineResponse `json:"lines,omitempty"`
+	Receipts     []PurchaseReceiptResponse   `json:"receipts,omitempty"`
+	UpdatedAt    time.Time                   `json:"updated_at" example:"2025-01-20T10:00:00Z"`
+	CreatedAt    time.Time                   `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromPurchaseOrderEntity(po entity.PurchaseOrder) PurchaseOrderResponse {
+	resp := PurchaseOrderResponse{
+		ID:           po.ID,
+		SupplierID:   po.SupplierID,
+		SupplierName: po.SupplierName,
+		LocationID:   po.LocationID,
+		LocationCode: po.LocationCode,
+		Status:       string(po.Status),
+		Currency:     string(po.Currency),
+		Total:        po.Total,
+		Note:         po.Note,
+		ExpectedAt:   po.ExpectedAt,
+		OrderedAt:    po.OrderedAt,
+		CreatedBy:    po.CreatedBy,
+		UpdatedAt:    po.UpdatedAt,
+		CreatedAt:    po.CreatedAt,
+	}
+	for _, l := range po.Lines {
+		resp.Lines = append(resp.Lines, PurchaseOrderLineResponse{
+			ID:        l.ID,
+			ProductID: l.ProductID,
+			Title:     l.Title,
+			Size:      l.Size,
+			Quantity:  l.Quantity,
+			Received:  l.Received,
+			Remaining: l.Remaining(),
+			UnitCost:  l.UnitCost,
+		})
+	}
+	for _, rc := range po.Receipts {
+		r := PurchaseReceiptResponse{ID: rc.ID, ActorID: rc.ActorID, Note: rc.Note, CreatedAt: rc.CreatedAt}
+		for _, l := range rc.Lines {
+			line := PurchaseReceiptLineResponse{LineID: l.LineID, ProductID: l.ProductID, Quantity: l.Quantity}
+			if l.UnitCost != nil {
+				line.UnitCost = *l.UnitCost
+			}
+			r.Lines = append(r.Lines, line)
+		}
+		resp.Receipts = append(resp.Receipts, r)
+	}
+	return resp
+}
+
+type ProductCostResponse struct {
+	ProductID   int64       `json:"product_id" example:"42"`
+	Currency    string      `json:"currency" example:"ARS"`
+	AverageCost money.Money `json:"average_cost" example:"4275.00" swaggertype:"number"`
+	UpdatedAt   time.Time   `json:"updated_at" example:"2025-01-20T10:00:00Z"`
+}
+
+func FromProductCostEntity(c entity.ProductCost) ProductCostResponse {
+	return ProductCostResponse{
+		ProductID:   c.ProductID,
+		Currency:    string(c.AverageCost.Currency()),
+		AverageCost: c.AverageCost,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// ProductMarginResponse es una fila del reporte de márgenes. Sin compras registradas
+// el costo y el margen quedan vacíos.
+type ProductMarginResponse struct {
+	ProductID   int64        `json:"product_id" example:"42"`
+	BarCode     string       `json:"bar_code" example:"7791234567890"`
+	Title       string       `json:"title" example:"Remera Básica Negra"`
+	Size        string       `json:"size" example:"M"`
+	Category    string       `json:"category" example:"Remeras"`
+	Currency    string       `json:"currency" example:"ARS"`
+	UnitPrice   money.Money  `json:"unit_price" example:"9999.00" swaggertype:"number"`
+	AverageCost *money.Money `json:"average_cost,omitempty" example:"4275.00" swaggertype:"number"`
+	Margin      *money.Money `json:"margin,omitempty" example:"5724.00" swaggertype:"number"`
+	MarginBps   *int64       `json:"margin_bps,omitempty" example:"5725"` // margen sobre el precio en puntos básicos
+}
+
+func FromProductMarginEntity(m entity.ProductMargin) ProductMarginResponse {
+	resp := ProductMarginResponse{
+		ProductID:   m.ProductID,
+		BarCode:     m.BarCode,
+		Title:       m.Title,
+		Size:        m.Size,
+		Category:    m.Category,
+		Currency:    string(m.UnitPrice.Currency()),
+		UnitPrice:   m.UnitPrice,
+		AverageCost: m.AverageCost,
+	}
+	if margin, bps, ok := m.Margin(); ok {
+		resp.Margin, resp.MarginBps = &margin, &bps
+	}
+	return resp
+}
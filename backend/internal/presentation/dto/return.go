@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"time"
+)
+
+// CreateReturnRequest pide la devolución o el cambio de líneas de una orden
+type CreateReturnRequest struct {
+	Comment string              `json:"comment,omitempty" example:"Me quedó chica, necesito un L"`
+	Items   []ReturnLineRequest `json:"items" validate:"required,min=1"`
+}
+
+type ReturnLineRequest struct {
+	OrderItemID       int64  `json:"order_item_id" example:"7" validate:"required"`
+	Quantity          int64  `json:"quantity" example:"1" validate:"required,min=1"`
+	Reason            string `json:"reason" example:"wrong_size" validate:"required,oneof=wrong_size defective not_as_described changed_mind other"`
+	Resolution        string `json:"resolution" example:"exchange" validate:"required,oneof=refund exchange"`
+	ExchangeProductID int64  `json:"exchange_product_id,omitempty" example:"43"` // otro talle del mismo artículo
+}
+
+func (r *CreateReturnRequest) ToLines() []entity.ReturnLine {
+	lines := make([]entity.ReturnLine, 0, len(r.Items))
+	for _, it := range r.Items {
+		lines = append(lines, entity.ReturnLine{
+			OrderItemID:       it.OrderItemID,
+			Quantity:          it.Quantity,
+			Reason:            entity.ReturnReason(it.Reason),
+			Resolution:        entity.ReturnResolution(it.Resolution),
+			ExchangeProductID: it.ExchangeProductID,
+		})
+	}
+	return lines
+}
+
+// UpdateReturnStatusRequest avanza una solicitud; la nota queda en el historial
+type UpdateReturnStatusRequest struct {
+	Status string `json:"status" example:"approved" validate:"required,oneof=approved rejected received completed cancelled"`
+	Note   string `json:"note,omitempty" example:"Se aprueba el cambio de talle"`
+}
+
+type ReturnItemResponse struct {
+	ID                int64       `json:"id" example:"1"`
+	OrderItemID       int64       `json:"order_item_id" example:"7"`
+	ProductID         int64       `json:"product_id" example:"42"`
+	Title             string      `json:"title" example:"Remera Básica Negra"`
+	Size              string      `json:"size" example:"M"`
+	Quantity          int64       `json:"quantity" example:"1"`
+	Reason            string      `json:"reason" example:"wrong_size"`
+	Resolution        string      `json:"resolution" example:"exchange"`
+	ExchangeProductID int64       `json:"exchange_product_id,omitempty" example:"43"`
+	ExchangeSize      string      `json:"exchange_size,omitempty" example:"L"`
+	RefundAmount      money.Money `json:"refund_amount" example:"0.00" swaggertype:"number"`
+}
+
+type ReturnStatusChangeResponse struct {
+	From      string    `json:"from,omitempty" example:"requested"`
+	To        string    `json:"to" example:"approved"`
+	ActorID   int64     `json:"actor_id,omitempty" example:"1"`
+	Note      string    `json:"note,omitempty" example:"Se aprueba el cambio de talle"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-20T10:00:00Z"`
+}
+
+type ReturnResponse struct {
+	ID              int64                        `json:"id" example:"1"`
+	OrderID         int64                        `json:"order_id" example:"12"`
+	UserID          int64                        `json:"user_id" example:"3"`
+	Status          string                       `json:"status" example:"approved"`
+	Comment         string                       `json:"comment,omitempty" example:"Me quedó chica, necesito un L"`
+	Currency        string                       `json:"currency" example:"ARS"`
+	RefundTotal     money.Money                  `json:"refund_total" example:"0.00" swaggertype:"number"`
+	RefundReference string                       `json:"refund_reference,omitempty" example:"RF-9f86d081884c7d65"`
+	RefundedAt      *time.Time                   `json:"refunded_at,omitempty" example:"2025-01-25T10:00:00Z"`
+	Items           []ReturnItemResponse         `json:"items"`
+	History         []ReturnStatusChangeResponse `json:"history"`
+	UpdatedAt       time.Time                    `json:"updated_at" example:"2025-01-20T10:00:00Z"`
+	CreatedAt       time.Time                    `json:"created_at" example:"2025-01-19T18:30:00Z"`
+}
+
+func FromReturnEntity(r entity.ReturnRequest) ReturnResponse {
+	items := make([]ReturnItemResponse, 0, len(r.Items))
+	for _, it := range r.Items {
+		items = append(items, ReturnItemResponse{
+			ID:                it.ID,
+			OrderItemID:       it.OrderItemID,
+			ProductID:         it.ProductID,
+			Title:             it.Title,
+			Size:              it.Size,
+			Quantity:          it.Quantity,
+			Reason:            string(it.Reason),
+			Resolution:        string(it.Resolution),
+			ExchangeProductID: it.ExchangeProductID,
+			ExchangeSize:      it.ExchangeSize,
+			RefundAmount:      it.RefundAmount,
+		})
+	}
+	history := make([]ReturnStatusChangeResponse, 0, len(r.History))
+	for _, ch := range r.History {
+		history = append(history, ReturnStatusChangeResponse{
+			From:      string(ch.From),
+			To:        string(ch.To),
+			ActorID:   ch.ActorID,
+			Note:      ch.Note,
+			CreatedAt: ch.CreatedAt,
+		})
+	}
+	return ReturnResponse{
+		ID:              r.ID,
+		OrderID:         r.OrderID,
+		UserID:          r.UserID,
+		Status:          string(r.Status),
+		Comment:         r.Comment,
+		Currency:        string(r.Currency),
+		RefundTotal:     r.RefundTotal,
+		RefundReference: r.RefundReference,
+		RefundedAt:      r.RefundedAt,
+		Items:           items,
+		History:         history,
+		UpdatedAt:       r.UpdatedAt,
+		CreatedAt:       r.CreatedAt,
+	}
+}
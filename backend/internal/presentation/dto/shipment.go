@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"time"
+)
+
+type TrackingEventResponse struct {
+	Status      string    `json:"status" example:"in_transit"`
+	Description string    `json:"description" example:"En viaje al centro de distribución"`
+	Location    string    `json:"location" example:"Centro de distribución"`
+	OccurredAt  time.Time `json:"occurred_at" example:"2025-01-16T09:00:00Z"`
+}
+
+type ShipmentResponse struct {
+	ID             int64                   `json:"id" example:"1"`
+	OrderID        int64                   `json:"order_id" example:"12"`
+	Carrier        string                  `json:"carrier" example:"fake"`
+	TrackingNumber string                  `json:"tracking_number" example:"FK17369876540001"`
+	Status         string                  `json:"status" example:"in_transit"`
+	Address        ShippingAddressResponse `json:"address"`
+	WeightGrams    int64                   `json:"weight_grams" example:"500"`
+	Events         []TrackingEventResponse `json:"events"`
+	DeliveredAt    *time.Time              `json:"delivered_at,omitempty" example:"2025-01-18T15:00:00Z"`
+	LastCheckedAt  *time.Time              `json:"last_checked_at,omitempty" example:"2025-01-16T09:05:00Z"`
+	UpdatedAt      time.Time               `json:"updated_at" example:"2025-01-16T09:05:00Z"`
+	CreatedAt      time.Time               `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromShipmentEntity(sh entity.Shipment) ShipmentResponse {
+	events := make([]TrackingEventResponse, 0, len(sh.Events))
+	for _, e := range sh.Events {
+		events = append(events, TrackingEventResponse{
+			Status:      string(e.Status),
+			Description: e.Description,
+			Location:    e.Location,
+			OccurredAt:  e.OccurredAt,
+		})
+	}
+	return ShipmentResponse{
+		ID:             sh.ID,
+		OrderID:        sh.OrderID,
+		Carrier:        sh.Carrier,
+		TrackingNumber: sh.TrackingNumber,
+		Status:         string(sh.Status),
+		Address:        ShippingAddressResponse(sh.Address),
+		WeightGrams:    sh.WeightGrams,
+		Events:         events,
+		DeliveredAt:    sh.DeliveredAt,
+		LastCheckedAt:  sh.LastCheckedAt,
+		UpdatedAt:      sh.UpdatedAt,
+		CreatedAt:      sh.CreatedAt,
+	}
+}
@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"encoding/json"
+	"time"
+)
+
+// ShippingZoneRequest crea o reemplaza una zona; las provincias son códigos ISO 3166-2
+type ShippingZoneRequest struct {
+	Name      string   `json:"name" example:"AMBA" validate:"required"`
+	Provinces []string `json:"provinces" example:"C,B" validate:"required,min=1"`
+}
+
+func (r *ShippingZoneRequest) ToEntity() *entity.ShippingZone {
+	return &entity.ShippingZone{Name: r.Name, Provinces: r.Provinces}
+}
+
+type ShippingZoneResponse struct {
+	ID        int64     `json:"id" example:"1"`
+	Name      string    `json:"name" example:"AMBA"`
+	Provinces []string  `json:"provinces" example:"B,C"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromShippingZoneEntity(z entity.ShippingZone) ShippingZoneResponse {
+	return ShippingZoneResponse(z)
+}
+
+// ShippingMethodRequest crea o reemplaza un método con su tabla de tarifas completa.
+// Los montos se cargan en la moneda base (ARS).
+type ShippingMethodRequest struct {
+	Name                  string                `json:"name" example:"Correo a domicilio" validate:"required"`
+	Description           string                `json:"description" example:"Entrega en el domicilio"`
+	MinDays               int                   `json:"min_days" example:"3"`
+	MaxDays               int                   `json:"max_days" example:"6"`
+	FreeShippingThreshold json.Number           `json:"free_shipping_threshold,omitempty" example:"50000.00" swaggertype:"number"`
+	Active                *bool                 `json:"active,omitempty" example:"true"` // por defecto true
+	Rates                 []ShippingRateRequest `json:"rates"`
+}
+
+// ShippingRateRequest es el precio de envío a una zona para paquetes de hasta max_weight_grams
+type ShippingRateRequest struct {
+	ZoneID         int64       `json:"zone_id" example:"1" validate:"required"`
+	MaxWeightGrams int64       `json:"max_weight_grams" example:"5000" validate:"required"`
+	Price          json.Number `json:"price" example:"4500.00" swaggertype:"number" validate:"required"`
+}
+
+func (r *ShippingMethodRequest) ToEntity() (*entity.ShippingMethod, error) {
+	threshold, err := parseOptionalMoney(r.FreeShippingThreshold, money.Base)
+	if err != nil {
+		return nil, err
+	}
+	rates := make([]entity.ShippingRate, 0, len(r.Rates))
+	for _, rt := range r.Rates {
+		price, err := money.Parse(rt.Price.String(), money.Base, money.RoundUnnecessary)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, entity.ShippingRate{ZoneID: rt.ZoneID, MaxWeightGrams: rt.MaxWeightGrams, Price: price})
+	}
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return &entity.ShippingMethod{
+		Name:                  r.Name,
+		Description:           r.Description,
+		MinDays:               r.MinDays,
+		MaxDays:               r.MaxDays,
+		FreeShippingThreshold: threshold,
+		Active:                active,
+		Rates:                 rates,
+	}, nil
+}
+
+type ShippingRateResponse struct {
+	ID             int64       `json:"id" example:"1"`
+	ZoneID         int64       `json:"zone_id" example:"1"`
+	MaxWeightGrams int64       `json:"max_weight_grams" example:"5000"`
+	Price          money.Money `json:"price" example:"4500.00" swaggertype:"number"`
+}
+
+type ShippingMethodResponse struct {
+	ID                    int64                  `json:"id" example:"1"`
+	Name                  string                 `json:"name" example:"Correo a domicilio"`
+	Description           string                 `json:"description" example:"Entrega en el domicilio"`
+	MinDays               int                    `json:"min_days" example:"3"`
+	MaxDays               int                    `json:"max_days" example:"6"`
+	Currency              string                 `json:"currency" example:"ARS"`
+	FreeShippingThreshold *money.Money           `json:"free_shipping_threshold,omitempty" example:"50000.00" swaggertype:"number"`
+	Active                bool                   `json:"active" example:"true"`
+	Rates                 []ShippingRateResponse `json:"rates"`
+	UpdatedAt             time.Time              `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+	CreatedAt             time.Time              `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromShippingMethodEntity(m entity.ShippingMethod) ShippingMethodResponse {
+	rates := make([]ShippingRateResponse, 0, len(m.Rates))
+	for _, rt := range m.Rates {
+		rates = append(rates, ShippingRateResponse{ID: rt.ID, ZoneID: rt.ZoneID, MaxWeightGrams: rt.MaxWeightGrams, Price: rt.Price})
+	}
+	return ShippingMethodResponse{
+		ID:                    m.ID,
+		Name:                  m.Name,
+		Description:           m.Description,
+		MinDays:               m.MinDays,
+		MaxDays:               m.MaxDays,
+		Currency:              string(money.Base),
+		FreeShippingThreshold: m.FreeShippingThreshold,
+		Active:                m.Active,
+		Rates:                 rates,
+		UpdatedAt:             m.UpdatedAt,
+		CreatedAt:             m.CreatedAt,
+	}
+}
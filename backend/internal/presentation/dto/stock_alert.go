@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"time"
+)
+
+// ReorderPointRequest fija el umbral de stock del producto; threshold 0 quita la alerta
+type ReorderPointRequest struct {
+	Threshold       int64 `json:"threshold" example:"5" validate:"min=0"`
+	ReorderQuantity int64 `json:"reorder_quantity" example:"20" validate:"min=0"`
+}
+
+func (r *ReorderPointRequest) ToEntity(productID int64) *entity.ReorderPoint {
+	return &entity.ReorderPoint{ProductID: productID, Threshold: r.Threshold, ReorderQuantity: r.ReorderQuantity}
+}
+
+type ReorderPointResponse struct {
+	ProductID       int64      `json:"product_id" example:"42"`
+	Threshold       int64      `json:"threshold" example:"5"`
+	ReorderQuantity int64      `json:"reorder_quantity" example:"20"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromReorderPointEntity(rp entity.ReorderPoint) ReorderPointResponse {
+	resp := ReorderPointResponse{ProductID: rp.ProductID, Threshold: rp.Threshold, ReorderQuantity: rp.ReorderQuantity}
+	if !rp.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &rp.UpdatedAt
+	}
+	return resp
+}
+
+type LowStockItemResponse struct {
+	ProductID       int64      `json:"product_id" example:"42"`
+	BarCode         string     `json:"bar_code" example:"7791234567890"`
+	Title           string     `json:"title" example:"Remera Básica Negra"`
+	Size            string     `json:"size" example:"M"`
+	Category        string     `json:"category" example:"Remeras"`
+	Stock           int64      `json:"stock" example:"2"`
+	Threshold       int64      `json:"threshold" example:"5"`
+	ReorderQuantity int64      `json:"reorder_quantity" example:"20"`
+	AlertedAt       *time.Time `json:"alerted_at,omitempty" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromLowStockItemEntity(it entity.LowStockItem) LowStockItemResponse {
+	return LowStockItemResponse(it)
+}
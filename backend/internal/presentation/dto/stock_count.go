@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"time"
+)
+
+// OpenStockCountRequest abre un recuento físico de una ubicación
+type OpenStockCountRequest struct {
+	LocationID int64  `json:"location_id" example:"2" validate:"required"`
+	Category   string `json:"category,omitempty" example:"Remeras"` // vacío = todos los productos
+	Note       string `json:"note,omitempty" example:"Recuento trimestral"`
+}
+
+func (r *OpenStockCountRequest) ToEntity() *entity.StockCount {
+	return &entity.StockCount{LocationID: r.LocationID, Category: r.Category, Note: r.Note}
+}
+
+// StockCountScansRequest es un lote de códigos escaneados
+type StockCountScansRequest struct {
+	Scans []StockCountScanRequest `json:"scans" validate:"required,min=1,max=500"`
+}
+
+type StockCountScanRequest struct {
+	BarCode  string `json:"bar_code" example:"7791234567890" validate:"required"`
+	Quantity int64  `json:"quantity" example:"1"` // por defecto 1, negativo para corregir
+}
+
+func (r *StockCountScansRequest) ToScans() []entity.StockCountScan {
+	scans := make([]entity.StockCountScan, 0, len(r.Scans))
+	for _, s := range r.Scans {
+		qty := s.Quantity
+		if qty == 0 {
+			qty = 1
+		}
+		scans = append(scans, entity.StockCountScan{BarCode: s.BarCode, Quantity: qty})
+	}
+	return scans
+}
+
+type StockCountScanResponse struct {
+	Accepted int      `json:"accepted" example:"24"`
+	Unknown  []string `json:"unknown"` // códigos fuera del alcance del recuento
+}
+
+type StockCountLineResponse struct {
+	ProductID int64      `json:"product_id" example:"42"`
+	BarCode   string     `json:"bar_code" example:"7791234567890"`
+	Title     string     `json:"title" example:"Remera Básica Negra"`
+	Size      string     `json:"size" example:"M"`
+	Expected  int64      `json:"expected" example:"10"`
+	Counted   int64      `json:"counted" example:"8"`
+	Variance  int64      `json:"variance" example:"-2"`
+	ScannedAt *time.Time `json:"scanned_at,omitempty" example:"2025-01-15T10:00:00Z"`
+}
+
+type StockCountSummaryResponse struct {
+	Lines        int   `json:"lines" example:"120"`
+	Scanned      int   `json:"scanned" example:"118"`
+	WithVariance int   `json:"with_variance" example:"3"`
+	UnitsOver    int64 `json:"units_over" example:"1"`
+	UnitsShort   int64 `json:"units_short" example:"4"`
+}
+
+type StockCountResponse struct {
+	ID           int64                      `json:"id" example:"1"`
+	LocationID   int64                      `json:"location_id" example:"2"`
+	LocationCode string                     `json:"location_code" example:"LOCAL"`
+	Category     string                     `json:"category,omitempty" example:"Remeras"`
+	Status       string                     `json:"status" example:"open"`
+	Note         string                     `json:"note,omitempty" example:"Recuento trimestral"`
+	OpenedBy     int64                      `json:"opened_by" example:"1"`
+	ApprovedBy   *int64                     `json:"approved_by,omitempty" example:"1"`
+	ApprovedAt   *time.Time                 `json:"approved_at,omitempty" example:"2025-01-15T18:00:00Z"`
+	Summary      *StockCountSummaryResponse `json:"summary,omitempty"`
+	Lines        []StockCountLineResponse   `json:"lines,omitempty"`
+	UpdatedAt    time.Time                  `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+	CreatedAt    time.Time                  `json:"created_at" example:"2025-01-15T09:00:00Z"`
+}
+
+// FromStockCountEntity arma la respuesta; con varianceOnly se omiten las líneas sin diferencia
+func FromStockCountEntity(c entity.StockCount, varianceOnly bool) StockCountResponse {
+	resp := StockCountResponse{
+		ID:           c.ID,
+		LocationID:   c.LocationID,
+		LocationCode: c.LocationCode,
+		Category:     c.Category,
+		Status:       string(c.Status),
+		Note:         c.Note,
+		OpenedBy:     c.OpenedBy,
+		ApprovedBy:   c.ApprovedBy,
+		ApprovedAt:   c.ApprovedAt,
+		UpdatedAt:    c.UpdatedAt,
+		CreatedAt:    c.CreatedAt,
+	}
+	if c.Lines == nil {
+		return resp
+	}
+	sum := StockCountSummaryResponse(c.Summary())
+	resp.Summary = &sum
+	resp.Lines = make([]StockCountLineResponse, 0, len(c.Lines))
+	for _, l := range c.Lines {
+		if varianceOnly && l.Variance() == 0 {
+			continue
+		}
+		resp.Lines = append(resp.Lines, StockCountLineResponse{
+			ProductID: l.ProductID,
+			BarCode:   l.BarCode,
+			Title:     l.Title,
+			Size:      l.Size,
+			Expected:  l.Expected,
+			Counted:   l.Counted,
+			Variance:  l.Variance(),
+			ScannedAt: l.ScannedAt,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"core/internal/domain/entity"
+	"core/internal/domain/money"
+	"core/internal/domain/tax"
+	"time"
+)
+
+// SetTaxRateRequest fija la alícuota de IVA de una categoría. La categoría vacía
+// cambia la alícuota por defecto.
+type SetTaxRateRequest struct {
+	Category string   `json:"category" example:"Libros"`
+	Rate     tax.Rate `json:"rate" example:"10.5" swaggertype:"number" validate:"required"`
+}
+
+type TaxRateResponse struct {
+	ID        int64     `json:"id" example:"2"`
+	Category  string    `json:"category" example:"Libros"`
+	Rate      tax.Rate  `json:"rate" example:"10.5" swaggertype:"number"`
+	IsDefault bool      `json:"is_default" example:"false"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromTaxRateEntity(t entity.TaxRate) TaxRateResponse {
+	return TaxRateResponse{
+		ID:        t.ID,
+		Category:  t.Category,
+		Rate:      t.Rate,
+		IsDefault: t.Category == "",
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+// TaxGroupResponse es el IVA de la orden agrupado por alícuota
+type TaxGroupResponse struct {
+	Rate  tax.Rate    `json:"rate" example:"21" swaggertype:"number"`
+	Net   money.Money `json:"net" example:"1000.00" swaggertype:"number"`
+	Tax   money.Money `json:"tax" example:"210.00" swaggertype:"number"`
+	Gross money.Money `json:"gross" example:"1210.00" swaggertype:"number"`
+}
+
+func fromTaxGroups(groups []tax.Group) []TaxGroupResponse {
+	out := make([]TaxGroupResponse, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, TaxGroupResponse(g))
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+package dto
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+)
+
+// Validator aplica las etiquetas validate de las peticiones; se registra en Echo para
+// c.Validate
+type Validator struct{}
+
+func (Validator) Validate(req any) error { return Validate(req) }
+
+// Validate revisa las reglas required, min=N y oneof=a b c de las etiquetas validate y
+// retorna un errors.ValidationError con un error por campo, nombrado como en el JSON
+func Validate(req any) error {
+	v := reflect.Indirect(reflect.ValueOf(req))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var out errors.ValidationError
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		rules := f.Tag.Get("validate")
+		if rules == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" {
+			name = f.Name
+		}
+		if msg := checkField(v.Field(i), rules); msg != "" {
+			out = append(out, errors.FieldError{Field: name, Message: name + " " + msg})
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// checkField retorna el mensaje de la primera regla que no se cumple
+func checkField(v reflect.Value, rules string) string {
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			// Un entero en cero no se distingue de uno ausente en el JSON: de eso se
+			// ocupa min
+			if _, isInt := fieldInt(v); isInt {
+				continue
+			}
+			if v.IsZero() || v.Kind() == reflect.String && strings.TrimSpace(v.String()) == "" {
+				return "is required"
+			}
+		case "min":
+			limit, _ := strconv.ParseInt(arg, 10, 64)
+			if n, ok := fieldNumber(v); ok && n < limit {
+				return "must be >= " + arg
+			}
+		case "oneof":
+			if v.Kind() == reflect.String && !slices.Contains(strings.Fields(arg), v.String()) {
+				return "must be one of " + arg
+			}
+		}
+	}
+	return ""
+}
+
+// fieldNumber retorna el valor de los enteros y las unidades menores de los montos
+func fieldNumber(v reflect.Value) (int64, bool) {
+	if n, ok := fieldInt(v); ok {
+		return n, true
+	}
+	if m, ok := v.Interface().(interface{ Minor() int64 }); ok {
+		return m.Minor(), true
+	}
+	return 0, false
+}
+
+func fieldInt(v reflect.Value) (int64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	}
+	return 0, false
+}
+
+// ProductValidator valida un producto con las reglas del alta por la API, armando el
+// CreateProductRequest que lo crearía
+type ProductValidator struct{}
+
+func (ProductValidator) ValidateProduct(p entity.Product) error {
+	req := CreateProductRequest{
+		BarCode:     p.BarCode,
+		Title:       p.Title,
+		Description: p.Description,
+		Stock:       p.Stock,
+		Size:        p.Size,
+		Category:    p.Category,
+		WeightGrams: p.WeightGrams,
+		UnitPrice:   p.UnitPrice,
+		PublishAt:   p.PublishAt,
+		UnpublishAt: p.UnpublishAt,
+	}
+	return Validate(&req)
+}
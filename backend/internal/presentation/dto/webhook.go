@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"time"
+
+	"core/internal/domain/entity"
+)
+
+// SaveWebhookEndpointRequest da de alta o reemplaza un destino. Sin secret el alta genera
+// uno y la modificación conserva el anterior.
+type SaveWebhookEndpointRequest struct {
+	URL         string   `json:"url" example:"https://erp.example.com/hooks/catalog" validate:"required,url"`
+	Description string   `json:"description,omitempty" example:"ERP"`
+	Secret      string   `json:"secret,omitempty" example:"3f1c9a7e0b5d4e2a8c6f"`
+	Events      []string `json:"events" example:"product.created,product.updated,stock.changed" validate:"required,min=1"`
+	Active      *bool    `json:"active,omitempty" example:"true"`
+}
+
+func (r *SaveWebhookEndpointRequest) ToEntity() *entity.WebhookEndpoint {
+	e := &entity.WebhookEndpoint{
+		URL:         r.URL,
+		Description: r.Description,
+		Secret:      r.Secret,
+		Events:      make([]entity.WebhookEvent, 0, len(r.Events)),
+		Active:      r.Active == nil || *r.Active,
+	}
+	for _, ev := range r.Events {
+		e.Events = append(e.Events, entity.WebhookEvent(ev))
+	}
+	return e
+}
+
+// WebhookEndpointResponse es un destino. El secreto solo se informa en el alta.
+type WebhookEndpointResponse struct {
+	ID          int64     `json:"id" example:"1"`
+	URL         string    `json:"url" example:"https://erp.example.com/hooks/catalog"`
+	Description string    `json:"description,omitempty" example:"ERP"`
+	Secret      string    `json:"secret,omitempty" example:"3f1c9a7e0b5d4e2a8c6f"`
+	Events      []string  `json:"events" example:"product.created,product.updated,stock.changed"`
+	Active      bool      `json:"active" example:"true"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2025-01-15T10:00:00Z"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromWebhookEndpointEntity(e entity.WebhookEndpoint) WebhookEndpointResponse {
+	resp := WebhookEndpointResponse{
+		ID:          e.ID,
+		URL:         e.URL,
+		Description: e.Description,
+		Events:      make([]string, 0, len(e.Events)),
+		Active:      e.Active,
+		UpdatedAt:   e.UpdatedAt,
+		CreatedAt:   e.CreatedAt,
+	}
+	for _, ev := range e.Events {
+		resp.Events = append(resp.Events, string(ev))
+	}
+	return resp
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64      `json:"id" example:"10"`
+	EventID        int64      `json:"event_id" example:"4"`
+	Event          string     `json:"event" example:"stock.changed"`
+	EndpointID     int64      `json:"endpoint_id" example:"1"`
+	Status         string     `json:"status" example:"pending"`
+	Attempts       int        `json:"attempts" example:"2"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty" example:"2025-01-15T10:01:00Z"`
+	ResponseStatus int        `json:"response_status,omitempty" example:"503"`
+	LastError      string     `json:"last_error,omitempty" example:"webhook responded 503 Service Unavailable"`
+	RedeliveryOf   *int64     `json:"redelivery_of,omitempty" example:"7"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty" example:"2025-01-15T10:01:02Z"`
+	CreatedAt      time.Time  `json:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+func FromWebhookDeliveryEntity(d entity.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		Event:          string(d.Event),
+		EndpointID:     d.EndpointID,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		RedeliveryOf:   d.RedeliveryOf,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+	jwtutil "core/internal/presentation/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AddressHandler struct {
+	Svc service.AddressService
+}
+
+func NewAddressHandler(s service.AddressService) *AddressHandler {
+	return &AddressHandler{Svc: s}
+}
+
+// List godoc
+// @Summary      Mis direcciones
+// @Description  Lista la libreta de direcciones del usuario autenticado, primero las marcadas por defecto
+// @Tags         addresses
+// @Produce      json
+// @Success      200  {array}   dto.AddressResponse
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/me/addresses [get]
+func (h *AddressHandler) List(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	addresses, err := h.Svc.ListForUser(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.AddressResponse, 0, len(addresses))
+	for _, a := range addresses {
+		resp = append(resp, dto.FromAddressEntity(a))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Create godoc
+// @Summary      Agregar dirección
+// @Description  Agrega una dirección a la libreta; marcarla por defecto desmarca a las demás
+// @Tags         addresses
+// @Accept       json
+// @Produce      json
+// @Param        address  body      dto.AddressRequest  true  "Dirección"
+// @Success      201      {object}  dto.AddressResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/me/addresses [post]
+func (h *AddressHandler) Create(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	var req dto.AddressRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	a, err := h.Svc.Create(c.Request().Context(), req.ToEntity(userID))
+	if err != nil {
+		return addressError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.FromAddressEntity(*a))
+}
+
+// Update godoc
+// @Summary      Reemplazar dirección
+// @Description  Reemplaza una dirección de la libreta. Para quitar un default se marca otra dirección.
+// @Tags         addresses
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                 true  "Address ID"
+// @Param        address  body      dto.AddressRequest  true  "Dirección"
+// @Success      200      {object}  dto.AddressResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/me/addresses/{id} [put]
+func (h *AddressHandler) Update(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.AddressRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	address := req.ToEntity(userID)
+	address.ID = id
+
+	a, err := h.Svc.Update(c.Request().Context(), address)
+	if err != nil {
+		return addressError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromAddressEntity(*a))
+}
+
+// Delete godoc
+// @Summary      Eliminar dirección
+// @Description  Elimina una dirección de la libreta; las órdenes conservan la dirección de entrega
+// @Tags         addresses
+// @Param        id   path  int  true  "Address ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/me/addresses/{id} [delete]
+func (h *AddressHandler) Delete(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	if err := h.Svc.Delete(c.Request().Context(), userID, id); err != nil {
+		return addressError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func addressError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "address not found"})
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "recipient_name, street, city and a valid province code are required"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/repository"
+	"core/internal/presentation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditHandler(auditRepo repository.AuditRepository) *AuditHandler {
+	return &AuditHandler{auditRepo: auditRepo}
+}
+
+// List godoc
+// @Summary      Consultar auditoría
+// @Description  Lista el historial de mutaciones del catálogo y usuarios, del más reciente al más antiguo (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        entity_type query string false "Tipo de entidad (product, product_image, price_change, product_price, exchange_rate_table, promotion, tax_rate, shipping_zone, shipping_method, location, stock_transfer, reorder_point, supplier, purchase_order, register_shift, feed_category, marketplace_listing, webhook_endpoint, user)"
+// @Param        entity_id   query int    false "ID de la entidad"
+// @Param        actor_id    query int    false "ID del usuario que realizó el cambio"
+// @Param        action      query string false "Acción (create, update, delete, stock, status, pricing, bulk)"
+// @Param        from        query string false "Desde (RFC3339)"
+// @Param        to          query string false "Hasta (RFC3339, exclusivo)"
+// @Param        limit       query int    false "Límite (<=500)"
+// @Param        offset      query int    false "Offset"
+// @Success      200 {array}  dto.AuditEntryResponse
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/audit [get]
+func (h *AuditHandler) List(c echo.Context) error {
+	filter := entity.AuditFilter{
+		EntityType: c.QueryParam("entity_type"),
+		Action:     entity.AuditAction(c.QueryParam("action")),
+	}
+
+	if v := c.QueryParam("entity_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid entity_id"})
+		}
+		filter.EntityID = id
+	}
+	if v := c.QueryParam("actor_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid actor_id"})
+		}
+		filter.ActorID = id
+	}
+	if v := c.QueryParam("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid from, expected RFC3339"})
+		}
+		filter.From = &t
+	}
+	if v := c.QueryParam("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid to, expected RFC3339"})
+		}
+		filter.To = &t
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	entries, err := h.auditRepo.List(c.Request().Context(), filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+
+	response := make([]dto.AuditEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		response = append(response, dto.FromAuditEntity(e))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
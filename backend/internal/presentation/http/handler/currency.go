@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+	jwtutil "core/internal/presentation/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAcceptCurrency permite al cliente elegir la moneda de visualización
+const HeaderAcceptCurrency = "Accept-Currency"
+
+// displayCurrency resuelve la moneda pedida: query param, luego header, luego la base
+func displayCurrency(c echo.Context) (money.Currency, error) {
+	c.Response().Header().Add(echo.HeaderVary, HeaderAcceptCurrency)
+	code := c.QueryParam("currency")
+	if code == "" {
+		code = c.Request().Header.Get(HeaderAcceptCurrency)
+	}
+	if code == "" {
+		return money.Base, nil
+	}
+	return money.ParseCurrency(code)
+}
+
+// currencyError traduce los errores de conversión de precios
+func currencyError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrCurrencyNotPriced:
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "prices are not available in the requested currency"})
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported currency"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
+
+type CurrencyHandler struct {
+	Svc service.CurrencyService
+}
+
+func NewCurrencyHandler(s service.CurrencyService) *CurrencyHandler {
+	return &CurrencyHandler{Svc: s}
+}
+
+// CurrentRates godoc
+// @Summary      Tasas de cambio vigentes
+// @Description  Retorna la tabla de tasas vigente usada para convertir precios desde ARS
+// @Tags         currency
+// @Produce      json
+// @Success      200  {object}  dto.ExchangeRateTableResponse
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/exchange-rates/current [get]
+func (h *CurrencyHandler) CurrentRates(c echo.Context) error {
+	t, err := h.Svc.CurrentRateTable(c.Request().Context())
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "no exchange rates loaded"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromExchangeRateTableEntity(*t))
+}
+
+// CreateRateTable godoc
+// @Summary      Cargar tabla de tasas
+// @Description  Carga una nueva tabla de tasas desde ARS. Rige desde effective_from (o ya) hasta que se cargue otra (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        table  body      dto.CreateExchangeRateTableRequest  true  "Tasas"
+// @Success      201    {object}  dto.ExchangeRateTableResponse
+// @Failure      400    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/exchange-rates [post]
+func (h *CurrencyHandler) CreateRateTable(c echo.Context) error {
+	var req dto.CreateExchangeRateTableRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	table, err := req.ToEntity()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if userID, ok := jwtutil.UserIDFromToken(c); ok {
+		table.CreatedBy = &userID
+	}
+
+	t, err := h.Svc.CreateRateTable(c.Request().Context(), table)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "rates must be positive and for supported currencies other than ARS"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusCreated, dto.FromExchangeRateTableEntity(*t))
+}
+
+// ListRateTables godoc
+// @Summary      Historial de tablas de tasas
+// @Description  Lista las tablas de tasas cargadas, de la más reciente a la más antigua (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        limit  query  int  false  "Límite (<=100)"
+// @Success      200  {array}   dto.ExchangeRateTableResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/exchange-rates [get]
+func (h *CurrencyHandler) ListRateTables(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	tables, err := h.Svc.ListRateTables(c.Request().Context(), limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+
+	resp := make([]dto.ExchangeRateTableResponse, 0, len(tables))
+	for _, t := range tables {
+		resp = append(resp, dto.FromExchangeRateTableEntity(t))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ListProductPrices godoc
+// @Summary      Precios fijos por moneda
+// @Description  Lista los precios fijos de un producto en otras monedas (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {array}   dto.ProductPriceResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/prices [get]
+func (h *CurrencyHandler) ListProductPrices(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	prices, err := h.Svc.ListProductPrices(c.Request().Context(), id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+
+	resp := make([]dto.ProductPriceResponse, 0, len(prices))
+	for _, p := range prices {
+		resp = append(resp, dto.FromProductPriceEntity(p))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// SetProductPrice godoc
+// @Summary      Fijar precio en otra moneda
+// @Description  Fija el precio de un producto en una moneda; tiene prioridad sobre la conversión por tasas (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                         true  "Product ID"
+// @Param        currency  path      string                      true  "Moneda (UYU,CLP,USD)"
+// @Param        price     body      dto.SetProductPriceRequest  true  "Precio"
+// @Success      200       {object}  dto.ProductPriceResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/prices/{currency} [put]
+func (h *CurrencyHandler) SetProductPrice(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	currency, err := money.ParseCurrency(c.Param("currency"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported currency"})
+	}
+
+	var req dto.SetProductPriceRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	price, err := req.ToEntity(id, currency)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	p, err := h.Svc.SetProductPrice(c.Request().Context(), price)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "price must be positive and in a currency other than the product's"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromProductPriceEntity(*p))
+}
+
+// DeleteProductPrice godoc
+// @Summary      Quitar precio en otra moneda
+// @Description  Elimina el precio fijo; el producto vuelve a convertirse por tasas (solo admin)
+// @Tags         admin
+// @Param        id        path  int     true  "Product ID"
+// @Param        currency  path  string  true  "Moneda (UYU,CLP,USD)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/prices/{currency} [delete]
+func (h *CurrencyHandler) DeleteProductPrice(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	currency, err := money.ParseCurrency(c.Param("currency"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported currency"})
+	}
+
+	if err := h.Svc.DeleteProductPrice(c.Request().Context(), id, currency); err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "price not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
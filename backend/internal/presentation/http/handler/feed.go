@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FeedHandler struct {
+	Svc     service.FeedService
+	baseURL string
+}
+
+// NewFeedHandler usa baseURL para informar las URLs públicas de los feeds
+func NewFeedHandler(s service.FeedService, baseURL string) *FeedHandler {
+	return &FeedHandler{Svc: s, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// feedPaths son las URLs fijas que se cargan en Merchant Center y en el administrador de
+// catálogos de Meta
+var feedPaths = map[entity.FeedChannel]string{
+	entity.FeedChannelGoogle: "/feeds/google.xml",
+	entity.FeedChannelMeta:   "/feeds/meta.csv",
+}
+
+var feedContentTypes = map[entity.FeedChannel]string{
+	entity.FeedChannelGoogle: "application/xml; charset=utf-8",
+	entity.FeedChannelMeta:   "text/csv; charset=utf-8",
+}
+
+// GoogleFeed godoc
+// @Summary      Feed de Google Merchant Center
+// @Description  Productos publicados con imagen en XML RSS 2.0 con el espacio de nombres g:. Se regenera periódicamente; responde 304 si no cambió desde If-Modified-Since
+// @Tags         feeds
+// @Produce      application/xml
+// @Success      200  {file}    file
+// @Success      304  "Not Modified"
+// @Failure      500  {object}  map[string]string
+// @Router       /feeds/google.xml [get]
+func (h *FeedHandler) GoogleFeed(c echo.Context) error {
+	return h.serveFeed(c, entity.FeedChannelGoogle)
+}
+
+// MetaFeed godoc
+// @Summary      Feed del catálogo de Meta
+// @Description  Productos publicados con imagen en CSV. Se regenera periódicamente; responde 304 si no cambió desde If-Modified-Since
+// @Tags         feeds
+// @Produce      text/csv
+// @Success      200  {file}    file
+// @Success      304  "Not Modified"
+// @Failure      500  {object}  map[string]string
+// @Router       /feeds/meta.csv [get]
+func (h *FeedHandler) MetaFeed(c echo.Context) error {
+	return h.serveFeed(c, entity.FeedChannelMeta)
+}
+
+func (h *FeedHandler) serveFeed(c echo.Context, channel entity.FeedChannel) error {
+	ctx := c.Request().Context()
+	st, err := h.Svc.State(ctx, channel)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	res := c.Response()
+	if st.GeneratedAt != nil {
+		modified := st.GeneratedAt.UTC().Truncate(time.Second)
+		if since, err := http.ParseTime(c.Request().Header.Get("If-Modified-Since")); err == nil && !modified.After(since) {
+			return c.NoContent(http.StatusNotModified)
+		}
+		res.Header().Set(echo.HeaderLastModified, modified.Format(http.TimeFormat))
+	}
+	res.Header().Set(echo.HeaderContentType, feedContentTypes[channel])
+
+	// Igual que en la exportación, un feed cortado no se debe tomar como completo
+	if err := h.Svc.WriteFeed(ctx, res, channel); err != nil {
+		if res.Committed {
+			log.Printf("[FEED] %s feed download interrupted: %v", channel, err)
+			panic(http.ErrAbortHandler)
+		}
+		res.Header().Del(echo.HeaderLastModified)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return nil
+}
+
+// States godoc
+// @Summary      Estado de los feeds
+// @Description  Lista los feeds con su URL, la cantidad de productos y cuándo cambiaron por última vez (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.FeedStateResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/feeds [get]
+func (h *FeedHandler) States(c echo.Context) error {
+	states, err := h.Svc.States(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.FeedStateResponse, 0, len(states))
+	for _, st := range states {
+		resp = append(resp, dto.FromFeedStateEntity(st, h.baseURL+feedPaths[st.Channel]))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Refresh godoc
+// @Summary      Regenerar feeds
+// @Description  Aplica ya los cambios del catálogo a los feeds, sin esperar la próxima pasada del scheduler (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   entity.FeedRefresh
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/feeds/refresh [post]
+func (h *FeedHandler) Refresh(c echo.Context) error {
+	out, err := h.Svc.Refresh(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// ListCategories godoc
+// @Summary      Listar mapeo de categorías
+// @Description  Lista las categorías del catálogo mapeadas a las taxonomías de Google y Meta (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.FeedCategoryResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/feeds/categories [get]
+func (h *FeedHandler) ListCategories(c echo.Context) error {
+	mappings, err := h.Svc.ListCategoryMappings(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.FeedCategoryResponse, 0, len(mappings))
+	for _, m := range mappings {
+		resp = append(resp, dto.FromFeedCategoryEntity(m))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// SaveCategory godoc
+// @Summary      Mapear categoría
+// @Description  Crea o reemplaza el mapeo de una categoría; los feeds lo toman en la próxima regeneración (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        mapping  body      dto.SaveFeedCategoryRequest  true  "Mapeo"
+// @Success      200      {object}  dto.FeedCategoryResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/feeds/categories [put]
+func (h *FeedHandler) SaveCategory(c echo.Context) error {
+	var req dto.SaveFeedCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	m, err := h.Svc.SaveCategoryMapping(c.Request().Context(), &entity.FeedCategoryMapping{
+		Category:       req.Category,
+		GoogleCategory: req.GoogleCategory,
+		MetaCategory:   req.MetaCategory,
+	})
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "category and google_category are required"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromFeedCategoryEntity(*m))
+}
+
+// DeleteCategory godoc
+// @Summary      Quitar mapeo de categoría
+// @Description  Elimina el mapeo; los productos de la categoría salen solo con product_type (solo admin)
+// @Tags         admin
+// @Param        category  query  string  true  "Categoría"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/feeds/categories [delete]
+func (h *FeedHandler) DeleteCategory(c echo.Context) error {
+	category := strings.TrimSpace(c.QueryParam("category"))
+	if category == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "category is required"})
+	}
+	if err := h.Svc.DeleteCategoryMapping(c.Request().Context(), category); err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "category mapping not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+	jwtutil "core/internal/presentation/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+type InventoryHandler struct {
+	Svc service.InventoryService
+}
+
+func NewInventoryHandler(s service.InventoryService) *InventoryHandler {
+	return &InventoryHandler{Svc: s}
+}
+
+// ListLocations godoc
+// @Summary      Listar ubicaciones
+// @Description  Lista locales y depósitos por prioridad de preparación (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.LocationResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/locations [get]
+func (h *InventoryHandler) ListLocations(c echo.Context) error {
+	locations, err := h.Svc.ListLocations(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.LocationResponse, 0, len(locations))
+	for _, l := range locations {
+		resp = append(resp, dto.FromLocationEntity(l))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CreateLocation godoc
+// @Summary      Crear ubicación
+// @Description  Da de alta un local o depósito. Marcarla por defecto desmarca la anterior (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        location  body      dto.LocationRequest  true  "Ubicación"
+// @Success      201  {object}  dto.LocationResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/locations [post]
+func (h *InventoryHandler) CreateLocation(c echo.Context) error {
+	var req dto.LocationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	l, err := h.Svc.CreateLocation(c.Request().Context(), req.ToEntity())
+	if err != nil {
+		return locationError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.FromLocationEntity(*l))
+}
+
+// UpdateLocation godoc
+// @Summary      Actualizar ubicación
+// @Description  Reemplaza los datos de una ubicación. Activarla o desactivarla recalcula el disponible para la venta (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                  true  "Location ID"
+// @Param        location  body      dto.LocationRequest  true  "Ubicación"
+// @Success      200  {object}  dto.LocationResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/locations/{id} [put]
+func (h *InventoryHandler) UpdateLocation(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.LocationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	location := req.ToEntity()
+	location.ID = id
+
+	l, err := h.Svc.UpdateLocation(c.Request().Context(), location)
+	if err != nil {
+		return locationError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromLocationEntity(*l))
+}
+
+func locationError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "location needs a code, a name and a kind (store or warehouse); the default location must stay active and can only be replaced by marking another one"})
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "location not found"})
+	case errors.ErrConflict:
+		return c.JSON(http.StatusConflict, map[string]string{"error": "location code already exists"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
+
+// ProductInventory godoc
+// @Summary      Stock por ubicación
+// @Description  Devuelve el stock del producto en cada ubicación y el disponible para la venta (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {object}  dto.ProductInventoryResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/inventory [get]
+func (h *InventoryHandler) ProductInventory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	inv, err := h.Svc.ProductInventory(c.Request().Context(), id)
+	if err != nil {
+		return inventoryError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromProductInventoryEntity(*inv))
+}
+
+// Adjust godoc
+// @Summary      Ajustar stock
+// @Description  Suma o resta unidades del producto en una ubicación (rotura, ingreso); el motivo queda en el historial de movimientos (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id          path  int                     true  "Product ID"
+// @Param        adjustment  body  dto.AdjustStockRequest  true  "Ajuste"
+// @Success      200  {object}  dto.ProductInventoryResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/inventory/adjust [post]
+func (h *InventoryHandler) Adjust(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.AdjustStockRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	actorID, _ := jwtutil.UserIDFromToken(c)
+	inv, err := h.Svc.Adjust(c.Request().Context(), id, req.LocationID, req.Delta, actorID, req.Reason)
+	if err != nil {
+		return inventoryError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromProductInventoryEntity(*inv))
+}
+
+// Transfer godoc
+// @Summary      Transferir stock
+// @Description  Mueve unidades de un producto entre dos ubicaciones en una sola operación (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        transfer  body      dto.StockTransferRequest  true  "Transferencia"
+// @Success      201  {object}  dto.StockTransferResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/inventory/transfers [post]
+func (h *InventoryHandler) Transfer(c echo.Context) error {
+	var req dto.StockTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	t := req.ToEntity()
+	t.ActorID, _ = jwtutil.UserIDFromToken(c)
+	t, err := h.Svc.Transfer(c.Request().Context(), t)
+	if err != nil {
+		return inventoryError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.FromStockTransferEntity(*t))
+}
+
+// ListTransfers godoc
+// @Summary      Listar transferencias
+// @Description  Lista las transferencias de stock, de la más reciente a la más antigua (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        product_id   query  int  false  "Producto"
+// @Param        location_id  query  int  false  "Ubicación de origen o destino"
+// @Param        limit        query  int  false  "Límite (<=100)"
+// @Param        offset       query  int  false  "Offset"
+// @Success      200  {array}   dto.StockTransferResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/inventory/transfers [get]
+func (h *InventoryHandler) ListTransfers(c echo.Context) error {
+	var filter entity.StockTransferFilter
+	if id, err := strconv.ParseInt(c.QueryParam("product_id"), 10, 64); err == nil {
+		filter.ProductID = id
+	}
+	if id, err := strconv.ParseInt(c.QueryParam("location_id"), 10, 64); err == nil {
+		filter.LocationID = id
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	transfers, err := h.Svc.ListTransfers(c.Request().Context(), filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.StockTransferResponse, 0, len(transfers))
+	for _, t := range transfers {
+		resp = append(resp, dto.FromStockTransferEntity(t))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Movements godoc
+// @Summary      Historial de movimientos de stock
+// @Description  Lista los movimientos del libro de stock del producto, del más reciente al más antiguo (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id           path   int     true   "Product ID"
+// @Param        location_id  query  int     false  "Ubicación"
+// @Param        kind         query  string  false  "Tipo (sale, return, adjustment, transfer, count, purchase)"
+// @Param        limit        query  int     false  "Límite (<=200)"
+// @Param        offset       query  int     false  "Offset"
+// @Success      200  {array}   dto.StockMovementResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/stock-movements [get]
+func (h *InventoryHandler) Movements(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	filter := entity.StockMovementFilter{ProductID: id, Kind: entity.StockMovementKind(c.QueryParam("kind"))}
+	if id, err := strconv.ParseInt(c.QueryParam("location_id"), 10, 64); err == nil {
+		filter.LocationID = id
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	movements, err := h.Svc.Movements(c.Request().Context(), filter)
+	switch err {
+	case nil:
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid movement kind"})
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.StockMovementResponse, 0, len(movements))
+	for _, m := range movements {
+		resp = append(resp, dto.FromStockMovementEntity(m))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func inventoryError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "quantity must be non-zero, adjustments need a reason and transfers need two different locations"})
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "product or location not found"})
+	case errors.ErrInsufficientStock:
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
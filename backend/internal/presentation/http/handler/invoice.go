@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+	jwtutil "core/internal/presentation/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+type InvoiceHandler struct {
+	Svc service.InvoiceService
+}
+
+func NewInvoiceHandler(s service.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{Svc: s}
+}
+
+// GetForOrder godoc
+// @Summary      Factura de una orden
+// @Description  Obtiene la factura autorizada de una orden del usuario autenticado
+// @Tags         orders
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  dto.InvoiceResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/orders/{id}/invoice [get]
+func (h *InvoiceHandler) GetForOrder(c echo.Context) error {
+	inv, err := h.customerInvoice(c)
+	if err != nil || inv == nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, dto.FromInvoiceEntity(*inv))
+}
+
+// GetForOrderPDF godoc
+// @Summary      Factura de una orden en PDF
+// @Description  Descarga el PDF de la factura autorizada de una orden del usuario autenticado
+// @Tags         orders
+// @Produce      application/pdf
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/orders/{id}/invoice/pdf [get]
+func (h *InvoiceHandler) GetForOrderPDF(c echo.Context) error {
+	inv, err := h.customerInvoice(c)
+	if err != nil || inv == nil {
+		return err
+	}
+	return h.pdf(c, inv)
+}
+
+// customerInvoice busca la factura de la orden del path; si no la encuentra ya escribió la respuesta y retorna nil
+func (h *InvoiceHandler) customerInvoice(c echo.Context) (*entity.Invoice, error) {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return nil, c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || orderID <= 0 {
+		return nil, c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	inv, err := h.Svc.GetForOrder(c.Request().Context(), userID, orderID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, c.JSON(http.StatusNotFound, map[string]string{"error": "invoice not found"})
+		}
+		return nil, c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return inv, nil
+}
+
+func (h *InvoiceHandler) pdf(c echo.Context, inv *entity.Invoice) error {
+	body, err := h.Svc.RenderPDF(inv)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to render invoice"})
+	}
+	filename := fmt.Sprintf("factura-%s-%s.pdf", inv.Type, inv.FullNumber())
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="`+filename+`"`)
+	return c.Blob(http.StatusOK, "application/pdf", body)
+}
+
+// List godoc
+// @Summary      Listar facturas
+// @Description  Lista las facturas emitidas, de la más reciente a la más antigua (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        order_id  query  int     false  "Orden"
+// @Param        status    query  string  false  "Estado (pending,authorized,rejected)"
+// @Param        limit     query  int     false  "Límite (<=100)"
+// @Param        offset    query  int     false  "Offset"
+// @Success      200  {array}   dto.InvoiceResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/invoices [get]
+func (h *InvoiceHandler) List(c echo.Context) error {
+	filter := entity.InvoiceFilter{Status: entity.InvoiceStatus(c.QueryParam("status"))}
+	if id, err := strconv.ParseInt(c.QueryParam("order_id"), 10, 64); err == nil {
+		filter.OrderID = id
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	invoices, err := h.Svc.List(c.Request().Context(), filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.InvoiceResponse, 0, len(invoices))
+	for _, inv := range invoices {
+		resp = append(resp, dto.FromInvoiceEntity(inv))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetByID godoc
+// @Summary      Obtener factura
+// @Description  Obtiene una factura en cualquier estado (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Invoice ID"
+// @Success      200  {object}  dto.InvoiceResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/invoices/{id} [get]
+func (h *InvoiceHandler) GetByID(c echo.Context) error {
+	inv, err := h.adminInvoice(c)
+	if err != nil || inv == nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, dto.FromInvoiceEntity(*inv))
+}
+
+// GetPDF godoc
+// @Summary      Factura en PDF
+// @Description  Descarga el PDF de una factura; si no está autorizada se marca sin validez fiscal (solo admin)
+// @Tags         admin
+// @Produce      application/pdf
+// @Param        id   path      int  true  "Invoice ID"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/invoices/{id}/pdf [get]
+func (h *InvoiceHandler) GetPDF(c echo.Context) error {
+	inv, err := h.adminInvoice(c)
+	if err != nil || inv == nil {
+		return err
+	}
+	return h.pdf(c, inv)
+}
+
+func (h *InvoiceHandler) adminInvoice(c echo.Context) (*entity.Invoice, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return nil, c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	inv, err := h.Svc.GetByID(c.Request().Context(), id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, c.JSON(http.StatusNotFound, map[string]string{"error": "invoice not found"})
+		}
+		return nil, c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return inv, nil
+}
+
+// Submit godoc
+// @Summary      Reintentar autorización
+// @Description  Vuelve a enviar al organismo fiscal una factura pendiente o rechazada (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Invoice ID"
+// @Success      200  {object}  dto.InvoiceResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/invoices/{id}/submit [post]
+func (h *InvoiceHandler) Submit(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	inv, err := h.Svc.Submit(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "invoice not found"})
+		case errors.ErrConflict:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "invoice is already authorized or was submitted concurrently"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromInvoiceEntity(*inv))
+}
+
+// IssueForOrder godoc
+// @Summary      Facturar orden
+// @Description  Emite y envía a autorizar la factura de una orden pagada sin esperar al scheduler; si ya existe la retorna (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  dto.InvoiceResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/orders/{id}/invoice [post]
+func (h *InvoiceHandler) IssueForOrder(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	inv, err := h.Svc.IssueForOrder(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "order not found"})
+		case errors.ErrInvalidTransition:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "only paid orders can be invoiced"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromInvoiceEntity(*inv))
+}
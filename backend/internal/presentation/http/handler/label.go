@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/barcode"
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LabelHandler struct {
+	Svc service.LabelService
+}
+
+func NewLabelHandler(s service.LabelService) *LabelHandler {
+	return &LabelHandler{Svc: s}
+}
+
+// BarCode godoc
+// @Summary      Imagen del código de barras
+// @Description  Dibuja el código de barras EAN-13 del producto. El PNG lleva solo las barras y el SVG también los dígitos (solo admin)
+// @Tags         admin
+// @Produce      image/png
+// @Produce      image/svg+xml
+// @Param        id      path   int     true   "Product ID"
+// @Param        format  query  string  false  "Formato (png, svg), por defecto png"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/barcode [get]
+func (h *LabelHandler) BarCode(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	format := entity.BarCodeFormat(c.QueryParam("format"))
+	if format == "" {
+		format = entity.BarCodeFormatPNG
+	}
+	body, err := h.Svc.BarCode(c.Request().Context(), id, format)
+	if err != nil {
+		return labelError(c, err)
+	}
+	contentType := "image/png"
+	if format == entity.BarCodeFormatSVG {
+		contentType = "image/svg+xml"
+	}
+	return c.Blob(http.StatusOK, contentType, body)
+}
+
+// Sheet godoc
+// @Summary      Hoja de etiquetas
+// @Description  Genera un PDF A4 de 3x8 etiquetas de 70x37 mm con título, talle, precio vigente y código de barras, hasta 480 etiquetas (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      application/pdf
+// @Param        labels  body  dto.LabelSheetRequest  true  "Productos y copias"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/labels [post]
+func (h *LabelHandler) Sheet(c echo.Context) error {
+	var req dto.LabelSheetRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	body, err := h.Svc.Sheet(c.Request().Context(), req.ToEntities())
+	if err != nil {
+		return labelError(c, err)
+	}
+	filename := fmt.Sprintf("etiquetas-%d.pdf", len(req.Items))
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="`+filename+`"`)
+	return c.Blob(http.StatusOK, "application/pdf", body)
+}
+
+func labelError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "format must be png or svg, and a sheet needs between 1 and 480 labels"})
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+	case barcode.ErrInvalidBarCode:
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "product bar code is not a valid EAN-13, assign a generated one first"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
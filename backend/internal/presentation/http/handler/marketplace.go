@@ -0,0 +1,303 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MarketplaceHandler struct {
+	Svc service.MarketplaceService
+}
+
+func NewMarketplaceHandler(s service.MarketplaceService) *MarketplaceHandler {
+	return &MarketplaceHandler{Svc: s}
+}
+
+// ListListings godoc
+// @Summary      Listar publicaciones en marketplaces
+// @Description  Lista los productos vinculados con publicaciones, con los últimos valores enviados y el error del último envío si falló (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        marketplace  query  string  false  "Marketplace"
+// @Param        product_id   query  int     false  "Product ID"
+// @Param        status       query  string  false  "Estado (active, paused)"
+// @Param        limit        query  int     false  "Límite (<=500)"
+// @Param        offset       query  int     false  "Offset"
+// @Success      200  {array}   dto.MarketplaceListingResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/marketplace/listings [get]
+func (h *MarketplaceHandler) ListListings(c echo.Context) error {
+	filter := entity.MarketplaceListingFilter{
+		Marketplace: c.QueryParam("marketplace"),
+		Status:      entity.MarketplaceListingStatus(c.QueryParam("status")),
+	}
+	if v := c.QueryParam("product_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid product_id"})
+		}
+		filter.ProductID = id
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	listings, err := h.Svc.ListListings(c.Request().Context(), filter)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.MarketplaceListingResponse, 0, len(listings))
+	for _, l := range listings {
+		resp = append(resp, dto.FromMarketplaceListingEntity(l))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// LinkListing godoc
+// @Summary      Vincular producto con un marketplace
+// @Description  Vincula el producto con una publicación existente, que recibe su stock y su precio en la próxima sincronización, o sin external_id lo publica con sus imágenes. Un producto tiene a lo sumo una publicación por marketplace (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        listing  body      dto.LinkMarketplaceListingRequest  true  "Vínculo"
+// @Success      201      {object}  dto.MarketplaceListingResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      502      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/marketplace/listings [post]
+func (h *MarketplaceHandler) LinkListing(c echo.Context) error {
+	var req dto.LinkMarketplaceListingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	if req.ProductID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "product_id is required"})
+	}
+	l, err := h.Svc.LinkListing(c.Request().Context(), req.Marketplace, req.ProductID, req.ExternalID)
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "unknown marketplace"})
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "product or marketplace item not found"})
+		case errors.ErrConflict:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "the product or the item is already linked"})
+		}
+		return c.JSON(http.StatusBadGateway, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, dto.FromMarketplaceListingEntity(*l))
+}
+
+// GetListing godoc
+// @Summary      Ver publicación en marketplace
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Listing ID"
+// @Success      200  {object}  dto.MarketplaceListingResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/marketplace/listings/{id} [get]
+func (h *MarketplaceHandler) GetListing(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	l, err := h.Svc.GetListing(c.Request().Context(), id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "listing not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromMarketplaceListingEntity(*l))
+}
+
+// UpdateListingStatus godoc
+// @Summary      Pausar o reanudar publicación
+// @Description  Una publicación pausada no recibe stock ni precio; sus ventas se siguen importando (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                                          true  "Listing ID"
+// @Param        status  body      dto.UpdateMarketplaceListingStatusRequest  true  "Estado"
+// @Success      200     {object}  dto.MarketplaceListingResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/marketplace/listings/{id}/status [put]
+func (h *MarketplaceHandler) UpdateListingStatus(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.UpdateMarketplaceListingStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	l, err := h.Svc.SetListingStatus(c.Request().Context(), id, entity.MarketplaceListingStatus(req.Status))
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status. Allowed: active, paused"})
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "listing not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromMarketplaceListingEntity(*l))
+}
+
+// UnlinkListing godoc
+// @Summary      Desvincular publicación
+// @Description  Deja de sincronizar la publicación; no la cierra en el marketplace (solo admin)
+// @Tags         admin
+// @Param        id  path  int  true  "Listing ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/marketplace/listings/{id} [delete]
+func (h *MarketplaceHandler) UnlinkListing(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	if err := h.Svc.UnlinkListing(c.Request().Context(), id); err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "listing not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Sync godoc
+// @Summary      Sincronizar marketplaces
+// @Description  Importa las ventas nuevas y envía el stock y el precio de las publicaciones que cambiaron, sin esperar al scheduler. Si no se pudieron traer las ventas de un marketplace no se le envía stock (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   entity.MarketplaceSync
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/marketplace/sync [post]
+func (h *MarketplaceHandler) Sync(c echo.Context) error {
+	out, err := h.Svc.Sync(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// Reconcile godoc
+// @Summary      Conciliar publicaciones
+// @Description  Compara cada publicación activa con su producto y lista las diferencias de estado, stock y precio. Con fix=true envía los valores locales a las que difieren en stock o precio (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        fix  query  bool  false  "Corregir las diferencias"
+// @Success      200  {array}   entity.MarketplaceDiscrepancy
+// @Failure      400  {object}  map[string]string
+// @Failure      502  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/marketplace/reconcile [post]
+func (h *MarketplaceHandler) Reconcile(c echo.Context) error {
+	var fix bool
+	if v := c.QueryParam("fix"); v != "" {
+		var err error
+		if fix, err = strconv.ParseBool(v); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid fix"})
+		}
+	}
+	out, err := h.Svc.Reconcile(c.Request().Context(), fix)
+	if err != nil {
+		return c.JSON(http.StatusBadGateway, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// ListOrders godoc
+// @Summary      Listar ventas de marketplaces
+// @Description  Lista las ventas importadas, de la más reciente a la más antigua. Las que están en review tienen artículos sin vincular o vendidos sin stock (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        marketplace  query  string  false  "Marketplace"
+// @Param        status       query  string  false  "Estado (imported, review)"
+// @Param        limit        query  int     false  "Límite (<=100)"
+// @Param        offset       query  int     false  "Offset"
+// @Success      200  {array}   dto.MarketplaceOrderResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/marketplace/orders [get]
+func (h *MarketplaceHandler) ListOrders(c echo.Context) error {
+	filter := entity.MarketplaceOrderFilter{
+		Marketplace: c.QueryParam("marketplace"),
+		Status:      entity.MarketplaceOrderStatus(c.QueryParam("status")),
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	orders, err := h.Svc.ListOrders(c.Request().Context(), filter)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.MarketplaceOrderResponse, 0, len(orders))
+	for _, o := range orders {
+		resp = append(resp, dto.FromMarketplaceOrderEntity(o))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetOrder godoc
+// @Summary      Ver venta de marketplace
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Marketplace order ID"
+// @Success      200  {object}  dto.MarketplaceOrderResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/marketplace/orders/{id} [get]
+func (h *MarketplaceHandler) GetOrder(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	o, err := h.Svc.GetOrder(c.Request().Context(), id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "marketplace order not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromMarketplaceOrderEntity(*o))
+}
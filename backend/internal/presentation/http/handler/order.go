@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+	jwtutil "core/internal/presentation/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+type OrderHandler struct {
+	Svc service.OrderService
+}
+
+func NewOrderHandler(s service.OrderService) *OrderHandler {
+	return &OrderHandler{Svc: s}
+}
+
+// Create godoc
+// @Summary      Crear orden
+// @Description  Hace checkout de los productos indicados, descuenta stock y congela precios y tasa de cambio
+// @Tags         orders
+// @Accept       json
+// @Produce      json
+// @Param        order  body      dto.CreateOrderRequest  true  "Orden"
+// @Param        Accept-Currency header string false "Moneda de la orden si no se indica en el body"
+// @Success      201    {object}  dto.OrderResponse
+// @Failure      400    {object}  map[string]string
+// @Failure      401    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Failure      422    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/orders [post]
+func (h *OrderHandler) Create(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+
+	var req dto.CreateOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	currency, err := orderCurrency(c, req.Currency)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported currency"})
+	}
+
+	checkout := req.ToCheckout(userID, currency)
+	order, err := h.Svc.Place(c.Request().Context(), checkout)
+	if err != nil {
+		if err == errors.ErrCouponRejected {
+			// Se devuelve la valuación para que el cliente vea por qué no aplicó el cupón
+			quote, qerr := h.Svc.Quote(c.Request().Context(), checkout)
+			if qerr == nil {
+				return c.JSON(http.StatusUnprocessableEntity, dto.FromCartQuote(*quote))
+			}
+		}
+		return orderError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.FromOrderEntity(*order))
+}
+
+// Quote godoc
+// @Summary      Valuar carrito
+// @Description  Calcula precios, promociones automáticas, cupones y envíos disponibles del carrito sin crear la orden, explicando qué reglas se aplicaron y cuáles no
+// @Tags         orders
+// @Accept       json
+// @Produce      json
+// @Param        cart  body      dto.CreateOrderRequest  true  "Carrito"
+// @Param        Accept-Currency header string false "Moneda del carrito si no se indica en el body"
+// @Success      200   {object}  dto.CartQuoteResponse
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Failure      422   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/cart/evaluate [post]
+func (h *OrderHandler) Quote(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+
+	var req dto.CreateOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	currency, err := orderCurrency(c, req.Currency)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported currency"})
+	}
+
+	quote, err := h.Svc.Quote(c.Request().Context(), req.ToCheckout(userID, currency))
+	if err != nil {
+		return orderError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromCartQuote(*quote))
+}
+
+// orderCurrency usa la moneda del body o, si no viene, la de visualización de la request
+func orderCurrency(c echo.Context, code string) (money.Currency, error) {
+	if code != "" {
+		return money.ParseCurrency(code)
+	}
+	return displayCurrency(c)
+}
+
+func orderError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "order must have items with positive quantities and addresses from the user's address book"})
+	case errors.ErrInvalidTaxID:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "billing tax_id must be a valid CUIT/CUIL or DNI"})
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+	case errors.ErrInsufficientStock, errors.ErrPromotionLimit:
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.ErrCouponRejected, errors.ErrShippingUnavailable:
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+	return currencyError(c, err)
+}
+
+// List godoc
+// @Summary      Mis órdenes
+// @Description  Lista las órdenes del usuario autenticado
+// @Tags         orders
+// @Produce      json
+// @Param        status  query  string  false  "Estado (pending,paid,cancelled)"
+// @Param        limit   query  int     false  "Límite (<=100)"
+// @Param        offset  query  int     false  "Offset"
+// @Success      200  {array}   dto.OrderResponse
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/orders [get]
+func (h *OrderHandler) List(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	filter := orderFilter(c)
+	filter.UserID = userID
+	return h.list(c, filter)
+}
+
+// GetByID godoc
+// @Summary      Obtener orden
+// @Description  Obtiene una orden del usuario autenticado
+// @Tags         orders
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  dto.OrderResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/orders/{id} [get]
+func (h *OrderHandler) GetByID(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	order, err := h.Svc.GetForUser(c.Request().Context(), userID, id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "order not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromOrderEntity(*order))
+}
+
+// AdminList godoc
+// @Summary      Listar órdenes (admin)
+// @Description  Lista las órdenes de todos los usuarios (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        user_id  query  int     false  "Usuario"
+// @Param        status   query  string  false  "Estado (pending,paid,cancelled)"
+// @Param        limit    query  int     false  "Límite (<=100)"
+// @Param        offset   query  int     false  "Offset"
+// @Success      200  {array}   dto.OrderResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/orders [get]
+func (h *OrderHandler) AdminList(c echo.Context) error {
+	filter := orderFilter(c)
+	if id, err := strconv.ParseInt(c.QueryParam("user_id"), 10, 64); err == nil {
+		filter.UserID = id
+	}
+	return h.list(c, filter)
+}
+
+// UpdateStatus godoc
+// @Summary      Cambiar estado de orden (admin)
+// @Description  Marca una orden pendiente como pagada (queda lista para facturar) o cancelada (devuelve el stock)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int                           true  "Order ID"
+// @Param        status  body  dto.UpdateOrderStatusRequest  true  "Nuevo estado"
+// @Success      200  {object}  dto.OrderResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/orders/{id}/status [put]
+func (h *OrderHandler) UpdateStatus(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.UpdateOrderStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	status := entity.OrderStatus(req.Status)
+	if status != entity.OrderStatusPaid && status != entity.OrderStatusCancelled {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "status must be paid or cancelled"})
+	}
+
+	order, err := h.Svc.UpdateStatus(c.Request().Context(), id, status)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "order not found"})
+		case errors.ErrInvalidTransition:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "only pending orders can be paid or cancelled"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromOrderEntity(*order))
+}
+
+func orderFilter(c echo.Context) entity.OrderFilter {
+	filter := entity.OrderFilter{Status: entity.OrderStatus(c.QueryParam("status"))}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+	return filter
+}
+
+func (h *OrderHandler) list(c echo.Context, filter entity.OrderFilter) error {
+	orders, err := h.Svc.List(c.Request().Context(), filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.OrderResponse, 0, len(orders))
+	for _, o := range orders {
+		resp = append(resp, dto.FromOrderEntity(o))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type OutboxHandler struct {
+	Svc service.OutboxService
+}
+
+func NewOutboxHandler(s service.OutboxService) *OutboxHandler {
+	return &OutboxHandler{Svc: s}
+}
+
+// List godoc
+// @Summary      Eventos del outbox
+// @Description  Lista los eventos de dominio, del más reciente al más antiguo, con su estado de publicación y el error del último intento. Un evento pendiente bloquea a los siguientes de su mismo agregado (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        status          query  string  false  "Estado (pending, published)"
+// @Param        aggregate_type  query  string  false  "Tipo de agregado (product, order)"
+// @Param        aggregate_id    query  int     false  "ID del agregado"
+// @Param        limit           query  int     false  "Límite (<=100)"
+// @Param        offset          query  int     false  "Offset"
+// @Success      200  {array}   dto.OutboxEventResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/outbox [get]
+func (h *OutboxHandler) List(c echo.Context) error {
+	filter := entity.OutboxFilter{
+		Status:        entity.OutboxStatus(c.QueryParam("status")),
+		AggregateType: c.QueryParam("aggregate_type"),
+	}
+	if v := c.QueryParam("aggregate_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid aggregate_id"})
+		}
+		filter.AggregateID = id
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	events, err := h.Svc.List(c.Request().Context(), filter)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.OutboxEventResponse, 0, len(events))
+	for _, e := range events {
+		resp = append(resp, dto.FromOutboxEventEntity(e))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,392 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+	jwtutil "core/internal/presentation/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PosHandler struct {
+	Svc service.PosService
+}
+
+func NewPosHandler(s service.PosService) *PosHandler {
+	return &PosHandler{Svc: s}
+}
+
+// OpenShift godoc
+// @Summary      Abrir turno de caja
+// @Description  Abre un turno del cajero en una caja de una ubicación activa con el fondo inicial en efectivo. Cada cajero y cada caja tienen a lo sumo un turno abierto (cajero o admin)
+// @Tags         pos
+// @Accept       json
+// @Produce      json
+// @Param        shift  body  dto.OpenShiftRequest  true  "Turno"
+// @Success      201  {object}  dto.RegisterShiftResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/pos/shifts [post]
+func (h *PosHandler) OpenShift(c echo.Context) error {
+	var req dto.OpenShiftRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	cashierID, _ := jwtutil.UserIDFromToken(c)
+	sh, err := req.ToEntity(cashierID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	sh, err = h.Svc.OpenShift(c.Request().Context(), sh)
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "register and an active location are required and the opening float cannot be negative"})
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "location not found"})
+		case errors.ErrConflict:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "the cashier or the register already has an open shift"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusCreated, dto.FromRegisterShiftEntity(*sh))
+}
+
+// CurrentShift godoc
+// @Summary      Turno actual
+// @Description  Retorna el turno abierto del cajero con el arqueo parcial: ventas cobradas y efectivo esperado en la caja (cajero o admin)
+// @Tags         pos
+// @Produce      json
+// @Success      200  {object}  dto.ShiftReportResponse
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/pos/shifts/current [get]
+func (h *PosHandler) CurrentShift(c echo.Context) error {
+	cashierID, _ := jwtutil.UserIDFromToken(c)
+	report, err := h.Svc.CurrentShift(c.Request().Context(), cashierID)
+	if err != nil {
+		return shiftError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromShiftReportEntity(*report))
+}
+
+// CloseShift godoc
+// @Summary      Cerrar turno de caja
+// @Description  Cierra el turno abierto del cajero con el efectivo contado y retorna el arqueo: esperado (fondo más efectivo cobrado neto del vuelto) contra contado. Las ventas sin cobrar se anulan (cajero o admin)
+// @Tags         pos
+// @Accept       json
+// @Produce      json
+// @Param        close  body  dto.CloseShiftRequest  true  "Cierre"
+// @Success      200  {object}  dto.ShiftReportResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/pos/shifts/current/close [post]
+func (h *PosHandler) CloseShift(c echo.Context) error {
+	var req dto.CloseShiftRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	counted, err := money.Parse(req.CountedCash.String(), money.Base, money.RoundUnnecessary)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	cashierID, _ := jwtutil.UserIDFromToken(c)
+	report, err := h.Svc.CloseShift(c.Request().Context(), cashierID, counted, req.Note)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "counted cash cannot be negative"})
+		}
+		return shiftError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromShiftReportEntity(*report))
+}
+
+// ListShifts godoc
+// @Summary      Listar turnos de caja
+// @Description  Lista los turnos, del más reciente al más antiguo (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        status       query  string  false  "Estado (open, closed)"
+// @Param        cashier_id   query  int     false  "Cajero"
+// @Param        location_id  query  int     false  "Ubicación"
+// @Param        limit        query  int     false  "Límite (<=100)"
+// @Param        offset       query  int     false  "Offset"
+// @Success      200  {array}   dto.RegisterShiftResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/pos/shifts [get]
+func (h *PosHandler) ListShifts(c echo.Context) error {
+	filter := entity.RegisterShiftFilter{Status: entity.RegisterShiftStatus(c.QueryParam("status"))}
+	if id, err := strconv.ParseInt(c.QueryParam("cashier_id"), 10, 64); err == nil {
+		filter.CashierID = id
+	}
+	if id, err := strconv.ParseInt(c.QueryParam("location_id"), 10, 64); err == nil {
+		filter.LocationID = id
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	shifts, err := h.Svc.ListShifts(c.Request().Context(), filter)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.RegisterShiftResponse, 0, len(shifts))
+	for _, sh := range shifts {
+		resp = append(resp, dto.FromRegisterShiftEntity(sh))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ShiftReport godoc
+// @Summary      Arqueo de un turno
+// @Description  Retorna el arqueo de un turno: ventas cobradas por medio de pago, efectivo esperado, contado y diferencia (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Shift ID"
+// @Success      200  {object}  dto.ShiftReportResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/pos/shifts/{id} [get]
+func (h *PosHandler) ShiftReport(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	report, err := h.Svc.ShiftReport(c.Request().Context(), id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "shift not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromShiftReportEntity(*report))
+}
+
+// StartSale godoc
+// @Summary      Nueva venta
+// @Description  Abre una venta vacía en el turno del cajero (cajero o admin)
+// @Tags         pos
+// @Produce      json
+// @Success      201  {object}  dto.PosSaleResponse
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/pos/sales [post]
+func (h *PosHandler) StartSale(c echo.Context) error {
+	cashierID, _ := jwtutil.UserIDFromToken(c)
+	sale, err := h.Svc.StartSale(c.Request().Context(), cashierID)
+	if err != nil {
+		return shiftError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.FromPosSaleEntity(*sale))
+}
+
+// GetSale godoc
+// @Summary      Ver venta
+// @Description  Retorna la venta con su valuación vigente (precios, promociones e IVA) o, si ya se cobró, con su orden (cajero o admin)
+// @Tags         pos
+// @Produce      json
+// @Param        id  path  int  true  "Sale ID"
+// @Success      200  {object}  dto.PosSaleResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/pos/sales/{id} [get]
+func (h *PosHandler) GetSale(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	cashierID, _ := jwtutil.UserIDFromToken(c)
+	sale, err := h.Svc.GetSale(c.Request().Context(), cashierID, id)
+	if err != nil {
+		return posSaleError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromPosSaleEntity(*sale))
+}
+
+// Scan godoc
+// @Summary      Escanear producto
+// @Description  Suma a la venta las unidades del producto del código de barras; una cantidad negativa las quita (cajero o admin)
+// @Tags         pos
+// @Accept       json
+// @Produce      json
+// @Param        id    path  int                 true  "Sale ID"
+// @Param        scan  body  dto.PosScanRequest  true  "Escaneo"
+// @Success      200  {object}  dto.PosSaleResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/pos/sales/{id}/scans [post]
+func (h *PosHandler) Scan(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.PosScanRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	cashierID, _ := jwtutil.UserIDFromToken(c)
+	sale, err := h.Svc.Scan(c.Request().Context(), cashierID, id, req.BarCode, req.Quantity)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid bar code, quantity below zero or too many lines in the sale"})
+		}
+		return posSaleError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromPosSaleEntity(*sale))
+}
+
+// Checkout godoc
+// @Summary      Cobrar venta
+// @Description  Cobra la venta en efectivo y/o tarjeta: confirma la orden descontando el stock de la ubicación de la caja, la marca pagada y calcula el vuelto. La tarjeta no puede superar el total (cajero o admin)
+// @Tags         pos
+// @Accept       json
+// @Produce      json
+// @Param        id        path  int                     true  "Sale ID"
+// @Param        checkout  body  dto.PosCheckoutRequest  true  "Cobro"
+// @Success      200  {object}  dto.PosSaleResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/pos/sales/{id}/checkout [post]
+func (h *PosHandler) Checkout(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.PosCheckoutRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	payments, billing, err := req.ToEntities()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	cashierID, _ := jwtutil.UserIDFromToken(c)
+	sale, err := h.Svc.Checkout(c.Request().Context(), cashierID, id, payments, billing)
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "the sale needs items and payments must be cash or card with positive amounts, card not above the total"})
+		case errors.ErrInvalidTaxID:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "billing tax_id must be a valid CUIT/CUIL or DNI"})
+		case errors.ErrPaymentShort:
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+		}
+		return posSaleError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromPosSaleEntity(*sale))
+}
+
+// VoidSale godoc
+// @Summary      Anular venta
+// @Description  Anula una venta sin cobrar; no toca el stock (cajero o admin)
+// @Tags         pos
+// @Produce      json
+// @Param        id  path  int  true  "Sale ID"
+// @Success      200  {object}  dto.PosSaleResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/pos/sales/{id}/void [post]
+func (h *PosHandler) VoidSale(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	cashierID, _ := jwtutil.UserIDFromToken(c)
+	sale, err := h.Svc.VoidSale(c.Request().Context(), cashierID, id)
+	if err != nil {
+		return posSaleError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromPosSaleEntity(*sale))
+}
+
+// Receipt godoc
+// @Summary      Ticket de la venta
+// @Description  Retorna el ticket en PDF de 80 mm de una venta cobrada, con ítems, descuentos, cobros y vuelto (cajero o admin)
+// @Tags         pos
+// @Produce      application/pdf
+// @Param        id  path  int  true  "Sale ID"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/pos/sales/{id}/receipt [get]
+func (h *PosHandler) Receipt(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	cashierID, _ := jwtutil.UserIDFromToken(c)
+	body, err := h.Svc.Receipt(c.Request().Context(), cashierID, id)
+	if err != nil {
+		if err == errors.ErrInvalidTransition {
+			return c.JSON(http.StatusConflict, map[string]string{"error": "sale has not been charged"})
+		}
+		return posSaleError(c, err)
+	}
+	filename := fmt.Sprintf("ticket-%d.pdf", id)
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="`+filename+`"`)
+	return c.Blob(http.StatusOK, "application/pdf", body)
+}
+
+func shiftError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "no open shift for the cashier"})
+	case errors.ErrInvalidTransition:
+		return c.JSON(http.StatusConflict, map[string]string{"error": "shift is already closed"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
+
+func posSaleError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "sale or product not found"})
+	case errors.ErrInvalidTransition:
+		return c.JSON(http.StatusConflict, map[string]string{"error": "sale is not open"})
+	case errors.ErrInsufficientStock, errors.ErrPromotionLimit:
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return currencyError(c, err)
+}
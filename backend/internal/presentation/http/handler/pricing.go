@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PricingHandler struct {
+	Svc service.PricingService
+}
+
+func NewPricingHandler(s service.PricingService) *PricingHandler {
+	return &PricingHandler{Svc: s}
+}
+
+// UpdatePricing godoc
+// @Summary      Configurar precio de referencia y oferta
+// @Description  Reemplaza compare-at price, precio de oferta y su ventana de vigencia (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                       true  "Product ID"
+// @Param        pricing  body      dto.UpdatePricingRequest  true  "Precios"
+// @Success      200      {object}  dto.PricingResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/pricing [put]
+func (h *PricingHandler) UpdatePricing(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	var req dto.UpdatePricingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	p, err := h.Svc.UpdatePricing(c.Request().Context(), req.ToEntity(id))
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "sale price must be lower than unit price and the sale window must be valid"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+
+	return c.JSON(http.StatusOK, dto.FromPricingEntity(*p))
+}
+
+// CreatePriceChange godoc
+// @Summary      Programar cambio de precio
+// @Description  Programa un nuevo precio de lista que se aplica automáticamente en effective_at (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                           true  "Product ID"
+// @Param        change  body      dto.CreatePriceChangeRequest  true  "Cambio de precio"
+// @Success      201     {object}  dto.PriceChangeResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/price-changes [post]
+func (h *PricingHandler) CreatePriceChange(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	var req dto.CreatePriceChangeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	change, err := h.Svc.SchedulePriceChange(c.Request().Context(), req.ToEntity(id))
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "unit_price must be positive and effective_at in the future"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+
+	return c.JSON(http.StatusCreated, dto.FromPriceChangeEntity(*change))
+}
+
+// ListPriceChanges godoc
+// @Summary      Listar cambios de precio
+// @Description  Lista los cambios de precio programados y aplicados de un producto (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {array}   dto.PriceChangeResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/price-changes [get]
+func (h *PricingHandler) ListPriceChanges(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	changes, err := h.Svc.ListPriceChanges(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+
+	response := make([]dto.PriceChangeResponse, 0, len(changes))
+	for _, ch := range changes {
+		response = append(response, dto.FromPriceChangeEntity(ch))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// DeletePriceChange godoc
+// @Summary      Cancelar cambio de precio
+// @Description  Elimina un cambio de precio pendiente (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id        path  int  true  "Product ID"
+// @Param        changeId  path  int  true  "Price change ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/price-changes/{changeId} [delete]
+func (h *PricingHandler) DeletePriceChange(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	changeID, err := strconv.ParseInt(c.Param("changeId"), 10, 64)
+	if err != nil || changeID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid price change id"})
+	}
+
+	if err := h.Svc.CancelPriceChange(c.Request().Context(), id, changeID); err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "price change not found"})
+		case errors.ErrConflict:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "price change already applied"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"core/internal/domain/barcode"
+	"core/internal/domain/entity"
 	"core/internal/domain/errors"
 	"core/internal/domain/service"
 
@@ -13,11 +15,12 @@ import (
 )
 
 type ProductHandler struct {
-	Svc service.ProductService
+	Svc      service.ProductService
+	Currency service.CurrencyService
 }
 
-func NewProductHandler(s service.ProductService) *ProductHandler {
-	return &ProductHandler{Svc: s}
+func NewProductHandler(s service.ProductService, currency service.CurrencyService) *ProductHandler {
+	return &ProductHandler{Svc: s, Currency: currency}
 }
 
 // List godoc
@@ -30,9 +33,17 @@ func NewProductHandler(s service.ProductService) *ProductHandler {
 // @Param        q        query string false "Búsqueda en título/desc"
 // @Param        limit    query int    false "Límite (<=100)"
 // @Param        offset   query int    false "Offset"
+// @Param        currency query string false "Moneda de visualización (ARS,UYU,CLP,USD)"
+// @Param        Accept-Currency header string false "Moneda de visualización si no se indica por query"
 // @Success      200 {array} dto.ProductResponse
+// @Failure      400 {object} map[string]string
 // @Router       /api/products [get]
 func (h *ProductHandler) List(c echo.Context) error {
+	currency, err := displayCurrency(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported currency"})
+	}
+
 	cursor := c.QueryParam("cursor")
 	num := int64(20)
 	if n := c.QueryParam("num"); n != "" {
@@ -46,9 +57,14 @@ func (h *ProductHandler) List(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
 	}
 
+	localized, _, err := h.Currency.Localize(c.Request().Context(), ps, currency)
+	if err != nil {
+		return currencyError(c, err)
+	}
+
 	var productResponses []dto.ProductResponse
-	for _, p := range ps {
-		productResponses = append(productResponses, dto.FromEntity(p))
+	for _, lp := range localized {
+		productResponses = append(productResponses, dto.FromLocalizedEntity(lp.Product, lp.PriceSource))
 	}
 
 	response := map[string]interface{}{
@@ -61,7 +77,7 @@ func (h *ProductHandler) List(c echo.Context) error {
 
 // Create godoc
 // @Summary      Crear producto
-// @Description  Crea un nuevo producto (solo admin)
+// @Description  Crea un nuevo producto (solo admin). El código de barras debe ser un EAN-13 o UPC-A con dígito verificador correcto
 // @Tags         products
 // @Accept       json
 // @Produce      json
@@ -92,6 +108,8 @@ func (h *ProductHandler) Create(c echo.Context) error {
 // @Tags         products
 // @Produce      json
 // @Param        id   path      int  true  "Product ID"
+// @Param        currency query string false "Moneda de visualización (ARS,UYU,CLP,USD)"
+// @Param        Accept-Currency header string false "Moneda de visualización si no se indica por query"
 // @Success      200  {object}  dto.ProductResponse
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
@@ -103,7 +121,12 @@ func (h *ProductHandler) GetByID(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
 	}
 
-	p, err := h.Svc.GetByID(c.Request().Context(), id)
+	currency, err := displayCurrency(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported currency"})
+	}
+
+	p, err := h.Svc.GetVisibleByID(c.Request().Context(), id)
 	if err != nil {
 		if err == errors.ErrNotFound {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
@@ -111,12 +134,84 @@ func (h *ProductHandler) GetByID(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
 	}
 
-	return c.JSON(http.StatusOK, dto.FromEntity(*p))
+	localized, _, err := h.Currency.Localize(c.Request().Context(), []entity.Product{*p}, currency)
+	if err != nil {
+		return currencyError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.FromLocalizedEntity(localized[0].Product, localized[0].PriceSource))
+}
+
+// GetByBarCode godoc
+// @Summary      Obtener producto por código de barras
+// @Description  Busca un producto publicado por su EAN-13 o UPC-A; los ceros a la izquierda son opcionales
+// @Tags         products
+// @Produce      json
+// @Param        code  path   string  true   "Código de barras"
+// @Param        currency query string false "Moneda de visualización (ARS,UYU,CLP,USD)"
+// @Param        Accept-Currency header string false "Moneda de visualización si no se indica por query"
+// @Success      200  {object}  dto.ProductResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /api/products/barcode/{code} [get]
+func (h *ProductHandler) GetByBarCode(c echo.Context) error {
+	currency, err := displayCurrency(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported currency"})
+	}
+
+	p, err := h.Svc.GetVisibleByBarCode(c.Request().Context(), c.Param("code"))
+	if err != nil {
+		switch err {
+		case barcode.ErrInvalidBarCode:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid bar code"})
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+
+	localized, _, err := h.Currency.Localize(c.Request().Context(), []entity.Product{*p}, currency)
+	if err != nil {
+		return currencyError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.FromLocalizedEntity(localized[0].Product, localized[0].PriceSource))
+}
+
+// GenerateBarCodes godoc
+// @Summary      Generar códigos de barras internos
+// @Description  Devuelve códigos EAN-13 válidos con prefijo de uso interno (20) que no están asignados, para dar de alta productos sin código del fabricante (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        count  query  int  false  "Cantidad (1-100, por defecto 1)"
+// @Success      200  {object}  dto.GeneratedBarCodesResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/barcodes [post]
+func (h *ProductHandler) GenerateBarCodes(c echo.Context) error {
+	count := 1
+	if n := c.QueryParam("count"); n != "" {
+		parsed, err := strconv.Atoi(n)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid count"})
+		}
+		count = parsed
+	}
+	codes, err := h.Svc.GenerateBarCodes(c.Request().Context(), count)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "count must be between 1 and 100"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.GeneratedBarCodesResponse{BarCodes: codes})
 }
 
 // Update godoc
 // @Summary      Actualizar producto
-// @Description  Actualiza un producto existente por ID
+// @Description  Actualiza un producto existente por ID. Un código de barras nuevo debe ser un EAN-13 o UPC-A válido
 // @Tags         products
 // @Accept       json
 // @Produce      json
@@ -125,6 +220,7 @@ func (h *ProductHandler) GetByID(c echo.Context) error {
 // @Success      200      {object}  dto.ProductResponse
 // @Failure      400      {object}  map[string]string
 // @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
 // @Failure      500      {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/products/{id} [put]
@@ -155,6 +251,18 @@ func (h *ProductHandler) Update(c echo.Context) error {
 		if err == errors.ErrNotFound {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
 		}
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "weight_grams must not be negative"})
+		}
+		if err == barcode.ErrInvalidBarCode {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "bar code must be a valid EAN-13 or UPC-A"})
+		}
+		if err == errors.ErrConflict {
+			return c.JSON(http.StatusConflict, map[string]string{"error": "bar code already in use"})
+		}
+		if err == errors.ErrInsufficientStock {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
@@ -163,13 +271,14 @@ func (h *ProductHandler) Update(c echo.Context) error {
 
 // Delete godoc
 // @Summary      Eliminar producto
-// @Description  Elimina un producto por ID
+// @Description  Elimina un producto por ID. Si tiene movimientos de stock, compras o ventas se archiva en su lugar.
 // @Tags         products
 // @Produce      json
 // @Param        id   path      int  true  "Product ID"
 // @Success      204  "No Content"
 // @Failure      400  {object}  dto.ErrorGeneral
 // @Failure      404  {object}  dto.ErrorGeneral
+// @Failure      409  {object}  dto.ErrorGeneral
 // @Failure      500  {object}  dto.ErrorGeneral
 // @Security     BearerAuth
 // @Router       /api/products/{id} [delete]
@@ -181,11 +290,105 @@ func (h *ProductHandler) Delete(c echo.Context) error {
 	}
 
 	if err := h.Svc.Delete(c.Request().Context(), id); err != nil {
-		if err == errors.ErrNotFound {
+		switch err {
+		case errors.ErrNotFound:
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+		case errors.ErrConflict:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "product is referenced by other records and cannot be deleted"})
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// AdminList godoc
+// @Summary      Listar productos (admin)
+// @Description  Lista productos en cualquier estado de publicación (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        status   query string false "Estado (draft,scheduled,published,archived)"
+// @Param        category query string false "Categoría"
+// @Param        size     query string false "Talla (S,M,L,XL,XXL)"
+// @Param        q        query string false "Búsqueda en título/desc"
+// @Param        limit    query int    false "Límite (<=100)"
+// @Param        offset   query int    false "Offset"
+// @Success      200 {array} dto.ProductResponse
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products [get]
+func (h *ProductHandler) AdminList(c echo.Context) error {
+	filter := entity.ProductFilter{
+		Status:   entity.ProductStatus(c.QueryParam("status")),
+		Category: c.QueryParam("category"),
+		Size:     c.QueryParam("size"),
+		Query:    c.QueryParam("q"),
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	ps, err := h.Svc.Search(c.Request().Context(), filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+
+	productResponses := make([]dto.ProductResponse, 0, len(ps))
+	for _, p := range ps {
+		productResponses = append(productResponses, dto.FromEntity(p))
+	}
+
+	return c.JSON(http.StatusOK, productResponses)
+}
+
+// ChangeStatus godoc
+// @Summary      Cambiar estado de publicación
+// @Description  Pasa un producto a borrador, programado, publicado o archivado (solo admin). Publicar con publish_at futuro lo deja programado.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                             true  "Product ID"
+// @Param        status  body      dto.ChangeProductStatusRequest  true  "Nuevo estado"
+// @Success      200     {object}  dto.ProductResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/status [put]
+func (h *ProductHandler) ChangeStatus(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	var req dto.ChangeProductStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	p, err := h.Svc.ChangeStatus(c.Request().Context(), id, entity.ProductStatus(req.Status), req.PublishAt, req.UnpublishAt)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status or dates"})
+		case errors.ErrInvalidTransition:
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+
+	return c.JSON(http.StatusOK, dto.FromEntity(*p))
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProductExportHandler struct {
+	Svc service.ProductExportService
+}
+
+func NewProductExportHandler(s service.ProductExportService) *ProductExportHandler {
+	return &ProductExportHandler{Svc: s}
+}
+
+var exportContentTypes = map[entity.FileFormat]string{
+	entity.FileFormatCSV:   "text/csv; charset=utf-8",
+	entity.FileFormatXLSX:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	entity.FileFormatJSONL: "application/x-ndjson",
+}
+
+// Export godoc
+// @Summary      Exportar catálogo
+// @Description  Descarga los productos del filtro en CSV, XLSX o JSON Lines, escribiendo a medida que se leen. Las URLs de las imágenes van absolutas, la principal primero. Los campos con el mismo nombre que la importación se pueden volver a importar (solo admin)
+// @Tags         admin
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      application/x-ndjson
+// @Param        format    query  string  false  "Formato (csv, xlsx, jsonl), por defecto csv"
+// @Param        fields    query  string  false  "Campos separados por coma, por defecto todos: id, bar_code, title, description, stock, size, category, weight_grams, unit_price, currency, status, publish_at, unpublish_at, compare_at_price, sale_price, sale_starts_at, sale_ends_at, image_urls, updated_at, created_at"
+// @Param        status    query  string  false  "Estado (draft,scheduled,published,archived)"
+// @Param        category  query  string  false  "Categoría"
+// @Param        size      query  string  false  "Talla (S,M,L,XL,XXL)"
+// @Param        q         query  string  false  "Búsqueda en título/desc"
+// @Success      200  {file}    file
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/export [get]
+func (h *ProductExportHandler) Export(c echo.Context) error {
+	format := entity.FileFormatCSV
+	if f := c.QueryParam("format"); f != "" {
+		format = entity.FileFormat(strings.ToLower(f))
+	}
+	if !format.IsValid() {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid format. Allowed: csv, xlsx, jsonl"})
+	}
+	filter := entity.ProductFilter{
+		Status:   entity.ProductStatus(c.QueryParam("status")),
+		Category: c.QueryParam("category"),
+		Size:     c.QueryParam("size"),
+		Query:    c.QueryParam("q"),
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+	}
+	var fields []string
+	if f := c.QueryParam("fields"); f != "" {
+		fields = strings.Split(f, ",")
+	}
+	columns, err := h.Svc.Columns(fields)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unknown field"})
+	}
+
+	filename := "catalogo-" + time.Now().Format("20060102") + "." + string(format)
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, exportContentTypes[format])
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+
+	// Los errores antes del primer byte todavía se pueden informar; después se corta la
+	// conexión para que el cliente no tome el archivo incompleto como terminado
+	if err := h.Svc.Export(c.Request().Context(), res, format, filter, columns); err != nil {
+		if res.Committed {
+			log.Printf("[EXPORT] Catalog export interrupted: %v", err)
+			panic(http.ErrAbortHandler)
+		}
+		res.Header().Del(echo.HeaderContentDisposition)
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid export parameters"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+	jwtutil "core/internal/presentation/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProductImportHandler struct {
+	Svc service.ProductImportService
+}
+
+func NewProductImportHandler(s service.ProductImportService) *ProductImportHandler {
+	return &ProductImportHandler{Svc: s}
+}
+
+// Submit godoc
+// @Summary      Importar productos desde una planilla
+// @Description  Encola la importación de un CSV o XLSX con encabezado. Cada fila da de alta o actualiza el producto con el mismo bar_code, validada con las reglas del alta de productos; en los existentes las celdas vacías conservan el valor y publish_at/unpublish_at se ignoran. Columnas: bar_code, title, description, stock, size, category, weight_grams, unit_price, publish_at, unpublish_at. Hasta 10 MB y 10000 filas (solo admin)
+// @Tags         admin
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData  file    true   "Planilla .csv o .xlsx"
+// @Param        dry_run  formData  bool    false  "Solo validar e informar qué se haría"
+// @Param        mapping  formData  string  false  "JSON encabezado -> campo, por ejemplo {\"EAN\":\"bar_code\",\"Precio\":\"unit_price\"}"
+// @Success      202  {object}  dto.ProductImportResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      413  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/imports [post]
+func (h *ProductImportHandler) Submit(c echo.Context) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "file is required"})
+	}
+	if file.Size > service.MaxImportFileSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "file is larger than 10 MB"})
+	}
+	imp := &entity.ProductImport{
+		Filename: filepath.Base(file.Filename),
+		Format:   entity.FileFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")),
+	}
+	if !imp.Format.IsSpreadsheet() {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file type. Allowed: csv, xlsx"})
+	}
+	if v := c.FormValue("dry_run"); v != "" {
+		if imp.DryRun, err = strconv.ParseBool(v); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid dry_run"})
+		}
+	}
+	if v := c.FormValue("mapping"); v != "" {
+		if err := json.Unmarshal([]byte(v), &imp.Mapping); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "mapping must be a JSON object of header -> field"})
+		}
+	}
+	imp.CreatedBy, _ = jwtutil.UserIDFromToken(c)
+
+	src, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to open file"})
+	}
+	defer src.Close()
+	data, err := io.ReadAll(io.LimitReader(src, service.MaxImportFileSize+1))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to read file"})
+	}
+
+	imp, err = h.Svc.Submit(c.Request().Context(), imp, data)
+	if err != nil {
+		switch {
+		case stderrors.Is(err, errors.ErrInvalidFile):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case err == errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "the file needs a bar_code column, between 1 and 10000 rows and a mapping to known fields without repeated columns"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusAccepted, dto.FromProductImportEntity(*imp))
+}
+
+// List godoc
+// @Summary      Listar importaciones de productos
+// @Description  Lista las importaciones, de la más reciente a la más antigua, sin los errores por fila (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        status  query  string  false  "Estado (queued, running, completed, failed)"
+// @Param        limit   query  int     false  "Límite (<=100)"
+// @Param        offset  query  int     false  "Offset"
+// @Success      200  {array}   dto.ProductImportResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/imports [get]
+func (h *ProductImportHandler) List(c echo.Context) error {
+	filter := entity.ProductImportFilter{Status: entity.ProductImportStatus(c.QueryParam("status"))}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	imports, err := h.Svc.List(c.Request().Context(), filter)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.ProductImportResponse, 0, len(imports))
+	for _, imp := range imports {
+		resp = append(resp, dto.FromProductImportEntity(imp))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetByID godoc
+// @Summary      Ver importación de productos
+// @Description  Retorna el avance de la importación y los errores de cada fila rechazada, numerando las filas como la planilla (el encabezado es la fila 1). Se guardan hasta 1000 errores (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Import ID"
+// @Success      200  {object}  dto.ProductImportResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/imports/{id} [get]
+func (h *ProductImportHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	imp, err := h.Svc.GetByID(c.Request().Context(), id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "product import not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromProductImportEntity(*imp))
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PromotionHandler struct {
+	Svc service.PromotionService
+}
+
+func NewPromotionHandler(s service.PromotionService) *PromotionHandler {
+	return &PromotionHandler{Svc: s}
+}
+
+// List godoc
+// @Summary      Listar promociones
+// @Description  Lista cupones y promociones automáticas por prioridad (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        active  query  bool    false  "Solo activas / inactivas"
+// @Param        code    query  string  false  "Código de cupón"
+// @Param        limit   query  int     false  "Límite (<=100)"
+// @Param        offset  query  int     false  "Offset"
+// @Success      200  {array}   dto.PromotionResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/promotions [get]
+func (h *PromotionHandler) List(c echo.Context) error {
+	filter := entity.PromotionFilter{Code: c.QueryParam("code")}
+	if a, err := strconv.ParseBool(c.QueryParam("active")); err == nil {
+		filter.Active = &a
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	promos, err := h.Svc.List(c.Request().Context(), filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.PromotionResponse, 0, len(promos))
+	for _, p := range promos {
+		resp = append(resp, dto.FromPromotionEntity(p))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetByID godoc
+// @Summary      Obtener promoción
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Promotion ID"
+// @Success      200  {object}  dto.PromotionResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/promotions/{id} [get]
+func (h *PromotionHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	p, err := h.Svc.GetByID(c.Request().Context(), id)
+	if err != nil {
+		return promotionError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromPromotionEntity(*p))
+}
+
+// Create godoc
+// @Summary      Crear promoción
+// @Description  Crea un cupón (con code) o una promoción automática (sin code) (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        promotion  body      dto.PromotionRequest  true  "Promoción"
+// @Success      201        {object}  dto.PromotionResponse
+// @Failure      400        {object}  map[string]string
+// @Failure      409        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/promotions [post]
+func (h *PromotionHandler) Create(c echo.Context) error {
+	var req dto.PromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	promo, err := req.ToEntity()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	p, err := h.Svc.Create(c.Request().Context(), promo)
+	if err != nil {
+		return promotionError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.FromPromotionEntity(*p))
+}
+
+// Update godoc
+// @Summary      Reemplazar promoción
+// @Description  Reemplaza la configuración completa de una promoción (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int                   true  "Promotion ID"
+// @Param        promotion  body      dto.PromotionRequest  true  "Promoción"
+// @Success      200        {object}  dto.PromotionResponse
+// @Failure      400        {object}  map[string]string
+// @Failure      404        {object}  map[string]string
+// @Failure      409        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/promotions/{id} [put]
+func (h *PromotionHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.PromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	promo, err := req.ToEntity()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	promo.ID = id
+
+	p, err := h.Svc.Update(c.Request().Context(), promo)
+	if err != nil {
+		return promotionError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromPromotionEntity(*p))
+}
+
+// Delete godoc
+// @Summary      Eliminar promoción
+// @Description  Elimina una promoción; las órdenes que la usaron conservan el descuento (solo admin)
+// @Tags         admin
+// @Param        id   path  int  true  "Promotion ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/promotions/{id} [delete]
+func (h *PromotionHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	if err := h.Svc.Delete(c.Request().Context(), id); err != nil {
+		return promotionError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func promotionError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "promotion not found"})
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid promotion configuration for its type"})
+	case errors.ErrConflict:
+		return c.JSON(http.StatusConflict, map[string]string{"error": "coupon code already in use"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
@@ -0,0 +1,394 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/money"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+	jwtutil "core/internal/presentation/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PurchaseHandler struct {
+	Svc service.PurchaseService
+}
+
+func NewPurchaseHandler(s service.PurchaseService) *PurchaseHandler {
+	return &PurchaseHandler{Svc: s}
+}
+
+// CreateSupplier godoc
+// @Summary      Crear proveedor
+// @Description  Da de alta un proveedor. El CUIT es opcional y se valida con su dígito verificador (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        supplier  body  dto.SupplierRequest  true  "Proveedor"
+// @Success      201  {object}  dto.SupplierResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/suppliers [post]
+func (h *PurchaseHandler) CreateSupplier(c echo.Context) error {
+	var req dto.SupplierRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	s, err := h.Svc.CreateSupplier(c.Request().Context(), req.ToEntity())
+	if err != nil {
+		return supplierError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.FromSupplierEntity(*s))
+}
+
+// UpdateSupplier godoc
+// @Summary      Actualizar proveedor
+// @Description  Reemplaza los datos del proveedor. Un proveedor inactivo no admite órdenes nuevas (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id        path  int                  true  "Supplier ID"
+// @Param        supplier  body  dto.SupplierRequest  true  "Proveedor"
+// @Success      200  {object}  dto.SupplierResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/suppliers/{id} [put]
+func (h *PurchaseHandler) UpdateSupplier(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.SupplierRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	s := req.ToEntity()
+	s.ID = id
+	s, err = h.Svc.UpdateSupplier(c.Request().Context(), s)
+	if err != nil {
+		return supplierError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromSupplierEntity(*s))
+}
+
+// GetSupplier godoc
+// @Summary      Ver proveedor
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Supplier ID"
+// @Success      200  {object}  dto.SupplierResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/suppliers/{id} [get]
+func (h *PurchaseHandler) GetSupplier(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	s, err := h.Svc.GetSupplier(c.Request().Context(), id)
+	if err != nil {
+		return supplierError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromSupplierEntity(*s))
+}
+
+// ListSuppliers godoc
+// @Summary      Listar proveedores
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.SupplierResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/suppliers [get]
+func (h *PurchaseHandler) ListSuppliers(c echo.Context) error {
+	suppliers, err := h.Svc.ListSuppliers(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.SupplierResponse, 0, len(suppliers))
+	for _, s := range suppliers {
+		resp = append(resp, dto.FromSupplierEntity(s))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func supplierError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "supplier needs a name and the tax id must be a valid CUIT"})
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "supplier not found"})
+	case errors.ErrConflict:
+		return c.JSON(http.StatusConflict, map[string]string{"error": "supplier name already exists"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
+
+// CreateOrder godoc
+// @Summary      Crear orden de compra
+// @Description  Crea una orden de compra en borrador con el costo pactado por producto, en la moneda base. Sin ubicación la mercadería se recibe en la ubicación por defecto (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        order  body  dto.CreatePurchaseOrderRequest  true  "Orden de compra"
+// @Success      201  {object}  dto.PurchaseOrderResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/purchase-orders [post]
+func (h *PurchaseHandler) CreateOrder(c echo.Context) error {
+	var req dto.CreatePurchaseOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	po, err := req.ToEntity()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	po.CreatedBy, _ = jwtutil.UserIDFromToken(c)
+	po, err = h.Svc.CreateOrder(c.Request().Context(), po)
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "the supplier must be active and each product can appear once with a positive quantity and a non-negative cost"})
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "supplier, location or product not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusCreated, dto.FromPurchaseOrderEntity(*po))
+}
+
+// ListOrders godoc
+// @Summary      Listar órdenes de compra
+// @Description  Lista las órdenes de compra, de la más reciente a la más antigua, sin sus líneas (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        supplier_id  query  int     false  "Proveedor"
+// @Param        status       query  string  false  "Estado (draft, ordered, partially_received, received, cancelled)"
+// @Param        limit        query  int     false  "Límite (<=100)"
+// @Param        offset       query  int     false  "Offset"
+// @Success      200  {array}   dto.PurchaseOrderResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/purchase-orders [get]
+func (h *PurchaseHandler) ListOrders(c echo.Context) error {
+	filter := entity.PurchaseOrderFilter{Status: entity.PurchaseOrderStatus(c.QueryParam("status"))}
+	if id, err := strconv.ParseInt(c.QueryParam("supplier_id"), 10, 64); err == nil {
+		filter.SupplierID = id
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	orders, err := h.Svc.ListOrders(c.Request().Context(), filter)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.PurchaseOrderResponse, 0, len(orders))
+	for _, po := range orders {
+		resp = append(resp, dto.FromPurchaseOrderEntity(po))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetOrder godoc
+// @Summary      Ver orden de compra
+// @Description  Retorna la orden con lo pedido y lo recibido por línea y sus recepciones (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Purchase order ID"
+// @Success      200  {object}  dto.PurchaseOrderResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/purchase-orders/{id} [get]
+func (h *PurchaseHandler) GetOrder(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	po, err := h.Svc.GetOrder(c.Request().Context(), id)
+	if err != nil {
+		return purchaseOrderError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromPurchaseOrderEntity(*po))
+}
+
+// UpdateOrderStatus godoc
+// @Summary      Cambiar estado de orden de compra
+// @Description  Envía el borrador al proveedor (ordered) o cancela la orden; lo ya recibido no se revierte (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int                                   true  "Purchase order ID"
+// @Param        status  body  dto.UpdatePurchaseOrderStatusRequest  true  "Nuevo estado"
+// @Success      200  {object}  dto.PurchaseOrderResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/purchase-orders/{id}/status [put]
+func (h *PurchaseHandler) UpdateOrderStatus(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.UpdatePurchaseOrderStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	po, err := h.Svc.UpdateOrderStatus(c.Request().Context(), id, entity.PurchaseOrderStatus(req.Status))
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		}
+		return purchaseOrderError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromPurchaseOrderEntity(*po))
+}
+
+// Receive godoc
+// @Summary      Recibir mercadería
+// @Description  Registra una entrega total o parcial: suma el stock en la ubicación de la orden y actualiza el costo promedio ponderado de cada producto (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id       path  int                         true  "Purchase order ID"
+// @Param        receipt  body  dto.ReceivePurchaseRequest  true  "Recepción"
+// @Success      201  {object}  dto.PurchaseOrderResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/purchase-orders/{id}/receipts [post]
+func (h *PurchaseHandler) Receive(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.ReceivePurchaseRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	actorID, _ := jwtutil.UserIDFromToken(c)
+	rc, err := req.ToEntity(id, actorID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	po, err := h.Svc.Receive(c.Request().Context(), rc)
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "each line of the order can appear once with a positive quantity and a non-negative cost"})
+		case errors.ErrConflict:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "received quantity exceeds what is pending on the line"})
+		case money.ErrCurrencyMismatch:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "receipt cost currency differs from the average cost of the stock on hand"})
+		}
+		return purchaseOrderError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.FromPurchaseOrderEntity(*po))
+}
+
+func purchaseOrderError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "purchase order not found"})
+	case errors.ErrInvalidTransition:
+		return c.JSON(http.StatusConflict, map[string]string{"error": "invalid purchase order status transition"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
+
+// ProductCost godoc
+// @Summary      Costo promedio del producto
+// @Description  Retorna el costo promedio ponderado que surge de las recepciones de compra (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Product ID"
+// @Success      200  {object}  dto.ProductCostResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/cost [get]
+func (h *PurchaseHandler) ProductCost(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	cost, err := h.Svc.Cost(c.Request().Context(), id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "product has no purchase cost"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromProductCostEntity(*cost))
+}
+
+// Margins godoc
+// @Summary      Reporte de márgenes
+// @Description  Compara el precio de lista de cada producto con su costo promedio ponderado. Los productos sin compras o con precio en otra moneda no informan margen (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        category  query  string  false  "Categoría"
+// @Param        size      query  string  false  "Talle"
+// @Param        q         query  string  false  "Texto en el título"
+// @Param        status    query  string  false  "Estado del producto"
+// @Param        limit     query  int     false  "Límite (<=200)"
+// @Param        offset    query  int     false  "Offset"
+// @Success      200  {array}   dto.ProductMarginResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/reports/margins [get]
+func (h *PurchaseHandler) Margins(c echo.Context) error {
+	filter := entity.ProductFilter{
+		Category: c.QueryParam("category"),
+		Size:     c.QueryParam("size"),
+		Query:    c.QueryParam("q"),
+		Status:   entity.ProductStatus(c.QueryParam("status")),
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+	margins, err := h.Svc.Margins(c.Request().Context(), filter)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.ProductMarginResponse, 0, len(margins))
+	for _, m := range margins {
+		resp = append(resp, dto.FromProductMarginEntity(m))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
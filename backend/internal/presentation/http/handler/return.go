@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+	jwtutil "core/internal/presentation/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReturnHandler struct {
+	Svc service.ReturnService
+}
+
+func NewReturnHandler(s service.ReturnService) *ReturnHandler {
+	return &ReturnHandler{Svc: s}
+}
+
+// Create godoc
+// @Summary      Pedir devolución o cambio
+// @Description  Abre una solicitud de devolución (reembolso) o cambio de talle sobre una orden pagada y entregada
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int                      true  "Order ID"
+// @Param        return  body  dto.CreateReturnRequest  true  "Líneas a devolver"
+// @Success      201  {object}  dto.ReturnResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/orders/{id}/returns [post]
+func (h *ReturnHandler) Create(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || orderID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.CreateReturnRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	rr, err := h.Svc.Create(c.Request().Context(), userID, orderID, strings.TrimSpace(req.Comment), req.ToLines())
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "order not found"})
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "each item must be a distinct order item with a valid quantity, reason and resolution; exchanges must be another size of the same product"})
+		case errors.ErrInvalidTransition:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "only paid orders that were delivered can be returned"})
+		case errors.ErrConflict:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "quantity exceeds the units not already in an open return"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusCreated, dto.FromReturnEntity(*rr))
+}
+
+// List godoc
+// @Summary      Mis devoluciones
+// @Description  Lista las solicitudes de devolución y cambio del usuario autenticado
+// @Tags         returns
+// @Produce      json
+// @Param        status  query  string  false  "Estado (requested,approved,rejected,received,completed,cancelled)"
+// @Param        limit   query  int     false  "Límite (<=100)"
+// @Param        offset  query  int     false  "Offset"
+// @Success      200  {array}   dto.ReturnResponse
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/returns [get]
+func (h *ReturnHandler) List(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	filter := returnFilter(c)
+	filter.UserID = userID
+	return h.list(c, filter)
+}
+
+// GetByID godoc
+// @Summary      Obtener devolución
+// @Description  Obtiene una solicitud del usuario autenticado con su historial de estados
+// @Tags         returns
+// @Produce      json
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.ReturnResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/returns/{id} [get]
+func (h *ReturnHandler) GetByID(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	rr, err := h.Svc.GetForUser(c.Request().Context(), userID, id)
+	if err != nil {
+		return returnError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromReturnEntity(*rr))
+}
+
+// Cancel godoc
+// @Summary      Cancelar devolución
+// @Description  Cancela una solicitud propia mientras no se haya recibido la mercadería; libera los talles reservados
+// @Tags         returns
+// @Produce      json
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.ReturnResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/returns/{id}/cancel [post]
+func (h *ReturnHandler) Cancel(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	rr, err := h.Svc.Cancel(c.Request().Context(), userID, id)
+	if err != nil {
+		return returnError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromReturnEntity(*rr))
+}
+
+// AdminList godoc
+// @Summary      Listar devoluciones (admin)
+// @Description  Lista las solicitudes de devolución y cambio de todos los usuarios (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        user_id   query  int     false  "Usuario"
+// @Param        order_id  query  int     false  "Orden"
+// @Param        status    query  string  false  "Estado (requested,approved,rejected,received,completed,cancelled)"
+// @Param        limit     query  int     false  "Límite (<=100)"
+// @Param        offset    query  int     false  "Offset"
+// @Success      200  {array}   dto.ReturnResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/returns [get]
+func (h *ReturnHandler) AdminList(c echo.Context) error {
+	filter := returnFilter(c)
+	if id, err := strconv.ParseInt(c.QueryParam("user_id"), 10, 64); err == nil {
+		filter.UserID = id
+	}
+	if id, err := strconv.ParseInt(c.QueryParam("order_id"), 10, 64); err == nil {
+		filter.OrderID = id
+	}
+	return h.list(c, filter)
+}
+
+// AdminGetByID godoc
+// @Summary      Obtener devolución (admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.ReturnResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/returns/{id} [get]
+func (h *ReturnHandler) AdminGetByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	rr, err := h.Svc.GetByID(c.Request().Context(), id)
+	if err != nil {
+		return returnError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromReturnEntity(*rr))
+}
+
+// UpdateStatus godoc
+// @Summary      Cambiar estado de devolución (admin)
+// @Description  Aprueba (reserva los talles de cambio), rechaza, recibe (reingresa el stock salvo fallas), completa (reembolsa por la pasarela) o cancela una solicitud
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int                            true  "Return ID"
+// @Param        status  body  dto.UpdateReturnStatusRequest  true  "Nuevo estado"
+// @Success      200  {object}  dto.ReturnResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      502  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/returns/{id}/status [put]
+func (h *ReturnHandler) UpdateStatus(c echo.Context) error {
+	actorID, _ := jwtutil.UserIDFromToken(c)
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.UpdateReturnStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	status := entity.ReturnStatus(req.Status)
+	if !status.IsValid() || status == entity.ReturnStatusRequested {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "status must be approved, rejected, received, completed or cancelled"})
+	}
+
+	rr, err := h.Svc.UpdateStatus(c.Request().Context(), id, status, actorID, strings.TrimSpace(req.Note))
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound, errors.ErrInvalidTransition:
+			return returnError(c, err)
+		case errors.ErrInsufficientStock:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "not enough stock of the exchange size"})
+		}
+		if status == entity.ReturnStatusCompleted {
+			return c.JSON(http.StatusBadGateway, map[string]string{"error": "refund failed"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromReturnEntity(*rr))
+}
+
+func returnFilter(c echo.Context) entity.ReturnFilter {
+	filter := entity.ReturnFilter{Status: entity.ReturnStatus(c.QueryParam("status"))}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+	return filter
+}
+
+func (h *ReturnHandler) list(c echo.Context, filter entity.ReturnFilter) error {
+	returns, err := h.Svc.List(c.Request().Context(), filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.ReturnResponse, 0, len(returns))
+	for _, rr := range returns {
+		resp = append(resp, dto.FromReturnEntity(rr))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func returnError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "return not found"})
+	case errors.ErrInvalidTransition:
+		return c.JSON(http.StatusConflict, map[string]string{"error": "invalid return status transition"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
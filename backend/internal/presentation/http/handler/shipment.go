@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+	jwtutil "core/internal/presentation/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxWebhookBody limita el cuerpo de las notificaciones de los carriers
+const maxWebhookBody = 1 << 20
+
+type ShipmentHandler struct {
+	Svc service.ShipmentService
+}
+
+func NewShipmentHandler(s service.ShipmentService) *ShipmentHandler {
+	return &ShipmentHandler{Svc: s}
+}
+
+// GetForOrder godoc
+// @Summary      Seguimiento de una orden
+// @Description  Obtiene el envío y los eventos de seguimiento de una orden del usuario autenticado
+// @Tags         orders
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  dto.ShipmentResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/orders/{id}/shipment [get]
+func (h *ShipmentHandler) GetForOrder(c echo.Context) error {
+	userID, ok := jwtutil.UserIDFromToken(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	sh, err := h.Svc.GetForOrder(c.Request().Context(), userID, id)
+	if err != nil {
+		return shipmentError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromShipmentEntity(*sh))
+}
+
+// CreateForOrder godoc
+// @Summary      Despachar orden
+// @Description  Genera la etiqueta con el carrier para una orden pagada con envío a domicilio (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      201  {object}  dto.ShipmentResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/orders/{id}/shipment [post]
+func (h *ShipmentHandler) CreateForOrder(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	sh, err := h.Svc.CreateForOrder(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "order not found"})
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "order has no shipping method (store pickup)"})
+		case errors.ErrInvalidTransition:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "only paid orders can be shipped"})
+		case errors.ErrConflict:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "order already shipped"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusCreated, dto.FromShipmentEntity(*sh))
+}
+
+// List godoc
+// @Summary      Listar envíos
+// @Description  Lista los envíos, del más reciente al más antiguo (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        status   query  string  false  "Estado (label_created,in_transit,out_for_delivery,delivered,exception,returned)"
+// @Param        carrier  query  string  false  "Carrier"
+// @Param        limit    query  int     false  "Límite (<=100)"
+// @Param        offset   query  int     false  "Offset"
+// @Success      200  {array}   dto.ShipmentResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipments [get]
+func (h *ShipmentHandler) List(c echo.Context) error {
+	filter := entity.ShipmentFilter{
+		Status:  entity.ShipmentStatus(c.QueryParam("status")),
+		Carrier: c.QueryParam("carrier"),
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	shipments, err := h.Svc.List(c.Request().Context(), filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.ShipmentResponse, 0, len(shipments))
+	for _, sh := range shipments {
+		resp = append(resp, dto.FromShipmentEntity(sh))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetByID godoc
+// @Summary      Obtener envío
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Shipment ID"
+// @Success      200  {object}  dto.ShipmentResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipments/{id} [get]
+func (h *ShipmentHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	sh, err := h.Svc.GetByID(c.Request().Context(), id)
+	if err != nil {
+		return shipmentError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromShipmentEntity(*sh))
+}
+
+// GetLabel godoc
+// @Summary      Etiqueta de envío
+// @Description  Descarga el PDF de la etiqueta generada por el carrier (solo admin)
+// @Tags         admin
+// @Produce      application/pdf
+// @Param        id   path      int  true  "Shipment ID"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipments/{id}/label [get]
+func (h *ShipmentHandler) GetLabel(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	label, err := h.Svc.Label(c.Request().Context(), id)
+	if err != nil {
+		return shipmentError(c, err)
+	}
+	filename := fmt.Sprintf("envio-%d.pdf", id)
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="`+filename+`"`)
+	return c.Blob(http.StatusOK, "application/pdf", label)
+}
+
+// Refresh godoc
+// @Summary      Actualizar seguimiento
+// @Description  Consulta al carrier el seguimiento del envío sin esperar al scheduler (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Shipment ID"
+// @Success      200  {object}  dto.ShipmentResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      502  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipments/{id}/refresh [post]
+func (h *ShipmentHandler) Refresh(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	sh, err := h.Svc.Refresh(c.Request().Context(), id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return shipmentError(c, err)
+		}
+		return c.JSON(http.StatusBadGateway, map[string]string{"error": "carrier tracking unavailable"})
+	}
+	return c.JSON(http.StatusOK, dto.FromShipmentEntity(*sh))
+}
+
+// CarrierWebhook godoc
+// @Summary      Webhook de carriers
+// @Description  Recibe las novedades de seguimiento de un carrier. El cuerpo va firmado en el header X-Carrier-Signature; los eventos repetidos se ignoran.
+// @Tags         shipping
+// @Accept       json
+// @Param        carrier              path    string  true  "Carrier"
+// @Param        X-Carrier-Signature  header  string  true  "Firma del cuerpo"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/webhooks/carriers/{carrier} [post]
+func (h *ShipmentHandler) CarrierWebhook(c echo.Context) error {
+	payload, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBody))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	err = h.Svc.HandleWebhook(c.Request().Context(), c.Param("carrier"), payload, c.Request().Header.Get("X-Carrier-Signature"))
+	switch err {
+	case nil:
+		return c.NoContent(http.StatusNoContent)
+	case errors.ErrInvalidToken:
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid signature"})
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid tracking update"})
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "unknown carrier or tracking number"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
+
+func shipmentError(c echo.Context, err error) error {
+	if err == errors.ErrNotFound {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "shipment not found"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
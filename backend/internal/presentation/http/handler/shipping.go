@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ShippingHandler struct {
+	Svc service.ShippingService
+}
+
+func NewShippingHandler(s service.ShippingService) *ShippingHandler {
+	return &ShippingHandler{Svc: s}
+}
+
+// ListMethods godoc
+// @Summary      Métodos de envío
+// @Description  Lista los métodos de envío activos con sus tarifas en la moneda base. El costo para un carrito se obtiene al valuarlo.
+// @Tags         shipping
+// @Produce      json
+// @Success      200  {array}   dto.ShippingMethodResponse
+// @Failure      500  {object}  map[string]string
+// @Router       /api/shipping/methods [get]
+func (h *ShippingHandler) ListMethods(c echo.Context) error {
+	return h.listMethods(c, true)
+}
+
+// AdminListMethods godoc
+// @Summary      Listar métodos de envío (admin)
+// @Description  Lista todos los métodos de envío, incluidos los inactivos (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.ShippingMethodResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipping/methods [get]
+func (h *ShippingHandler) AdminListMethods(c echo.Context) error {
+	return h.listMethods(c, false)
+}
+
+func (h *ShippingHandler) listMethods(c echo.Context, activeOnly bool) error {
+	methods, err := h.Svc.ListMethods(c.Request().Context(), activeOnly)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.ShippingMethodResponse, 0, len(methods))
+	for _, m := range methods {
+		resp = append(resp, dto.FromShippingMethodEntity(m))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetMethod godoc
+// @Summary      Obtener método de envío
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Shipping method ID"
+// @Success      200  {object}  dto.ShippingMethodResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipping/methods/{id} [get]
+func (h *ShippingHandler) GetMethod(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	m, err := h.Svc.GetMethod(c.Request().Context(), id)
+	if err != nil {
+		return shippingMethodError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromShippingMethodEntity(*m))
+}
+
+// CreateMethod godoc
+// @Summary      Crear método de envío
+// @Description  Crea un método de envío con su tabla de tarifas por zona y peso (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        method  body      dto.ShippingMethodRequest  true  "Método de envío"
+// @Success      201     {object}  dto.ShippingMethodResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipping/methods [post]
+func (h *ShippingHandler) CreateMethod(c echo.Context) error {
+	var req dto.ShippingMethodRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	method, err := req.ToEntity()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	m, err := h.Svc.CreateMethod(c.Request().Context(), method)
+	if err != nil {
+		return shippingMethodError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.FromShippingMethodEntity(*m))
+}
+
+// UpdateMethod godoc
+// @Summary      Reemplazar método de envío
+// @Description  Reemplaza la configuración y la tabla de tarifas completa del método (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                        true  "Shipping method ID"
+// @Param        method  body      dto.ShippingMethodRequest  true  "Método de envío"
+// @Success      200     {object}  dto.ShippingMethodResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipping/methods/{id} [put]
+func (h *ShippingHandler) UpdateMethod(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.ShippingMethodRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	method, err := req.ToEntity()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	method.ID = id
+
+	m, err := h.Svc.UpdateMethod(c.Request().Context(), method)
+	if err != nil {
+		return shippingMethodError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromShippingMethodEntity(*m))
+}
+
+// DeleteMethod godoc
+// @Summary      Eliminar método de envío
+// @Description  Elimina un método y sus tarifas; las órdenes conservan el envío cobrado (solo admin)
+// @Tags         admin
+// @Param        id   path  int  true  "Shipping method ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipping/methods/{id} [delete]
+func (h *ShippingHandler) DeleteMethod(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	if err := h.Svc.DeleteMethod(c.Request().Context(), id); err != nil {
+		return shippingMethodError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func shippingMethodError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "shipping method not found"})
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid shipping method: check days, existing zones and positive weights and prices in ARS"})
+	case errors.ErrConflict:
+		return c.JSON(http.StatusConflict, map[string]string{"error": "duplicated rate for the same zone and weight"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
+
+// ListZones godoc
+// @Summary      Listar zonas de envío
+// @Description  Lista las zonas de envío con sus provincias (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.ShippingZoneResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipping/zones [get]
+func (h *ShippingHandler) ListZones(c echo.Context) error {
+	zones, err := h.Svc.ListZones(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.ShippingZoneResponse, 0, len(zones))
+	for _, z := range zones {
+		resp = append(resp, dto.FromShippingZoneEntity(z))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CreateZone godoc
+// @Summary      Crear zona de envío
+// @Description  Crea una zona con las provincias indicadas; cada provincia pertenece a una sola zona (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        zone  body      dto.ShippingZoneRequest  true  "Zona"
+// @Success      201   {object}  dto.ShippingZoneResponse
+// @Failure      400   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipping/zones [post]
+func (h *ShippingHandler) CreateZone(c echo.Context) error {
+	var req dto.ShippingZoneRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	z, err := h.Svc.CreateZone(c.Request().Context(), req.ToEntity())
+	if err != nil {
+		return shippingZoneError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.FromShippingZoneEntity(*z))
+}
+
+// UpdateZone godoc
+// @Summary      Reemplazar zona de envío
+// @Description  Reemplaza el nombre y las provincias de la zona (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                      true  "Shipping zone ID"
+// @Param        zone  body      dto.ShippingZoneRequest  true  "Zona"
+// @Success      200   {object}  dto.ShippingZoneResponse
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipping/zones/{id} [put]
+func (h *ShippingHandler) UpdateZone(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.ShippingZoneRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	zone := req.ToEntity()
+	zone.ID = id
+
+	z, err := h.Svc.UpdateZone(c.Request().Context(), zone)
+	if err != nil {
+		return shippingZoneError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromShippingZoneEntity(*z))
+}
+
+// DeleteZone godoc
+// @Summary      Eliminar zona de envío
+// @Description  Elimina una zona sin tarifas asociadas (solo admin)
+// @Tags         admin
+// @Param        id   path  int  true  "Shipping zone ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/shipping/zones/{id} [delete]
+func (h *ShippingHandler) DeleteZone(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	if err := h.Svc.DeleteZone(c.Request().Context(), id); err != nil {
+		return shippingZoneError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func shippingZoneError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "shipping zone not found"})
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "zone needs a name and valid province codes"})
+	case errors.ErrConflict:
+		return c.JSON(http.StatusConflict, map[string]string{"error": "province already assigned to another zone, or zone still has rates"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type StockAlertHandler struct {
+	Svc service.StockAlertService
+}
+
+func NewStockAlertHandler(s service.StockAlertService) *StockAlertHandler {
+	return &StockAlertHandler{Svc: s}
+}
+
+// GetReorderPoint godoc
+// @Summary      Ver punto de reposición
+// @Description  Retorna el umbral de stock del producto; threshold 0 = sin alerta (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Product ID"
+// @Success      200  {object}  dto.ReorderPointResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/reorder-point [get]
+func (h *StockAlertHandler) GetReorderPoint(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	rp, err := h.Svc.GetReorderPoint(c.Request().Context(), id)
+	if err != nil {
+		return reorderPointError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromReorderPointEntity(*rp))
+}
+
+// SetReorderPoint godoc
+// @Summary      Fijar punto de reposición
+// @Description  Define con cuántas unidades disponibles se alerta stock bajo y cuánto reponer; threshold 0 quita la alerta (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id             path  int                      true  "Product ID"
+// @Param        reorder_point  body  dto.ReorderPointRequest  true  "Umbral"
+// @Success      200  {object}  dto.ReorderPointResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/products/{id}/reorder-point [put]
+func (h *StockAlertHandler) SetReorderPoint(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.ReorderPointRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	rp, err := h.Svc.SetReorderPoint(c.Request().Context(), req.ToEntity(id))
+	if err != nil {
+		return reorderPointError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromReorderPointEntity(*rp))
+}
+
+// LowStock godoc
+// @Summary      Productos con stock bajo
+// @Description  Lista los productos con el disponible por debajo de su punto de reposición, los más faltantes primero (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.LowStockItemResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/inventory/low-stock [get]
+func (h *StockAlertHandler) LowStock(c echo.Context) error {
+	items, err := h.Svc.LowStock(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.LowStockItemResponse, 0, len(items))
+	for _, it := range items {
+		resp = append(resp, dto.FromLowStockItemEntity(it))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func reorderPointError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrInvalidInput:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "threshold and reorder_quantity cannot be negative"})
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+	jwtutil "core/internal/presentation/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+type StockCountHandler struct {
+	Svc service.StockCountService
+}
+
+func NewStockCountHandler(s service.StockCountService) *StockCountHandler {
+	return &StockCountHandler{Svc: s}
+}
+
+// Open godoc
+// @Summary      Abrir recuento de stock
+// @Description  Abre un recuento físico de una ubicación, opcionalmente limitado a una categoría. El stock de cada producto al abrir queda como esperado (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        count  body  dto.OpenStockCountRequest  true  "Recuento"
+// @Success      201  {object}  dto.StockCountResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/stock-counts [post]
+func (h *StockCountHandler) Open(c echo.Context) error {
+	var req dto.OpenStockCountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	sc := req.ToEntity()
+	sc.OpenedBy, _ = jwtutil.UserIDFromToken(c)
+	sc, err := h.Svc.Open(c.Request().Context(), sc)
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "location is required and the category must have products"})
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "location not found"})
+		case errors.ErrConflict:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "location already has an open stock count"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusCreated, dto.FromStockCountEntity(*sc, false))
+}
+
+// List godoc
+// @Summary      Listar recuentos de stock
+// @Description  Lista los recuentos, del más reciente al más antiguo, sin sus líneas (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        status       query  string  false  "Estado (open, approved, cancelled)"
+// @Param        location_id  query  int     false  "Ubicación"
+// @Param        limit        query  int     false  "Límite (<=100)"
+// @Param        offset       query  int     false  "Offset"
+// @Success      200  {array}   dto.StockCountResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/stock-counts [get]
+func (h *StockCountHandler) List(c echo.Context) error {
+	filter := entity.StockCountFilter{Status: entity.StockCountStatus(c.QueryParam("status"))}
+	if id, err := strconv.ParseInt(c.QueryParam("location_id"), 10, 64); err == nil {
+		filter.LocationID = id
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	counts, err := h.Svc.List(c.Request().Context(), filter)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.StockCountResponse, 0, len(counts))
+	for _, sc := range counts {
+		resp = append(resp, dto.FromStockCountEntity(sc, false))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetByID godoc
+// @Summary      Ver recuento de stock
+// @Description  Retorna el recuento con lo esperado, lo contado y la diferencia de cada producto (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id             path   int   true   "Stock count ID"
+// @Param        variance_only  query  bool  false  "Solo las líneas con diferencia"
+// @Success      200  {object}  dto.StockCountResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/stock-counts/{id} [get]
+func (h *StockCountHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	sc, err := h.Svc.GetByID(c.Request().Context(), id)
+	if err != nil {
+		return stockCountError(c, err)
+	}
+	varianceOnly, _ := strconv.ParseBool(c.QueryParam("variance_only"))
+	return c.JSON(http.StatusOK, dto.FromStockCountEntity(*sc, varianceOnly))
+}
+
+// Scan godoc
+// @Summary      Cargar escaneos
+// @Description  Suma un lote de códigos de barra escaneados al recuento abierto. Los códigos fuera del alcance se informan sin frenar el lote (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path  int                         true  "Stock count ID"
+// @Param        scans  body  dto.StockCountScansRequest  true  "Escaneos"
+// @Success      200  {object}  dto.StockCountScanResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/stock-counts/{id}/scans [post]
+func (h *StockCountHandler) Scan(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.StockCountScansRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	result, err := h.Svc.Scan(c.Request().Context(), id, req.ToScans())
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "scans need a bar code, between 1 and 500 per batch, and counts cannot go below zero"})
+		}
+		return stockCountError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.StockCountScanResponse(result))
+}
+
+// Approve godoc
+// @Summary      Aprobar recuento de stock
+// @Description  Ajusta el stock de la ubicación con la diferencia de cada producto (lo no escaneado cuenta como cero) y cierra el recuento (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Stock count ID"
+// @Success      200  {object}  dto.StockCountResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/stock-counts/{id}/approve [post]
+func (h *StockCountHandler) Approve(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	actorID, _ := jwtutil.UserIDFromToken(c)
+	sc, err := h.Svc.Approve(c.Request().Context(), id, actorID)
+	if err != nil {
+		if err == errors.ErrInsufficientStock {
+			return c.JSON(http.StatusConflict, map[string]string{"error": "stock moved below the counted shortage since the count was opened, recount the affected products"})
+		}
+		return stockCountError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromStockCountEntity(*sc, true))
+}
+
+// Cancel godoc
+// @Summary      Cancelar recuento de stock
+// @Description  Cierra un recuento abierto sin ajustar stock (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Stock count ID"
+// @Success      200  {object}  dto.StockCountResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/stock-counts/{id}/cancel [post]
+func (h *StockCountHandler) Cancel(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	sc, err := h.Svc.Cancel(c.Request().Context(), id)
+	if err != nil {
+		return stockCountError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.FromStockCountEntity(*sc, true))
+}
+
+func stockCountError(c echo.Context, err error) error {
+	switch err {
+	case errors.ErrNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "stock count not found"})
+	case errors.ErrInvalidTransition:
+		return c.JSON(http.StatusConflict, map[string]string{"error": "stock count is not open"})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TaxHandler struct {
+	Svc service.TaxService
+}
+
+func NewTaxHandler(s service.TaxService) *TaxHandler {
+	return &TaxHandler{Svc: s}
+}
+
+// ListRates godoc
+// @Summary      Listar alícuotas de IVA
+// @Description  Lista las alícuotas por categoría y la alícuota por defecto (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.TaxRateResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/tax-rates [get]
+func (h *TaxHandler) ListRates(c echo.Context) error {
+	rates, err := h.Svc.ListRates(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.TaxRateResponse, 0, len(rates))
+	for _, r := range rates {
+		resp = append(resp, dto.FromTaxRateEntity(r))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// SetRate godoc
+// @Summary      Fijar alícuota de IVA
+// @Description  Crea o reemplaza la alícuota de una categoría; con categoría vacía cambia la alícuota por defecto (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        rate  body      dto.SetTaxRateRequest  true  "Alícuota"
+// @Success      200   {object}  dto.TaxRateResponse
+// @Failure      400   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/tax-rates [put]
+func (h *TaxHandler) SetRate(c echo.Context) error {
+	var req dto.SetTaxRateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	t, err := h.Svc.SetRate(c.Request().Context(), req.Category, req.Rate)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "rate must be between 0 and 100"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromTaxRateEntity(*t))
+}
+
+// DeleteRate godoc
+// @Summary      Eliminar alícuota de IVA
+// @Description  Elimina la alícuota de una categoría, que pasa a usar la alícuota por defecto (solo admin)
+// @Tags         admin
+// @Param        id   path  int  true  "Tax rate ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/tax-rates/{id} [delete]
+func (h *TaxHandler) DeleteRate(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	if err := h.Svc.DeleteRate(c.Request().Context(), id); err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "tax rate not found"})
+		case errors.ErrConflict:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "the default rate cannot be deleted"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"core/internal/domain/entity"
+	"core/internal/domain/errors"
+	"core/internal/domain/service"
+	"core/internal/presentation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	Svc service.WebhookService
+}
+
+func NewWebhookHandler(s service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{Svc: s}
+}
+
+const invalidWebhookEndpoint = "url must be http(s), events must be a non-empty list of product.created, product.updated, product.deleted, stock.changed, order.paid and secret must have between 16 and 128 characters"
+
+// ListEndpoints godoc
+// @Summary      Listar destinos de webhooks
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.WebhookEndpointResponse
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/webhooks [get]
+func (h *WebhookHandler) ListEndpoints(c echo.Context) error {
+	endpoints, err := h.Svc.ListEndpoints(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.WebhookEndpointResponse, 0, len(endpoints))
+	for _, e := range endpoints {
+		resp = append(resp, dto.FromWebhookEndpointEntity(e))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CreateEndpoint godoc
+// @Summary      Registrar destino de webhooks
+// @Description  Registra una URL que recibe por POST los eventos suscriptos (product.created, product.updated, product.deleted, stock.changed, order.paid). Cada envío lleva en X-Signature el HMAC-SHA256 en hex del cuerpo firmado con el secreto, que solo se informa en esta respuesta. Los envíos fallidos se reintentan con espera exponencial y los eventos siguientes del mismo producto u orden esperan a que se entregue o se agoten sus intentos (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        endpoint  body      dto.SaveWebhookEndpointRequest  true  "Destino"
+// @Success      201       {object}  dto.WebhookEndpointResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/webhooks [post]
+func (h *WebhookHandler) CreateEndpoint(c echo.Context) error {
+	var req dto.SaveWebhookEndpointRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	e, err := h.Svc.CreateEndpoint(c.Request().Context(), req.ToEntity())
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": invalidWebhookEndpoint})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := dto.FromWebhookEndpointEntity(*e)
+	resp.Secret = e.Secret
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// GetEndpoint godoc
+// @Summary      Ver destino de webhooks
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Endpoint ID"
+// @Success      200  {object}  dto.WebhookEndpointResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/webhooks/{id} [get]
+func (h *WebhookHandler) GetEndpoint(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	e, err := h.Svc.GetEndpoint(c.Request().Context(), id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "webhook endpoint not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromWebhookEndpointEntity(*e))
+}
+
+// UpdateEndpoint godoc
+// @Summary      Modificar destino de webhooks
+// @Description  Reemplaza URL, descripción, eventos y estado. Sin secret conserva el anterior. Un destino desactivado no recibe eventos nuevos y sus envíos pendientes esperan a que se reactive (solo admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                             true  "Endpoint ID"
+// @Param        endpoint  body      dto.SaveWebhookEndpointRequest  true  "Destino"
+// @Success      200       {object}  dto.WebhookEndpointResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateEndpoint(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req dto.SaveWebhookEndpointRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	e := req.ToEntity()
+	e.ID = id
+	e, err = h.Svc.UpdateEndpoint(c.Request().Context(), e)
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": invalidWebhookEndpoint})
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "webhook endpoint not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromWebhookEndpointEntity(*e))
+}
+
+// DeleteEndpoint godoc
+// @Summary      Eliminar destino de webhooks
+// @Description  Elimina el destino junto con su registro de envíos (solo admin)
+// @Tags         admin
+// @Param        id  path  int  true  "Endpoint ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteEndpoint(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	if err := h.Svc.DeleteEndpoint(c.Request().Context(), id); err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "webhook endpoint not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary      Registro de envíos de webhooks
+// @Description  Lista los envíos, del más reciente al más antiguo, con la respuesta y el error del último intento (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        endpoint_id  query  int     false  "Endpoint ID"
+// @Param        event_id     query  int     false  "Event ID"
+// @Param        status       query  string  false  "Estado (pending, delivered, failed)"
+// @Param        limit        query  int     false  "Límite (<=100)"
+// @Param        offset       query  int     false  "Offset"
+// @Success      200  {array}   dto.WebhookDeliveryResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/webhooks/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	filter := entity.WebhookDeliveryFilter{Status: entity.WebhookDeliveryStatus(c.QueryParam("status"))}
+	if v := c.QueryParam("endpoint_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid endpoint_id"})
+		}
+		filter.EndpointID = id
+	}
+	if v := c.QueryParam("event_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid event_id"})
+		}
+		filter.EventID = id
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o >= 0 {
+		filter.Offset = o
+	}
+
+	deliveries, err := h.Svc.ListDeliveries(c.Request().Context(), filter)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	resp := make([]dto.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, dto.FromWebhookDeliveryEntity(d))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetDelivery godoc
+// @Summary      Ver envío de webhook
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Delivery ID"
+// @Success      200  {object}  dto.WebhookDeliveryResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/webhooks/deliveries/{id} [get]
+func (h *WebhookHandler) GetDelivery(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	d, err := h.Svc.GetDelivery(c.Request().Context(), id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "webhook delivery not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusOK, dto.FromWebhookDeliveryEntity(*d))
+}
+
+// Redeliver godoc
+// @Summary      Reenviar webhook
+// @Description  Encola un envío nuevo del mismo evento al mismo destino; lleva el mismo X-Webhook-Id para que el destino descarte repetidos (solo admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id  path  int  true  "Delivery ID"
+// @Success      202  {object}  dto.WebhookDeliveryResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/admin/webhooks/deliveries/{id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	d, err := h.Svc.Redeliver(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "webhook delivery not found"})
+		case errors.ErrConflict:
+			return c.JSON(http.StatusConflict, map[string]string{"error": "webhook endpoint is disabled"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+	return c.JSON(http.StatusAccepted, dto.FromWebhookDeliveryEntity(*d))
+}
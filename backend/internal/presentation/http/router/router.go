@@ -2,7 +2,9 @@ package router
 
 import (
 	"core/internal/config"
+	"core/internal/presentation/dto"
 	"core/internal/presentation/http/handler"
+	jwtutil "core/internal/presentation/middleware"
 
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
@@ -14,14 +16,39 @@ func Router(
 	productHandler *handler.ProductHandler,
 	productImageHandler *handler.ProductImageHandler,
 	authHandler *handler.AuthHandler,
+	pricingHandler *handler.PricingHandler,
+	auditHandler *handler.AuditHandler,
+	currencyHandler *handler.CurrencyHandler,
+	orderHandler *handler.OrderHandler,
+	promotionHandler *handler.PromotionHandler,
+	taxHandler *handler.TaxHandler,
+	invoiceHandler *handler.InvoiceHandler,
+	addressHandler *handler.AddressHandler,
+	shippingHandler *handler.ShippingHandler,
+	shipmentHandler *handler.ShipmentHandler,
+	returnHandler *handler.ReturnHandler,
+	inventoryHandler *handler.InventoryHandler,
+	stockCountHandler *handler.StockCountHandler,
+	stockAlertHandler *handler.StockAlertHandler,
+	purchaseHandler *handler.PurchaseHandler,
+	labelHandler *handler.LabelHandler,
+	posHandler *handler.PosHandler,
+	productImportHandler *handler.ProductImportHandler,
+	productExportHandler *handler.ProductExportHandler,
+	feedHandler *handler.FeedHandler,
+	marketplaceHandler *handler.MarketplaceHandler,
+	webhookHandler *handler.WebhookHandler,
+	outboxHandler *handler.OutboxHandler,
 	cfg config.Config,
 ) *echo.Echo {
 	e := echo.New()
+	e.Validator = dto.Validator{}
 
 	// Middlewares globales
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
+	e.Use(jwtutil.Actor(&cfg))
 
 	// Servir archivos estáticos
 	e.Static("/static", "static")
@@ -42,7 +69,17 @@ func Router(
 	// Rutas públicas de productos (GET)
 	e.GET("/api/products", productHandler.List)
 	e.GET("/api/products/:id", productHandler.GetByID)
+	e.GET("/api/products/barcode/:code", productHandler.GetByBarCode)
 	e.GET("/api/products/:id/images", productImageHandler.GetProductImages)
+	e.GET("/api/exchange-rates/current", currencyHandler.CurrentRates)
+	e.GET("/api/shipping/methods", shippingHandler.ListMethods)
+
+	// Feeds de productos para anuncios de compras, en URLs fijas
+	e.GET("/feeds/google.xml", feedHandler.GoogleFeed)
+	e.GET("/feeds/meta.csv", feedHandler.MetaFeed)
+
+	// Webhooks de carriers: se autentican con la firma del cuerpo, no con JWT
+	e.POST("/api/webhooks/carriers/:carrier", shipmentHandler.CarrierWebhook)
 
 	api := e.Group("/api")
 
@@ -53,6 +90,22 @@ func Router(
 		// TokenLookup: "header:Authorization",
 		// AuthScheme:  "Bearer",
 	}))
+	protected.POST("/orders", orderHandler.Create)
+	protected.GET("/orders", orderHandler.List)
+	protected.GET("/orders/:id", orderHandler.GetByID)
+	protected.GET("/orders/:id/invoice", invoiceHandler.GetForOrder)
+	protected.GET("/orders/:id/invoice/pdf", invoiceHandler.GetForOrderPDF)
+	protected.GET("/orders/:id/shipment", shipmentHandler.GetForOrder)
+	protected.POST("/orders/:id/returns", returnHandler.Create)
+	protected.GET("/returns", returnHandler.List)
+	protected.GET("/returns/:id", returnHandler.GetByID)
+	protected.POST("/returns/:id/cancel", returnHandler.Cancel)
+	protected.POST("/cart/evaluate", orderHandler.Quote)
+	protected.GET("/me/addresses", addressHandler.List)
+	protected.POST("/me/addresses", addressHandler.Create)
+	protected.PUT("/me/addresses/:id", addressHandler.Update)
+	protected.DELETE("/me/addresses/:id", addressHandler.Delete)
+
 	// Rutas protegidas de productos
 	api.POST("/products", productHandler.Create)
 	api.PUT("/products/:id", productHandler.Update)
@@ -62,5 +115,130 @@ func Router(
 	api.POST("/products/:id/images", productImageHandler.UploadImage)
 	api.DELETE("/products/:id/images/:imageId", productImageHandler.DeleteImage)
 
+	// Punto de venta (JWT + rol cajero o admin)
+	pos := api.Group("/pos")
+	pos.Use(jwtutil.JWTMiddleware(&cfg), jwtutil.CashierOnly)
+	pos.POST("/shifts", posHandler.OpenShift)
+	pos.GET("/shifts/current", posHandler.CurrentShift)
+	pos.POST("/shifts/current/close", posHandler.CloseShift)
+	pos.POST("/sales", posHandler.StartSale)
+	pos.GET("/sales/:id", posHandler.GetSale)
+	pos.POST("/sales/:id/scans", posHandler.Scan)
+	pos.POST("/sales/:id/checkout", posHandler.Checkout)
+	pos.POST("/sales/:id/void", posHandler.VoidSale)
+	pos.GET("/sales/:id/receipt", posHandler.Receipt)
+
+	// Rutas de administración (JWT + rol admin)
+	admin := api.Group("/admin")
+	admin.Use(jwtutil.JWTMiddleware(&cfg), jwtutil.AdminOnly)
+	admin.GET("/products", productHandler.AdminList)
+	admin.GET("/products/export", productExportHandler.Export)
+	admin.GET("/products/imports", productImportHandler.List)
+	admin.POST("/products/imports", productImportHandler.Submit)
+	admin.GET("/products/imports/:id", productImportHandler.GetByID)
+	admin.GET("/feeds", feedHandler.States)
+	admin.POST("/feeds/refresh", feedHandler.Refresh)
+	admin.GET("/feeds/categories", feedHandler.ListCategories)
+	admin.PUT("/feeds/categories", feedHandler.SaveCategory)
+	admin.DELETE("/feeds/categories", feedHandler.DeleteCategory)
+	admin.GET("/marketplace/listings", marketplaceHandler.ListListings)
+	admin.POST("/marketplace/listings", marketplaceHandler.LinkListing)
+	admin.GET("/marketplace/listings/:id", marketplaceHandler.GetListing)
+	admin.PUT("/marketplace/listings/:id/status", marketplaceHandler.UpdateListingStatus)
+	admin.DELETE("/marketplace/listings/:id", marketplaceHandler.UnlinkListing)
+	admin.POST("/marketplace/sync", marketplaceHandler.Sync)
+	admin.POST("/marketplace/reconcile", marketplaceHandler.Reconcile)
+	admin.GET("/marketplace/orders", marketplaceHandler.ListOrders)
+	admin.GET("/marketplace/orders/:id", marketplaceHandler.GetOrder)
+	admin.GET("/webhooks", webhookHandler.ListEndpoints)
+	admin.POST("/webhooks", webhookHandler.CreateEndpoint)
+	admin.GET("/webhooks/deliveries", webhookHandler.ListDeliveries)
+	admin.GET("/webhooks/deliveries/:id", webhookHandler.GetDelivery)
+	admin.POST("/webhooks/deliveries/:id/redeliver", webhookHandler.Redeliver)
+	admin.GET("/webhooks/:id", webhookHandler.GetEndpoint)
+	admin.PUT("/webhooks/:id", webhookHandler.UpdateEndpoint)
+	admin.DELETE("/webhooks/:id", webhookHandler.DeleteEndpoint)
+	admin.GET("/outbox", outboxHandler.List)
+	admin.POST("/barcodes", productHandler.GenerateBarCodes)
+	admin.GET("/products/:id/barcode", labelHandler.BarCode)
+	admin.POST("/labels", labelHandler.Sheet)
+	admin.PUT("/products/:id/status", productHandler.ChangeStatus)
+	admin.PUT("/products/:id/pricing", pricingHandler.UpdatePricing)
+	admin.GET("/products/:id/price-changes", pricingHandler.ListPriceChanges)
+	admin.POST("/products/:id/price-changes", pricingHandler.CreatePriceChange)
+	admin.DELETE("/products/:id/price-changes/:changeId", pricingHandler.DeletePriceChange)
+	admin.GET("/products/:id/prices", currencyHandler.ListProductPrices)
+	admin.PUT("/products/:id/prices/:currency", currencyHandler.SetProductPrice)
+	admin.DELETE("/products/:id/prices/:currency", currencyHandler.DeleteProductPrice)
+	admin.GET("/exchange-rates", currencyHandler.ListRateTables)
+	admin.POST("/exchange-rates", currencyHandler.CreateRateTable)
+	admin.GET("/orders", orderHandler.AdminList)
+	admin.PUT("/orders/:id/status", orderHandler.UpdateStatus)
+	admin.POST("/orders/:id/invoice", invoiceHandler.IssueForOrder)
+	admin.GET("/invoices", invoiceHandler.List)
+	admin.GET("/invoices/:id", invoiceHandler.GetByID)
+	admin.GET("/invoices/:id/pdf", invoiceHandler.GetPDF)
+	admin.POST("/invoices/:id/submit", invoiceHandler.Submit)
+
+	admin.POST("/orders/:id/shipment", shipmentHandler.CreateForOrder)
+	admin.GET("/shipments", shipmentHandler.List)
+	admin.GET("/shipments/:id", shipmentHandler.GetByID)
+	admin.GET("/shipments/:id/label", shipmentHandler.GetLabel)
+	admin.POST("/shipments/:id/refresh", shipmentHandler.Refresh)
+
+	admin.GET("/locations", inventoryHandler.ListLocations)
+	admin.POST("/locations", inventoryHandler.CreateLocation)
+	admin.PUT("/locations/:id", inventoryHandler.UpdateLocation)
+	admin.GET("/products/:id/inventory", inventoryHandler.ProductInventory)
+	admin.POST("/products/:id/inventory/adjust", inventoryHandler.Adjust)
+	admin.GET("/products/:id/stock-movements", inventoryHandler.Movements)
+	admin.GET("/products/:id/reorder-point", stockAlertHandler.GetReorderPoint)
+	admin.PUT("/products/:id/reorder-point", stockAlertHandler.SetReorderPoint)
+	admin.GET("/inventory/low-stock", stockAlertHandler.LowStock)
+	admin.GET("/inventory/transfers", inventoryHandler.ListTransfers)
+	admin.POST("/inventory/transfers", inventoryHandler.Transfer)
+	admin.GET("/stock-counts", stockCountHandler.List)
+	admin.POST("/stock-counts", stockCountHandler.Open)
+	admin.GET("/stock-counts/:id", stockCountHandler.GetByID)
+	admin.POST("/stock-counts/:id/scans", stockCountHandler.Scan)
+	admin.POST("/stock-counts/:id/approve", stockCountHandler.Approve)
+	admin.POST("/stock-counts/:id/cancel", stockCountHandler.Cancel)
+
+	admin.GET("/suppliers", purchaseHandler.ListSuppliers)
+	admin.POST("/suppliers", purchaseHandler.CreateSupplier)
+	admin.GET("/suppliers/:id", purchaseHandler.GetSupplier)
+	admin.PUT("/suppliers/:id", purchaseHandler.UpdateSupplier)
+	admin.GET("/purchase-orders", purchaseHandler.ListOrders)
+	admin.POST("/purchase-orders", purchaseHandler.CreateOrder)
+	admin.GET("/purchase-orders/:id", purchaseHandler.GetOrder)
+	admin.PUT("/purchase-orders/:id/status", purchaseHandler.UpdateOrderStatus)
+	admin.POST("/purchase-orders/:id/receipts", purchaseHandler.Receive)
+	admin.GET("/products/:id/cost", purchaseHandler.ProductCost)
+	admin.GET("/reports/margins", purchaseHandler.Margins)
+	admin.GET("/pos/shifts", posHandler.ListShifts)
+	admin.GET("/pos/shifts/:id", posHandler.ShiftReport)
+
+	admin.GET("/returns", returnHandler.AdminList)
+	admin.GET("/returns/:id", returnHandler.AdminGetByID)
+	admin.PUT("/returns/:id/status", returnHandler.UpdateStatus)
+	admin.GET("/promotions", promotionHandler.List)
+	admin.POST("/promotions", promotionHandler.Create)
+	admin.GET("/promotions/:id", promotionHandler.GetByID)
+	admin.PUT("/promotions/:id", promotionHandler.Update)
+	admin.DELETE("/promotions/:id", promotionHandler.Delete)
+	admin.GET("/shipping/zones", shippingHandler.ListZones)
+	admin.POST("/shipping/zones", shippingHandler.CreateZone)
+	admin.PUT("/shipping/zones/:id", shippingHandler.UpdateZone)
+	admin.DELETE("/shipping/zones/:id", shippingHandler.DeleteZone)
+	admin.GET("/shipping/methods", shippingHandler.AdminListMethods)
+	admin.POST("/shipping/methods", shippingHandler.CreateMethod)
+	admin.GET("/shipping/methods/:id", shippingHandler.GetMethod)
+	admin.PUT("/shipping/methods/:id", shippingHandler.UpdateMethod)
+	admin.DELETE("/shipping/methods/:id", shippingHandler.DeleteMethod)
+	admin.GET("/tax-rates", taxHandler.ListRates)
+	admin.PUT("/tax-rates", taxHandler.SetRate)
+	admin.DELETE("/tax-rates/:id", taxHandler.DeleteRate)
+	admin.GET("/audit", auditHandler.List)
+
 	return e
 }
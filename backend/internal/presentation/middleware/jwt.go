@@ -1,8 +1,11 @@
 package jwtutil
 
 import (
+	"core/internal/application/audit"
 	"core/internal/config"
+	"core/internal/domain/entity"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -73,3 +76,84 @@ func UserFromToken(c echo.Context) (map[string]interface{}, bool) {
 
 	return data, true
 }
+
+// UserIDFromToken retorna el id del usuario autenticado
+func UserIDFromToken(c echo.Context) (int64, bool) {
+	data, ok := UserFromToken(c)
+	if !ok {
+		return 0, false
+	}
+	id, ok := data["user_id"].(float64)
+	if !ok || id <= 0 {
+		return 0, false
+	}
+	return int64(id), true
+}
+
+// AdminOnly permite el paso solo a usuarios con rol admin.
+// Debe usarse después de JWTMiddleware.
+func AdminOnly(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		data, ok := UserFromToken(c)
+		if !ok {
+			return c.JSON(http.StatusUnauthorized, map[string]string{
+				"message": "invalid or missing token",
+			})
+		}
+		if role, _ := data["role"].(string); role != "admin" {
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"message": "admin role required",
+			})
+		}
+		return next(c)
+	}
+}
+
+// CashierOnly permite el paso a cajeros y administradores.
+// Debe usarse después de JWTMiddleware.
+func CashierOnly(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		data, ok := UserFromToken(c)
+		if !ok {
+			return c.JSON(http.StatusUnauthorized, map[string]string{
+				"message": "invalid or missing token",
+			})
+		}
+		if role, _ := data["role"].(string); role != string(entity.RoleCashier) && role != string(entity.RoleAdmin) {
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"message": "cashier role required",
+			})
+		}
+		return next(c)
+	}
+}
+
+// Actor agrega al contexto de la request el actor usado por la auditoría.
+// No rechaza requests: si no hay token válido el actor queda anónimo con su IP.
+func Actor(cfg *config.Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			actor := entity.Actor{IP: c.RealIP()}
+
+			auth := c.Request().Header.Get(echo.HeaderAuthorization)
+			if raw, ok := strings.CutPrefix(auth, "Bearer "); ok {
+				claims := jwt.MapClaims{}
+				token, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (interface{}, error) {
+					return []byte(cfg.JWTSecret), nil
+				}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
+				if err == nil && token.Valid {
+					if id, ok := claims["user_id"].(float64); ok {
+						userID := int64(id)
+						actor.UserID = &userID
+					}
+					actor.Email, _ = claims["email"].(string)
+					actor.Role, _ = claims["role"].(string)
+				}
+			}
+
+			ctx := audit.WithActor(c.Request().Context(), actor)
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+ALTER TABLE products
+    ADD COLUMN status ENUM('draft', 'scheduled', 'published', 'archived') NOT NULL DEFAULT 'draft' AFTER unit_price,
+    ADD COLUMN publish_at TIMESTAMP NULL DEFAULT NULL AFTER status,
+    ADD COLUMN unpublish_at TIMESTAMP NULL DEFAULT NULL AFTER publish_at,
+    ADD INDEX idx_status (status),
+    ADD INDEX idx_publish_at (publish_at),
+    ADD INDEX idx_unpublish_at (unpublish_at);
+
+-- Los productos existentes ya estaban visibles en el catálogo
+UPDATE products SET status = 'published';
@@ -0,0 +1,18 @@
+ALTER TABLE products
+    ADD COLUMN compare_at_price DECIMAL(10, 2) NULL DEFAULT NULL AFTER unit_price,
+    ADD COLUMN sale_price DECIMAL(10, 2) NULL DEFAULT NULL AFTER compare_at_price,
+    ADD COLUMN sale_starts_at TIMESTAMP NULL DEFAULT NULL AFTER sale_price,
+    ADD COLUMN sale_ends_at TIMESTAMP NULL DEFAULT NULL AFTER sale_starts_at;
+
+CREATE TABLE IF NOT EXISTS product_price_changes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    unit_price DECIMAL(10, 2) NOT NULL,
+    compare_at_price DECIMAL(10, 2) NULL DEFAULT NULL,
+    effective_at TIMESTAMP NOT NULL,
+    applied_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    INDEX idx_product_id (product_id),
+    INDEX idx_pending (applied_at, effective_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id BIGINT NOT NULL DEFAULT 0,
+    action VARCHAR(20) NOT NULL,
+    changes JSON NOT NULL,
+    actor_id BIGINT NULL DEFAULT NULL,
+    actor_email VARCHAR(255) NOT NULL DEFAULT '',
+    actor_role VARCHAR(50) NOT NULL DEFAULT '',
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMP(6) DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_entity (entity_type, entity_id),
+    INDEX idx_actor_id (actor_id),
+    INDEX idx_action (action),
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
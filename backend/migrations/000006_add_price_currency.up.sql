@@ -0,0 +1,7 @@
+-- Los montos siguen en DECIMAL (exacto), se agrega la moneda de cada precio.
+-- Todos los precios cargados hasta ahora están en pesos argentinos.
+ALTER TABLE products
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'ARS' AFTER unit_price;
+
+ALTER TABLE product_price_changes
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'ARS' AFTER compare_at_price;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS exchange_rate_tables (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    base_currency CHAR(3) NOT NULL,
+    effective_from TIMESTAMP NOT NULL,
+    created_by BIGINT NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_base_effective (base_currency, effective_from)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    table_id BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    rate DECIMAL(20, 10) NOT NULL,
+    PRIMARY KEY (table_id, currency),
+    FOREIGN KEY (table_id) REFERENCES exchange_rate_tables(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS product_prices (
+    product_id BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    unit_price DECIMAL(10, 2) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, currency),
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS orders (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    status ENUM('pending', 'paid', 'cancelled') NOT NULL DEFAULT 'pending',
+    currency CHAR(3) NOT NULL,
+    subtotal DECIMAL(12, 2) NOT NULL,
+    total DECIMAL(12, 2) NOT NULL,
+    -- Tasa congelada al momento de la compra (NULL si la orden está en la moneda base)
+    rate_table_id BIGINT NULL DEFAULT NULL,
+    rate_base_currency CHAR(3) NULL DEFAULT NULL,
+    exchange_rate DECIMAL(20, 10) NULL DEFAULT NULL,
+    rate_captured_at TIMESTAMP NULL DEFAULT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    INDEX idx_user_id (user_id),
+    INDEX idx_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    bar_code BIGINT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    size VARCHAR(10) NOT NULL,
+    category VARCHAR(100) NOT NULL,
+    quantity BIGINT NOT NULL,
+    base_unit_price DECIMAL(10, 2) NOT NULL,
+    base_currency CHAR(3) NOT NULL,
+    unit_price DECIMAL(10, 2) NOT NULL,
+    price_source VARCHAR(20) NOT NULL,
+    line_total DECIMAL(12, 2) NOT NULL,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    INDEX idx_order_id (order_id),
+    INDEX idx_product_id (product_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS promotions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    code VARCHAR(64) NULL DEFAULT NULL,
+    type ENUM('percentage', 'fixed', 'buy_x_get_y') NOT NULL,
+    percent_off INT NOT NULL DEFAULT 0,
+    amount_off DECIMAL(10, 2) NULL DEFAULT NULL,
+    buy_quantity INT NOT NULL DEFAULT 0,
+    get_quantity INT NOT NULL DEFAULT 0,
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    min_subtotal DECIMAL(12, 2) NULL DEFAULT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'ARS',
+    usage_limit BIGINT NULL DEFAULT NULL,
+    per_customer_limit BIGINT NULL DEFAULT NULL,
+    starts_at TIMESTAMP NULL DEFAULT NULL,
+    ends_at TIMESTAMP NULL DEFAULT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    exclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_code (code),
+    INDEX idx_active (active)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE orders
+    ADD COLUMN discount DECIMAL(12, 2) NOT NULL DEFAULT 0 AFTER subtotal;
+
+ALTER TABLE order_items
+    ADD COLUMN discount DECIMAL(12, 2) NOT NULL DEFAULT 0 AFTER line_total;
+
+-- Sin FK a promotions: la orden conserva nombre y código aunque la promoción se elimine
+CREATE TABLE IF NOT EXISTS order_discounts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    promotion_id BIGINT NOT NULL,
+    code VARCHAR(64) NOT NULL DEFAULT '',
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    INDEX idx_order_id (order_id),
+    INDEX idx_promotion_id (promotion_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS tax_rates (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    category VARCHAR(100) NOT NULL, -- '' = alícuota por defecto
+    rate_bps INT NOT NULL,          -- centésimos de punto: 2100 = 21%
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_category (category)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT IGNORE INTO tax_rates (category, rate_bps) VALUES ('', 2100);
+
+ALTER TABLE orders
+    ADD COLUMN tax_mode ENUM('inclusive', 'exclusive') NOT NULL DEFAULT 'inclusive' AFTER discount,
+    ADD COLUMN tax DECIMAL(12, 2) NOT NULL DEFAULT 0 AFTER tax_mode;
+
+ALTER TABLE order_items
+    ADD COLUMN tax_rate_bps INT NOT NULL DEFAULT 0 AFTER discount,
+    ADD COLUMN net_amount DECIMAL(12, 2) NOT NULL DEFAULT 0 AFTER tax_rate_bps,
+    ADD COLUMN tax_amount DECIMAL(12, 2) NOT NULL DEFAULT 0 AFTER net_amount;
@@ -0,0 +1,60 @@
+ALTER TABLE orders
+    ADD COLUMN billing_tax_id VARCHAR(11) NOT NULL DEFAULT '' AFTER rate_captured_at,
+    ADD COLUMN billing_tax_id_type VARCHAR(4) NOT NULL DEFAULT '' AFTER billing_tax_id,
+    ADD COLUMN billing_name VARCHAR(255) NOT NULL DEFAULT '' AFTER billing_tax_id_type,
+    ADD COLUMN billing_address VARCHAR(255) NOT NULL DEFAULT '' AFTER billing_name,
+    ADD COLUMN paid_at TIMESTAMP NULL DEFAULT NULL AFTER billing_address;
+
+-- Último número emitido por punto de venta y letra, se bloquea al numerar
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    point_of_sale INT NOT NULL,
+    type CHAR(1) NOT NULL,
+    last_number BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (point_of_sale, type)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    point_of_sale INT NOT NULL,
+    type CHAR(1) NOT NULL,
+    number BIGINT NOT NULL,
+    issuer_name VARCHAR(255) NOT NULL,
+    issuer_tax_id VARCHAR(11) NOT NULL,
+    issuer_address VARCHAR(255) NOT NULL,
+    customer_tax_id VARCHAR(11) NOT NULL,
+    customer_tax_id_type VARCHAR(4) NOT NULL,
+    customer_name VARCHAR(255) NOT NULL,
+    customer_address VARCHAR(255) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    exchange_rate DECIMAL(20, 10) NULL DEFAULT NULL,
+    net DECIMAL(12, 2) NOT NULL,
+    tax DECIMAL(12, 2) NOT NULL,
+    total DECIMAL(12, 2) NOT NULL,
+    status ENUM('pending', 'authorized', 'rejected') NOT NULL DEFAULT 'pending',
+    authorization_code VARCHAR(20) NOT NULL DEFAULT '',
+    authorization_expires_at TIMESTAMP NULL DEFAULT NULL,
+    rejection_reason VARCHAR(500) NOT NULL DEFAULT '',
+    submit_attempts INT NOT NULL DEFAULT 0,
+    issued_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders(id),
+    UNIQUE KEY uq_order_id (order_id),
+    UNIQUE KEY uq_number (point_of_sale, type, number),
+    INDEX idx_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS invoice_lines (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    invoice_id BIGINT NOT NULL,
+    description VARCHAR(300) NOT NULL,
+    quantity BIGINT NOT NULL,
+    unit_price DECIMAL(10, 2) NOT NULL,
+    discount DECIMAL(12, 2) NOT NULL,
+    tax_rate_bps INT NOT NULL,
+    net DECIMAL(12, 2) NOT NULL,
+    tax DECIMAL(12, 2) NOT NULL,
+    total DECIMAL(12, 2) NOT NULL,
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE,
+    INDEX idx_invoice_id (invoice_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
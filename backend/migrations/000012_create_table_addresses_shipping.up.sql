@@ -0,0 +1,76 @@
+ALTER TABLE products
+    ADD COLUMN weight_grams BIGINT NOT NULL DEFAULT 0 AFTER category;
+
+ALTER TABLE order_items
+    ADD COLUMN weight_grams BIGINT NOT NULL DEFAULT 0 AFTER category;
+
+CREATE TABLE IF NOT EXISTS addresses (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    recipient_name VARCHAR(255) NOT NULL,
+    phone VARCHAR(50) NOT NULL DEFAULT '',
+    street VARCHAR(255) NOT NULL,
+    number VARCHAR(20) NOT NULL DEFAULT '',
+    apartment VARCHAR(50) NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL,
+    province CHAR(1) NOT NULL,
+    postal_code VARCHAR(10) NOT NULL DEFAULT '',
+    is_default_shipping BOOLEAN NOT NULL DEFAULT FALSE,
+    is_default_billing BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS shipping_zones (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Cada provincia pertenece a una sola zona
+CREATE TABLE IF NOT EXISTS shipping_zone_provinces (
+    province CHAR(1) NOT NULL PRIMARY KEY,
+    zone_id BIGINT NOT NULL,
+    FOREIGN KEY (zone_id) REFERENCES shipping_zones(id) ON DELETE CASCADE,
+    INDEX idx_zone_id (zone_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Los montos de los métodos y tarifas están en la moneda base (ARS)
+CREATE TABLE IF NOT EXISTS shipping_methods (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    min_days INT NOT NULL DEFAULT 0,
+    max_days INT NOT NULL DEFAULT 0,
+    free_shipping_threshold DECIMAL(12, 2) NULL DEFAULT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS shipping_rates (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    method_id BIGINT NOT NULL,
+    zone_id BIGINT NOT NULL,
+    max_weight_grams BIGINT NOT NULL,
+    price DECIMAL(10, 2) NOT NULL,
+    FOREIGN KEY (method_id) REFERENCES shipping_methods(id) ON DELETE CASCADE,
+    -- Una zona con tarifas no se puede eliminar
+    FOREIGN KEY (zone_id) REFERENCES shipping_zones(id),
+    UNIQUE KEY uq_rate (method_id, zone_id, max_weight_grams)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Envío congelado en la orden: shipping_method_id NULL = retiro en local
+ALTER TABLE orders
+    ADD COLUMN shipping_method_id BIGINT NULL DEFAULT NULL AFTER billing_address,
+    ADD COLUMN shipping_method_name VARCHAR(100) NULL DEFAULT NULL AFTER shipping_method_id,
+    ADD COLUMN shipping_address JSON NULL DEFAULT NULL AFTER shipping_method_name,
+    ADD COLUMN shipping_weight_grams BIGINT NULL DEFAULT NULL AFTER shipping_address,
+    ADD COLUMN shipping_amount DECIMAL(12, 2) NULL DEFAULT NULL AFTER shipping_weight_grams,
+    ADD COLUMN shipping_tax_rate_bps INT NULL DEFAULT NULL AFTER shipping_amount,
+    ADD COLUMN shipping_net DECIMAL(12, 2) NULL DEFAULT NULL AFTER shipping_tax_rate_bps,
+    ADD COLUMN shipping_tax DECIMAL(12, 2) NULL DEFAULT NULL AFTER shipping_net;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS shipments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    carrier VARCHAR(50) NOT NULL,
+    tracking_number VARCHAR(100) NOT NULL,
+    status ENUM('label_created', 'in_transit', 'out_for_delivery', 'delivered', 'exception', 'returned') NOT NULL DEFAULT 'label_created',
+    -- Copia de la dirección de la orden al momento de generar la etiqueta
+    address JSON NOT NULL,
+    weight_grams BIGINT NOT NULL DEFAULT 0,
+    label MEDIUMBLOB NULL,
+    delivered_at TIMESTAMP NULL,
+    last_checked_at TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders(id),
+    UNIQUE KEY uq_order_id (order_id),
+    UNIQUE KEY uq_carrier_tracking (carrier, tracking_number),
+    INDEX idx_status_checked (status, last_checked_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Un mismo evento puede llegar por webhook y por consulta: la clave única lo deduplica
+CREATE TABLE IF NOT EXISTS shipment_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    shipment_id BIGINT NOT NULL,
+    status ENUM('label_created', 'in_transit', 'out_for_delivery', 'delivered', 'exception', 'returned') NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (shipment_id) REFERENCES shipments(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_shipment_event (shipment_id, status, occurred_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
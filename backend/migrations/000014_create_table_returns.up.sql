@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS return_requests (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    status ENUM('requested', 'approved', 'rejected', 'received', 'completed', 'cancelled') NOT NULL DEFAULT 'requested',
+    comment VARCHAR(1000) NOT NULL DEFAULT '',
+    currency CHAR(3) NOT NULL,
+    refund_total DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    refund_reference VARCHAR(100) NOT NULL DEFAULT '',
+    refunded_at TIMESTAMP NULL DEFAULT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders(id),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    INDEX idx_order_id (order_id),
+    INDEX idx_user_id (user_id),
+    INDEX idx_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Datos de la línea de la orden congelados al pedir la devolución
+CREATE TABLE IF NOT EXISTS return_items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    return_id BIGINT NOT NULL,
+    order_item_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    size VARCHAR(10) NOT NULL,
+    quantity BIGINT NOT NULL,
+    reason ENUM('wrong_size', 'defective', 'not_as_described', 'changed_mind', 'other') NOT NULL,
+    resolution ENUM('refund', 'exchange') NOT NULL,
+    exchange_product_id BIGINT NULL DEFAULT NULL,
+    exchange_size VARCHAR(10) NOT NULL DEFAULT '',
+    refund_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    FOREIGN KEY (return_id) REFERENCES return_requests(id) ON DELETE CASCADE,
+    FOREIGN KEY (order_item_id) REFERENCES order_items(id),
+    INDEX idx_return_id (return_id),
+    INDEX idx_order_item_id (order_item_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Historial completo de estados, from_status NULL es el alta
+CREATE TABLE IF NOT EXISTS return_status_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    return_id BIGINT NOT NULL,
+    from_status VARCHAR(20) NULL DEFAULT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor_id BIGINT NULL DEFAULT NULL,
+    note VARCHAR(1000) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (return_id) REFERENCES return_requests(id) ON DELETE CASCADE,
+    INDEX idx_return_id (return_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,60 @@
+CREATE TABLE IF NOT EXISTS locations (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    kind ENUM('store', 'warehouse') NOT NULL,
+    -- Menor prioridad = se usa primero al preparar órdenes
+    priority INT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    -- Recibe el stock sin ubicación indicada (alta de productos, devoluciones)
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_code (code)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO locations (code, name, kind, priority, active, is_default) VALUES
+    ('DEP', 'Depósito', 'warehouse', 1, TRUE, TRUE),
+    ('LOCAL', 'Local', 'store', 2, TRUE, FALSE);
+
+-- products.stock pasa a ser el disponible para la venta: la suma de las ubicaciones activas
+CREATE TABLE IF NOT EXISTS inventory_levels (
+    product_id BIGINT NOT NULL,
+    location_id BIGINT NOT NULL,
+    quantity BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, location_id),
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    FOREIGN KEY (location_id) REFERENCES locations(id),
+    INDEX idx_location_id (location_id),
+    CHECK (quantity >= 0)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- El stock existente queda en el depósito
+INSERT INTO inventory_levels (product_id, location_id, quantity)
+SELECT p.id, l.id, p.stock FROM products p JOIN locations l ON l.code = 'DEP' WHERE p.stock > 0;
+
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    from_location_id BIGINT NOT NULL,
+    to_location_id BIGINT NOT NULL,
+    quantity BIGINT NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    FOREIGN KEY (from_location_id) REFERENCES locations(id),
+    FOREIGN KEY (to_location_id) REFERENCES locations(id),
+    INDEX idx_product_id (product_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- De qué ubicaciones sale cada línea: al cancelar la orden las unidades vuelven ahí
+CREATE TABLE IF NOT EXISTS order_item_allocations (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    order_item_id BIGINT NOT NULL,
+    location_id BIGINT NOT NULL,
+    quantity BIGINT NOT NULL,
+    FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE CASCADE,
+    FOREIGN KEY (location_id) REFERENCES locations(id),
+    INDEX idx_order_item_id (order_item_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,25 @@
+-- Libro de stock: cada cambio de inventory_levels agrega una fila y nunca se modifica.
+-- El saldo por producto y ubicación es SUM(delta), y reconcile-stock lo compara con inventory_levels
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    location_id BIGINT NOT NULL,
+    delta BIGINT NOT NULL,
+    kind ENUM('sale', 'return', 'adjustment', 'transfer', 'count') NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    -- Documento de origen, ej. order:12, return:3, transfer:5
+    reference VARCHAR(64) NOT NULL DEFAULT '',
+    -- 0 = sistema
+    actor_id BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    FOREIGN KEY (location_id) REFERENCES locations(id),
+    INDEX idx_product_location (product_id, location_id),
+    INDEX idx_reference (reference)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- El stock actual abre el libro como saldo inicial
+INSERT INTO stock_movements (product_id, location_id, delta, kind, reason)
+SELECT product_id, location_id, quantity, 'adjustment', 'Saldo inicial' FROM inventory_levels WHERE quantity <> 0;
+
+ALTER TABLE stock_transfers ADD COLUMN actor_id BIGINT NOT NULL DEFAULT 0 AFTER note;
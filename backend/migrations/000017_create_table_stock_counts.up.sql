@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS stock_counts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    location_id BIGINT NOT NULL,
+    -- Vacío = todos los productos
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    status ENUM('open', 'approved', 'cancelled') NOT NULL DEFAULT 'open',
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    opened_by BIGINT NOT NULL DEFAULT 0,
+    approved_by BIGINT NULL,
+    approved_at TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (location_id) REFERENCES locations(id),
+    INDEX idx_location_status (location_id, status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Una línea por producto del alcance. expected es el stock de la ubicación al abrir el recuento
+CREATE TABLE IF NOT EXISTS stock_count_lines (
+    count_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    expected BIGINT NOT NULL DEFAULT 0,
+    counted BIGINT NOT NULL DEFAULT 0,
+    -- NULL = no escaneado, cuenta como cero al aprobar
+    scanned_at TIMESTAMP NULL,
+    PRIMARY KEY (count_id, product_id),
+    FOREIGN KEY (count_id) REFERENCES stock_counts(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CHECK (counted >= 0)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,21 @@
+-- Umbral de stock por producto. Sin fila = sin alerta
+CREATE TABLE IF NOT EXISTS reorder_points (
+    product_id BIGINT PRIMARY KEY,
+    threshold BIGINT NOT NULL,
+    reorder_quantity BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CHECK (threshold > 0 AND reorder_quantity >= 0)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Alertas enviadas por canal. La fila se borra cuando el producto vuelve a superar el
+-- umbral, así cada baja se avisa una sola vez por canal
+CREATE TABLE IF NOT EXISTS low_stock_alerts (
+    product_id BIGINT NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    stock BIGINT NOT NULL,
+    threshold BIGINT NOT NULL,
+    notified_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, channel),
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
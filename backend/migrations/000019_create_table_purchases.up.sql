@@ -0,0 +1,81 @@
+CREATE TABLE IF NOT EXISTS suppliers (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    -- CUIT, solo dígitos
+    tax_id VARCHAR(20) NOT NULL DEFAULT '',
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(50) NOT NULL DEFAULT '',
+    address VARCHAR(255) NOT NULL DEFAULT '',
+    notes VARCHAR(1000) NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    supplier_id BIGINT NOT NULL,
+    -- Ubicación que recibe la mercadería
+    location_id BIGINT NOT NULL,
+    status ENUM('draft', 'ordered', 'partially_received', 'received', 'cancelled') NOT NULL DEFAULT 'draft',
+    currency CHAR(3) NOT NULL,
+    total DECIMAL(12, 2) NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    expected_at TIMESTAMP NULL,
+    ordered_at TIMESTAMP NULL,
+    created_by BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (supplier_id) REFERENCES suppliers(id),
+    FOREIGN KEY (location_id) REFERENCES locations(id),
+    INDEX idx_supplier (supplier_id),
+    INDEX idx_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    purchase_order_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity BIGINT NOT NULL,
+    received BIGINT NOT NULL DEFAULT 0,
+    unit_cost DECIMAL(12, 2) NOT NULL,
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    UNIQUE KEY uq_order_product (purchase_order_id, product_id),
+    CHECK (quantity > 0),
+    CHECK (received >= 0 AND received <= quantity)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS purchase_receipts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    purchase_order_id BIGINT NOT NULL,
+    actor_id BIGINT NOT NULL DEFAULT 0,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- unit_cost es el costo efectivo de lo recibido, que puede diferir del pactado en la orden
+CREATE TABLE IF NOT EXISTS purchase_receipt_lines (
+    receipt_id BIGINT NOT NULL,
+    line_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity BIGINT NOT NULL,
+    unit_cost DECIMAL(12, 2) NOT NULL,
+    PRIMARY KEY (receipt_id, line_id),
+    FOREIGN KEY (receipt_id) REFERENCES purchase_receipts(id) ON DELETE CASCADE,
+    FOREIGN KEY (line_id) REFERENCES purchase_order_lines(id) ON DELETE CASCADE,
+    CHECK (quantity > 0)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Costo promedio ponderado por producto, se recalcula con cada recepción
+CREATE TABLE IF NOT EXISTS product_costs (
+    product_id BIGINT PRIMARY KEY,
+    currency CHAR(3) NOT NULL,
+    average_cost DECIMAL(12, 2) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE stock_movements MODIFY COLUMN kind ENUM('sale', 'return', 'adjustment', 'transfer', 'count', 'purchase') NOT NULL;
@@ -0,0 +1,8 @@
+-- Los códigos de barras se guardan como texto de 13 dígitos (EAN-13). Como BIGINT perdían
+-- los ceros a la izquierda, que se recuperan completando hasta 13. Un UPC-A de 12 dígitos
+-- queda igual que su EAN-13 con el 0 adelante.
+ALTER TABLE products MODIFY COLUMN bar_code VARCHAR(14) NOT NULL;
+UPDATE products SET bar_code = LPAD(bar_code, 13, '0') WHERE CHAR_LENGTH(bar_code) < 13;
+
+ALTER TABLE order_items MODIFY COLUMN bar_code VARCHAR(14) NOT NULL;
+UPDATE order_items SET bar_code = LPAD(bar_code, 13, '0') WHERE CHAR_LENGTH(bar_code) < 13;
@@ -0,0 +1,62 @@
+-- Turnos de caja del punto de venta. Los montos están en la moneda base
+CREATE TABLE IF NOT EXISTS register_shifts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    register VARCHAR(50) NOT NULL,
+    location_id BIGINT NOT NULL,
+    cashier_id BIGINT NOT NULL,
+    status ENUM('open', 'closed') NOT NULL DEFAULT 'open',
+    currency CHAR(3) NOT NULL,
+    opening_float DECIMAL(12, 2) NOT NULL,
+    -- Se completan al cerrar: fondo más efectivo cobrado neto del vuelto, y lo contado
+    expected_cash DECIMAL(12, 2) NULL,
+    counted_cash DECIMAL(12, 2) NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    opened_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMP NULL,
+    FOREIGN KEY (location_id) REFERENCES locations(id),
+    FOREIGN KEY (cashier_id) REFERENCES users(id),
+    INDEX idx_cashier_status (cashier_id, status),
+    INDEX idx_location_status (location_id, status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Ventas de mostrador. Al cobrarse quedan ligadas a la orden que descontó el stock
+CREATE TABLE IF NOT EXISTS pos_sales (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    shift_id BIGINT NOT NULL,
+    cashier_id BIGINT NOT NULL,
+    status ENUM('open', 'completed', 'voided') NOT NULL DEFAULT 'open',
+    order_id BIGINT NULL,
+    total DECIMAL(12, 2) NULL,
+    change_given DECIMAL(12, 2) NULL,
+    completed_at TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (shift_id) REFERENCES register_shifts(id),
+    FOREIGN KEY (order_id) REFERENCES orders(id),
+    UNIQUE KEY uq_order (order_id),
+    INDEX idx_shift_status (shift_id, status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS pos_sale_lines (
+    sale_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity BIGINT NOT NULL,
+    -- Orden de escaneo, para mostrar las líneas como se cargaron
+    seq BIGINT NOT NULL,
+    PRIMARY KEY (sale_id, product_id),
+    FOREIGN KEY (sale_id) REFERENCES pos_sales(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    CHECK (quantity > 0)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- En efectivo amount es lo entregado por el cliente, el vuelto está en la venta
+CREATE TABLE IF NOT EXISTS pos_payments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    sale_id BIGINT NOT NULL,
+    method ENUM('cash', 'card') NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (sale_id) REFERENCES pos_sales(id) ON DELETE CASCADE,
+    INDEX idx_sale (sale_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
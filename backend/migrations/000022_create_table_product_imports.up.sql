@@ -0,0 +1,36 @@
+-- Importaciones de productos desde planillas. El archivo se guarda hasta que termina
+-- de procesarse, así una importación cortada por un reinicio se retoma
+CREATE TABLE IF NOT EXISTS product_imports (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    filename VARCHAR(255) NOT NULL,
+    format ENUM('csv', 'xlsx') NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    -- Encabezado de la planilla -> campo del producto
+    mapping JSON NOT NULL,
+    status ENUM('queued', 'running', 'completed', 'failed') NOT NULL DEFAULT 'queued',
+    total_rows INT NOT NULL DEFAULT 0,
+    processed_rows INT NOT NULL DEFAULT 0,
+    created_count INT NOT NULL DEFAULT 0,
+    updated_count INT NOT NULL DEFAULT 0,
+    failed_count INT NOT NULL DEFAULT 0,
+    error VARCHAR(255) NOT NULL DEFAULT '',
+    payload MEDIUMBLOB NULL,
+    created_by BIGINT NOT NULL DEFAULT 0,
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_status (status, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Filas rechazadas. row_num cuenta el encabezado como la fila 1
+CREATE TABLE IF NOT EXISTS product_import_errors (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    import_id BIGINT NOT NULL,
+    row_num INT NOT NULL,
+    column_name VARCHAR(50) NOT NULL DEFAULT '',
+    bar_code VARCHAR(32) NOT NULL DEFAULT '',
+    message VARCHAR(255) NOT NULL,
+    FOREIGN KEY (import_id) REFERENCES product_imports(id) ON DELETE CASCADE,
+    INDEX idx_import_row (import_id, row_num)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
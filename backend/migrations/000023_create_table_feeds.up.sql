@@ -0,0 +1,29 @@
+-- Mapeo de las categorías del catálogo a las taxonomías de Google y Meta
+CREATE TABLE IF NOT EXISTS feed_category_mappings (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    category VARCHAR(100) NOT NULL,
+    google_category VARCHAR(255) NOT NULL,
+    meta_category VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_category (category)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Cada producto ya escrito en el formato del canal. Sin clave foránea a products: un
+-- producto borrado se quita en la próxima regeneración, que marca el feed como cambiado
+CREATE TABLE IF NOT EXISTS feed_items (
+    channel ENUM('google', 'meta') NOT NULL,
+    product_id BIGINT NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    content TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (channel, product_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS feed_states (
+    channel ENUM('google', 'meta') NOT NULL PRIMARY KEY,
+    items INT NOT NULL DEFAULT 0,
+    -- Última vez que cambió el contenido, para Last-Modified
+    generated_at TIMESTAMP NULL,
+    checked_at TIMESTAMP NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,54 @@
+-- Publicaciones de productos en marketplaces. synced_* son los últimos valores que
+-- aceptó el marketplace
+CREATE TABLE IF NOT EXISTS marketplace_listings (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    marketplace VARCHAR(50) NOT NULL,
+    product_id BIGINT NOT NULL,
+    external_id VARCHAR(100) NOT NULL,
+    status ENUM('active', 'paused') NOT NULL DEFAULT 'active',
+    synced_stock BIGINT NOT NULL DEFAULT 0,
+    synced_price DECIMAL(12, 2) NULL,
+    synced_currency CHAR(3) NULL,
+    synced_at TIMESTAMP NULL,
+    sync_error VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_marketplace_product (marketplace, product_id),
+    UNIQUE KEY uq_marketplace_external (marketplace, external_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Ventas importadas. La clave única evita descontar dos veces el stock de una venta
+CREATE TABLE IF NOT EXISTS marketplace_orders (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    marketplace VARCHAR(50) NOT NULL,
+    external_id VARCHAR(100) NOT NULL,
+    status ENUM('imported', 'review') NOT NULL,
+    buyer VARCHAR(255) NOT NULL DEFAULT '',
+    total DECIMAL(12, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    ordered_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_marketplace_order (marketplace, external_id),
+    INDEX idx_status (status, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS marketplace_order_lines (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    external_item_id VARCHAR(100) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    product_id BIGINT NULL,
+    quantity BIGINT NOT NULL,
+    unit_price DECIMAL(12, 2) NOT NULL,
+    shortage BIGINT NOT NULL DEFAULT 0,
+    FOREIGN KEY (order_id) REFERENCES marketplace_orders(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Fecha de la última venta importada de cada marketplace
+CREATE TABLE IF NOT EXISTS marketplace_states (
+    marketplace VARCHAR(50) PRIMARY KEY,
+    orders_cursor TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
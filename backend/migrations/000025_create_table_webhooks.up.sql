@@ -0,0 +1,47 @@
+-- Destinos de los webhooks. events es la lista de eventos suscriptos y secret firma
+-- cada envío con HMAC-SHA256
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    url VARCHAR(500) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    secret VARCHAR(128) NOT NULL,
+    events JSON NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Eventos publicados. El ID viaja en cada envío para que el destino descarte repetidos
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event VARCHAR(50) NOT NULL,
+    payload JSON NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Registro de envíos: uno por evento y destino, más uno por cada reenvío manual
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    endpoint_id BIGINT NOT NULL,
+    status ENUM('pending', 'delivered', 'failed') NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NULL,
+    response_status INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(500) NOT NULL DEFAULT '',
+    redelivery_of BIGINT NULL,
+    delivered_at TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (event_id) REFERENCES webhook_events(id) ON DELETE CASCADE,
+    FOREIGN KEY (endpoint_id) REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    INDEX idx_due (status, next_attempt_at),
+    INDEX idx_endpoint (endpoint_id, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Último movimiento del libro de stock publicado como stock.changed
+CREATE TABLE IF NOT EXISTS webhook_states (
+    name VARCHAR(50) PRIMARY KEY,
+    position BIGINT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,23 @@
+-- Outbox de eventos de dominio. Cada fila se escribe en la misma transacción que la
+-- mutación que la origina y el relay la publica después, en orden de id por agregado.
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id BIGINT NOT NULL,
+    event VARCHAR(50) NOT NULL,
+    payload JSON NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(500) NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NULL,
+    published_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_pending (published_at, id),
+    INDEX idx_aggregate (aggregate_type, aggregate_id, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Evento del outbox que originó cada evento de webhook, para no encolarlo dos veces
+-- cuando el relay reintenta
+ALTER TABLE webhook_events ADD COLUMN source_id BIGINT NULL UNIQUE;
+
+-- stock.changed sale ahora del outbox y no del libro de stock
+DROP TABLE IF EXISTS webhook_states;
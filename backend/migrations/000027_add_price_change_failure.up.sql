@@ -0,0 +1,5 @@
+-- Un cambio programado que no se puede aplicar (el producto cambió de moneda) queda
+-- marcado como fallido con el motivo, en lugar de pendiente o aplicado
+ALTER TABLE product_price_changes
+    ADD COLUMN failed_at TIMESTAMP NULL DEFAULT NULL AFTER applied_at,
+    ADD COLUMN failure VARCHAR(255) NOT NULL DEFAULT '' AFTER failed_at;
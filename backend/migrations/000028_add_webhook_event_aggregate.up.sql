@@ -0,0 +1,6 @@
+-- Agregado del evento del outbox que originó cada evento de webhook. Un envío espera
+-- mientras el mismo destino tenga pendiente un evento anterior del mismo agregado.
+ALTER TABLE webhook_events
+    ADD COLUMN aggregate_type VARCHAR(50) NOT NULL DEFAULT '' AFTER source_id,
+    ADD COLUMN aggregate_id BIGINT NOT NULL DEFAULT 0 AFTER aggregate_type,
+    ADD INDEX idx_aggregate (aggregate_type, aggregate_id, id);
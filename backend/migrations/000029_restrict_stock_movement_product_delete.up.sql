@@ -0,0 +1,5 @@
+-- El libro de stock no se borra con el producto: un producto con movimientos se archiva
+ALTER TABLE stock_movements DROP FOREIGN KEY stock_movements_ibfk_1;
+
+ALTER TABLE stock_movements
+    ADD CONSTRAINT fk_stock_movements_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE RESTRICT;